@@ -12,6 +12,11 @@ const (
 	//
 	// Each group of 32 bytes starts with a 0x00 byte so that they can be parsed as valid bn254 field elements.
 	PayloadEncodingVersion0 PayloadEncodingVersion = 0x0
+	// PayloadEncodingVersion1 compresses the payload with zstd (see [CompressPayload]), and then encodes the compressed
+	// bytes exactly like PayloadEncodingVersion0, except that the header's version byte is 0x01.
+	//
+	// The length in the header is the length of the compressed payload, not of the original payload.
+	PayloadEncodingVersion1 PayloadEncodingVersion = 0x1
 )
 
 type BlobCodec interface {
@@ -23,6 +28,8 @@ func BlobEncodingVersionToCodec(version PayloadEncodingVersion) (BlobCodec, erro
 	switch version {
 	case PayloadEncodingVersion0:
 		return DefaultBlobCodec{}, nil
+	case PayloadEncodingVersion1:
+		return CompressedBlobCodec{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported blob encoding version: %x", version)
 	}
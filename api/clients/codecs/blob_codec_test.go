@@ -88,3 +88,37 @@ func TestNoIFFTCodec(t *testing.T) {
 		}
 	}
 }
+
+// TestCompressedBlobCodec tests that compressed blobs round trip, and can be decoded through the version byte
+func TestCompressedBlobCodec(t *testing.T) {
+	codec := codecs.NewCompressedBlobCodec()
+
+	for _, originalData := range [][]byte{
+		randomByteSlice(1000),
+		bytes.Repeat([]byte{0x42}, 10000),
+	} {
+		encodedData, err := codec.EncodeBlob(originalData)
+		if err != nil {
+			t.Fatalf("failed to encode blob: %v", err)
+		}
+		if encodedData[1] != byte(codecs.PayloadEncodingVersion1) {
+			t.Fatalf("unexpected version byte: %d", encodedData[1])
+		}
+
+		decodedData, err := codec.DecodeBlob(encodedData)
+		if err != nil {
+			t.Fatalf("failed to decode blob: %v", err)
+		}
+		if !bytes.Equal(originalData, decodedData) {
+			t.Fatalf("original and decoded data do not match")
+		}
+
+		decodedData, err = codecs.GenericDecodeBlob(encodedData)
+		if err != nil {
+			t.Fatalf("failed to generically decode blob: %v", err)
+		}
+		if !bytes.Equal(originalData, decodedData) {
+			t.Fatalf("original and generically decoded data do not match")
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package codecs
+
+import (
+	"fmt"
+)
+
+// CompressedBlobCodec implements [PayloadEncodingVersion1]: data is compressed before being encoded with the
+// [DefaultBlobCodec] layout.
+type CompressedBlobCodec struct{}
+
+var _ BlobCodec = CompressedBlobCodec{}
+
+func NewCompressedBlobCodec() CompressedBlobCodec {
+	return CompressedBlobCodec{}
+}
+
+func (v CompressedBlobCodec) EncodeBlob(rawData []byte) ([]byte, error) {
+	encodedData, err := DefaultBlobCodec{}.EncodeBlob(CompressPayload(rawData))
+	if err != nil {
+		return nil, fmt.Errorf("encode compressed data: %w", err)
+	}
+	encodedData[1] = byte(PayloadEncodingVersion1)
+
+	return encodedData, nil
+}
+
+func (v CompressedBlobCodec) DecodeBlob(data []byte) ([]byte, error) {
+	compressedData, err := DefaultBlobCodec{}.DecodeBlob(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode compressed data: %w", err)
+	}
+
+	return DecompressPayload(compressedData)
+}
@@ -0,0 +1,48 @@
+package codecs
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// MaxDecompressedPayloadBytes is the maximum size of a payload that can be decompressed from a
+// [PayloadEncodingVersion1] encoded payload. It bounds the memory used to decode maliciously crafted blobs
+// with an extreme compression ratio.
+const MaxDecompressedPayloadBytes = 256 * 1024 * 1024
+
+// zstdEncoder returns a shared zstd encoder. EncodeAll() is safe to call concurrently.
+var zstdEncoder = sync.OnceValue(func() *zstd.Encoder {
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		// This only fails if given invalid options.
+		panic(fmt.Sprintf("failed to create zstd encoder: %v", err))
+	}
+	return encoder
+})
+
+// zstdDecoder returns a shared zstd decoder. DecodeAll() is safe to call concurrently.
+var zstdDecoder = sync.OnceValue(func() *zstd.Decoder {
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderMaxMemory(MaxDecompressedPayloadBytes))
+	if err != nil {
+		// This only fails if given invalid options.
+		panic(fmt.Sprintf("failed to create zstd decoder: %v", err))
+	}
+	return decoder
+})
+
+// CompressPayload compresses a payload, as is done by [PayloadEncodingVersion1] before the payload is encoded.
+func CompressPayload(payload []byte) []byte {
+	return zstdEncoder().EncodeAll(payload, nil)
+}
+
+// DecompressPayload reverses [CompressPayload]. Returns an error if the data is not a valid zstd stream, or if it
+// decompresses to more than [MaxDecompressedPayloadBytes].
+func DecompressPayload(data []byte) ([]byte, error) {
+	payload, err := zstdDecoder().DecodeAll(data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decompress payload: %w", err)
+	}
+	return payload, nil
+}
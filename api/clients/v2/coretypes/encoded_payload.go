@@ -61,21 +61,32 @@ func (ep *EncodedPayload) LenSymbols() uint32 {
 	return uint32(len(ep.bytes)) / encoding.BYTES_PER_SYMBOL
 }
 
-// Decode applies the inverse of PayloadEncodingVersion0 to an EncodedPayload, and returns the decoded Payload
+// Decode applies the inverse of the encoding version recorded in the header to an EncodedPayload,
+// and returns the decoded Payload. See [codecs.PayloadEncodingVersion] for the supported versions.
 func (ep *EncodedPayload) Decode() (Payload, error) {
 	err := ep.checkLenInvariant()
 	if err != nil {
 		return nil, fmt.Errorf("check length invariant: %w", err)
 	}
-	payloadLenInHeader, err := ep.decodeHeader()
+	version, dataLenInHeader, err := ep.decodeHeader()
 	if err != nil {
 		return nil, fmt.Errorf("decodeHeader: %w", err)
 	}
-	payload, err := ep.decodePayload(payloadLenInHeader)
+	data, err := ep.decodePayload(dataLenInHeader)
 	if err != nil {
 		return nil, fmt.Errorf("decodePayload: %w", err)
 	}
-	return payload, nil
+
+	switch version {
+	case codecs.PayloadEncodingVersion1:
+		payload, err := codecs.DecompressPayload(data)
+		if err != nil {
+			return nil, fmt.Errorf("decompress: %w", err)
+		}
+		return payload, nil
+	default:
+		return data, nil
+	}
 }
 
 // ToBlob converts the EncodedPayload into a Blob
@@ -105,30 +116,32 @@ func (ep *EncodedPayload) ToBlob(payloadForm codecs.PolynomialForm) (*Blob, erro
 }
 
 // decodeHeader validates the header (first field element = 32 bytes) of the encoded payload,
-// and returns the claimed length of the payload if the header is valid.
-func (ep *EncodedPayload) decodeHeader() (uint32, error) {
+// and returns the encoding version and the claimed length of the encoded data if the header is valid.
+func (ep *EncodedPayload) decodeHeader() (codecs.PayloadEncodingVersion, uint32, error) {
 	if len(ep.bytes) < codec.EncodedPayloadHeaderLenBytes {
-		return 0, fmt.Errorf("encoded payload must be at least %d bytes long to contain a header, but got %d bytes",
+		return 0, 0, fmt.Errorf("encoded payload must be at least %d bytes long to contain a header, but got %d bytes",
 			codec.EncodedPayloadHeaderLenBytes, len(ep.bytes))
 	}
 	if ep.bytes[0] != 0x00 {
-		return 0, fmt.Errorf("encoded payload header first byte must be 0x00, but got %x", ep.bytes[0])
-	}
-	var payloadLength uint32
-	switch ep.bytes[1] {
-	case byte(codecs.PayloadEncodingVersion0):
-		payloadLength = binary.BigEndian.Uint32(ep.bytes[2:6])
+		return 0, 0, fmt.Errorf("encoded payload header first byte must be 0x00, but got %x", ep.bytes[0])
+	}
+	version := codecs.PayloadEncodingVersion(ep.bytes[1])
+	var dataLength uint32
+	switch version {
+	case codecs.PayloadEncodingVersion0, codecs.PayloadEncodingVersion1:
+		// both versions share the same header layout
+		dataLength = binary.BigEndian.Uint32(ep.bytes[2:6])
 	default:
-		return 0, fmt.Errorf("unknown encoded payload header version: %x", ep.bytes[1])
+		return 0, 0, fmt.Errorf("unknown encoded payload header version: %x", ep.bytes[1])
 	}
 
 	for _, b := range ep.bytes[6:codec.EncodedPayloadHeaderLenBytes] {
 		if b != 0x00 {
-			return 0, fmt.Errorf("padding in encoded payload header must be 0x00: %x", b)
+			return 0, 0, fmt.Errorf("padding in encoded payload header must be 0x00: %x", b)
 		}
 	}
 
-	return payloadLength, nil
+	return version, dataLength, nil
 }
 
 // decodePayload decodes the body by checking for and removing internal zero-byte padding,
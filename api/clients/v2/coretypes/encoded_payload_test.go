@@ -2,9 +2,11 @@
 package coretypes
 
 import (
+	"bytes"
 	"encoding/hex"
 	"testing"
 
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/stretchr/testify/require"
 )
 
@@ -88,8 +90,13 @@ func TestDecodePayloadErrors(t *testing.T) {
 			encodedPayloadHex: "0100000000000000000000000000000000000000000000000000000000000000",
 		},
 		{
-			name:              "Only version 0x00 is supported",
-			encodedPayloadHex: "0001000000000000000000000000000000000000000000000000000000000000",
+			name:              "Only versions 0x00 and 0x01 are supported",
+			encodedPayloadHex: "0002000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name: "version 0x01 data must be a valid zstd stream",
+			encodedPayloadHex: "0001000000030000000000000000000000000000000000000000000000000000" +
+				"0001020300000000000000000000000000000000000000000000000000000000",
 		},
 		{
 			name:              "Payload length must be a multiple of 32 bytes",
@@ -125,3 +132,44 @@ func TestDecodePayloadErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodeDecodeCompressedPayload(t *testing.T) {
+	testCases := []struct {
+		name    string
+		payload []byte
+	}{
+		{name: "empty", payload: []byte{}},
+		{name: "small", payload: []byte("hello world")},
+		{name: "compressible", payload: bytes.Repeat([]byte("rollup batch "), 1000)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encodedPayload, err := Payload(tc.payload).ToEncodedPayloadWithVersion(codecs.PayloadEncodingVersion1)
+			require.NoError(t, err)
+			require.Equal(t, byte(codecs.PayloadEncodingVersion1), encodedPayload.bytes[1])
+
+			decodedPayload, err := encodedPayload.Decode()
+			require.NoError(t, err)
+			require.True(t, bytes.Equal(tc.payload, decodedPayload))
+		})
+	}
+
+	// Compressible payloads result in smaller encoded payloads than PayloadEncodingVersion0.
+	payload := Payload(bytes.Repeat([]byte("rollup batch "), 1000))
+	compressed, err := payload.ToEncodedPayloadWithVersion(codecs.PayloadEncodingVersion1)
+	require.NoError(t, err)
+	require.Less(t, compressed.LenSymbols(), payload.ToEncodedPayload().LenSymbols())
+}
+
+func TestEncodePayloadWithVersion(t *testing.T) {
+	payload := Payload("payload")
+
+	// PayloadEncodingVersion0 is identical to the default encoding.
+	encodedPayload, err := payload.ToEncodedPayloadWithVersion(codecs.PayloadEncodingVersion0)
+	require.NoError(t, err)
+	require.Equal(t, payload.ToEncodedPayload().bytes, encodedPayload.bytes)
+
+	_, err = payload.ToEncodedPayloadWithVersion(codecs.PayloadEncodingVersion(2))
+	require.Error(t, err)
+}
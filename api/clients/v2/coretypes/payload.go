@@ -2,6 +2,7 @@ package coretypes
 
 import (
 	"encoding/binary"
+	"fmt"
 
 	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/common/math"
@@ -14,8 +15,29 @@ type Payload []byte
 
 // ToEncodedPayload performs the [codecs.PayloadEncodingVersion0] encoding to create an encoded payload.
 func (p Payload) ToEncodedPayload() *EncodedPayload {
-	// Encode payload modulo bn254, and align to 32 bytes
-	encodedData := codec.PadPayload(p)
+	return encodePayloadData(codecs.PayloadEncodingVersion0, p)
+}
+
+// ToEncodedPayloadWithVersion encodes the payload with the given [codecs.PayloadEncodingVersion].
+//
+// Returns an error if the version is not supported.
+func (p Payload) ToEncodedPayloadWithVersion(version codecs.PayloadEncodingVersion) (*EncodedPayload, error) {
+	switch version {
+	case codecs.PayloadEncodingVersion0:
+		return p.ToEncodedPayload(), nil
+	case codecs.PayloadEncodingVersion1:
+		return encodePayloadData(version, codecs.CompressPayload(p)), nil
+	default:
+		return nil, fmt.Errorf("unsupported payload encoding version: %d", version)
+	}
+}
+
+// encodePayloadData writes the header for the given version, followed by the data padded modulo bn254.
+// For [codecs.PayloadEncodingVersion0] the data is the payload itself, whereas later versions may transform the
+// payload first.
+func encodePayloadData(version codecs.PayloadEncodingVersion, data []byte) *EncodedPayload {
+	// Encode data modulo bn254, and align to 32 bytes
+	encodedData := codec.PadPayload(data)
 
 	// Calculate the length of the EncodedPayload in symbols (including the header) which has to be a power of 2.
 	encodedDataLenSymbols := uint32(len(encodedData)) / encoding.BYTES_PER_SYMBOL
@@ -27,11 +49,11 @@ func (p Payload) ToEncodedPayload() *EncodedPayload {
 	// Write the header
 	encodedPayloadHeader := encodedPayloadBytes[:codec.EncodedPayloadHeaderLenBytes]
 	// first byte is always 0 to ensure the payloadHeader is a valid bn254 element
-	encodedPayloadHeader[1] = byte(codecs.PayloadEncodingVersion0) // encode version byte
-	// encode payload length as uint32
+	encodedPayloadHeader[1] = byte(version) // encode version byte
+	// encode data length as uint32
 	binary.BigEndian.PutUint32(
 		encodedPayloadHeader[2:6],
-		uint32(len(p))) // uint32 should be more than enough to store the length (approx 4gb)
+		uint32(len(data))) // uint32 should be more than enough to store the length (approx 4gb)
 
 	// Write the encoded data, starting after the header
 	copy(encodedPayloadBytes[codec.EncodedPayloadHeaderLenBytes:], encodedData)
@@ -257,6 +257,31 @@ func (c *DisperserClient) GetBlobStatus(
 	return reply, nil
 }
 
+// SubscribeBlobStatus opens a stream on which the disperser sends the status of the blob with the given blob key each
+// time it changes. The disperser closes the stream once the blob reaches a terminal status.
+//
+// Dispersers that don't support subscriptions return a codes.Unimplemented error, either from this method or from
+// the first call to Recv on the stream.
+func (c *DisperserClient) SubscribeBlobStatus(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+) (disperser_rpc.Disperser_SubscribeBlobStatusClient, error) {
+	request := &disperser_rpc.BlobStatusRequest{
+		BlobKey: blobKey[:],
+	}
+
+	client, err := c.clientPool.GetClient()
+	if err != nil {
+		return nil, fmt.Errorf("get client: %w", err)
+	}
+
+	stream, err := client.SubscribeBlobStatus(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("error while calling SubscribeBlobStatus: %w", err)
+	}
+	return stream, nil
+}
+
 // GetPaymentState returns the payment state of the disperser client
 func (c *DisperserClient) GetPaymentState(ctx context.Context) (*disperser_rpc.GetPaymentStateReply, error) {
 	accountID, err := c.signer.GetAccountID()
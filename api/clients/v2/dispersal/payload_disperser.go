@@ -71,7 +71,8 @@ func NewPayloadDisperser(
 //
 //  1. Encode payload into a blob
 //  2. Disperse the blob
-//  3. Poll the disperser with GetBlobStatus until a terminal status is reached, or until the polling timeout is reached
+//  3. Subscribe to the blob status with SubscribeBlobStatus (falling back to polling GetBlobStatus if the subscription
+//     fails) until a terminal status is reached, or until the timeout is reached
 //  4. Construct an EigenDACert if dispersal is successful
 //  5. Verify the constructed cert via an eth_call to the EigenDACertVerifier contract
 //  6. Return the valid cert
@@ -85,9 +86,14 @@ func (pd *PayloadDisperser) SendPayload(
 	defer probe.End()
 	probe.SetStage("convert_to_blob")
 
-	// convert the payload into an EigenDA blob by interpreting the payload in polynomial form,
+	encodedPayload, err := payload.ToEncodedPayloadWithVersion(pd.config.PayloadEncodingVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	// convert the encoded payload into an EigenDA blob by interpreting it in polynomial form,
 	// which means the encoded payload will need to be IFFT'd since EigenDA blobs are in coefficient form.
-	blob, err := payload.ToBlob(pd.config.PayloadPolynomialForm)
+	blob, err := encodedPayload.ToBlob(pd.config.PayloadPolynomialForm)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert payload to blob: %w", err)
 	}
@@ -183,13 +189,13 @@ func (pd *PayloadDisperser) buildEigenDACert(
 
 	probe.SetStage("QUEUED")
 
-	// poll the disperser for the status of the blob until it's received adequate signatures in regards to
+	// wait for the status of the blob from the disperser until it's received adequate signatures in regards to
 	// confirmation thresholds, a terminal error, or a timeout
 	timeoutCtx, cancel := context.WithTimeout(ctx, pd.config.BlobCompleteTimeout)
 	defer cancel()
-	blobStatusReply, err := pd.pollBlobStatusUntilSigned(timeoutCtx, disperserClient, blobKey, initialBlobStatus, probe)
+	blobStatusReply, err := pd.waitForBlobStatusUntilSigned(timeoutCtx, disperserClient, blobKey, initialBlobStatus, probe)
 	if err != nil {
-		return nil, fmt.Errorf("poll blob status until signed: %w", err)
+		return nil, fmt.Errorf("wait for blob status until signed: %w", err)
 	}
 
 	pd.logSigningPercentages(blobKey, blobStatusReply)
@@ -290,6 +296,77 @@ func (pd *PayloadDisperser) Close() error {
 	return nil
 }
 
+// errBlobStatusSubscriptionFailed is returned by subscribeBlobStatusUntilSigned when the disperser can't be relied on
+// to stream status updates (e.g. because it doesn't support subscriptions), and the status must be polled instead.
+var errBlobStatusSubscriptionFailed = errors.New("blob status subscription failed")
+
+// waitForBlobStatusUntilSigned waits for a blob that has been dispersed to be signed, by subscribing to status updates
+// from the disperser. If the subscription fails, it falls back to polling the disperser for the remaining time.
+//
+// This method will only return a non-nil BlobStatusReply if all quorums meet the required confirmation threshold prior
+// to timeout. In all other cases, this method will return a nil BlobStatusReply, along with an error describing the
+// failure.
+func (pd *PayloadDisperser) waitForBlobStatusUntilSigned(
+	ctx context.Context,
+	disperserClient *DisperserClient,
+	blobKey corev2.BlobKey,
+	initialStatus dispgrpc.BlobStatus,
+	probe *common.SequenceProbe,
+) (*dispgrpc.BlobStatusReply, error) {
+	blobStatusReply, lastStatus, err := pd.subscribeBlobStatusUntilSigned(
+		ctx, disperserClient, blobKey, initialStatus, probe)
+	if !errors.Is(err, errBlobStatusSubscriptionFailed) {
+		return blobStatusReply, err
+	}
+
+	pd.logger.Debug("Falling back to polling blob status", "blobKey", blobKey.Hex(), "err", err)
+	return pd.pollBlobStatusUntilSigned(ctx, disperserClient, blobKey, lastStatus, probe)
+}
+
+// subscribeBlobStatusUntilSigned receives status updates for a blob that has been dispersed, until it is signed.
+//
+// Along with the result, this method returns the last status received, so that polling can resume from there if the
+// subscription fails. Subscription failures are wrapped in errBlobStatusSubscriptionFailed.
+func (pd *PayloadDisperser) subscribeBlobStatusUntilSigned(
+	ctx context.Context,
+	disperserClient *DisperserClient,
+	blobKey corev2.BlobKey,
+	initialStatus dispgrpc.BlobStatus,
+	probe *common.SequenceProbe,
+) (*dispgrpc.BlobStatusReply, dispgrpc.BlobStatus, error) {
+
+	previousStatus := initialStatus
+
+	// Cancelling the context closes the stream, in case this method returns before the disperser closes it.
+	subscriptionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := disperserClient.SubscribeBlobStatus(subscriptionCtx, blobKey)
+	if err != nil {
+		return nil, previousStatus, fmt.Errorf("%w: %w", errBlobStatusSubscriptionFailed, err)
+	}
+
+	for {
+		// The stream only ends with io.EOF once a terminal status has been sent, in which case this method has
+		// already returned. So any error here means that the subscription failed.
+		blobStatusReply, err := stream.Recv()
+		if err != nil {
+			return nil, previousStatus, fmt.Errorf("%w: receive blob status: %w", errBlobStatusSubscriptionFailed, err)
+		}
+
+		pd.logStatusChange(blobKey, previousStatus, blobStatusReply.GetStatus())
+		previousStatus = blobStatusReply.GetStatus()
+
+		signed, err := pd.checkBlobStatusReply(ctx, blobKey, blobStatusReply, probe)
+		if err != nil {
+			return nil, previousStatus, err
+		}
+		if signed {
+			return blobStatusReply, previousStatus, nil
+		}
+	}
+}
+
 // pollBlobStatusUntilSigned polls the disperser for the status of a blob that has been dispersed
 //
 // This method will only return a non-nil BlobStatusReply if all quorums meet the required confirmation threshold prior
@@ -327,62 +404,90 @@ func (pd *PayloadDisperser) pollBlobStatusUntilSigned(
 				continue
 			}
 
-			newStatus := blobStatusReply.GetStatus()
-			if newStatus != previousStatus {
-				pd.logger.Debug(
-					"Blob status changed",
-					"blob key", blobKey.Hex(),
-					"previous status", previousStatus.String(),
-					"new status", newStatus.String())
-				previousStatus = newStatus
+			pd.logStatusChange(blobKey, previousStatus, blobStatusReply.GetStatus())
+			previousStatus = blobStatusReply.GetStatus()
+
+			signed, err := pd.checkBlobStatusReply(ctx, blobKey, blobStatusReply, probe)
+			if err != nil {
+				return nil, err
+			}
+			if signed {
+				return blobStatusReply, nil
 			}
+		}
+	}
+}
 
-			switch newStatus {
-			case dispgrpc.BlobStatus_COMPLETE:
-				err := checkThresholds(ctx, pd.certVerifier, blobStatusReply, blobKey.Hex())
-				if err != nil {
-					// TODO(samlaf): checkThresholds should return more fine-grained errors
-					// For now, we only failover if thresholds were unmet, not anything else.
-					// The risk of failing over for everything is that eth-rpc calls could fail
-					// for networking reasons, which we don't want to failover to eth for!
-					var thresholdNotMetErr *thresholdNotMetError
-					if errors.As(err, &thresholdNotMetErr) {
-						return nil, api.NewErrorFailover(fmt.Errorf("check thresholds: %w", err))
-					}
-					return nil, fmt.Errorf("check thresholds: %w", err)
-				}
+// logStatusChange logs the status of a blob if it differs from the previous status.
+func (pd *PayloadDisperser) logStatusChange(
+	blobKey corev2.BlobKey,
+	previousStatus dispgrpc.BlobStatus,
+	newStatus dispgrpc.BlobStatus,
+) {
+	if newStatus != previousStatus {
+		pd.logger.Debug(
+			"Blob status changed",
+			"blob key", blobKey.Hex(),
+			"previous status", previousStatus.String(),
+			"new status", newStatus.String())
+	}
+}
 
-				return blobStatusReply, nil
-			case dispgrpc.BlobStatus_QUEUED, dispgrpc.BlobStatus_ENCODED:
-				// Report all non-terminal statuses to the probe. Repeat reports are no-ops.
-				probe.SetStage(newStatus.String())
-				continue
-			case dispgrpc.BlobStatus_GATHERING_SIGNATURES:
-				// Report all non-terminal statuses to the probe. Repeat reports are no-ops.
-				probe.SetStage(newStatus.String())
-
-				err := checkThresholds(ctx, pd.certVerifier, blobStatusReply, blobKey.Hex())
-				if err == nil {
-					// If there's no error, then all thresholds are met, so we can stop polling
-					return blobStatusReply, nil
-				}
-
-				var thresholdNotMetErr *thresholdNotMetError
-				if !errors.As(err, &thresholdNotMetErr) {
-					// an error occurred which was unrelated to an unmet threshold: something went wrong while checking!
-					pd.logger.Warnf("error checking thresholds: %v", err)
-				}
-
-				// thresholds weren't met yet. that's ok, since signature gathering is still in progress
-				continue
-			default:
-				// Failover to another DA layer because something is wrong with EigenDA.
-				return nil, api.NewErrorFailover(
-					fmt.Errorf("terminal dispersal failure for blobKey %v. blob status: %v",
-						blobKey.Hex(),
-						newStatus.String()))
+// checkBlobStatusReply checks a BlobStatusReply received from the disperser.
+//
+// Returns true if all quorums meet the required confirmation threshold, false if the blob status needs to be checked
+// again later, and an error if the dispersal failed.
+func (pd *PayloadDisperser) checkBlobStatusReply(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	blobStatusReply *dispgrpc.BlobStatusReply,
+	probe *common.SequenceProbe,
+) (bool, error) {
+	newStatus := blobStatusReply.GetStatus()
+	switch newStatus {
+	case dispgrpc.BlobStatus_COMPLETE:
+		err := checkThresholds(ctx, pd.certVerifier, blobStatusReply, blobKey.Hex())
+		if err != nil {
+			// TODO(samlaf): checkThresholds should return more fine-grained errors
+			// For now, we only failover if thresholds were unmet, not anything else.
+			// The risk of failing over for everything is that eth-rpc calls could fail
+			// for networking reasons, which we don't want to failover to eth for!
+			var thresholdNotMetErr *thresholdNotMetError
+			if errors.As(err, &thresholdNotMetErr) {
+				return false, api.NewErrorFailover(fmt.Errorf("check thresholds: %w", err))
 			}
+			return false, fmt.Errorf("check thresholds: %w", err)
+		}
+
+		return true, nil
+	case dispgrpc.BlobStatus_QUEUED, dispgrpc.BlobStatus_ENCODED:
+		// Report all non-terminal statuses to the probe. Repeat reports are no-ops.
+		probe.SetStage(newStatus.String())
+		return false, nil
+	case dispgrpc.BlobStatus_GATHERING_SIGNATURES:
+		// Report all non-terminal statuses to the probe. Repeat reports are no-ops.
+		probe.SetStage(newStatus.String())
+
+		err := checkThresholds(ctx, pd.certVerifier, blobStatusReply, blobKey.Hex())
+		if err == nil {
+			// If there's no error, then all thresholds are met, so we can stop waiting
+			return true, nil
+		}
+
+		var thresholdNotMetErr *thresholdNotMetError
+		if !errors.As(err, &thresholdNotMetErr) {
+			// an error occurred which was unrelated to an unmet threshold: something went wrong while checking!
+			pd.logger.Warnf("error checking thresholds: %v", err)
 		}
+
+		// thresholds weren't met yet. that's ok, since signature gathering is still in progress
+		return false, nil
+	default:
+		// Failover to another DA layer because something is wrong with EigenDA.
+		return false, api.NewErrorFailover(
+			fmt.Errorf("terminal dispersal failure for blobKey %v. blob status: %v",
+				blobKey.Hex(),
+				newStatus.String()))
 	}
 }
 
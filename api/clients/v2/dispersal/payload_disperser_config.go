@@ -1,8 +1,10 @@
 package dispersal
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
 )
 
@@ -59,5 +61,9 @@ func (dc *PayloadDisperserConfig) checkAndSetDefaults() error {
 		dc.ContractCallTimeout = defaultConfig.ContractCallTimeout
 	}
 
+	if _, err := codecs.BlobEncodingVersionToCodec(dc.PayloadEncodingVersion); err != nil {
+		return fmt.Errorf("invalid payload encoding version: %w", err)
+	}
+
 	return nil
 }
@@ -1,15 +1,26 @@
 package dispersal
 
 import (
+	"context"
 	"math/big"
+	"net"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/Layr-Labs/eigenda/api"
+	clients "github.com/Layr-Labs/eigenda/api/clients/v2"
 	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/test"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestVerifyReceivedBlobKey(t *testing.T) {
@@ -51,3 +62,135 @@ func TestVerifyReceivedBlobKey(t *testing.T) {
 	_, err = verifyReceivedBlobKey(blobHeader, &reply)
 	require.Error(t, err, "Any modification to the header should cause verification to fail")
 }
+
+// fakeDisperserServer serves blob statuses from a fixed list of subscription replies, and a fixed polling reply.
+type fakeDisperserServer struct {
+	dispgrpc.UnimplementedDisperserServer
+
+	// If false, SubscribeBlobStatus is left unimplemented.
+	subscriptionsSupported bool
+	// Sent in order on each subscription. The stream fails once all replies have been sent.
+	subscriptionReplies []*dispgrpc.BlobStatusReply
+	// Returned by GetBlobStatus.
+	pollReply *dispgrpc.BlobStatusReply
+
+	getBlobStatusCalls atomic.Int32
+}
+
+func (s *fakeDisperserServer) GetBlobStatus(
+	context.Context,
+	*dispgrpc.BlobStatusRequest,
+) (*dispgrpc.BlobStatusReply, error) {
+	s.getBlobStatusCalls.Add(1)
+	return s.pollReply, nil
+}
+
+func (s *fakeDisperserServer) SubscribeBlobStatus(
+	req *dispgrpc.BlobStatusRequest,
+	stream dispgrpc.Disperser_SubscribeBlobStatusServer,
+) error {
+	if !s.subscriptionsSupported {
+		return s.UnimplementedDisperserServer.SubscribeBlobStatus(req, stream)
+	}
+	for _, reply := range s.subscriptionReplies {
+		err := stream.Send(reply)
+		if err != nil {
+			return err
+		}
+	}
+	return status.Error(codes.Unavailable, "subscription dropped")
+}
+
+// newTestPayloadDisperser starts the given fake disperser, and returns a PayloadDisperser and a DisperserClient that
+// are connected to it.
+func newTestPayloadDisperser(t *testing.T, server *fakeDisperserServer) (*PayloadDisperser, *DisperserClient) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+	dispgrpc.RegisterDisperserServer(grpcServer, server)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	logger := test.GetLogger()
+	clientPool, err := common.NewGRPCClientPool(
+		logger,
+		dispgrpc.NewDisperserClient,
+		1,
+		listener.Addr().String(),
+		clients.GetGrpcDialOptions(false, 1024*1024)...)
+	require.NoError(t, err)
+	disperserClient := &DisperserClient{
+		logger:     logger,
+		clientPool: clientPool,
+	}
+	t.Cleanup(func() { require.NoError(t, disperserClient.Close()) })
+
+	payloadDisperser := &PayloadDisperser{
+		logger: logger,
+		config: PayloadDisperserConfig{BlobStatusPollInterval: 10 * time.Millisecond},
+	}
+	return payloadDisperser, disperserClient
+}
+
+func TestWaitForBlobStatusUntilSigned(t *testing.T) {
+	queuedReply := &dispgrpc.BlobStatusReply{Status: dispgrpc.BlobStatus_QUEUED}
+	failedReply := &dispgrpc.BlobStatusReply{Status: dispgrpc.BlobStatus_FAILED}
+	blobKey := corev2.BlobKey{1}
+
+	waitForBlobStatus := func(t *testing.T, server *fakeDisperserServer) error {
+		payloadDisperser, disperserClient := newTestPayloadDisperser(t, server)
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+		defer cancel()
+		reply, err := payloadDisperser.waitForBlobStatusUntilSigned(
+			ctx, disperserClient, blobKey, dispgrpc.BlobStatus_QUEUED, nil)
+		require.Nil(t, reply)
+		return err
+	}
+
+	t.Run("subscription", func(t *testing.T) {
+		server := &fakeDisperserServer{
+			subscriptionsSupported: true,
+			subscriptionReplies:    []*dispgrpc.BlobStatusReply{queuedReply, failedReply},
+		}
+		err := waitForBlobStatus(t, server)
+		require.ErrorContains(t, err, "terminal dispersal failure")
+		var failoverErr *api.ErrorFailover
+		require.ErrorAs(t, err, &failoverErr)
+		require.Zero(t, server.getBlobStatusCalls.Load(), "status shouldn't be polled while the subscription works")
+	})
+
+	t.Run("subscription not supported", func(t *testing.T) {
+		server := &fakeDisperserServer{pollReply: failedReply}
+		err := waitForBlobStatus(t, server)
+		require.ErrorContains(t, err, "terminal dispersal failure")
+		require.Positive(t, server.getBlobStatusCalls.Load())
+	})
+
+	t.Run("subscription dropped", func(t *testing.T) {
+		server := &fakeDisperserServer{
+			subscriptionsSupported: true,
+			subscriptionReplies:    []*dispgrpc.BlobStatusReply{queuedReply},
+			pollReply:              failedReply,
+		}
+		err := waitForBlobStatus(t, server)
+		require.ErrorContains(t, err, "terminal dispersal failure")
+		require.Positive(t, server.getBlobStatusCalls.Load())
+	})
+
+	t.Run("polling times out", func(t *testing.T) {
+		server := &fakeDisperserServer{pollReply: queuedReply}
+		payloadDisperser, disperserClient := newTestPayloadDisperser(t, server)
+		ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+		defer cancel()
+		reply, err := payloadDisperser.waitForBlobStatusUntilSigned(
+			ctx, disperserClient, blobKey, dispgrpc.BlobStatus_QUEUED, nil)
+		require.Nil(t, reply)
+		require.ErrorContains(t, err, "final status was QUEUED")
+		var failoverErr *api.ErrorFailover
+		require.ErrorAs(t, err, &failoverErr)
+	})
+}
@@ -1,6 +1,10 @@
 package metrics
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/metrics"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -9,14 +13,40 @@ const (
 	retrievalSubsystem = "retrieval"
 )
 
+// Outcomes of a request to retrieve a blob from a relay.
+const (
+	RelayRequestSuccess = "success"
+	RelayRequestFailure = "failure"
+	// The request was cancelled because the blob was retrieved from another relay first.
+	RelayRequestCancelled = "cancelled"
+)
+
+// Sources a CompositePayloadRetriever retrieves blobs from.
+const (
+	RetrievalSourceRelay     = "relay"
+	RetrievalSourceValidator = "validator"
+)
+
+// Outcomes of an attempt to retrieve a payload from a retrieval source.
+const (
+	SourceRetrievalSuccess = "success"
+	SourceRetrievalFailure = "failure"
+)
+
 type RetrievalMetricer interface {
 	RecordPayloadSizeBytes(size int)
+	RecordRelayRequestLatency(relayKey uint32, outcome string, latency time.Duration)
+	RecordRelayReputationScore(relayKey uint32, score float64)
+	RecordSourceRetrievalLatency(source string, outcome string, latency time.Duration)
 
 	Document() []metrics.DocumentedMetric
 }
 
 type RetrievalMetrics struct {
-	PayloadSize prometheus.Histogram
+	PayloadSize          prometheus.Histogram
+	RelayRequestLatency  *prometheus.SummaryVec
+	RelayReputationScore *prometheus.GaugeVec
+	SourceLatency        *prometheus.SummaryVec
 
 	factory *metrics.Documentor
 }
@@ -36,6 +66,26 @@ func NewRetrievalMetrics(registry *prometheus.Registry) RetrievalMetricer {
 			Help:      "Size of decoded payloads in bytes",
 			Buckets:   blobSizeBuckets,
 		}),
+		RelayRequestLatency: factory.NewSummaryVec(prometheus.SummaryOpts{
+			Name:       "relay_request_latency_ms",
+			Namespace:  namespace,
+			Subsystem:  retrievalSubsystem,
+			Help:       "Latency of requests to retrieve and verify a blob from each relay",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		}, []string{"relay_key", "outcome"}),
+		RelayReputationScore: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name:      "relay_reputation_score",
+			Namespace: namespace,
+			Subsystem: retrievalSubsystem,
+			Help:      "Current reputation score for each relay",
+		}, []string{"relay_key"}),
+		SourceLatency: factory.NewSummaryVec(prometheus.SummaryOpts{
+			Name:       "source_latency_ms",
+			Namespace:  namespace,
+			Subsystem:  retrievalSubsystem,
+			Help:       "Latency of attempts to retrieve a payload from each retrieval source (relay or validator)",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		}, []string{"source", "outcome"}),
 		factory: factory,
 	}
 }
@@ -44,6 +94,18 @@ func (m *RetrievalMetrics) RecordPayloadSizeBytes(size int) {
 	m.PayloadSize.Observe(float64(size))
 }
 
+func (m *RetrievalMetrics) RecordRelayRequestLatency(relayKey uint32, outcome string, latency time.Duration) {
+	m.RelayRequestLatency.WithLabelValues(fmt.Sprintf("%d", relayKey), outcome).Observe(common.ToMilliseconds(latency))
+}
+
+func (m *RetrievalMetrics) RecordRelayReputationScore(relayKey uint32, score float64) {
+	m.RelayReputationScore.WithLabelValues(fmt.Sprintf("%d", relayKey)).Set(score)
+}
+
+func (m *RetrievalMetrics) RecordSourceRetrievalLatency(source string, outcome string, latency time.Duration) {
+	m.SourceLatency.WithLabelValues(source, outcome).Observe(common.ToMilliseconds(latency))
+}
+
 func (m *RetrievalMetrics) Document() []metrics.DocumentedMetric {
 	return m.factory.Document()
 }
@@ -56,6 +118,15 @@ var NoopRetrievalMetrics RetrievalMetricer = new(noopRetrievalMetricer)
 func (n *noopRetrievalMetricer) RecordPayloadSizeBytes(_ int) {
 }
 
+func (n *noopRetrievalMetricer) RecordRelayRequestLatency(_ uint32, _ string, _ time.Duration) {
+}
+
+func (n *noopRetrievalMetricer) RecordRelayReputationScore(_ uint32, _ float64) {
+}
+
+func (n *noopRetrievalMetricer) RecordSourceRetrievalLatency(_ string, _ string, _ time.Duration) {
+}
+
 func (n *noopRetrievalMetricer) Document() []metrics.DocumentedMetric {
 	return []metrics.DocumentedMetric{}
 }
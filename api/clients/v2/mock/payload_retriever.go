@@ -0,0 +1,43 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPayloadRetriever struct {
+	mock.Mock
+}
+
+var _ clients.PayloadRetriever = (*MockPayloadRetriever)(nil)
+
+func NewPayloadRetriever() *MockPayloadRetriever {
+	return &MockPayloadRetriever{}
+}
+
+//nolint:wrapcheck // mock code intentionally returns unwrapped errors
+func (r *MockPayloadRetriever) GetPayload(
+	ctx context.Context,
+	eigenDACert coretypes.EigenDACert,
+) (coretypes.Payload, error) {
+	args := r.Called(ctx, eigenDACert)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(coretypes.Payload), args.Error(1)
+}
+
+//nolint:wrapcheck // mock code intentionally returns unwrapped errors
+func (r *MockPayloadRetriever) GetEncodedPayload(
+	ctx context.Context,
+	eigenDACert coretypes.EigenDACert,
+) (*coretypes.EncodedPayload, error) {
+	args := r.Called(ctx, eigenDACert)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*coretypes.EncodedPayload), args.Error(1)
+}
@@ -28,6 +28,19 @@ func (c *MockRelayClient) GetBlob(ctx context.Context, cert coretypes.EigenDACer
 	return args.Get(0).(*coretypes.Blob), args.Error(1)
 }
 
+//nolint:wrapcheck // mock code intentionally returns unwrapped errors
+func (c *MockRelayClient) GetBlobFromRelay(
+	ctx context.Context,
+	relayKey corev2.RelayKey,
+	cert coretypes.EigenDACert,
+) (*coretypes.Blob, error) {
+	args := c.Called(ctx, relayKey, cert)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*coretypes.Blob), args.Error(1)
+}
+
 func (c *MockRelayClient) GetChunksByRange(ctx context.Context, relayKey corev2.RelayKey, requests []*relay.ChunkRequestByRange) ([][]byte, error) {
 	args := c.Called(ctx, relayKey, requests)
 	if args.Get(0) == nil {
@@ -22,6 +22,11 @@ type PayloadClientConfig struct {
 	// entirety to perform a verification that any part of the data matches the KZG commitment.
 	PayloadPolynomialForm codecs.PolynomialForm
 
+	// PayloadEncodingVersion is the encoding applied to payloads before they are converted into blobs during
+	// dispersal. Payload retrieval decodes blobs according to the version recorded in the encoded payload header,
+	// regardless of this value, so that blobs dispersed with any supported version can be retrieved.
+	PayloadEncodingVersion codecs.PayloadEncodingVersion
+
 	// The BlobVersion to use when creating new blobs, or interpreting blob bytes.
 	//
 	// BlobVersion needs to point to a version defined in the threshold registry contract.
@@ -32,7 +37,8 @@ type PayloadClientConfig struct {
 // GetDefaultPayloadClientConfig creates a PayloadClientConfig with default values
 func GetDefaultPayloadClientConfig() *PayloadClientConfig {
 	return &PayloadClientConfig{
-		PayloadPolynomialForm: codecs.PolynomialFormEval,
-		BlobVersion:           0,
+		PayloadPolynomialForm:  codecs.PolynomialFormEval,
+		PayloadEncodingVersion: codecs.PayloadEncodingVersion0,
+		BlobVersion:            0,
 	}
 }
@@ -0,0 +1,187 @@
+package payloadretrieval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	clients "github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/metrics"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// CompositePayloadRetriever retrieves payloads from the relays, and falls back to reconstructing them from the
+// validators when the relays fail. Blobs returned by either source are verified against the cert commitment by the
+// underlying retrievers, so relays that return tampered data trigger the fallback just like relays that are down.
+//
+// Which sources are used is determined by the configured RetrievalSourcePolicy.
+//
+// This struct is goroutine safe.
+type CompositePayloadRetriever struct {
+	log                logging.Logger
+	config             CompositePayloadRetrieverConfig
+	relayRetriever     clients.PayloadRetriever
+	validatorRetriever clients.PayloadRetriever
+	metrics            metrics.RetrievalMetricer
+}
+
+var _ clients.PayloadRetriever = &CompositePayloadRetriever{}
+
+// NewCompositePayloadRetriever assembles a CompositePayloadRetriever from retrievers that have already been
+// constructed. Typically, relayRetriever is a RelayPayloadRetriever and validatorRetriever a ValidatorPayloadRetriever.
+//
+// A retriever may be nil if the source policy doesn't use it.
+func NewCompositePayloadRetriever(
+	log logging.Logger,
+	config CompositePayloadRetrieverConfig,
+	relayRetriever clients.PayloadRetriever,
+	validatorRetriever clients.PayloadRetriever,
+	metrics metrics.RetrievalMetricer,
+) (*CompositePayloadRetriever, error) {
+	err := config.checkAndSetDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("check and set CompositePayloadRetrieverConfig config: %w", err)
+	}
+
+	if relayRetriever == nil && config.SourcePolicy != ValidatorsOnly {
+		return nil, fmt.Errorf("relay retriever is required with source policy %q", config.SourcePolicy)
+	}
+	if validatorRetriever == nil && config.SourcePolicy != RelaysOnly {
+		return nil, fmt.Errorf("validator retriever is required with source policy %q", config.SourcePolicy)
+	}
+
+	return &CompositePayloadRetriever{
+		log:                log,
+		config:             config,
+		relayRetriever:     relayRetriever,
+		validatorRetriever: validatorRetriever,
+		metrics:            metrics,
+	}, nil
+}
+
+// GetPayload retrieves the blob of the EigenDACert from the sources allowed by the RetrievalSourcePolicy, and decodes
+// it to yield the payload.
+//
+// This method does NOT verify the eigenDACert on chain: it is assumed that the input eigenDACert has already been
+// verified prior to calling this method.
+func (pr *CompositePayloadRetriever) GetPayload(
+	ctx context.Context,
+	eigenDACert coretypes.EigenDACert,
+) (coretypes.Payload, error) {
+
+	encodedPayload, err := pr.GetEncodedPayload(ctx, eigenDACert)
+	if err != nil {
+		return nil, err
+	}
+
+	// Blobs that were verified against the cert but can't be decoded would fail to decode no matter which source
+	// they were retrieved from, so decoding errors don't trigger a fallback.
+	payload, err := encodedPayload.Decode()
+	if err != nil {
+		// If we successfully compute the blob key, we add it to the error message to help with debugging.
+		blobKey, keyErr := eigenDACert.ComputeBlobKey()
+		if keyErr == nil {
+			err = fmt.Errorf("blob %v: %w", blobKey.Hex(), err)
+		}
+		return nil, coretypes.ErrBlobDecodingFailedDerivationError.WithMessage(err.Error())
+	}
+
+	pr.metrics.RecordPayloadSizeBytes(len(payload))
+
+	return payload, nil
+}
+
+// GetEncodedPayload retrieves the blob of the EigenDACert from the sources allowed by the RetrievalSourcePolicy, and
+// returns it in encoded payload form.
+//
+// With the RelaysThenValidators policy, the validators are only queried if the relays fail, and the failure isn't
+// caused by the cert itself or by the cancellation of ctx.
+//
+// This method does NOT verify the eigenDACert on chain: it is assumed that the input eigenDACert has already been
+// verified prior to calling this method.
+func (pr *CompositePayloadRetriever) GetEncodedPayload(
+	ctx context.Context,
+	eigenDACert coretypes.EigenDACert,
+) (*coretypes.EncodedPayload, error) {
+
+	switch pr.config.SourcePolicy {
+	case RelaysOnly:
+		return pr.getFromRelays(ctx, eigenDACert)
+	case ValidatorsOnly:
+		return pr.getFromValidators(ctx, eigenDACert)
+	case RelaysThenValidators:
+	default:
+		return nil, fmt.Errorf("unknown retrieval source policy %q", pr.config.SourcePolicy)
+	}
+
+	encodedPayload, relayErr := pr.getFromRelays(ctx, eigenDACert)
+	if relayErr == nil {
+		return encodedPayload, nil
+	}
+	if !shouldFallBackToValidators(ctx, relayErr) {
+		return nil, relayErr
+	}
+
+	pr.log.Warn("retrieval from relays failed, falling back to validators", "error", relayErr)
+	encodedPayload, validatorErr := pr.getFromValidators(ctx, eigenDACert)
+	if validatorErr != nil {
+		return nil, fmt.Errorf("retrieve from relays and validators: %w", errors.Join(relayErr, validatorErr))
+	}
+
+	return encodedPayload, nil
+}
+
+// getFromRelays retrieves the encoded payload from the relays, within the RelayBudget if one is configured.
+func (pr *CompositePayloadRetriever) getFromRelays(
+	ctx context.Context,
+	eigenDACert coretypes.EigenDACert,
+) (*coretypes.EncodedPayload, error) {
+	if pr.config.RelayBudget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, pr.config.RelayBudget)
+		defer cancel()
+	}
+
+	start := time.Now()
+	encodedPayload, err := pr.relayRetriever.GetEncodedPayload(ctx, eigenDACert)
+	pr.recordSourceRetrieval(metrics.RetrievalSourceRelay, start, err)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve from relays: %w", err)
+	}
+	return encodedPayload, nil
+}
+
+// getFromValidators reconstructs the encoded payload from the chunks held by the validators.
+func (pr *CompositePayloadRetriever) getFromValidators(
+	ctx context.Context,
+	eigenDACert coretypes.EigenDACert,
+) (*coretypes.EncodedPayload, error) {
+	start := time.Now()
+	encodedPayload, err := pr.validatorRetriever.GetEncodedPayload(ctx, eigenDACert)
+	pr.recordSourceRetrieval(metrics.RetrievalSourceValidator, start, err)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve from validators: %w", err)
+	}
+	return encodedPayload, nil
+}
+
+func (pr *CompositePayloadRetriever) recordSourceRetrieval(source string, start time.Time, err error) {
+	outcome := metrics.SourceRetrievalSuccess
+	if err != nil {
+		outcome = metrics.SourceRetrievalFailure
+	}
+	pr.metrics.RecordSourceRetrievalLatency(source, outcome, time.Since(start))
+}
+
+// shouldFallBackToValidators returns whether a failure to retrieve from the relays may be recovered by retrieving from
+// the validators. This isn't the case if the caller gave up, or if the cert itself is malformed, since the validators
+// would reject it as well.
+func shouldFallBackToValidators(ctx context.Context, relayErr error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var maliciousOperatorsError coretypes.MaliciousOperatorsError
+	return !errors.As(relayErr, &maliciousOperatorsError)
+}
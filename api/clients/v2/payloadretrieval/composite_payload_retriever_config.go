@@ -0,0 +1,58 @@
+package payloadretrieval
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetrievalSourcePolicy determines which retrieval sources a CompositePayloadRetriever uses, and in which order.
+type RetrievalSourcePolicy string
+
+const (
+	// Payloads are retrieved from the relays. If the relays fail, the blob is reconstructed from the validators.
+	RelaysThenValidators RetrievalSourcePolicy = "relays-then-validators"
+	// Payloads are only retrieved from the relays.
+	RelaysOnly RetrievalSourcePolicy = "relays-only"
+	// Payloads are only reconstructed from the validators.
+	ValidatorsOnly RetrievalSourcePolicy = "validators-only"
+)
+
+// CompositePayloadRetrieverConfig contains the configuration values needed by a CompositePayloadRetriever.
+//
+// The configuration of the underlying relay and validator retrievers is passed to their own constructors.
+type CompositePayloadRetrieverConfig struct {
+	// Which retrieval sources are used. Defaults to RelaysThenValidators.
+	SourcePolicy RetrievalSourcePolicy
+
+	// With the RelaysThenValidators policy, the maximum amount of time spent retrieving from the relays before
+	// falling back to the validators. 0 means that the relays aren't given an overall deadline, and are only limited
+	// by their own timeouts.
+	RelayBudget time.Duration
+}
+
+// getDefaultCompositePayloadRetrieverConfig creates a CompositePayloadRetrieverConfig with default values
+func getDefaultCompositePayloadRetrieverConfig() *CompositePayloadRetrieverConfig {
+	return &CompositePayloadRetrieverConfig{
+		SourcePolicy: RelaysThenValidators,
+	}
+}
+
+// checkAndSetDefaults checks an existing config struct. If a given field is 0, and 0 is not an acceptable value, then
+// this method sets it to the default.
+func (cc *CompositePayloadRetrieverConfig) checkAndSetDefaults() error {
+	defaultConfig := getDefaultCompositePayloadRetrieverConfig()
+	if cc.SourcePolicy == "" {
+		cc.SourcePolicy = defaultConfig.SourcePolicy
+	}
+	switch cc.SourcePolicy {
+	case RelaysThenValidators, RelaysOnly, ValidatorsOnly:
+	default:
+		return fmt.Errorf("unknown retrieval source policy %q", cc.SourcePolicy)
+	}
+
+	if cc.RelayBudget < 0 {
+		return fmt.Errorf("relay budget must not be negative, got %v", cc.RelayBudget)
+	}
+
+	return nil
+}
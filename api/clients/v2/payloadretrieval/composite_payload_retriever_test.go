@@ -0,0 +1,181 @@
+package payloadretrieval
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/metrics"
+	clientsmock "github.com/Layr-Labs/eigenda/api/clients/v2/mock"
+	"github.com/Layr-Labs/eigenda/test"
+	testrandom "github.com/Layr-Labs/eigenda/test/random"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type compositePayloadRetrieverTester struct {
+	retriever          *CompositePayloadRetriever
+	relayRetriever     *clientsmock.MockPayloadRetriever
+	validatorRetriever *clientsmock.MockPayloadRetriever
+	cert               coretypes.EigenDACert
+	payload            coretypes.Payload
+}
+
+func buildCompositePayloadRetrieverTester(
+	t *testing.T,
+	config CompositePayloadRetrieverConfig,
+) compositePayloadRetrieverTester {
+	relayRetriever := clientsmock.NewPayloadRetriever()
+	validatorRetriever := clientsmock.NewPayloadRetriever()
+	retriever, err := NewCompositePayloadRetriever(
+		test.GetLogger(), config, relayRetriever, validatorRetriever, metrics.NoopRetrievalMetrics)
+	require.NoError(t, err)
+
+	return compositePayloadRetrieverTester{
+		retriever:          retriever,
+		relayRetriever:     relayRetriever,
+		validatorRetriever: validatorRetriever,
+		// the cert is only passed through to the mocked retrievers
+		cert:    &coretypes.EigenDACertV3{},
+		payload: testrandom.NewTestRandom().Bytes(100),
+	}
+}
+
+func TestCompositeRelaySuccess(t *testing.T) {
+	tester := buildCompositePayloadRetrieverTester(t, CompositePayloadRetrieverConfig{})
+
+	tester.relayRetriever.On("GetEncodedPayload", mock.Anything, tester.cert).
+		Return(tester.payload.ToEncodedPayload(), nil).Once()
+
+	payload, err := tester.retriever.GetPayload(t.Context(), tester.cert)
+	require.NoError(t, err)
+	require.Equal(t, tester.payload, payload)
+
+	tester.relayRetriever.AssertExpectations(t)
+	tester.validatorRetriever.AssertNotCalled(t, "GetEncodedPayload", mock.Anything, mock.Anything)
+}
+
+func TestCompositeFallsBackToValidators(t *testing.T) {
+	tester := buildCompositePayloadRetrieverTester(t, CompositePayloadRetrieverConfig{})
+
+	tester.relayRetriever.On("GetEncodedPayload", mock.Anything, tester.cert).
+		Return(nil, errors.New("commitment mismatch")).Once()
+	tester.validatorRetriever.On("GetEncodedPayload", mock.Anything, tester.cert).
+		Return(tester.payload.ToEncodedPayload(), nil).Once()
+
+	payload, err := tester.retriever.GetPayload(t.Context(), tester.cert)
+	require.NoError(t, err)
+	require.Equal(t, tester.payload, payload)
+
+	tester.relayRetriever.AssertExpectations(t)
+	tester.validatorRetriever.AssertExpectations(t)
+}
+
+func TestCompositeBothSourcesFail(t *testing.T) {
+	tester := buildCompositePayloadRetrieverTester(t, CompositePayloadRetrieverConfig{})
+
+	relayErr := errors.New("relay error")
+	validatorErr := errors.New("validator error")
+	tester.relayRetriever.On("GetEncodedPayload", mock.Anything, tester.cert).Return(nil, relayErr).Once()
+	tester.validatorRetriever.On("GetEncodedPayload", mock.Anything, tester.cert).Return(nil, validatorErr).Once()
+
+	payload, err := tester.retriever.GetPayload(t.Context(), tester.cert)
+	require.Nil(t, payload)
+	require.ErrorIs(t, err, relayErr)
+	require.ErrorIs(t, err, validatorErr)
+}
+
+// TestCompositeNoFallbackForMaliciousOperatorsError checks that certs rejected by the relay retriever aren't sent to
+// the validators, since they would be rejected again.
+func TestCompositeNoFallbackForMaliciousOperatorsError(t *testing.T) {
+	tester := buildCompositePayloadRetrieverTester(t, CompositePayloadRetrieverConfig{})
+
+	tester.relayRetriever.On("GetEncodedPayload", mock.Anything, tester.cert).
+		Return(nil, coretypes.ErrCertCommitmentBlobLengthNotPowerOf2MaliciousOperatorsError).Once()
+
+	payload, err := tester.retriever.GetPayload(t.Context(), tester.cert)
+	require.Nil(t, payload)
+	var maliciousOperatorsError coretypes.MaliciousOperatorsError
+	require.ErrorAs(t, err, &maliciousOperatorsError)
+
+	tester.validatorRetriever.AssertNotCalled(t, "GetEncodedPayload", mock.Anything, mock.Anything)
+}
+
+func TestCompositeNoFallbackWhenCancelled(t *testing.T) {
+	tester := buildCompositePayloadRetrieverTester(t, CompositePayloadRetrieverConfig{})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	tester.relayRetriever.On("GetEncodedPayload", mock.Anything, tester.cert).
+		Run(func(mock.Arguments) { cancel() }).
+		Return(nil, context.Canceled).Once()
+
+	_, err := tester.retriever.GetEncodedPayload(ctx, tester.cert)
+	require.ErrorIs(t, err, context.Canceled)
+
+	tester.validatorRetriever.AssertNotCalled(t, "GetEncodedPayload", mock.Anything, mock.Anything)
+}
+
+func TestCompositeRelayBudget(t *testing.T) {
+	tester := buildCompositePayloadRetrieverTester(t, CompositePayloadRetrieverConfig{
+		RelayBudget: 10 * time.Millisecond,
+	})
+
+	// the relays hang until their budget runs out
+	tester.relayRetriever.On("GetEncodedPayload", mock.Anything, tester.cert).
+		Run(func(args mock.Arguments) { <-args.Get(0).(context.Context).Done() }).
+		Return(nil, context.DeadlineExceeded).Once()
+	tester.validatorRetriever.On("GetEncodedPayload", mock.Anything, tester.cert).
+		Return(tester.payload.ToEncodedPayload(), nil).Once()
+
+	encodedPayload, err := tester.retriever.GetEncodedPayload(t.Context(), tester.cert)
+	require.NoError(t, err)
+	require.Equal(t, tester.payload.ToEncodedPayload(), encodedPayload)
+}
+
+func TestCompositeSourcePolicies(t *testing.T) {
+	relaysOnlyTester := buildCompositePayloadRetrieverTester(t, CompositePayloadRetrieverConfig{
+		SourcePolicy: RelaysOnly,
+	})
+	relaysOnlyTester.relayRetriever.On("GetEncodedPayload", mock.Anything, relaysOnlyTester.cert).
+		Return(nil, errors.New("relay error")).Once()
+	_, err := relaysOnlyTester.retriever.GetEncodedPayload(t.Context(), relaysOnlyTester.cert)
+	require.Error(t, err)
+	relaysOnlyTester.validatorRetriever.AssertNotCalled(t, "GetEncodedPayload", mock.Anything, mock.Anything)
+
+	validatorsOnlyTester := buildCompositePayloadRetrieverTester(t, CompositePayloadRetrieverConfig{
+		SourcePolicy: ValidatorsOnly,
+	})
+	validatorsOnlyTester.validatorRetriever.On("GetEncodedPayload", mock.Anything, validatorsOnlyTester.cert).
+		Return(validatorsOnlyTester.payload.ToEncodedPayload(), nil).Once()
+	_, err = validatorsOnlyTester.retriever.GetEncodedPayload(t.Context(), validatorsOnlyTester.cert)
+	require.NoError(t, err)
+	validatorsOnlyTester.relayRetriever.AssertNotCalled(t, "GetEncodedPayload", mock.Anything, mock.Anything)
+}
+
+func TestCompositeConfigValidation(t *testing.T) {
+	_, err := NewCompositePayloadRetriever(
+		test.GetLogger(),
+		CompositePayloadRetrieverConfig{SourcePolicy: "unknown"},
+		clientsmock.NewPayloadRetriever(),
+		clientsmock.NewPayloadRetriever(),
+		metrics.NoopRetrievalMetrics)
+	require.Error(t, err)
+
+	_, err = NewCompositePayloadRetriever(
+		test.GetLogger(),
+		CompositePayloadRetrieverConfig{},
+		clientsmock.NewPayloadRetriever(),
+		nil,
+		metrics.NoopRetrievalMetrics)
+	require.Error(t, err)
+
+	_, err = NewCompositePayloadRetriever(
+		test.GetLogger(),
+		CompositePayloadRetrieverConfig{SourcePolicy: RelaysOnly},
+		clientsmock.NewPayloadRetriever(),
+		nil,
+		metrics.NoopRetrievalMetrics)
+	require.NoError(t, err)
+}
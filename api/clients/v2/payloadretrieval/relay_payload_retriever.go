@@ -2,7 +2,12 @@ package payloadretrieval
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
 
 	clients "github.com/Layr-Labs/eigenda/api/clients/v2"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
@@ -10,10 +15,26 @@ import (
 	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
 	"github.com/Layr-Labs/eigenda/common/math"
+	"github.com/Layr-Labs/eigenda/common/reputation"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 )
 
+// Contains the information needed for relay selection.
+type relayInfo struct {
+	key             corev2.RelayKey
+	reputationScore float64
+}
+
+// The outcome of a request to retrieve a blob from a single relay.
+type relayResult struct {
+	relayKey corev2.RelayKey
+	blob     *coretypes.Blob
+	err      error
+}
+
 // RelayPayloadRetriever provides the ability to get payloads from the relay subsystem.
 //
 // This struct is goroutine safe.
@@ -23,6 +44,12 @@ type RelayPayloadRetriever struct {
 	relayClient relay.RelayClient
 	g1Srs       []bn254.G1Affine
 	metrics     metrics.RetrievalMetricer
+	// map from relay key to its reputation tracker
+	reputations map[corev2.RelayKey]*reputation.Reputation
+	// orders relays based on reputation
+	reputationSelector *reputation.ReputationSelector[*relayInfo]
+	// protects reputations and reputationSelector, which are not goroutine safe
+	lock sync.Mutex
 }
 
 var _ clients.PayloadRetriever = &RelayPayloadRetriever{}
@@ -41,12 +68,24 @@ func NewRelayPayloadRetriever(
 		return nil, fmt.Errorf("check and set RelayPayloadRetrieverConfig config: %w", err)
 	}
 
+	reputationSelector, err := reputation.NewReputationSelector(
+		log,
+		&relayPayloadRetrieverConfig.SelectorConfig,
+		rand.New(rand.NewSource(time.Now().UnixNano())),
+		func(r *relayInfo) float64 { return r.reputationScore },
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create reputation selector: %w", err)
+	}
+
 	return &RelayPayloadRetriever{
-		log:         log,
-		config:      relayPayloadRetrieverConfig,
-		relayClient: relayClient,
-		g1Srs:       g1Srs,
-		metrics:     metrics,
+		log:                log,
+		config:             relayPayloadRetrieverConfig,
+		relayClient:        relayClient,
+		g1Srs:              g1Srs,
+		metrics:            metrics,
+		reputations:        make(map[corev2.RelayKey]*reputation.Reputation),
+		reputationSelector: reputationSelector,
 	}, nil
 }
 
@@ -83,10 +122,12 @@ func (pr *RelayPayloadRetriever) GetPayload(
 	return payload, nil
 }
 
-// GetEncodedPayload retrieves a blob from the relay specified in the EigenDACert.
+// GetEncodedPayload retrieves a blob from the relays specified in the EigenDACert.
 //
-// If the blob is successfully retrieved, then the blob is verified against the EigenDACert.
-// If the verification succeeds, the blob is converted to an encoded payload form and returned.
+// Relays are tried according to the configured RelayRetrievalStrategy, in an order that prefers relays with a good
+// reputation. Each blob received from a relay is verified against the EigenDACert: relays returning a blob that
+// doesn't match are treated like relays returning an error. Once a blob is verified, it is converted to an encoded
+// payload form and returned.
 //
 // This method does NOT verify the eigenDACert on chain: it is assumed that the input
 // eigenDACert has already been verified prior to calling this method.
@@ -110,22 +151,222 @@ func (pr *RelayPayloadRetriever) GetEncodedPayload(
 		return nil, coretypes.ErrCertCommitmentBlobLengthNotPowerOf2MaliciousOperatorsError.WithBlobKey(blobKey.Hex())
 	}
 
+	relayKeys, err := pr.orderRelays(eigenDACert.RelayKeys(), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("blob %s: order relays: %w", blobKey.Hex(), err)
+	}
+
+	var blob *coretypes.Blob
+	switch pr.config.RetrievalStrategy {
+	case HedgedRelayRetrieval:
+		blob, err = pr.getBlobInParallel(ctx, eigenDACert, blobCommitments.Commitment, relayKeys, 1)
+	case RaceRelayRetrieval:
+		blob, err = pr.getBlobInParallel(ctx, eigenDACert, blobCommitments.Commitment, relayKeys, len(relayKeys))
+	case SequentialRelayRetrieval:
+		blob, err = pr.getBlobSequentially(ctx, eigenDACert, blobCommitments.Commitment, relayKeys)
+	default:
+		return nil, fmt.Errorf("unknown relay retrieval strategy %q", pr.config.RetrievalStrategy)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("blob %s: get blob from relays: %w", blobKey.Hex(), err)
+	}
+
+	return blob.ToEncodedPayloadUnchecked(pr.config.PayloadPolynomialForm), nil
+}
+
+// getBlobSequentially tries the relays one at a time, and returns the first blob that matches the commitment.
+func (pr *RelayPayloadRetriever) getBlobSequentially(
+	ctx context.Context,
+	eigenDACert coretypes.EigenDACert,
+	commitment *encoding.G1Commitment,
+	relayKeys []corev2.RelayKey,
+) (*coretypes.Blob, error) {
+	var errs []error
+	for _, relayKey := range relayKeys {
+		blob, err := pr.getVerifiedBlobFromRelay(ctx, eigenDACert, commitment, relayKey)
+		if err == nil {
+			return blob, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+// getBlobInParallel starts by sending requests to the first initialRequests relays. A request is then sent to the
+// next relay each time a request fails, or when no request has returned within the HedgeDelay. The first blob that
+// matches the commitment is returned, and the requests still in flight are cancelled.
+func (pr *RelayPayloadRetriever) getBlobInParallel(
+	ctx context.Context,
+	eigenDACert coretypes.EigenDACert,
+	commitment *encoding.G1Commitment,
+	relayKeys []corev2.RelayKey,
+	initialRequests int,
+) (*coretypes.Blob, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	// cancels the requests that lost the race
+	defer cancel()
+
+	// buffered so that the goroutines of cancelled requests don't block
+	results := make(chan relayResult, len(relayKeys))
+	nextRelay := 0
+	inFlight := 0
+	sendNextRequest := func() {
+		relayKey := relayKeys[nextRelay]
+		nextRelay++
+		inFlight++
+		go func() {
+			blob, err := pr.getVerifiedBlobFromRelay(ctx, eigenDACert, commitment, relayKey)
+			results <- relayResult{relayKey: relayKey, blob: blob, err: err}
+		}()
+	}
+
+	for nextRelay < initialRequests && nextRelay < len(relayKeys) {
+		sendNextRequest()
+	}
+
+	hedgeTimer := time.NewTimer(pr.config.HedgeDelay)
+	defer hedgeTimer.Stop()
+
+	var errs []error
+	for inFlight > 0 {
+		select {
+		case <-hedgeTimer.C:
+			if nextRelay < len(relayKeys) {
+				pr.log.Debug("Relay is slow, hedging with the next relay",
+					"nextRelayKey", relayKeys[nextRelay], "hedgeDelay", pr.config.HedgeDelay)
+				sendNextRequest()
+			}
+		case result := <-results:
+			inFlight--
+			if result.err == nil {
+				return result.blob, nil
+			}
+			errs = append(errs, result.err)
+			if nextRelay < len(relayKeys) {
+				sendNextRequest()
+			}
+		}
+		hedgeTimer.Reset(pr.config.HedgeDelay)
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// getVerifiedBlobFromRelay retrieves a blob from a single relay, and verifies it against the commitment.
+//
+// The outcome is reported to the relay's reputation and to the metrics, unless the request was cancelled by the
+// caller, in which case it says nothing about the relay.
+func (pr *RelayPayloadRetriever) getVerifiedBlobFromRelay(
+	ctx context.Context,
+	eigenDACert coretypes.EigenDACert,
+	commitment *encoding.G1Commitment,
+	relayKey corev2.RelayKey,
+) (*coretypes.Blob, error) {
+	start := time.Now()
+	blob, err := pr.getBlobFromRelay(ctx, eigenDACert, commitment, relayKey)
+	latency := time.Since(start)
+
+	switch {
+	case err == nil:
+		pr.metrics.RecordRelayRequestLatency(relayKey, metrics.RelayRequestSuccess, latency)
+		pr.reportRelayOutcome(relayKey, true, time.Now())
+	case ctx.Err() != nil:
+		pr.metrics.RecordRelayRequestLatency(relayKey, metrics.RelayRequestCancelled, latency)
+	default:
+		pr.log.Debug("Failed to get blob from relay", "relayKey", relayKey, "err", err)
+		pr.metrics.RecordRelayRequestLatency(relayKey, metrics.RelayRequestFailure, latency)
+		pr.reportRelayOutcome(relayKey, false, time.Now())
+	}
+	return blob, err
+}
+
+// getBlobFromRelay retrieves a blob from a single relay, within the RelayTimeout, and verifies it against the
+// commitment.
+func (pr *RelayPayloadRetriever) getBlobFromRelay(
+	ctx context.Context,
+	eigenDACert coretypes.EigenDACert,
+	commitment *encoding.G1Commitment,
+	relayKey corev2.RelayKey,
+) (*coretypes.Blob, error) {
 	timeoutCtx, cancel := context.WithTimeout(ctx, pr.config.RelayTimeout)
 	defer cancel()
-	blob, err := pr.relayClient.GetBlob(timeoutCtx, eigenDACert)
+	blob, err := pr.relayClient.GetBlobFromRelay(timeoutCtx, relayKey, eigenDACert)
 	if err != nil {
-		return nil, fmt.Errorf("blob %s: get blob from relay: %w", blobKey.Hex(), err)
+		return nil, fmt.Errorf("relay %d: get blob: %w", relayKey, err)
 	}
 
-	valid, err := verification.GenerateAndCompareBlobCommitment(pr.g1Srs, blob, blobCommitments.Commitment)
+	valid, err := verification.GenerateAndCompareBlobCommitment(pr.g1Srs, blob, commitment)
 	if err != nil {
-		return nil, fmt.Errorf("blob %s: generate and compare blob commitment: %w", blobKey.Hex(), err)
+		return nil, fmt.Errorf("relay %d: generate and compare blob commitment: %w", relayKey, err)
 	}
 	if !valid {
-		return nil, fmt.Errorf("blob %s: commitment mismatch with cert", blobKey.Hex())
+		return nil, fmt.Errorf("relay %d: commitment mismatch with cert", relayKey)
 	}
 
-	return blob.ToEncodedPayloadUnchecked(pr.config.PayloadPolynomialForm), nil
+	return blob, nil
+}
+
+// orderRelays returns the given relays in the order in which they should be tried.
+//
+// The order is drawn from the reputation selector, so that healthy relays are usually tried first, while relays
+// with a poor reputation still occasionally get a chance to recover.
+func (pr *RelayPayloadRetriever) orderRelays(
+	relayKeys []corev2.RelayKey,
+	now time.Time,
+) ([]corev2.RelayKey, error) {
+	if len(relayKeys) == 0 {
+		return nil, errors.New("cert contains no relay keys")
+	}
+
+	pr.lock.Lock()
+	defer pr.lock.Unlock()
+
+	candidates := make([]*relayInfo, 0, len(relayKeys))
+	for _, relayKey := range relayKeys {
+		if slices.ContainsFunc(candidates, func(r *relayInfo) bool { return r.key == relayKey }) {
+			continue
+		}
+
+		// Initialize reputation if it doesn't exist
+		if _, exists := pr.reputations[relayKey]; !exists {
+			pr.reputations[relayKey] = reputation.NewReputation(pr.config.ReputationConfig, now)
+		}
+		score := pr.reputations[relayKey].Score(now)
+		pr.metrics.RecordRelayReputationScore(relayKey, score)
+		candidates = append(candidates, &relayInfo{
+			key:             relayKey,
+			reputationScore: score,
+		})
+	}
+
+	orderedRelayKeys := make([]corev2.RelayKey, 0, len(candidates))
+	for len(candidates) > 0 {
+		selected, err := pr.reputationSelector.Select(candidates)
+		if err != nil {
+			return nil, fmt.Errorf("select relay: %w", err)
+		}
+		orderedRelayKeys = append(orderedRelayKeys, selected.key)
+		candidates = slices.DeleteFunc(candidates, func(r *relayInfo) bool { return r == selected })
+	}
+
+	return orderedRelayKeys, nil
+}
+
+// reportRelayOutcome reports the outcome of a request to a relay to the reputation system.
+func (pr *RelayPayloadRetriever) reportRelayOutcome(relayKey corev2.RelayKey, success bool, now time.Time) {
+	pr.lock.Lock()
+	defer pr.lock.Unlock()
+
+	relayReputation, exists := pr.reputations[relayKey]
+	if !exists {
+		relayReputation = reputation.NewReputation(pr.config.ReputationConfig, now)
+		pr.reputations[relayKey] = relayReputation
+	}
+	if success {
+		relayReputation.ReportSuccess(now)
+	} else {
+		relayReputation.ReportFailure(now)
+	}
 }
 
 // Close is responsible for calling close on all internal clients. This method will do its best to close all internal
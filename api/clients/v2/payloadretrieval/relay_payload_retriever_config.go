@@ -1,9 +1,26 @@
 package payloadretrieval
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/common/reputation"
+)
+
+// RelayRetrievalStrategy determines how a RelayPayloadRetriever spreads requests over the relays of a cert.
+//
+// With all strategies, relays are tried in an order chosen by their reputation, so that healthy relays are preferred.
+type RelayRetrievalStrategy string
+
+const (
+	// Relays are tried one at a time. A relay is only tried once the previous one has failed or timed out.
+	SequentialRelayRetrieval RelayRetrievalStrategy = "sequential"
+	// A request is sent to the next relay each time the previous one fails, or hasn't returned within the
+	// HedgeDelay. The first valid blob is returned, and the other requests are cancelled.
+	HedgedRelayRetrieval RelayRetrievalStrategy = "hedged"
+	// Requests are sent to all relays at once. The first valid blob is returned, and the other requests are cancelled.
+	RaceRelayRetrieval RelayRetrievalStrategy = "race"
 )
 
 // RelayPayloadRetrieverConfig contains an embedded PayloadClientConfig, plus all additional configuration values needed
@@ -13,6 +30,19 @@ type RelayPayloadRetrieverConfig struct {
 
 	// The timeout duration for relay calls to retrieve blobs.
 	RelayTimeout time.Duration
+
+	// How requests are spread over the relays of a cert. Defaults to SequentialRelayRetrieval.
+	RetrievalStrategy RelayRetrievalStrategy
+
+	// With the HedgedRelayRetrieval strategy, how long to wait for a relay before also sending a request to the next
+	// one.
+	HedgeDelay time.Duration
+
+	// Configuration for the reputation system used to order relays. Zero values are replaced by the defaults.
+	ReputationConfig reputation.ReputationConfig
+
+	// Configuration for the reputation selector used to order relays. Zero values are replaced by the defaults.
+	SelectorConfig reputation.ReputationSelectorConfig
 }
 
 // getDefaultRelayPayloadRetrieverConfig creates a RelayPayloadRetrieverConfig with default values
@@ -20,6 +50,10 @@ func getDefaultRelayPayloadRetrieverConfig() *RelayPayloadRetrieverConfig {
 	return &RelayPayloadRetrieverConfig{
 		PayloadClientConfig: *clients.GetDefaultPayloadClientConfig(),
 		RelayTimeout:        5 * time.Second,
+		RetrievalStrategy:   SequentialRelayRetrieval,
+		HedgeDelay:          500 * time.Millisecond,
+		ReputationConfig:    reputation.DefaultConfig(),
+		SelectorConfig:      reputation.DefaultReputationSelectorConfig(),
 	}
 }
 
@@ -31,5 +65,34 @@ func (rc *RelayPayloadRetrieverConfig) checkAndSetDefaults() error {
 		rc.RelayTimeout = defaultConfig.RelayTimeout
 	}
 
+	if rc.RetrievalStrategy == "" {
+		rc.RetrievalStrategy = defaultConfig.RetrievalStrategy
+	}
+	switch rc.RetrievalStrategy {
+	case SequentialRelayRetrieval, HedgedRelayRetrieval, RaceRelayRetrieval:
+	default:
+		return fmt.Errorf("unknown relay retrieval strategy %q", rc.RetrievalStrategy)
+	}
+
+	if rc.HedgeDelay == 0 {
+		rc.HedgeDelay = defaultConfig.HedgeDelay
+	}
+
+	if rc.ReputationConfig == (reputation.ReputationConfig{}) {
+		rc.ReputationConfig = defaultConfig.ReputationConfig
+	}
+	err := rc.ReputationConfig.Verify()
+	if err != nil {
+		return fmt.Errorf("verify reputation config: %w", err)
+	}
+
+	if rc.SelectorConfig == (reputation.ReputationSelectorConfig{}) {
+		rc.SelectorConfig = defaultConfig.SelectorConfig
+	}
+	err = rc.SelectorConfig.Verify()
+	if err != nil {
+		return fmt.Errorf("verify selector config: %w", err)
+	}
+
 	return nil
 }
@@ -48,12 +48,18 @@ func (t *RelayPayloadRetrieverTester) PayloadPolynomialForm() codecs.PolynomialF
 
 // buildRelayPayloadRetrieverTester sets up a client with mocks necessary for testing
 func buildRelayPayloadRetrieverTester(t *testing.T) RelayPayloadRetrieverTester {
-	logger := test.GetLogger()
-
-	clientConfig := RelayPayloadRetrieverConfig{
+	return buildRelayPayloadRetrieverTesterWithConfig(t, RelayPayloadRetrieverConfig{
 		PayloadClientConfig: clients.PayloadClientConfig{},
 		RelayTimeout:        50 * time.Millisecond,
-	}
+	})
+}
+
+// buildRelayPayloadRetrieverTesterWithConfig sets up a client with the given config, and mocks necessary for testing
+func buildRelayPayloadRetrieverTesterWithConfig(
+	t *testing.T,
+	clientConfig RelayPayloadRetrieverConfig,
+) RelayPayloadRetrieverTester {
+	logger := test.GetLogger()
 
 	mockRelayClient := clientsmock.MockRelayClient{}
 	random := testrandom.NewTestRandom()
@@ -152,7 +158,7 @@ func TestGetPayloadSuccess(t *testing.T) {
 	tester := buildRelayPayloadRetrieverTester(t)
 	blob, blobCert := buildBlobAndCert(t, tester)
 
-	tester.MockRelayClient.On("GetBlob", mock.Anything, blobCert).Return(blob, nil).Once()
+	tester.MockRelayClient.On("GetBlobFromRelay", mock.Anything, mock.Anything, blobCert).Return(blob, nil).Once()
 
 	payload, err := tester.RelayPayloadRetriever.GetPayload(ctx, blobCert)
 
@@ -170,7 +176,7 @@ func TestRelayCallTimeout(t *testing.T) {
 	_, blobCert := buildBlobAndCert(t, tester)
 
 	// the timeout should occur before the panic has a chance to be triggered
-	tester.MockRelayClient.On("GetBlob", mock.Anything, blobCert).Return(
+	tester.MockRelayClient.On("GetBlobFromRelay", mock.Anything, mock.Anything, blobCert).Return(
 		nil, errors.New("timeout")).Once().Run(
 		func(args mock.Arguments) {
 			ctx := args.Get(0).(context.Context)
@@ -184,7 +190,7 @@ func TestRelayCallTimeout(t *testing.T) {
 		})
 
 	// the panic should be triggered, since it happens faster than the configured timeout
-	tester.MockRelayClient.On("GetBlob", mock.Anything, blobCert).Return(
+	tester.MockRelayClient.On("GetBlobFromRelay", mock.Anything, mock.Anything, blobCert).Return(
 		nil, errors.New("timeout")).Once().Run(
 		func(args mock.Arguments) {
 			ctx := args.Get(0).(context.Context)
@@ -217,7 +223,8 @@ func TestGetBlobReturnsError(t *testing.T) {
 	tester := buildRelayPayloadRetrieverTester(t)
 	_, blobCert := buildBlobAndCert(t, tester)
 
-	tester.MockRelayClient.On("GetBlob", mock.Anything, blobCert).Return(nil, fmt.Errorf("relay error"))
+	tester.MockRelayClient.On("GetBlobFromRelay", mock.Anything, mock.Anything, blobCert).
+		Return(nil, fmt.Errorf("relay error"))
 
 	payload, err := tester.RelayPayloadRetriever.GetPayload(ctx, blobCert)
 	require.Nil(t, payload)
@@ -236,7 +243,7 @@ func TestGetBlobReturnsDifferentBlob(t *testing.T) {
 	wrongBlob, _ := buildBlobAndCert(t, tester)
 
 	// Return a wrong blob that doesn't match the cert commitment
-	tester.MockRelayClient.On("GetBlob", mock.Anything, blobCert).Return(wrongBlob, nil).Once()
+	tester.MockRelayClient.On("GetBlobFromRelay", mock.Anything, mock.Anything, blobCert).Return(wrongBlob, nil).Once()
 
 	payload, err := tester.RelayPayloadRetriever.GetPayload(ctx, blobCert)
 	require.Nil(t, payload)
@@ -263,7 +270,8 @@ func TestFailedDecoding(t *testing.T) {
 	require.NoError(t, err)
 
 	// The mock returns this malicious blob, which passes commitment verification but fails decoding
-	tester.MockRelayClient.On("GetBlob", mock.Anything, maliciousCert).Return(maliciousBlob, nil).Once()
+	tester.MockRelayClient.On("GetBlobFromRelay", mock.Anything, mock.Anything, maliciousCert).
+		Return(maliciousBlob, nil).Once()
 
 	payload, err := tester.RelayPayloadRetriever.GetPayload(ctx, maliciousCert)
 	require.Error(t, err)
@@ -319,7 +327,7 @@ func TestCommitmentVerifiesButBlobToPayloadFails(t *testing.T) {
 	require.NoError(t, err)
 
 	// Mock the relay to return our incorrectly encoded blob
-	tester.MockRelayClient.On("GetBlob", mock.Anything, blobCert).Return(maliciousBlob, nil).Once()
+	tester.MockRelayClient.On("GetBlobFromRelay", mock.Anything, mock.Anything, blobCert).Return(maliciousBlob, nil).Once()
 
 	// Try to get the payload - this should fail during blob to payload conversion
 	payload, err := tester.RelayPayloadRetriever.GetPayload(ctx, blobCert)
@@ -333,3 +341,126 @@ func TestCommitmentVerifiesButBlobToPayloadFails(t *testing.T) {
 
 	tester.MockRelayClient.AssertExpectations(t)
 }
+
+// slowRelay makes the mock relay with the given key block until its request is cancelled. The returned channel is
+// closed once the request has been cancelled.
+func slowRelay(
+	tester RelayPayloadRetrieverTester,
+	relayKey core.RelayKey,
+	blobCert *coretypes.EigenDACertV3,
+) chan struct{} {
+	cancelled := make(chan struct{})
+	tester.MockRelayClient.On("GetBlobFromRelay", mock.Anything, relayKey, blobCert).
+		Return(nil, context.Canceled).Run(
+		func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+			close(cancelled)
+		})
+	return cancelled
+}
+
+// TestHedgedRetrieval tests that a slow relay is hedged with the next relay, and cancelled once the blob is retrieved.
+func TestHedgedRetrieval(t *testing.T) {
+	ctx := t.Context()
+
+	tester := buildRelayPayloadRetrieverTesterWithConfig(t, RelayPayloadRetrieverConfig{
+		RelayTimeout:      time.Minute,
+		RetrievalStrategy: HedgedRelayRetrieval,
+		HedgeDelay:        10 * time.Millisecond,
+	})
+	blob, blobCert := buildBlobAndCert(t, tester)
+	blobCert.BlobInclusionInfo.BlobCertificate.RelayKeys = []core.RelayKey{1, 2}
+
+	// Give relay 2 a poor reputation, so that the slow relay 1 is always tried first.
+	for range 10 {
+		tester.RelayPayloadRetriever.reportRelayOutcome(2, false, time.Now())
+	}
+	cancelled := slowRelay(tester, 1, blobCert)
+	tester.MockRelayClient.On("GetBlobFromRelay", mock.Anything, core.RelayKey(2), blobCert).Return(blob, nil).Once()
+
+	payload, err := tester.RelayPayloadRetriever.GetPayload(ctx, blobCert)
+	require.NoError(t, err)
+	require.NotNil(t, payload)
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		require.Fail(t, "request to the slow relay should have been cancelled")
+	}
+	tester.MockRelayClient.AssertExpectations(t)
+}
+
+// TestRaceRetrieval tests that all relays are requested at once, and that the losers are cancelled.
+func TestRaceRetrieval(t *testing.T) {
+	ctx := t.Context()
+
+	tester := buildRelayPayloadRetrieverTesterWithConfig(t, RelayPayloadRetrieverConfig{
+		RelayTimeout:      time.Minute,
+		RetrievalStrategy: RaceRelayRetrieval,
+		// large enough that the slow relay can't be requested because of hedging
+		HedgeDelay: time.Minute,
+	})
+	blob, blobCert := buildBlobAndCert(t, tester)
+	blobCert.BlobInclusionInfo.BlobCertificate.RelayKeys = []core.RelayKey{1, 2}
+
+	cancelled := slowRelay(tester, 1, blobCert)
+	tester.MockRelayClient.On("GetBlobFromRelay", mock.Anything, core.RelayKey(2), blobCert).Return(blob, nil).Once()
+
+	payload, err := tester.RelayPayloadRetriever.GetPayload(ctx, blobCert)
+	require.NoError(t, err)
+	require.NotNil(t, payload)
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		require.Fail(t, "request to the slow relay should have been cancelled")
+	}
+	tester.MockRelayClient.AssertExpectations(t)
+}
+
+// TestSequentialRetrievalFallback tests that the next relay is tried when a relay fails or returns a wrong blob.
+func TestSequentialRetrievalFallback(t *testing.T) {
+	ctx := t.Context()
+
+	tester := buildRelayPayloadRetrieverTester(t)
+	blob, blobCert := buildBlobAndCert(t, tester)
+	wrongBlob, _ := buildBlobAndCert(t, tester)
+	blobCert.BlobInclusionInfo.BlobCertificate.RelayKeys = []core.RelayKey{1, 2, 3}
+
+	tester.MockRelayClient.On("GetBlobFromRelay", mock.Anything, core.RelayKey(1), blobCert).
+		Return(nil, errors.New("relay error")).Maybe()
+	tester.MockRelayClient.On("GetBlobFromRelay", mock.Anything, core.RelayKey(2), blobCert).
+		Return(wrongBlob, nil).Maybe()
+	tester.MockRelayClient.On("GetBlobFromRelay", mock.Anything, core.RelayKey(3), blobCert).Return(blob, nil).Once()
+
+	payload, err := tester.RelayPayloadRetriever.GetPayload(ctx, blobCert)
+	require.NoError(t, err)
+	require.NotNil(t, payload)
+
+	tester.MockRelayClient.AssertExpectations(t)
+}
+
+// TestRelayOrderPrefersHealthyRelays tests that relays with a poor reputation are tried last.
+func TestRelayOrderPrefersHealthyRelays(t *testing.T) {
+	retriever, err := NewRelayPayloadRetriever(
+		test.GetLogger(),
+		RelayPayloadRetrieverConfig{},
+		clientsmock.NewRelayClient(),
+		nil,
+		metrics.NoopRetrievalMetrics)
+	require.NoError(t, err)
+
+	now := time.Now()
+	for range 10 {
+		retriever.reportRelayOutcome(1, false, now)
+	}
+
+	for range 100 {
+		relayKeys, err := retriever.orderRelays([]core.RelayKey{1, 2, 2}, now)
+		require.NoError(t, err)
+		require.Equal(t, []core.RelayKey{2, 1}, relayKeys)
+	}
+
+	_, err = retriever.orderRelays(nil, now)
+	require.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
@@ -16,6 +17,8 @@ import (
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/hashicorp/go-multierror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // an upper limit on the number of parallel connections open to each relay, for the sake of sanity
@@ -47,9 +50,18 @@ type ChunkRequestByIndex struct {
 type RelayClient interface {
 	// GetBlob retrieves a blob from a relay using the information in the EigenDACert.
 	GetBlob(ctx context.Context, cert coretypes.EigenDACert) (*coretypes.Blob, error)
+	// GetBlobFromRelay retrieves a blob from a specific relay, which should be one of the relays in the EigenDACert.
+	GetBlobFromRelay(
+		ctx context.Context,
+		relayKey corev2.RelayKey,
+		cert coretypes.EigenDACert,
+	) (*coretypes.Blob, error)
 	// GetChunksByRange retrieves blob chunks from a relay by chunk index range
 	// The returned slice has the same length and ordering as the input slice, and the i-th element is the bundle for the i-th request.
 	// Each bundle is a sequence of frames in raw form (i.e., serialized core.Bundle bytearray).
+	//
+	// Chunks are streamed from relays that support it, so that the size of the request is not limited by the maximum
+	// gRPC message size.
 	GetChunksByRange(ctx context.Context, relayKey corev2.RelayKey, requests []*ChunkRequestByRange) ([][]byte, error)
 	// GetChunksByIndex retrieves blob chunks from a relay by index
 	// The returned slice has the same length and ordering as the input slice, and the i-th element is the bundle for the i-th request.
@@ -76,6 +88,9 @@ type relayClient struct {
 	relayClientPools sync.Map
 	// relayUrlProvider knows how to retrieve the relay URLs
 	relayUrlProvider RelayUrlProvider
+	// streamingUnsupported holds the keys of the relays that reported StreamChunks as unimplemented. GetChunks is
+	// used to fetch chunks from these relays.
+	streamingUnsupported sync.Map
 }
 
 var _ RelayClient = (*relayClient)(nil)
@@ -125,8 +140,14 @@ func (c *relayClient) GetBlob(
 	if len(relayKeys) == 0 {
 		return nil, errors.New("cert contains no relay keys")
 	}
-	relayKey := relayKeys[0]
+	return c.GetBlobFromRelay(ctx, relayKeys[0], cert)
+}
 
+func (c *relayClient) GetBlobFromRelay(
+	ctx context.Context,
+	relayKey corev2.RelayKey,
+	cert coretypes.EigenDACert,
+) (*coretypes.Blob, error) {
 	blobKey, err := cert.ComputeBlobKey()
 	if err != nil {
 		return nil, fmt.Errorf("compute blob key from cert: %w", err)
@@ -220,12 +241,7 @@ func (c *relayClient) GetChunksByRange(
 		return nil, err
 	}
 
-	res, err := client.GetChunks(ctx, request)
-	if err != nil {
-		return nil, err
-	}
-
-	return res.GetData(), nil
+	return c.getChunks(ctx, relayKey, client, request)
 }
 
 func (c *relayClient) GetChunksByIndex(
@@ -264,8 +280,33 @@ func (c *relayClient) GetChunksByIndex(
 		return nil, err
 	}
 
-	res, err := client.GetChunks(ctx, request)
+	return c.getChunks(ctx, relayKey, client, request)
+}
+
+// getChunks sends a signed GetChunksRequest to a relay, and returns the bundles of the reply.
+//
+// The reply is streamed with StreamChunks if the relay supports it. Relays that predate StreamChunks report it as
+// unimplemented, in which case the request is sent again with GetChunks, as are all later requests to that relay.
+// An unimplemented method is rejected before the request is authenticated, so resending the same signed request
+// doesn't trip the relay's replay protection.
+func (c *relayClient) getChunks(
+	ctx context.Context,
+	relayKey corev2.RelayKey,
+	client relaygrpc.RelayClient,
+	request *relaygrpc.GetChunksRequest,
+) ([][]byte, error) {
+
+	if _, unsupported := c.streamingUnsupported.Load(relayKey); !unsupported {
+		bundles, err := streamChunks(ctx, client, request)
+		if status.Code(err) != codes.Unimplemented {
+			return bundles, err
+		}
+
+		c.logger.Info("relay does not support StreamChunks, falling back to GetChunks", "relayKey", relayKey)
+		c.streamingUnsupported.Store(relayKey, struct{}{})
+	}
 
+	res, err := client.GetChunks(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -273,6 +314,31 @@ func (c *relayClient) GetChunksByIndex(
 	return res.GetData(), nil
 }
 
+// streamChunks sends a signed GetChunksRequest to a relay with StreamChunks, and collects the streamed bundles.
+func streamChunks(
+	ctx context.Context,
+	client relaygrpc.RelayClient,
+	request *relaygrpc.GetChunksRequest,
+) ([][]byte, error) {
+
+	stream, err := client.StreamChunks(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	bundles := make([][]byte, 0, len(request.GetChunkRequests()))
+	for {
+		reply, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return bundles, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		bundles = append(bundles, reply.GetData()...)
+	}
+}
+
 // getClient gets the grpc relay client, which has a connection to a given relay
 func (c *relayClient) getClient(ctx context.Context, key corev2.RelayKey) (relaygrpc.RelayClient, error) {
 	if err := c.initOnceGrpcConnection(ctx, key); err != nil {
@@ -0,0 +1,142 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	relaygrpc "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/test"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeRelayServer replies to each chunk request with a bundle holding the blob key of the request.
+type fakeRelayServer struct {
+	relaygrpc.UnimplementedRelayServer
+	// if false, StreamChunks is left unimplemented, like on relays that predate it
+	streaming bool
+
+	getChunksCalls    atomic.Int32
+	streamChunksCalls atomic.Int32
+}
+
+func fakeBundles(request *relaygrpc.GetChunksRequest) [][]byte {
+	bundles := make([][]byte, 0, len(request.GetChunkRequests()))
+	for _, chunkRequest := range request.GetChunkRequests() {
+		bundles = append(bundles, chunkRequest.GetByRange().GetBlobKey())
+	}
+	return bundles
+}
+
+func (s *fakeRelayServer) GetChunks(
+	_ context.Context,
+	request *relaygrpc.GetChunksRequest,
+) (*relaygrpc.GetChunksReply, error) {
+	s.getChunksCalls.Add(1)
+	return &relaygrpc.GetChunksReply{Data: fakeBundles(request)}, nil
+}
+
+func (s *fakeRelayServer) StreamChunks(
+	request *relaygrpc.GetChunksRequest,
+	stream relaygrpc.Relay_StreamChunksServer,
+) error {
+	if !s.streaming {
+		return s.UnimplementedRelayServer.StreamChunks(request, stream)
+	}
+	s.streamChunksCalls.Add(1)
+	for _, bundle := range fakeBundles(request) {
+		err := stream.Send(&relaygrpc.GetChunksReply{Data: [][]byte{bundle}})
+		if err != nil {
+			return fmt.Errorf("send: %w", err)
+		}
+	}
+	return nil
+}
+
+// fakeRelayUrlProvider maps relay keys to the addresses of fake relays.
+type fakeRelayUrlProvider map[corev2.RelayKey]string
+
+func (p fakeRelayUrlProvider) GetRelayUrl(_ context.Context, relayKey corev2.RelayKey) (string, error) {
+	return p[relayKey], nil
+}
+
+func (p fakeRelayUrlProvider) GetRelayCount(_ context.Context) (uint32, error) {
+	return uint32(len(p)), nil
+}
+
+func startFakeRelay(t *testing.T, server *fakeRelayServer) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	grpcServer := grpc.NewServer()
+	relaygrpc.RegisterRelayServer(grpcServer, server)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	return listener.Addr().String()
+}
+
+func TestGetChunksByRangeStreaming(t *testing.T) {
+	rand := random.NewTestRandom()
+
+	streamingRelay := &fakeRelayServer{streaming: true}
+	legacyRelay := &fakeRelayServer{streaming: false}
+	urlProvider := fakeRelayUrlProvider{
+		0: startFakeRelay(t, streamingRelay),
+		1: startFakeRelay(t, legacyRelay),
+	}
+
+	keyPair, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	operatorID := core.OperatorID(rand.Bytes(32))
+
+	client, err := NewRelayClient(
+		&RelayClientConfig{
+			MaxGRPCMessageSize: 1024 * 1024,
+			OperatorID:         &operatorID,
+			MessageSigner: func(_ context.Context, data [32]byte) (*core.Signature, error) {
+				return keyPair.SignMessage(data), nil
+			},
+		},
+		test.GetLogger(),
+		urlProvider)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, client.Close()) }()
+
+	requests := make([]*ChunkRequestByRange, 0)
+	expectedBundles := make([][]byte, 0)
+	for i := 0; i < 3+rand.Intn(5); i++ {
+		blobKey := corev2.BlobKey(rand.Bytes(32))
+		requests = append(requests, &ChunkRequestByRange{BlobKey: blobKey, Start: 0, End: 1})
+		expectedBundles = append(expectedBundles, blobKey[:])
+	}
+
+	for i := 0; i < 2; i++ {
+		bundles, err := client.GetChunksByRange(t.Context(), 0, requests)
+		require.NoError(t, err)
+		require.Equal(t, expectedBundles, bundles)
+
+		bundles, err = client.GetChunksByRange(t.Context(), 1, requests)
+		require.NoError(t, err)
+		require.Equal(t, expectedBundles, bundles)
+	}
+
+	// Relays that support streaming are only ever asked to stream.
+	require.Equal(t, int32(2), streamingRelay.streamChunksCalls.Load())
+	require.Equal(t, int32(0), streamingRelay.getChunksCalls.Load())
+
+	// Once a relay reports that it doesn't support streaming, it isn't asked to stream again.
+	require.Equal(t, int32(2), legacyRelay.getChunksCalls.Load())
+	_, unsupported := client.(*relayClient).streamingUnsupported.Load(corev2.RelayKey(1))
+	require.True(t, unsupported)
+}
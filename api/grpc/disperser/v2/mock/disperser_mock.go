@@ -9,6 +9,8 @@ import (
 	"github.com/Layr-Labs/eigenda/api/grpc/common"
 	v2 "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // DisperserRPC is a mock implementation of disperser_rpc.DisperserClient
@@ -51,6 +53,11 @@ func (m *DisperserRPC) GetBlobStatus(ctx context.Context, in *v2.BlobStatusReque
 	return &v2.BlobStatusReply{}, nil
 }
 
+// SubscribeBlobStatus is a mock implementation that simulates a disperser without support for subscriptions
+func (m *DisperserRPC) SubscribeBlobStatus(ctx context.Context, in *v2.BlobStatusRequest, opts ...grpc.CallOption) (v2.Disperser_SubscribeBlobStatusClient, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubscribeBlobStatus not implemented")
+}
+
 // GetBlobCommitment is a mock implementation
 func (m *DisperserRPC) GetBlobCommitment(ctx context.Context, in *v2.BlobCommitmentRequest, opts ...grpc.CallOption) (*v2.BlobCommitmentReply, error) {
 	return &v2.BlobCommitmentReply{
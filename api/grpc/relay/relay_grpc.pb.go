@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion7
 const (
 	Relay_GetBlob_FullMethodName            = "/relay.Relay/GetBlob"
 	Relay_GetChunks_FullMethodName          = "/relay.Relay/GetChunks"
+	Relay_StreamChunks_FullMethodName       = "/relay.Relay/StreamChunks"
 	Relay_GetValidatorChunks_FullMethodName = "/relay.Relay/GetValidatorChunks"
 )
 
@@ -32,6 +33,11 @@ type RelayClient interface {
 	GetBlob(ctx context.Context, in *GetBlobRequest, opts ...grpc.CallOption) (*GetBlobReply, error)
 	// GetChunks retrieves chunks from blobs stored by the relay.
 	GetChunks(ctx context.Context, in *GetChunksRequest, opts ...grpc.CallOption) (*GetChunksReply, error)
+	// StreamChunks retrieves chunks from blobs stored by the relay, like GetChunks, but streams the reply. Each
+	// GetChunksReply in the stream holds exactly one bundle, and bundles are sent in the same order GetChunks would
+	// return them. Since each message holds a single bundle, the size of a request is not bounded by the maximum
+	// gRPC message size. Authentication and rate limiting are the same as for GetChunks.
+	StreamChunks(ctx context.Context, in *GetChunksRequest, opts ...grpc.CallOption) (Relay_StreamChunksClient, error)
 	// GetValidatorChunks retrieves all chunks allocated to a validator.
 	// The relay computes which chunks to return based on the deterministic chunk allocation algorithm.
 	GetValidatorChunks(ctx context.Context, in *GetValidatorChunksRequest, opts ...grpc.CallOption) (*GetChunksReply, error)
@@ -63,6 +69,38 @@ func (c *relayClient) GetChunks(ctx context.Context, in *GetChunksRequest, opts
 	return out, nil
 }
 
+func (c *relayClient) StreamChunks(ctx context.Context, in *GetChunksRequest, opts ...grpc.CallOption) (Relay_StreamChunksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Relay_ServiceDesc.Streams[0], Relay_StreamChunks_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &relayStreamChunksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Relay_StreamChunksClient interface {
+	Recv() (*GetChunksReply, error)
+	grpc.ClientStream
+}
+
+type relayStreamChunksClient struct {
+	grpc.ClientStream
+}
+
+func (x *relayStreamChunksClient) Recv() (*GetChunksReply, error) {
+	m := new(GetChunksReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *relayClient) GetValidatorChunks(ctx context.Context, in *GetValidatorChunksRequest, opts ...grpc.CallOption) (*GetChunksReply, error) {
 	out := new(GetChunksReply)
 	err := c.cc.Invoke(ctx, Relay_GetValidatorChunks_FullMethodName, in, out, opts...)
@@ -80,6 +118,11 @@ type RelayServer interface {
 	GetBlob(context.Context, *GetBlobRequest) (*GetBlobReply, error)
 	// GetChunks retrieves chunks from blobs stored by the relay.
 	GetChunks(context.Context, *GetChunksRequest) (*GetChunksReply, error)
+	// StreamChunks retrieves chunks from blobs stored by the relay, like GetChunks, but streams the reply. Each
+	// GetChunksReply in the stream holds exactly one bundle, and bundles are sent in the same order GetChunks would
+	// return them. Since each message holds a single bundle, the size of a request is not bounded by the maximum
+	// gRPC message size. Authentication and rate limiting are the same as for GetChunks.
+	StreamChunks(*GetChunksRequest, Relay_StreamChunksServer) error
 	// GetValidatorChunks retrieves all chunks allocated to a validator.
 	// The relay computes which chunks to return based on the deterministic chunk allocation algorithm.
 	GetValidatorChunks(context.Context, *GetValidatorChunksRequest) (*GetChunksReply, error)
@@ -96,6 +139,9 @@ func (UnimplementedRelayServer) GetBlob(context.Context, *GetBlobRequest) (*GetB
 func (UnimplementedRelayServer) GetChunks(context.Context, *GetChunksRequest) (*GetChunksReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChunks not implemented")
 }
+func (UnimplementedRelayServer) StreamChunks(*GetChunksRequest, Relay_StreamChunksServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamChunks not implemented")
+}
 func (UnimplementedRelayServer) GetValidatorChunks(context.Context, *GetValidatorChunksRequest) (*GetChunksReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetValidatorChunks not implemented")
 }
@@ -148,6 +194,27 @@ func _Relay_GetChunks_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Relay_StreamChunks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetChunksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RelayServer).StreamChunks(m, &relayStreamChunksServer{stream})
+}
+
+type Relay_StreamChunksServer interface {
+	Send(*GetChunksReply) error
+	grpc.ServerStream
+}
+
+type relayStreamChunksServer struct {
+	grpc.ServerStream
+}
+
+func (x *relayStreamChunksServer) Send(m *GetChunksReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Relay_GetValidatorChunks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetValidatorChunksRequest)
 	if err := dec(in); err != nil {
@@ -186,6 +253,12 @@ var Relay_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Relay_GetValidatorChunks_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamChunks",
+			Handler:       _Relay_StreamChunks_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "relay/relay.proto",
 }
@@ -9,8 +9,10 @@ import (
 	enabled_apis "github.com/Layr-Labs/eigenda/api/proxy/config/enablement"
 	proxy_logging "github.com/Layr-Labs/eigenda/api/proxy/logging"
 	proxy_metrics "github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	srs "github.com/Layr-Labs/eigenda/api/proxy/resources"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/arbitrum_altda"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/asyncput"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/builder"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
 	common_eigenda "github.com/Layr-Labs/eigenda/common"
@@ -111,11 +113,31 @@ func StartProxyService(cliCtx *cli.Context) error {
 
 	if cfg.EnabledServersConfig.RestAPIConfig.DAEndpointEnabled() {
 		cfg.RestSvrCfg.CompatibilityCfg = compatibilityCfg
-		restServer := rest.NewServer(cfg.RestSvrCfg, certMgr, keccakMgr, log, metrics)
+		var asyncPutMgr *asyncput.Manager
+		if cfg.AsyncPutConfig.Enabled() {
+			asyncPutMgr, err = asyncput.NewManager(ctx, log, certMgr, cfg.AsyncPutConfig)
+			if err != nil {
+				return fmt.Errorf("new async put manager: %w", err)
+			}
+			// Registered before the server's deferred stop, so that it runs after the server stops accepting jobs.
+			defer asyncPutMgr.Stop()
+		}
+
+		restServer := rest.NewServer(cfg.RestSvrCfg, certMgr, keccakMgr, asyncPutMgr, log, metrics)
 		router := mux.NewRouter()
+		if len(cfg.NetworksConfig.Profiles) > 0 {
+			networkHandlers, err := buildNetworkProfileHandlers(ctx, log, metrics, cfg)
+			if err != nil {
+				return fmt.Errorf("build network profiles: %w", err)
+			}
+			rest.RegisterNetworkRoutes(router, networkHandlers)
+		}
 		restServer.RegisterRoutes(router)
 		if cfg.StoreBuilderConfig.MemstoreEnabled {
-			memconfig.NewHandlerHTTP(log, cfg.StoreBuilderConfig.MemstoreConfig).RegisterMemstoreConfigHandlers(router)
+			// The memstore backs the export and import routes.
+			snapshotter, _ := certMgr.EigenDAV2Store().(memconfig.Snapshotter)
+			memconfig.NewHandlerHTTP(log, cfg.StoreBuilderConfig.MemstoreConfig, snapshotter).
+				RegisterMemstoreConfigHandlers(router)
 		}
 
 		restEnabledCfg := cfg.EnabledServersConfig.RestAPIConfig
@@ -127,7 +149,8 @@ func StartProxyService(cliCtx *cli.Context) error {
 			string(enabled_apis.Admin), restEnabledCfg.Admin,
 			string(enabled_apis.StandardCommitment), restEnabledCfg.StandardCommitment,
 			string(enabled_apis.OpGenericCommitment), restEnabledCfg.OpGenericCommitment,
-			string(enabled_apis.OpKeccakCommitment), restEnabledCfg.OpKeccakCommitment)
+			string(enabled_apis.OpKeccakCommitment), restEnabledCfg.OpKeccakCommitment,
+			"asyncPut", asyncPutMgr != nil)
 
 		defer func() {
 			if err := restServer.Stop(); err != nil {
@@ -148,9 +171,14 @@ func StartProxyService(cliCtx *cli.Context) error {
 			arbEthClient = ethClient
 		}
 
+		preimageProver, err := arbitrum_altda.NewReadPreimageProver(srs.GetG1SRS(), srs.GetG2SRS())
+		if err != nil {
+			return fmt.Errorf("new read preimage prover: %w", err)
+		}
+
 		cfg.ArbCustomDASvrCfg.CompatibilityCfg = compatibilityCfg
 		h := arbitrum_altda.NewHandlers(certMgr, log, cfg.ArbCustomDASvrCfg.ProcessInvalidCert,
-			arbEthClient, compatibilityCfg)
+			arbEthClient, compatibilityCfg, preimageProver)
 
 		arbitrumRpcServer, err := arbitrum_altda.NewServer(ctx, &cfg.ArbCustomDASvrCfg, h)
 		if err != nil {
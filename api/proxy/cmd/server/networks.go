@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/config"
+	proxy_metrics "github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/builder"
+	common_eigenda "github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gorilla/mux"
+)
+
+// buildNetworkProfileHandlers builds the storage managers of each additional network profile, and returns the
+// handler serving the REST routes of each profile, keyed by profile name.
+func buildNetworkProfileHandlers(
+	ctx context.Context,
+	log logging.Logger,
+	metrics proxy_metrics.Metricer,
+	cfg config.AppConfig,
+) (map[string]http.Handler, error) {
+	handlers := make(map[string]http.Handler, len(cfg.NetworksConfig.Profiles))
+	for _, profile := range cfg.NetworksConfig.Profiles {
+		profileLog := log.With("network", profile.Name)
+		profileMetrics := proxy_metrics.WithNetwork(metrics, profile.Name)
+
+		var ethClient common_eigenda.EthClient
+		var chainID = ""
+		var readOnlyMode = false
+		if !cfg.StoreBuilderConfig.MemstoreEnabled {
+			gethCfg := geth.EthClientConfig{
+				RPCURLs:    []string{profile.SecretConfig.EthRPCURL},
+				NumRetries: cfg.StoreBuilderConfig.RetryCount,
+				RetryDelay: cfg.StoreBuilderConfig.RetryDelay,
+			}
+			var err error
+			ethClient, chainID, err = common.BuildEthClient(
+				ctx, profileLog, gethCfg, profile.ClientConfigV2.EigenDANetwork)
+			if err != nil {
+				return nil, fmt.Errorf("build eth client for network profile %s: %w", profile.Name, err)
+			}
+			readOnlyMode = profile.SecretConfig.SignerPaymentKey == ""
+		}
+
+		// The EigenDA client metrics aren't labeled by network, so they are only registered for the default network.
+		certMgr, keccakMgr, err := builder.BuildManagers(
+			ctx,
+			profileLog,
+			profileMetrics,
+			cfg.StoreBuilderConfig.ForNetworkProfile(profile.Name, profile.ClientConfigV2),
+			profile.SecretConfig,
+			nil,
+			ethClient,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("build storage managers for network profile %s: %w", profile.Name, err)
+		}
+
+		compatibilityCfg, err := common.NewCompatibilityConfig(
+			Version,
+			chainID,
+			profile.ClientConfigV2,
+			readOnlyMode,
+			cfg.EnabledServersConfig.ToAPIStrings(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("new compatibility config for network profile %s: %w", profile.Name, err)
+		}
+
+		restCfg := cfg.RestSvrCfg
+		restCfg.CompatibilityCfg = compatibilityCfg
+		// Async puts are only served for the default network.
+		restServer := rest.NewServer(restCfg, certMgr, keccakMgr, nil, profileLog, profileMetrics)
+		router := mux.NewRouter()
+		restServer.RegisterRoutes(router)
+		handlers[profile.Name] = router
+
+		log.Info("Built network profile", "network", profile.Name,
+			"eigenDANetwork", profile.ClientConfigV2.EigenDANetwork, "chainID", chainID, "readOnly", readOnlyMode)
+	}
+	return handlers, nil
+}
@@ -5,6 +5,7 @@ import (
 	"slices"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/dispersal"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/payloadretrieval"
 	"github.com/Layr-Labs/eigenda/core/payments/clientledger"
@@ -16,6 +17,7 @@ type ClientConfigV2 struct {
 	PayloadDisperserCfg          dispersal.PayloadDisperserConfig
 	RelayPayloadRetrieverCfg     payloadretrieval.RelayPayloadRetrieverConfig
 	ValidatorPayloadRetrieverCfg payloadretrieval.ValidatorPayloadRetrieverConfig
+	CompositePayloadRetrieverCfg payloadretrieval.CompositePayloadRetrieverConfig
 
 	// The following fields are not needed directly by any underlying components. Rather, these are configuration
 	// values required by the proxy itself.
@@ -79,7 +81,8 @@ func (cfg *ClientConfigV2) Check() error {
 		}
 	}
 
-	if slices.Contains(cfg.RetrieversToEnable, ValidatorRetrieverType) {
+	if slices.Contains(cfg.RetrieversToEnable, ValidatorRetrieverType) ||
+		slices.Contains(cfg.RetrieversToEnable, CompositeRetrieverType) {
 		if cfg.EigenDADirectory == "" {
 			return fmt.Errorf("EigenDA directory is required for validator retrieval in EigenDA V2 backend")
 		}
@@ -97,6 +100,10 @@ func (cfg *ClientConfigV2) Check() error {
 		return fmt.Errorf("vault monitor interval cannot be negative")
 	}
 
+	if _, err := codecs.BlobEncodingVersionToCodec(cfg.PayloadDisperserCfg.PayloadEncodingVersion); err != nil {
+		return fmt.Errorf("invalid payload encoding version: %w", err)
+	}
+
 	return nil
 }
 
@@ -106,4 +113,7 @@ type RetrieverType string
 const (
 	RelayRetrieverType     RetrieverType = "relayRetriever"
 	ValidatorRetrieverType RetrieverType = "validatorRetriever"
+	// Retrieves from the relays, and falls back to the validators within a single retriever. See
+	// [payloadretrieval.CompositePayloadRetriever].
+	CompositeRetrieverType RetrieverType = "compositeRetriever"
 )
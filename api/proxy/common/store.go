@@ -17,6 +17,8 @@ const (
 	MemstoreV1BackendType
 	MemstoreV2BackendType
 	S3BackendType
+	RedisBackendType
+	LittDBBackendType
 
 	UnknownBackendType
 )
@@ -33,6 +35,10 @@ func (b BackendType) String() string {
 		return "EigenDAV2Memstore"
 	case S3BackendType:
 		return "S3"
+	case RedisBackendType:
+		return "Redis"
+	case LittDBBackendType:
+		return "LittDB"
 	case UnknownBackendType:
 		fallthrough
 	default:
@@ -54,6 +60,10 @@ func StringToBackendType(s string) BackendType {
 		return MemstoreV2BackendType
 	case "s3":
 		return S3BackendType
+	case "redis":
+		return RedisBackendType
+	case "littdb":
+		return LittDBBackendType
 	case "unknown":
 		fallthrough
 	default:
@@ -82,6 +92,18 @@ type GETOpts struct {
 	// it. This is useful when clients need to decode the encoded_payload themselves,
 	// such as inside an fpvm to prove that a decoding fails and can thus be discarded.
 	ReturnEncodedPayload bool
+
+	// When set, the cert refers to a batch of payloads that were dispersed together in a single blob,
+	// and only the payload located by this entry is returned. Cannot be combined with ReturnEncodedPayload.
+	BatchEntry *BatchEntry
+}
+
+// BatchEntry locates a single payload within a batch of payloads that were dispersed together in a single blob.
+type BatchEntry struct {
+	// Byte offset of the payload within the batch.
+	Offset uint32
+	// Byte length of the payload.
+	Length uint32
 }
 
 type Store interface {
@@ -0,0 +1,51 @@
+package commitments
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+)
+
+// BatchEntryLenBytes is the length of the serialized batch entry contained in a BatchedCommitment.
+const BatchEntryLenBytes = 8
+
+// BatchedCommitment references a single payload within a batch of payloads that were dispersed together in one blob.
+// It contains the versioned cert of the batch, along with the location of the payload within the batch.
+type BatchedCommitment struct {
+	versionedCert certs.VersionedCert
+	entry         common.BatchEntry
+}
+
+func NewBatchedCommitment(versionedCert certs.VersionedCert, entry common.BatchEntry) BatchedCommitment {
+	return BatchedCommitment{versionedCert, entry}
+}
+
+// Encode inserts the batch entry between the version byte and the serialized cert.
+// Encoding is thus [ version_byte | uint32 offset | uint32 length | serialized_cert ], with big-endian integers.
+func (c BatchedCommitment) Encode() []byte {
+	encoded := make([]byte, 0, 1+BatchEntryLenBytes+len(c.versionedCert.SerializedCert))
+	encoded = append(encoded, byte(c.versionedCert.Version))
+	encoded = append(encoded, EncodeBatchEntry(c.entry)...)
+	return append(encoded, c.versionedCert.SerializedCert...)
+}
+
+// EncodeBatchEntry serializes a batch entry as [ uint32 offset | uint32 length ], with big-endian integers.
+func EncodeBatchEntry(entry common.BatchEntry) []byte {
+	encoded := make([]byte, BatchEntryLenBytes)
+	binary.BigEndian.PutUint32(encoded[0:4], entry.Offset)
+	binary.BigEndian.PutUint32(encoded[4:8], entry.Length)
+	return encoded
+}
+
+// DecodeBatchEntry is the inverse of EncodeBatchEntry.
+func DecodeBatchEntry(encoded []byte) (common.BatchEntry, error) {
+	if len(encoded) != BatchEntryLenBytes {
+		return common.BatchEntry{}, fmt.Errorf("batch entry must be %d bytes, got %d", BatchEntryLenBytes, len(encoded))
+	}
+	return common.BatchEntry{
+		Offset: binary.BigEndian.Uint32(encoded[0:4]),
+		Length: binary.BigEndian.Uint32(encoded[4:8]),
+	}, nil
+}
@@ -12,6 +12,10 @@ const (
 	OptimismKeccakCommitmentMode  CommitmentMode = "optimism_keccak256"
 	OptimismGenericCommitmentMode CommitmentMode = "optimism_generic"
 	StandardCommitmentMode        CommitmentMode = "standard"
+	// BatchedCommitmentMode commitments reference a single payload within a batch of payloads dispersed in one blob.
+	// They are encoded with [NewBatchedCommitment] instead of EncodeCommitment, since they also need the location of
+	// the payload within the batch.
+	BatchedCommitmentMode CommitmentMode = "batched"
 )
 
 // EncodeCommitment serializes the versionedCert prepends commitmentMode-related header bytes.
@@ -4,12 +4,16 @@ import (
 	"fmt"
 	"slices"
 
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
 	enablement "github.com/Layr-Labs/eigenda/api/proxy/config/enablement"
+	"github.com/Layr-Labs/eigenda/api/proxy/config/networks"
 	"github.com/Layr-Labs/eigenda/api/proxy/config/v2/eigendaflags"
 	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/arbitrum_altda"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest"
+	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/asyncput"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/builder"
 	"github.com/urfave/cli/v2"
 )
@@ -25,6 +29,11 @@ type AppConfig struct {
 	ArbCustomDASvrCfg arbitrum_altda.Config
 	RestSvrCfg        rest.Config
 	MetricsSvrConfig  metrics.Config
+
+	AsyncPutConfig asyncput.Config
+
+	// Additional EigenDA networks served next to the one configured by StoreBuilderConfig
+	NetworksConfig networks.Config
 }
 
 // Check checks critical config invariants and returns an error
@@ -48,6 +57,37 @@ func (c AppConfig) Check() error {
 		return fmt.Errorf("check enabled APIs: %w", err)
 	}
 
+	// READPREIMAGE proofs open the preimage bytes stored in the blob's field elements, which is only possible for
+	// payloads that aren't compressed before being encoded. See [arbitrum_altda.ErrUnsupportedPayloadEncoding].
+	payloadEncodingVersion := c.StoreBuilderConfig.ClientConfigV2.PayloadDisperserCfg.PayloadEncodingVersion
+	if c.EnabledServersConfig.ArbCustomDA && payloadEncodingVersion != codecs.PayloadEncodingVersion0 {
+		return fmt.Errorf("payload encoding version %d is not supported by the Arbitrum Custom DA server, "+
+			"which requires payload encoding version 0 to generate READPREIMAGE proofs", payloadEncodingVersion)
+	}
+
+	err = c.AsyncPutConfig.Check()
+	if err != nil {
+		return fmt.Errorf("check async put config: %w", err)
+	}
+
+	err = c.NetworksConfig.Check(c.StoreBuilderConfig.MemstoreEnabled)
+	if err != nil {
+		return fmt.Errorf("check networks config: %w", err)
+	}
+
+	// Each network profile builds its own memstore, and the LevelDB database in the persistence dir can only be
+	// opened by one of them.
+	memstoreConfig := c.StoreBuilderConfig.MemstoreConfig
+	if c.StoreBuilderConfig.MemstoreEnabled && memstoreConfig != nil && memstoreConfig.PersistenceDir() != "" &&
+		len(c.NetworksConfig.Profiles) > 0 {
+		return fmt.Errorf("memstore persistence can't be used with network profiles")
+	}
+
+	// LittDB holds a lock on its directories, so it can't be opened by the managers of several networks.
+	if len(c.StoreBuilderConfig.LittDBConfig.Paths) > 0 && len(c.NetworksConfig.Profiles) > 0 {
+		return fmt.Errorf("littdb can't be used with network profiles")
+	}
+
 	return nil
 }
 
@@ -58,6 +98,15 @@ func ReadAppConfig(ctx *cli.Context) (AppConfig, error) {
 	}
 
 	enabledServersCfg := enablement.ReadEnabledServersCfg(ctx)
+	if !enabledServersCfg.RestAPIConfig.BatchedCommitment {
+		// The batcher is only needed to serve the batched commitment mode, so it isn't built otherwise.
+		storeBuilderConfig.StoreConfig.Batch = store.BatchConfig{}
+	}
+
+	networksConfig, err := networks.ReadConfig(ctx, storeBuilderConfig.ClientConfigV2)
+	if err != nil {
+		return AppConfig{}, fmt.Errorf("read networks config: %w", err)
+	}
 
 	return AppConfig{
 		StoreBuilderConfig:   storeBuilderConfig,
@@ -67,5 +116,8 @@ func ReadAppConfig(ctx *cli.Context) (AppConfig, error) {
 		ArbCustomDASvrCfg: arbitrum_altda.ReadConfig(ctx),
 		RestSvrCfg:        rest.ReadConfig(ctx, &enabledServersCfg.RestAPIConfig),
 		MetricsSvrConfig:  metrics.ReadConfig(ctx),
+
+		AsyncPutConfig: asyncput.ReadConfig(ctx),
+		NetworksConfig: networksConfig,
 	}, nil
 }
@@ -0,0 +1,88 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/config"
+	"github.com/Layr-Labs/eigenda/api/proxy/config/enablement"
+	"github.com/Layr-Labs/eigenda/api/proxy/config/networks"
+	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/builder"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/littdb"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/redis"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
+	"github.com/stretchr/testify/require"
+)
+
+// validMemstoreAppConfig returns a config serving the standard commitment API from memstore.
+func validMemstoreAppConfig() config.AppConfig {
+	return config.AppConfig{
+		StoreBuilderConfig: builder.Config{
+			StoreConfig: store.Config{
+				BackendsToEnable: []common.EigenDABackend{common.V2EigenDABackend},
+				DispersalBackend: common.V2EigenDABackend,
+			},
+			MemstoreConfig:  memconfig.NewSafeConfig(memconfig.Config{}),
+			MemstoreEnabled: true,
+		},
+		EnabledServersConfig: &enablement.EnabledServersConfig{
+			RestAPIConfig: enablement.RestApisEnabled{StandardCommitment: true},
+		},
+	}
+}
+
+func TestCheckMemstorePersistenceWithNetworkProfiles(t *testing.T) {
+	cfg := validMemstoreAppConfig()
+	require.NoError(t, cfg.Check())
+
+	cfg.StoreBuilderConfig.MemstoreConfig = memconfig.NewSafeConfig(memconfig.Config{PersistenceDir: t.TempDir()})
+	require.NoError(t, cfg.Check())
+
+	cfg.NetworksConfig = networks.Config{Profiles: []networks.Profile{{Name: "sepolia"}}}
+	require.ErrorContains(t, cfg.Check(), "memstore persistence can't be used with network profiles")
+}
+
+func TestCheckPayloadEncodingWithArbCustomDA(t *testing.T) {
+	cfg := validMemstoreAppConfig()
+	cfg.StoreBuilderConfig.ClientConfigV2.PayloadDisperserCfg.PayloadEncodingVersion = codecs.PayloadEncodingVersion1
+	require.NoError(t, cfg.Check())
+
+	// READPREIMAGE proofs can't be generated for compressed payloads.
+	cfg.EnabledServersConfig.ArbCustomDA = true
+	require.ErrorContains(t, cfg.Check(), "not supported by the Arbitrum Custom DA server")
+
+	cfg.StoreBuilderConfig.ClientConfigV2.PayloadDisperserCfg.PayloadEncodingVersion = codecs.PayloadEncodingVersion0
+	require.NoError(t, cfg.Check())
+}
+
+func TestCheckLittDBWithNetworkProfiles(t *testing.T) {
+	cfg := validMemstoreAppConfig()
+	cfg.StoreBuilderConfig.LittDBConfig = littdb.Config{Paths: []string{t.TempDir()}}
+	require.NoError(t, cfg.Check())
+
+	cfg.NetworksConfig = networks.Config{Profiles: []networks.Profile{{Name: "sepolia"}}}
+	require.ErrorContains(t, cfg.Check(), "littdb can't be used with network profiles")
+}
+
+func TestNetworkProfileSecondaryKeysNamespaced(t *testing.T) {
+	cfg := validMemstoreAppConfig()
+	cfg.StoreBuilderConfig.StoreConfig.CacheTargets = []string{"redis"}
+	cfg.StoreBuilderConfig.StoreConfig.FallbackTargets = []string{"s3"}
+	cfg.StoreBuilderConfig.S3Config = s3.Config{Bucket: "bucket", Path: "proxy"}
+	cfg.StoreBuilderConfig.RedisConfig = redis.Config{Endpoint: "localhost:6379", KeyPrefix: "proxy:"}
+
+	profileConfig := cfg.StoreBuilderConfig.ForNetworkProfile("sepolia", cfg.StoreBuilderConfig.ClientConfigV2)
+	require.Equal(t, []string{"redis"}, profileConfig.StoreConfig.CacheTargets)
+	require.Equal(t, []string{"s3"}, profileConfig.StoreConfig.FallbackTargets)
+	require.Equal(t, "bucket", profileConfig.S3Config.Bucket)
+	require.Equal(t, "proxy/sepolia", profileConfig.S3Config.Path)
+	require.Equal(t, "localhost:6379", profileConfig.RedisConfig.Endpoint)
+	require.Equal(t, "proxy:sepolia:", profileConfig.RedisConfig.KeyPrefix)
+
+	// The config of the default network is left as is.
+	require.Equal(t, "proxy", cfg.StoreBuilderConfig.S3Config.Path)
+	require.Equal(t, "proxy:", cfg.StoreBuilderConfig.RedisConfig.KeyPrefix)
+}
@@ -25,11 +25,12 @@ type RestApisEnabled struct {
 	OpGenericCommitment bool
 	OpKeccakCommitment  bool
 	StandardCommitment  bool
+	BatchedCommitment   bool
 }
 
 func (e *RestApisEnabled) DAEndpointEnabled() bool {
 	return e.OpGenericCommitment ||
-		e.OpKeccakCommitment || e.StandardCommitment
+		e.OpKeccakCommitment || e.StandardCommitment || e.BatchedCommitment
 }
 
 // Check ... Ensures that expression of the enabled API set is correct
@@ -62,6 +63,9 @@ func (e EnabledServersConfig) ToAPIStrings() []string {
 	if e.RestAPIConfig.StandardCommitment {
 		enabled = append(enabled, string(StandardCommitment))
 	}
+	if e.RestAPIConfig.BatchedCommitment {
+		enabled = append(enabled, string(BatchedCommitment))
+	}
 	return enabled
 }
 
@@ -96,6 +100,7 @@ func APIStringsToEnabledServersConfig(strSlice []string) (*EnabledServersConfig,
 			OpGenericCommitment: common.Contains(apis, OpGenericCommitment),
 			OpKeccakCommitment:  common.Contains(apis, OpKeccakCommitment),
 			StandardCommitment:  common.Contains(apis, StandardCommitment),
+			BatchedCommitment:   common.Contains(apis, BatchedCommitment),
 		},
 	}, nil
 }
@@ -108,14 +113,15 @@ const (
 	OpKeccakCommitment  API = "op-keccak"
 	OpGenericCommitment API = "op-generic"
 	StandardCommitment  API = "standard"
+	BatchedCommitment   API = "batched"
 	ArbCustomDAServer   API = "arb"
 	MetricsServer       API = "metrics"
 )
 
 func AllAPIsString() string {
 	return fmt.Sprintf(
-		"%s, %s, %s, %s, %s, %s", Admin, StandardCommitment,
-		OpGenericCommitment, OpKeccakCommitment,
+		"%s, %s, %s, %s, %s, %s, %s", Admin, StandardCommitment,
+		OpGenericCommitment, OpKeccakCommitment, BatchedCommitment,
 		ArbCustomDAServer, MetricsServer)
 }
 
@@ -132,6 +138,8 @@ func APIFromString(s string) (API, error) {
 		return OpKeccakCommitment, nil
 	case "standard":
 		return StandardCommitment, nil
+	case "batched":
+		return BatchedCommitment, nil
 	case "arb":
 		return ArbCustomDAServer, nil
 	case "metrics":
@@ -25,9 +25,10 @@ func TestToAPIStrings(t *testing.T) {
 					OpGenericCommitment: true,
 					OpKeccakCommitment:  true,
 					StandardCommitment:  true,
+					BatchedCommitment:   true,
 				},
 			},
-			expected: []string{"metrics", "arb", "admin", "op-generic", "op-keccak", "standard"},
+			expected: []string{"metrics", "arb", "admin", "op-generic", "op-keccak", "standard", "batched"},
 		},
 		{
 			name: "No APIs enabled",
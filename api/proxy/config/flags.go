@@ -2,14 +2,17 @@ package config
 
 import (
 	enabled_apis "github.com/Layr-Labs/eigenda/api/proxy/config/enablement"
+	"github.com/Layr-Labs/eigenda/api/proxy/config/networks"
 	eigenda_v2_flags "github.com/Layr-Labs/eigenda/api/proxy/config/v2/eigendaflags"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/arbitrum_altda"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest"
 	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/asyncput"
 
 	"github.com/Layr-Labs/eigenda/api/proxy/logging"
 	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/littdb"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/redis"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
 	"github.com/urfave/cli/v2"
@@ -19,6 +22,7 @@ const (
 	EnabledAPIsCategory     = "Enabled APIs"
 	ProxyRestServerCategory = "Proxy REST API Server (compatible with OP Stack ALT DA and standard commitment clients)"
 	ArbCustomDASvrCategory  = "Arbitrum Custom DA JSON RPC Server"
+	AsyncPutCategory        = "Async PUT"
 
 	LoggingFlagsCategory = "Logging"
 	MetricsFlagCategory  = "Metrics"
@@ -26,10 +30,11 @@ const (
 	StorageFlagsCategory  = "Storage"
 	MemstoreFlagsCategory = "Memstore (for testing purposes - replaces EigenDA backend)"
 	S3Category            = "S3 Cache/Fallback"
+	RedisCategory         = "Redis Cache/Fallback"
+	LittDBCategory        = "LittDB Cache/Fallback"
 
 	EigenDAV2ClientCategory = "EigenDA V2 Client"
-
-	DeprecatedRedisCategory = "Redis Cache/Fallback"
+	NetworksCategory        = "Network Profiles"
 )
 
 // EnvVar prefix added in front of all environment variables accepted by the binary.
@@ -43,17 +48,20 @@ func init() {
 	Flags = append(Flags, enabled_apis.CLIFlags(EnabledAPIsCategory, GlobalEnvVarPrefix)...)
 
 	Flags = append(Flags, rest.CLIFlags(GlobalEnvVarPrefix, ProxyRestServerCategory)...)
+	Flags = append(Flags, asyncput.CLIFlags(GlobalEnvVarPrefix, AsyncPutCategory)...)
 	Flags = append(Flags, arbitrum_altda.CLIFlags(GlobalEnvVarPrefix, ArbCustomDASvrCategory)...)
 	Flags = append(Flags, metrics.CLIFlags(GlobalEnvVarPrefix, MetricsFlagCategory)...)
 
 	Flags = append(Flags, logging.CLIFlags(GlobalEnvVarPrefix, LoggingFlagsCategory)...)
 	Flags = append(Flags, eigenda_v2_flags.CLIFlags(GlobalEnvVarPrefix, EigenDAV2ClientCategory)...)
+	Flags = append(Flags, networks.CLIFlags(GlobalEnvVarPrefix, NetworksCategory)...)
 	Flags = append(Flags, store.CLIFlags(GlobalEnvVarPrefix, StorageFlagsCategory)...)
 	Flags = append(Flags, s3.CLIFlags(GlobalEnvVarPrefix, S3Category)...)
+	Flags = append(Flags, redis.CLIFlags(GlobalEnvVarPrefix, RedisCategory)...)
+	Flags = append(Flags, littdb.CLIFlags(GlobalEnvVarPrefix, LittDBCategory)...)
 	Flags = append(Flags, memstore.CLIFlags(GlobalEnvVarPrefix, MemstoreFlagsCategory)...)
 
 	Flags = append(Flags, metrics.DeprecatedCLIFlags(GlobalEnvVarPrefix, MetricsFlagCategory)...)
 	Flags = append(Flags, eigenda_v2_flags.DeprecatedCLIFlags(GlobalEnvVarPrefix, EigenDAV2ClientCategory)...)
 	Flags = append(Flags, store.DeprecatedCLIFlags(GlobalEnvVarPrefix, StorageFlagsCategory)...)
-	Flags = append(Flags, redis.DeprecatedCLIFlags(GlobalEnvVarPrefix, DeprecatedRedisCategory)...)
 }
@@ -0,0 +1,75 @@
+package networks
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	ProfilesFileFlagName = withFlagPrefix("profiles-file")
+)
+
+func withFlagPrefix(s string) string {
+	return "networks." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_NETWORKS_" + s}
+}
+
+type Config struct {
+	// Path of the JSON file the profiles were loaded from. Empty if the proxy only serves its default network.
+	ProfilesFile string
+	Profiles     []Profile
+}
+
+// Check ... verifies that configuration values are adequately set
+func (cfg Config) Check(memstoreEnabled bool) error {
+	names := make(map[string]struct{}, len(cfg.Profiles))
+	for _, profile := range cfg.Profiles {
+		if _, ok := names[profile.Name]; ok {
+			return fmt.Errorf("duplicate network profile name: %s", profile.Name)
+		}
+		names[profile.Name] = struct{}{}
+
+		err := profile.Check(memstoreEnabled)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CLIFlags ... used for network profile configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name: ProfilesFileFlagName,
+			Usage: "JSON file defining additional EigenDA network profiles served by this proxy, next to the network " +
+				"configured by the eigenda.v2 flags. Requests are routed to a profile by the /networks/<name> path " +
+				"prefix or the X-EigenDA-Network header.",
+			EnvVars:  withEnvPrefix(envPrefix, "PROFILES_FILE"),
+			Category: category,
+		},
+	}
+}
+
+// ReadConfig ... reads the network profiles, deriving their client configs from the default network's client config.
+func ReadConfig(ctx *cli.Context, base common.ClientConfigV2) (Config, error) {
+	profilesFile := ctx.String(ProfilesFileFlagName)
+	if profilesFile == "" {
+		return Config{}, nil
+	}
+
+	profiles, err := LoadProfiles(profilesFile, base)
+	if err != nil {
+		return Config{}, fmt.Errorf("load network profiles: %w", err)
+	}
+	return Config{
+		ProfilesFile: profilesFile,
+		Profiles:     profiles,
+	}, nil
+}
@@ -0,0 +1,136 @@
+package networks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+)
+
+// profileNameRegex restricts profile names to characters that can be used as-is in a URL path and a header value.
+var profileNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// Profile is a named EigenDA network that a single proxy serves alongside its default network, which is the one
+// configured by the eigenda.v2.* flags.
+type Profile struct {
+	Name           string
+	ClientConfigV2 common.ClientConfigV2
+	SecretConfig   common.SecretConfigV2
+}
+
+// profileJSON is the format of a profile in the profiles file.
+type profileJSON struct {
+	// Name of the profile, which requests are routed by.
+	Name string `json:"name"`
+	// If set, the EigenDA directory and disperser endpoint default to those of this network, instead of those of
+	// the default network.
+	EigenDANetwork string `json:"eigenda_network"`
+	EthRPCURL      string `json:"eth_rpc_url"`
+	// Name of the environment variable that holds the payment key, so that it doesn't need to be written to the
+	// profiles file. The profile is read-only if unset.
+	SignerPaymentKeyEnvVar string `json:"signer_payment_key_env_var"`
+	// Fields of [common.ClientConfigV2] to override, using the Go field names. Fields that are not overridden are
+	// inherited from the default network's config.
+	ClientConfig json.RawMessage `json:"client_config"`
+}
+
+// LoadProfiles reads the network profiles defined in a JSON file, whose top level is an array of profiles.
+// The client config of each profile is derived from base, which is the default network's client config.
+func LoadProfiles(path string, base common.ClientConfigV2) ([]Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read network profiles file: %w", err)
+	}
+
+	var profilesJSON []profileJSON
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&profilesJSON)
+	if err != nil {
+		return nil, fmt.Errorf("parse network profiles file %s: %w", path, err)
+	}
+
+	profiles := make([]Profile, 0, len(profilesJSON))
+	for _, profileJSON := range profilesJSON {
+		profile, err := profileJSON.toProfile(base)
+		if err != nil {
+			return nil, fmt.Errorf("network profile %q: %w", profileJSON.Name, err)
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
+func (p profileJSON) toProfile(base common.ClientConfigV2) (Profile, error) {
+	// The base config is deep copied, so that overrides of slices and pointers don't leak into it.
+	clientConfig, err := copyClientConfig(base)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	if p.EigenDANetwork != "" {
+		network, err := common.EigenDANetworkFromString(p.EigenDANetwork)
+		if err != nil {
+			return Profile{}, fmt.Errorf("parse eigenda_network: %w", err)
+		}
+		clientConfig.EigenDANetwork = network
+		clientConfig.EigenDADirectory = network.GetEigenDADirectory()
+		clientConfig.DisperserClientCfg.GrpcUri = network.GetDisperserGrpcUri()
+	}
+
+	if len(p.ClientConfig) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(p.ClientConfig))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&clientConfig)
+		if err != nil {
+			return Profile{}, fmt.Errorf("parse client_config: %w", err)
+		}
+	}
+
+	secretConfig := common.SecretConfigV2{EthRPCURL: p.EthRPCURL}
+	if p.SignerPaymentKeyEnvVar != "" {
+		secretConfig.SignerPaymentKey = os.Getenv(p.SignerPaymentKeyEnvVar)
+	}
+
+	return Profile{
+		Name:           p.Name,
+		ClientConfigV2: clientConfig,
+		SecretConfig:   secretConfig,
+	}, nil
+}
+
+func copyClientConfig(cfg common.ClientConfigV2) (common.ClientConfigV2, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return common.ClientConfigV2{}, fmt.Errorf("marshal client config: %w", err)
+	}
+	var clientConfigCopy common.ClientConfigV2
+	err = json.Unmarshal(data, &clientConfigCopy)
+	if err != nil {
+		return common.ClientConfigV2{}, fmt.Errorf("unmarshal client config: %w", err)
+	}
+	return clientConfigCopy, nil
+}
+
+// Check ... verifies that the profiles are adequately set. The client and secret configs are only checked when
+// the proxy is backed by EigenDA, as they are unused by memstore.
+func (p Profile) Check(memstoreEnabled bool) error {
+	if !profileNameRegex.MatchString(p.Name) {
+		return fmt.Errorf("network profile name %q must only contain letters, digits, '_' and '-'", p.Name)
+	}
+	if memstoreEnabled {
+		return nil
+	}
+	err := p.ClientConfigV2.Check()
+	if err != nil {
+		return fmt.Errorf("check network profile %s client config: %w", p.Name, err)
+	}
+	err = p.SecretConfig.Check()
+	if err != nil {
+		return fmt.Errorf("check network profile %s secret config: %w", p.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package networks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/core/payments/clientledger"
+	"github.com/stretchr/testify/require"
+)
+
+func writeProfilesFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func baseClientConfig() common.ClientConfigV2 {
+	cfg := common.ClientConfigV2{
+		PutTries:                           3,
+		MaxBlobSizeBytes:                   1024,
+		EigenDACertVerifierOrRouterAddress: "0x1111111111111111111111111111111111111111",
+		RetrieversToEnable:                 []common.RetrieverType{common.RelayRetrieverType, common.ValidatorRetrieverType},
+		EigenDANetwork:                     common.MainnetEigenDANetwork,
+		EigenDADirectory:                   common.MainnetEigenDANetwork.GetEigenDADirectory(),
+		ClientLedgerMode:                   clientledger.ClientLedgerModeReservationOnly,
+	}
+	cfg.DisperserClientCfg.GrpcUri = common.MainnetEigenDANetwork.GetDisperserGrpcUri()
+	return cfg
+}
+
+func TestLoadProfiles(t *testing.T) {
+	t.Setenv("TEST_SEPOLIA_PAYMENT_KEY", "payment-key")
+	path := writeProfilesFile(t, `[
+		{
+			"name": "sepolia",
+			"eigenda_network": "sepolia_testnet",
+			"eth_rpc_url": "https://sepolia.example",
+			"signer_payment_key_env_var": "TEST_SEPOLIA_PAYMENT_KEY",
+			"client_config": {
+				"EigenDACertVerifierOrRouterAddress": "0x2222222222222222222222222222222222222222",
+				"RetrieversToEnable": ["validatorRetriever"]
+			}
+		},
+		{
+			"name": "mainnet-readonly",
+			"eth_rpc_url": "https://mainnet.example"
+		}
+	]`)
+	base := baseClientConfig()
+
+	profiles, err := LoadProfiles(path, base)
+	require.NoError(t, err)
+	require.Len(t, profiles, 2)
+
+	sepolia := profiles[0]
+	require.Equal(t, "sepolia", sepolia.Name)
+	require.Equal(t, common.SecretConfigV2{EthRPCURL: "https://sepolia.example", SignerPaymentKey: "payment-key"},
+		sepolia.SecretConfig)
+	// network defaults
+	require.Equal(t, common.SepoliaTestnetEigenDANetwork, sepolia.ClientConfigV2.EigenDANetwork)
+	require.Equal(t, common.SepoliaTestnetEigenDANetwork.GetEigenDADirectory(), sepolia.ClientConfigV2.EigenDADirectory)
+	require.Equal(t, common.SepoliaTestnetEigenDANetwork.GetDisperserGrpcUri(),
+		sepolia.ClientConfigV2.DisperserClientCfg.GrpcUri)
+	// overrides
+	require.Equal(t, "0x2222222222222222222222222222222222222222",
+		sepolia.ClientConfigV2.EigenDACertVerifierOrRouterAddress)
+	require.Equal(t, []common.RetrieverType{common.ValidatorRetrieverType}, sepolia.ClientConfigV2.RetrieversToEnable)
+	// inherited
+	require.Equal(t, base.PutTries, sepolia.ClientConfigV2.PutTries)
+	require.Equal(t, base.MaxBlobSizeBytes, sepolia.ClientConfigV2.MaxBlobSizeBytes)
+
+	readOnly := profiles[1]
+	require.Equal(t, common.SecretConfigV2{EthRPCURL: "https://mainnet.example"}, readOnly.SecretConfig)
+	require.Equal(t, base, readOnly.ClientConfigV2)
+
+	// Overrides don't leak into the base config.
+	require.Equal(t, baseClientConfig(), base)
+}
+
+func TestLoadProfilesErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "invalid json", content: `[{"name": "sepolia"`},
+		{name: "unknown profile field", content: `[{"name": "sepolia", "eth_rpc": "https://sepolia.example"}]`},
+		{name: "unknown client config field", content: `[{"name": "sepolia", "client_config": {"PutTry": 3}}]`},
+		{name: "unknown network", content: `[{"name": "sepolia", "eigenda_network": "sepolia"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadProfiles(writeProfilesFile(t, tt.content), baseClientConfig())
+			require.Error(t, err)
+		})
+	}
+
+	_, err := LoadProfiles(filepath.Join(t.TempDir(), "missing.json"), baseClientConfig())
+	require.Error(t, err)
+}
+
+func TestConfigCheck(t *testing.T) {
+	profile := func(name string) Profile {
+		return Profile{
+			Name:           name,
+			ClientConfigV2: baseClientConfig(),
+			SecretConfig:   common.SecretConfigV2{EthRPCURL: "https://rpc.example"},
+		}
+	}
+
+	require.NoError(t, Config{}.Check(false))
+	require.NoError(t, Config{Profiles: []Profile{profile("sepolia"), profile("hoodi_preprod-2")}}.Check(true))
+
+	require.ErrorContains(t, Config{Profiles: []Profile{profile("sepolia"), profile("sepolia")}}.Check(true),
+		"duplicate")
+	require.ErrorContains(t, Config{Profiles: []Profile{profile("sepolia/v2")}}.Check(true), "must only contain")
+
+	// The client and secret configs are only checked when backed by EigenDA.
+	noRPC := profile("sepolia")
+	noRPC.SecretConfig.EthRPCURL = ""
+	require.NoError(t, Config{Profiles: []Profile{noRPC}}.Check(true))
+	require.ErrorContains(t, Config{Profiles: []Profile{noRPC}}.Check(false), "eth rpc url")
+}
@@ -2,6 +2,7 @@ package eigendaflags
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/codecs"
@@ -18,6 +19,7 @@ var (
 	DisableTLSFlagName              = withFlagPrefix("disable-tls")
 	BlobStatusPollIntervalFlagName  = withFlagPrefix("blob-status-poll-interval")
 	PointEvaluationDisabledFlagName = withFlagPrefix("disable-point-evaluation")
+	PayloadEncodingVersionFlagName  = withFlagPrefix("payload-encoding-version")
 
 	PutRetriesFlagName                                = withFlagPrefix("put-retries")
 	SignerPaymentKeyHexFlagName                       = withFlagPrefix("signer-payment-key-hex")
@@ -28,6 +30,10 @@ var (
 	)
 	EigenDADirectoryFlagName          = withFlagPrefix("eigenda-directory")
 	RelayTimeoutFlagName              = withFlagPrefix("relay-timeout")
+	RelayRetrievalStrategyFlagName    = withFlagPrefix("relay-retrieval-strategy")
+	RelayHedgeDelayFlagName           = withFlagPrefix("relay-hedge-delay")
+	CompositeRetrievalFlagName        = withFlagPrefix("composite-retrieval")
+	CompositeRelayBudgetFlagName      = withFlagPrefix("composite-relay-budget")
 	ValidatorTimeoutFlagName          = withFlagPrefix("validator-timeout")
 	ContractCallTimeoutFlagName       = withFlagPrefix("contract-call-timeout")
 	BlobParamsVersionFlagName         = withFlagPrefix("blob-version")
@@ -82,6 +88,16 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			Value:    false,
 			Category: category,
 		},
+		&cli.UintFlag{
+			Name: PayloadEncodingVersionFlagName,
+			Usage: "Payload encoding version used when dispersing. 0 encodes payloads as-is, 1 compresses payloads " +
+				"with zstd before encoding them. Retrieval decodes blobs of either version regardless of this setting. " +
+				"Version 1 can't be used with the arb server, since Arbitrum READPREIMAGE proofs can only be " +
+				"generated for version 0 payloads.",
+			EnvVars:  []string{withEnvPrefix(envPrefix, "PAYLOAD_ENCODING_VERSION")},
+			Value:    uint(codecs.PayloadEncodingVersion0),
+			Category: category,
+		},
 		&cli.StringFlag{
 			Name:     EthRPCURLFlagName,
 			Usage:    "URL of the Ethereum RPC endpoint.",
@@ -166,6 +182,48 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			Value:    10 * time.Second,
 			Required: false,
 		},
+		&cli.StringFlag{
+			Name: RelayRetrievalStrategyFlagName,
+			Usage: fmt.Sprintf("How blob requests are spread over the relays of a cert. '%s' tries one relay at a "+
+				"time, '%s' also queries the next relay whenever a relay hasn't answered within the relay hedge delay, "+
+				"and '%s' queries all relays at once. Relays are always tried in order of their observed reliability.",
+				payloadretrieval.SequentialRelayRetrieval,
+				payloadretrieval.HedgedRelayRetrieval,
+				payloadretrieval.RaceRelayRetrieval,
+			),
+			EnvVars:  []string{withEnvPrefix(envPrefix, "RELAY_RETRIEVAL_STRATEGY")},
+			Category: category,
+			Value:    string(payloadretrieval.SequentialRelayRetrieval),
+			Required: false,
+		},
+		&cli.DurationFlag{
+			Name: RelayHedgeDelayFlagName,
+			Usage: "With the 'hedged' relay retrieval strategy, how long to wait for a relay before also " +
+				"querying the next one.",
+			EnvVars:  []string{withEnvPrefix(envPrefix, "RELAY_HEDGE_DELAY")},
+			Category: category,
+			Value:    500 * time.Millisecond,
+			Required: false,
+		},
+		&cli.BoolFlag{
+			Name: CompositeRetrievalFlagName,
+			Usage: "Retrieve payloads with a single retriever that falls back from the relays to the validators " +
+				"when the relays fail or return blobs that don't match the cert commitment. " +
+				"Reports per-source (relay/validator) retrieval metrics.",
+			EnvVars:  []string{withEnvPrefix(envPrefix, "COMPOSITE_RETRIEVAL")},
+			Category: category,
+			Value:    false,
+			Required: false,
+		},
+		&cli.DurationFlag{
+			Name: CompositeRelayBudgetFlagName,
+			Usage: "With composite retrieval, the maximum time spent retrieving from the relays before falling " +
+				"back to the validators. 0 means no limit other than the relay timeouts.",
+			EnvVars:  []string{withEnvPrefix(envPrefix, "COMPOSITE_RELAY_BUDGET")},
+			Category: category,
+			Value:    0,
+			Required: false,
+		},
 		&cli.DurationFlag{
 			Name: ValidatorTimeoutFlagName,
 			Usage: "Timeout used when retrieving chunks directly from EigenDA validators. " +
@@ -246,6 +304,11 @@ Permitted EigenDANetwork values include %s, %s, & %s.`,
 }
 
 func ReadClientConfigV2(ctx *cli.Context) (common.ClientConfigV2, error) {
+	payloadEncodingVersion := ctx.Uint(PayloadEncodingVersionFlagName)
+	if payloadEncodingVersion > math.MaxUint8 {
+		return common.ClientConfigV2{}, fmt.Errorf("payload encoding version %d is out of range", payloadEncodingVersion)
+	}
+
 	disperserConfig, err := readDisperserCfg(ctx)
 	if err != nil {
 		return common.ClientConfigV2{}, fmt.Errorf("read disperser config: %w", err)
@@ -280,21 +343,28 @@ func ReadClientConfigV2(ctx *cli.Context) (common.ClientConfigV2, error) {
 		eigenDADirectory = eigenDANetwork.GetEigenDADirectory()
 	}
 
+	// we don't expose the list of retrievers to users, as all production use cases should have
+	// both retrieval methods enabled. Users can only choose to combine them in a composite retriever.
+	// Note the order of these retrievers, which is significant: the relay retriever will be
+	// tried first, and the validator retriever will only be tried if the relay retriever fails
+	retrieversToEnable := []common.RetrieverType{
+		common.RelayRetrieverType,
+		common.ValidatorRetrieverType,
+	}
+	if ctx.Bool(CompositeRetrievalFlagName) {
+		// the composite retriever falls back from the relays to the validators by itself
+		retrieversToEnable = []common.RetrieverType{common.CompositeRetrieverType}
+	}
+
 	return common.ClientConfigV2{
-		DisperserClientCfg:           disperserConfig,
-		PayloadDisperserCfg:          readPayloadDisperserCfg(ctx),
-		RelayPayloadRetrieverCfg:     readRelayRetrievalConfig(ctx),
-		ValidatorPayloadRetrieverCfg: readValidatorRetrievalConfig(ctx),
-		PutTries:                     ctx.Int(PutRetriesFlagName),
-		MaxBlobSizeBytes:             maxBlobLengthBytes,
-		// we don't expose this configuration to users, as all production use cases should have
-		// both retrieval methods enabled. This could be exposed in the future, if necessary.
-		// Note the order of these retrievers, which is significant: the relay retriever will be
-		// tried first, and the validator retriever will only be tried if the relay retriever fails
-		RetrieversToEnable: []common.RetrieverType{
-			common.RelayRetrieverType,
-			common.ValidatorRetrieverType,
-		},
+		DisperserClientCfg:                 disperserConfig,
+		PayloadDisperserCfg:                readPayloadDisperserCfg(ctx),
+		RelayPayloadRetrieverCfg:           readRelayRetrievalConfig(ctx),
+		ValidatorPayloadRetrieverCfg:       readValidatorRetrievalConfig(ctx),
+		CompositePayloadRetrieverCfg:       readCompositeRetrievalConfig(ctx),
+		PutTries:                           ctx.Int(PutRetriesFlagName),
+		MaxBlobSizeBytes:                   maxBlobLengthBytes,
+		RetrieversToEnable:                 retrieversToEnable,
 		EigenDACertVerifierOrRouterAddress: ctx.String(CertVerifierRouterOrImmutableVerifierAddrFlagName),
 		EigenDADirectory:                   eigenDADirectory,
 		EigenDANetwork:                     eigenDANetwork,
@@ -322,6 +392,8 @@ func readPayloadClientConfig(ctx *cli.Context) clients_v2.PayloadClientConfig {
 
 	return clients_v2.PayloadClientConfig{
 		PayloadPolynomialForm: polyForm,
+		// #nosec G115 - range checked in ReadClientConfigV2
+		PayloadEncodingVersion: codecs.PayloadEncodingVersion(ctx.Uint(PayloadEncodingVersionFlagName)),
 		// #nosec G115 - only overflow on incorrect user input
 		BlobVersion: uint16(ctx.Int(BlobParamsVersionFlagName)),
 	}
@@ -366,6 +438,15 @@ func readRelayRetrievalConfig(ctx *cli.Context) payloadretrieval.RelayPayloadRet
 	return payloadretrieval.RelayPayloadRetrieverConfig{
 		PayloadClientConfig: readPayloadClientConfig(ctx),
 		RelayTimeout:        ctx.Duration(RelayTimeoutFlagName),
+		RetrievalStrategy:   payloadretrieval.RelayRetrievalStrategy(ctx.String(RelayRetrievalStrategyFlagName)),
+		HedgeDelay:          ctx.Duration(RelayHedgeDelayFlagName),
+	}
+}
+
+func readCompositeRetrievalConfig(ctx *cli.Context) payloadretrieval.CompositePayloadRetrieverConfig {
+	return payloadretrieval.CompositePayloadRetrieverConfig{
+		SourcePolicy: payloadretrieval.RelaysThenValidators,
+		RelayBudget:  ctx.Duration(CompositeRelayBudgetFlagName),
 	}
 }
 
@@ -39,6 +39,9 @@ type Metrics struct {
 	SecondaryRequestDurationSec *prometheus.HistogramVec
 
 	factory *metrics.Documentor
+
+	// network profile that HTTP server requests are labeled with, empty for the default network
+	network string
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -73,7 +76,7 @@ func NewMetrics(registry *prometheus.Registry) Metricer {
 			Name:      "requests_total",
 			Help:      "Total requests to the HTTP server",
 		}, []string{
-			"method", "status", "commitment_mode", "cert_version",
+			"method", "status", "commitment_mode", "cert_version", "network",
 		}),
 		HTTPServerBadRequestHeader: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
@@ -94,6 +97,7 @@ func NewMetrics(registry *prometheus.Registry) Metricer {
 			Help:    "Histogram of HTTP server request durations",
 		}, []string{
 			"method", // no status on histograms because those are very expensive
+			"network",
 		}),
 		SecondaryRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
@@ -134,9 +138,9 @@ func (m *Metrics) RecordUp() {
 func (m *Metrics) RecordRPCServerRequest(method string) func(status, mode, ver string) {
 	// we don't want to track the status code on the histogram because that would
 	// create a huge number of labels, and cost a lot on cloud hosted services
-	timer := prometheus.NewTimer(m.HTTPServerRequestDurationSeconds.WithLabelValues(method))
+	timer := prometheus.NewTimer(m.HTTPServerRequestDurationSeconds.WithLabelValues(method, m.network))
 	return func(status, mode, ver string) {
-		m.HTTPServerRequestsTotal.WithLabelValues(method, status, mode, ver).Inc()
+		m.HTTPServerRequestsTotal.WithLabelValues(method, status, mode, ver, m.network).Inc()
 		timer.ObserveDuration()
 	}
 }
@@ -155,6 +159,18 @@ func (m *Metrics) Document() []metrics.DocumentedMetric {
 	return m.factory.Document()
 }
 
+// WithNetwork returns a Metricer that shares the metrics of m, but labels the HTTP server requests it records
+// with the given network profile. Metricers other than [Metrics] are returned unchanged.
+func WithNetwork(m Metricer, network string) Metricer {
+	metrics, ok := m.(*Metrics)
+	if !ok {
+		return m
+	}
+	withNetwork := *metrics
+	withNetwork.network = network
+	return &withNetwork
+}
+
 type noopMetricer struct {
 }
 
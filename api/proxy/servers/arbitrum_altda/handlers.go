@@ -2,6 +2,7 @@ package arbitrum_altda
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"time"
@@ -12,7 +13,10 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
 	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
 	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	certTypesBinding "github.com/Layr-Labs/eigenda/contracts/bindings/IEigenDACertTypeBindings"
+	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -38,8 +42,8 @@ type IEthClient interface {
 		[X] Store // trusted integration
 		[X] RecoveryPayload // trusted integration
 		[-] CollectPreimages // trusted integration
-		[ ] GenerateProof // trustless AND secure integration
-		[ ] GenerateCertificateValidityProof // trustless AND secure integration
+		[X] GenerateReadPreimageProof // trustless AND secure integration
+		[X] GenerateCertificateValidityProof // trustless AND secure integration
 */
 
 // IHandlers defines the expected JSON RPC interface as defined per Arbitrum Nitro's Custom DA interface:
@@ -87,8 +91,6 @@ type IHandlers interface {
 // This method implementations should serve as a thin wrapper over the existing EigenDA manager construct
 // with translation mapping 503 (failover) and 418 (invalid_cert) status codes into error messages that
 // arbitrum nitro can understand to take actions preserving both rollup liveness and safety
-//
-// Some custom code / refactoring will likely be necessary for supporting the READPREIMAGE proof serialization logic
 type Handlers struct {
 	// TODO: Metrics support - makes sense to share metrics server between both rest and arbitrum alt da
 	//       servers. There should exist some label used or tag that can be used to filter between
@@ -115,6 +117,8 @@ type Handlers struct {
 	eigenDAManager     store.IEigenDAManager
 	ethClient          IEthClient
 	compatibilityCfg   proxy_common.CompatibilityConfig
+	// generates READPREIMAGE proofs. nil if proof generation is disabled.
+	preimageProver *ReadPreimageProver
 }
 
 // NewHandlers is a constructor
@@ -124,6 +128,7 @@ func NewHandlers(
 	processInvalidCert bool,
 	ethClient IEthClient,
 	compatCfg proxy_common.CompatibilityConfig,
+	preimageProver *ReadPreimageProver,
 ) IHandlers {
 	return &Handlers{
 		log:                l,
@@ -131,6 +136,7 @@ func NewHandlers(
 		eigenDAManager:     m,
 		ethClient:          ethClient,
 		compatibilityCfg:   compatCfg,
+		preimageProver:     preimageProver,
 	}
 }
 
@@ -168,7 +174,18 @@ func (h *Handlers) deserializeCertFromSequencerMsg(sequencerMsg hexutil.Bytes) (
 				DACertOffset, len(sequencerMsg))
 	}
 
-	daCommit := sequencerMsg[MessageHeaderOffset:]
+	return h.deserializeCertFromDACommitment(sequencerMsg[MessageHeaderOffset:])
+}
+
+// deserializeCertFromDACommitment reads the VersionedCert from the Arbitrum Custom DA commitment
+// (i.e, the sequencer message without its message header), which is the certificate
+// that nitro provides to the proof generation methods
+func (h *Handlers) deserializeCertFromDACommitment(daCommit []byte) (*certs.VersionedCert, error) {
+	if len(daCommit) <= DACommitPrefixBytes {
+		return nil,
+			fmt.Errorf("DA commitment expected to be >%d bytes, got: %d",
+				DACommitPrefixBytes, len(daCommit))
+	}
 
 	daHeaderByte := daCommit[0]
 	if daHeaderByte != commitments.ArbCustomDAHeaderByte {
@@ -249,8 +266,7 @@ func (h *Handlers) RecoverPayload(
 		L1InclusionBlockNum: l1InclusionBlockNum,
 	})
 	if err != nil {
-		var dpError *coretypes.DerivationError
-		if errors.As(err, &dpError) && h.processInvalidCert {
+		if isDerivationError(err) && h.processInvalidCert {
 			err = errors.Join(err, ErrCertValidationError)
 		}
 
@@ -353,8 +369,7 @@ func (h *Handlers) CollectPreimages(
 	payload, err := h.eigenDAManager.Get(ctx, daCert,
 		coretypes.CertSerializationABI, proxy_common.GETOpts{})
 	if err != nil {
-		var dpError *coretypes.DerivationError
-		if errors.As(err, &dpError) {
+		if isDerivationError(err) {
 			// returning nil for the batch payload indicates to the
 			// nitro derivation pipeline to "discard" this batch and move
 			// onto the next DA Cert in the Sequencer Inbox
@@ -377,74 +392,220 @@ func (h *Handlers) CollectPreimages(
 	}, nil
 }
 
-// GenerateReadPreimageProof is used to prove a 32 byte CustomDA preimage type for READPREIMAGE
-// The exact implementation here is still a bit TBD - but we'll prove availability of the 32 bytes
-// by computing a kzg point opening proof using the data commitment provided in the DA Cert.
-// This will be equivalent to what's already done in the arbitrator for serializing an EigenDA READPREIMAGE
-// proof. The large difference is this is done on the Custom DA server in go code as an
-// "extension" of the one step proof
-// construction logic.
+// GenerateReadPreimageProof is used to prove a 32 byte CustomDA preimage type for READPREIMAGE.
+// Availability of the 32 bytes is proven by computing kzg point openings against the data commitment
+// in the DA Cert for the field elements of the blob that hold the requested preimage bytes. This is
+// equivalent to what's already done in the arbitrator for serializing an EigenDA READPREIMAGE proof,
+// except that it's done on the Custom DA server as an "extension" of the one step proof construction logic.
 //
-// READPREIMAGE only cares about the availability or corectness of an EigenDA blob wrt it's kzg data commitment that's
-// persisted in the already agreed upon DA Cert.
-// Let's assumes that the EigenDA disperser would never sign over a DA Cert with an invalid data commitment.
-// Pulling that off would require majority corruption of the EigenDA operator quorums and collusion with disperser
-// which is a highly improbable event.
-// The data commitment is a tamper resistant field in the rollup domain since modification would result
-// in an incorrect merkle leaf hash being constructed from the blob header and result in an invalid merkle inclusion
-// proof which would be treated as an invalid DA Cert by the rollup.
+// READPREIMAGE only cares about the availability or correctness of an EigenDA blob wrt its kzg data commitment
+// that's persisted in the already agreed upon DA Cert. The data commitment is a tamper resistant field in the
+// rollup domain since modification would result in an incorrect merkle leaf hash being constructed from the
+// blob header and result in an invalid merkle inclusion proof which would be treated as an invalid DA Cert
+// by the rollup.
 //
-// TODO: Generating the data witness "opening" proof requires access to the entire EigenDA blob
-// which isn't provided by client here. We can do a storage retrieval operation through the EigenDA Manager
-// to fetch the blob corresponding to the DA Cert. Redundantly performing DA Cert verification is a necessary
-// invariant here to strictly enforce given that this function would only ever be called if checkDACert(DA Cert)=true.
-// It's slow to do another storage lookup but performance considerations are irrelevant given this is only callable
-// in the worst case one step proof.
+// Generating the opening proofs requires access to the entire EigenDA blob, which is fetched through
+// the EigenDA Manager. This redundantly performs DA Cert verification, which is a necessary invariant to
+// strictly enforce given that this function should only ever be called if checkDACert(DA Cert)=true.
+// The extra storage lookup is slow, but performance is irrelevant given this is only callable in the
+// worst case one step proof.
 //
-// TODO: Determine encoding standard that's also understood for onchain verification
+// See proofs.go for the proof encoding.
 //
-
-/*
-current encoding proposal:
-
-	Assumptions:
-		- kzg commitment and preimage length are extractable
-		  from the existing DA Cert
-
-	Proposed schema:
-		- [0:32]: root of unity @ field element offset
-		- [32:64]: field element or preimageChunk being one step proven
-		- [64:128]: point opening proof (g1 point)
-		- [128:256]: g2TauMinusG2z
-*/
+// @param cert_hash: keccak256 hash of the certificate, i.e. the preimage key
+// @param offset: byte offset into the preimage (the rollup payload) being read
+// @param certificate: The DA Certificate
+//
+//	@return proof: serialized READPREIMAGE proof
+//	@return error: a structured error message (if applicable)
 func (h *Handlers) GenerateReadPreimageProof(
 	ctx context.Context,
 	certHash common.Hash,
 	offset hexutil.Uint64,
 	certificate hexutil.Bytes,
 ) (*GenerateReadPreimageProofResult, error) {
-	panic("GenerateProof method is unimplemented")
+	callBack := h.logMethodCall(MethodGenerateReadPreimageProof,
+		"cert_hash", certHash.Hex(), "offset", uint64(offset))
+	defer callBack()
+
+	if h.preimageProver == nil {
+		return nil, errors.New("read preimage proof generation is not enabled")
+	}
+
+	if crypto.Keccak256Hash(certificate) != certHash {
+		return nil, fmt.Errorf("cert hash %s does not match keccak256 hash of the certificate", certHash.Hex())
+	}
+
+	daCert, err := h.deserializeCertFromDACommitment(certificate)
+	if err != nil {
+		return nil, fmt.Errorf("deserialize cert: %w", err)
+	}
+
+	blobCommitment, blobLength, err := blobCommitmentFromCert(daCert)
+	if err != nil {
+		return nil, fmt.Errorf("read blob commitment from cert: %w", err)
+	}
+
+	encodedPayload, err := h.eigenDAManager.Get(ctx, daCert,
+		coretypes.CertSerializationABI, proxy_common.GETOpts{ReturnEncodedPayload: true})
+	if err != nil {
+		return nil, fmt.Errorf("get encoded payload from DA Cert: %w", err)
+	}
+
+	proof, err := h.preimageProver.GenerateProof(blobCommitment, blobLength, encodedPayload, uint64(offset))
+	if err != nil {
+		return nil, fmt.Errorf("generate read preimage proof: %w", err)
+	}
+
+	return &GenerateReadPreimageProofResult{
+		Proof: proof,
+	}, nil
 }
 
-// Non operational implementation.
+// GenerateCertificateValidityProof is used to prove the result of the ValidateCertificate opcode.
+//
 // The DA Cert is already tamper resistant given its already been pre-committed to a rollup inbox
-// and is verified against memory pre-state agreed upon by all challenging parties
+// and is verified against memory pre-state agreed upon by all challenging parties. The one step prover
+// is able to re-verify the DA Cert onchain through the EigenDA cert verifier, so no additional proof
+// metadata needs to be appended beyond the validity claimed by this server, which the one step prover
+// checks against the onchain verification result.
+//
+// A DA Cert is claimed to be valid iff CollectPreimages would record a preimage for it, i.e. it can be
+// deserialized and retrieving its payload doesn't result in a derivation error.
+//
+// The proof also carries the reason a DA Cert is claimed to be invalid, and the reference block number that
+// determines which cert verifier the one step prover checks the claim against (see EigenDACertVerifierRouter).
+//
+// Proof encoding:
+//   - [0]: 0x01 if the DA Cert is claimed to be valid, otherwise 0x00
+//   - [1]: DA Cert version byte, or 0x00 if the certificate is too short to contain one
+//   - [2]: the derivation error status code (see coretypes.DerivationErrorStatusCode) that makes the DA Cert
+//     invalid, or 0x00 if the DA Cert is claimed to be valid
+//   - [3:11]: big-endian reference block number of the DA Cert, or 0 if the DA Cert can't be parsed
 //
-// There’s no need for appending additional proof metadata for a one step proof tx
-// contesting DA Cert validity
+// @param certificate: The DA Certificate
 //
-// TODO: Assuming we have to manage a custom fork of nitro, should we remove the proof enhancement step for
-// ValidateCert opcode given the client<>server latency introduced given its noop? Then again,
-// this is only ever called in the worst case one step proof WHEN the determined canonnical prestate between
-// challengers is the step before calling a ValidateCert type opcode so performance considerations are rather
-// irrelevant
+//	@return proof: serialized validity proof
+//	@return error: a structured error message (if applicable)
 func (h *Handlers) GenerateCertificateValidityProof(
 	ctx context.Context,
 	certificate hexutil.Bytes,
 ) (*GenerateCertificateValidityProofResult, error) {
+	callBack := h.logMethodCall(MethodGenerateCertValidityProof, "certificate", certificate.String())
+	defer callBack()
+
+	versionByte := byte(0x00)
+	if len(certificate) > DACommitPrefixBytes {
+		versionByte = certificate[DACommitPrefixBytes]
+	}
+
+	daCert, err := h.deserializeCertFromDACommitment(certificate)
+	if err != nil {
+		h.log.Warn("Failed to deserialize DA Cert, claiming it is invalid", "err", err)
+		return newCertValidityProof(false, versionByte, coretypes.ErrCertParsingFailedDerivationError.StatusCode, 0), nil
+	}
+	cert, err := deserializeEigenDACert(daCert)
+	if err != nil {
+		h.log.Warn("Failed to deserialize DA Cert, claiming it is invalid", "err", err)
+		return newCertValidityProof(false, versionByte, coretypes.ErrCertParsingFailedDerivationError.StatusCode, 0), nil
+	}
+	referenceBlockNumber := cert.ReferenceBlockNumber()
+
+	_, err = h.eigenDAManager.Get(ctx, daCert, coretypes.CertSerializationABI, proxy_common.GETOpts{})
+	if err != nil {
+		statusCode, ok := derivationErrorStatusCode(err)
+		if ok {
+			h.log.Warn("DA Cert failed derivation, claiming it is invalid", "err", err)
+			return newCertValidityProof(false, versionByte, statusCode, referenceBlockNumber), nil
+		}
+
+		return nil, fmt.Errorf("get rollup payload from DA Cert: %w", err)
+	}
+
+	return newCertValidityProof(true, versionByte, 0, referenceBlockNumber), nil
+}
+
+// newCertValidityProof serializes a cert validity proof, see GenerateCertificateValidityProof for the encoding.
+func newCertValidityProof(
+	valid bool,
+	versionByte byte,
+	statusCode uint8,
+	referenceBlockNumber uint64,
+) *GenerateCertificateValidityProofResult {
+	validByte := byte(0x00)
+	if valid {
+		validByte = 0x01
+	}
+
+	proof := make([]byte, 0, 11)
+	proof = append(proof, validByte, versionByte, statusCode)
+	proof = binary.BigEndian.AppendUint64(proof, referenceBlockNumber)
 	return &GenerateCertificateValidityProofResult{
-		Proof: []byte{},
-	}, nil
+		Proof: proof,
+	}
+}
+
+// isDerivationError returns true if the error chain contains a DerivationError, which
+// may be returned either by value or by pointer
+func isDerivationError(err error) bool {
+	_, ok := derivationErrorStatusCode(err)
+	return ok
+}
+
+// derivationErrorStatusCode returns the status code of the DerivationError in the error chain, if there is one.
+func derivationErrorStatusCode(err error) (uint8, bool) {
+	var dpError coretypes.DerivationError
+	if errors.As(err, &dpError) {
+		return dpError.StatusCode, true
+	}
+	var dpErrorPtr *coretypes.DerivationError
+	if errors.As(err, &dpErrorPtr) && dpErrorPtr != nil {
+		return dpErrorPtr.StatusCode, true
+	}
+	return 0, false
+}
+
+// deserializeEigenDACert deserializes the EigenDA cert contained in a versioned DA Cert.
+func deserializeEigenDACert(daCert *certs.VersionedCert) (coretypes.EigenDACert, error) {
+	certVersion, err := daCert.Version.IntoCertVersion()
+	if err != nil {
+		return nil, fmt.Errorf("convert version byte to cert version: %w", err)
+	}
+
+	cert, err := coretypes.DeserializeEigenDACert(daCert.SerializedCert, certVersion, coretypes.CertSerializationABI)
+	if err != nil {
+		return nil, fmt.Errorf("deserialize cert: %w", err)
+	}
+	return cert, nil
+}
+
+// blobCommitmentFromCert reads the kzg data commitment and blob length (in symbols) from a DA Cert.
+// Only the G1 data commitment is deserialized, since the length commitment and proof aren't needed
+// for READPREIMAGE proofs.
+func blobCommitmentFromCert(daCert *certs.VersionedCert) (*encoding.G1Commitment, uint32, error) {
+	cert, err := deserializeEigenDACert(daCert)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var blobCommitment certTypesBinding.EigenDATypesV2BlobCommitment
+	switch c := cert.(type) {
+	case *coretypes.EigenDACertV3:
+		blobCommitment = c.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment
+	case *coretypes.EigenDACertV4:
+		blobCommitment = c.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment
+	default:
+		return nil, 0, fmt.Errorf("unsupported cert type %T", cert)
+	}
+
+	commitment := &encoding.G1Commitment{}
+	commitment.X.SetBigInt(blobCommitment.Commitment.X)
+	commitment.Y.SetBigInt(blobCommitment.Commitment.Y)
+	if !(*bn254.G1Affine)(commitment).IsOnCurve() {
+		return nil, 0, errors.New("blob commitment is not a valid g1 point")
+	}
+
+	return commitment, blobCommitment.Length, nil
 }
 
 // CompatibilityConfig returns compatibility values an external service can use to verify compatibility between
@@ -13,6 +13,7 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
 	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
 	"github.com/Layr-Labs/eigenda/api/proxy/test/mocks"
+	certTypesBinding "github.com/Layr-Labs/eigenda/contracts/bindings/IEigenDACertTypeBindings"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -62,7 +63,7 @@ func TestMethod_GetMaxMessageSize(t *testing.T) {
 		Version:             "1.0.0",
 		MaxPayloadSizeBytes: testMaxPayloadSize,
 	}
-	handlers := NewHandlers(mockEigenDAManager, testLogger, false, nil, compatCfg)
+	handlers := NewHandlers(mockEigenDAManager, testLogger, false, nil, compatCfg, nil)
 
 	result, err := handlers.GetMaxMessageSize(context.Background())
 	require.NoError(t, err)
@@ -78,7 +79,7 @@ func TestMethod_GetSupportedHeaderBytes(t *testing.T) {
 
 	mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
 	compatCfg := proxy_common.CompatibilityConfig{Version: "1.0.0", MaxPayloadSizeBytes: 100_000_000}
-	handlers := NewHandlers(mockEigenDAManager, testLogger, false, nil, compatCfg)
+	handlers := NewHandlers(mockEigenDAManager, testLogger, false, nil, compatCfg, nil)
 
 	result, err := handlers.GetSupportedHeaderBytes(context.Background())
 	require.NoError(t, err)
@@ -182,7 +183,7 @@ func TestMethod_Store(t *testing.T) {
 				maxPayloadSize = 10
 			}
 			compatCfg := proxy_common.CompatibilityConfig{Version: "1.0.0", MaxPayloadSizeBytes: maxPayloadSize}
-			handlers := NewHandlers(mockEigenDAManager, testLogger, false, nil, compatCfg)
+			handlers := NewHandlers(mockEigenDAManager, testLogger, false, nil, compatCfg, nil)
 
 			mockEigenDAManager.EXPECT().
 				GetDispersalBackend().
@@ -291,7 +292,7 @@ func TestRecoverPayload(t *testing.T) {
 			mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
 			mockEthClient := mocks.NewMockIEthClient(ctrl)
 			compatCfg := proxy_common.CompatibilityConfig{Version: "1.0.0"}
-			handlers := NewHandlers(mockEigenDAManager, testLogger, tt.processInvalidCert, mockEthClient, compatCfg)
+			handlers := NewHandlers(mockEigenDAManager, testLogger, tt.processInvalidCert, mockEthClient, compatCfg, nil)
 
 			// Mock eth client to return a valid block
 			mockEthClient.EXPECT().
@@ -394,7 +395,7 @@ func TestCollectPreimages(t *testing.T) {
 			mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
 			mockEthClient := mocks.NewMockIEthClient(ctrl)
 			compatCfg := proxy_common.CompatibilityConfig{Version: "1.0.0"}
-			handlers := NewHandlers(mockEigenDAManager, testLogger, false, mockEthClient, compatCfg)
+			handlers := NewHandlers(mockEigenDAManager, testLogger, false, mockEthClient, compatCfg, nil)
 
 			// Mock eth client to return a valid block
 			mockEthClient.EXPECT().
@@ -433,21 +434,137 @@ func TestCollectPreimages(t *testing.T) {
 	}
 }
 
-// TestGenerateCertificateValidityProof verifies the GenerateCertificateValidityProof handler
+// createTestCert creates a versioned certificate containing an ABI serialized EigenDACertV4 with the given reference
+// block number.
+func createTestCert(t *testing.T, referenceBlockNumber uint32) *certs.VersionedCert {
+	g1Point := certTypesBinding.BN254G1Point{X: big.NewInt(1), Y: big.NewInt(2)}
+	cert := coretypes.EigenDACertV4{
+		BlobInclusionInfo: certTypesBinding.EigenDATypesV2BlobInclusionInfo{
+			BlobCertificate: certTypesBinding.EigenDATypesV2BlobCertificate{
+				BlobHeader: certTypesBinding.EigenDATypesV2BlobHeaderV2{
+					QuorumNumbers: []byte{0, 1},
+					Commitment: certTypesBinding.EigenDATypesV2BlobCommitment{
+						Commitment: g1Point,
+						LengthCommitment: certTypesBinding.BN254G2Point{
+							X: [2]*big.Int{big.NewInt(0), big.NewInt(0)},
+							Y: [2]*big.Int{big.NewInt(0), big.NewInt(0)},
+						},
+						LengthProof: certTypesBinding.BN254G2Point{
+							X: [2]*big.Int{big.NewInt(0), big.NewInt(0)},
+							Y: [2]*big.Int{big.NewInt(0), big.NewInt(0)},
+						},
+						Length: 64,
+					},
+				},
+			},
+		},
+		BatchHeader: certTypesBinding.EigenDATypesV2BatchHeaderV2{ReferenceBlockNumber: referenceBlockNumber},
+		NonSignerStakesAndSignature: certTypesBinding.EigenDATypesV1NonSignerStakesAndSignature{
+			ApkG2: certTypesBinding.BN254G2Point{
+				X: [2]*big.Int{big.NewInt(0), big.NewInt(0)},
+				Y: [2]*big.Int{big.NewInt(0), big.NewInt(0)},
+			},
+			Sigma: g1Point,
+		},
+		SignedQuorumNumbers: []byte{0, 1},
+	}
+	serializedCert, err := cert.Serialize(coretypes.CertSerializationABI)
+	require.NoError(t, err)
+	return certs.NewVersionedCert(serializedCert, certs.V3VersionByte)
+}
+
+// TestGenerateCertificateValidityProof verifies the GenerateCertificateValidityProof handler behavior
+// using table-driven tests
 func TestGenerateCertificateValidityProof(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+	testCert := createTestCert(t, 12345)
+	validCertificate := createSequencerMsg(testCert)[MessageHeaderOffset:]
+	version := byte(testCert.Version)
+	rbn := []byte{0, 0, 0, 0, 0, 0, 0x30, 0x39}
+	noRBN := make([]byte, 8)
 
-	mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
-	compatCfg := proxy_common.CompatibilityConfig{Version: "1.0.0"}
-	handlers := NewHandlers(mockEigenDAManager, testLogger, false, nil, compatCfg)
+	tests := []struct {
+		name          string
+		certificate   hexutil.Bytes
+		expectGetCall bool
+		mockGetError  error
+		expectError   bool
+		errorContains string
+		expectedProof hexutil.Bytes
+	}{
+		{
+			name:          "Success - Valid Cert",
+			certificate:   validCertificate,
+			expectGetCall: true,
+			expectedProof: append(hexutil.Bytes{0x01, version, 0x00}, rbn...),
+		},
+		{
+			name:          "Success - Derivation Error Claims Invalid",
+			certificate:   validCertificate,
+			expectGetCall: true,
+			mockGetError:  coretypes.ErrInvalidCertDerivationError.WithMessage("invalid"),
+			expectedProof: append(hexutil.Bytes{0x00, version, 0x03}, rbn...),
+		},
+		{
+			name:          "Success - Derivation Error By Pointer Claims Invalid",
+			certificate:   validCertificate,
+			expectGetCall: true,
+			mockGetError:  &coretypes.DerivationError{StatusCode: 2},
+			expectedProof: append(hexutil.Bytes{0x00, version, 0x02}, rbn...),
+		},
+		{
+			name:          "Success - Malformed Certificate Claims Invalid",
+			certificate:   hexutil.Bytes([]byte("some certificate")),
+			expectedProof: append(hexutil.Bytes{0x00, byte('m'), 0x01}, noRBN...),
+		},
+		{
+			name: "Success - Unparseable EigenDA Cert Claims Invalid",
+			certificate: createSequencerMsg(
+				certs.NewVersionedCert([]byte("not an abi encoded cert"), certs.V3VersionByte))[MessageHeaderOffset:],
+			expectedProof: append(hexutil.Bytes{0x00, byte(certs.V3VersionByte), 0x01}, noRBN...),
+		},
+		{
+			name:          "Success - Truncated Certificate Claims Invalid",
+			certificate:   hexutil.Bytes{commitments.ArbCustomDAHeaderByte},
+			expectedProof: append(hexutil.Bytes{0x00, 0x00, 0x01}, noRBN...),
+		},
+		{
+			name:          "Error - Get Failed With Non-Derivation Error",
+			certificate:   validCertificate,
+			expectGetCall: true,
+			mockGetError:  errors.New("generic error"),
+			expectError:   true,
+			errorContains: "get rollup payload",
+		},
+	}
 
-	certificate := hexutil.Bytes([]byte("some certificate"))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
 
-	result, err := handlers.GenerateCertificateValidityProof(context.Background(), certificate)
-	require.NoError(t, err)
-	require.NotNil(t, result)
-	require.Equal(t, hexutil.Bytes([]byte{}), result.Proof)
+			mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+			compatCfg := proxy_common.CompatibilityConfig{Version: "1.0.0"}
+			handlers := NewHandlers(mockEigenDAManager, testLogger, false, nil, compatCfg, nil)
+
+			if tt.expectGetCall {
+				mockEigenDAManager.EXPECT().
+					Get(gomock.Any(), gomock.Any(), coretypes.CertSerializationABI, gomock.Any()).
+					Return([]byte("recovered payload"), tt.mockGetError)
+			}
+
+			result, err := handlers.GenerateCertificateValidityProof(context.Background(), tt.certificate)
+
+			if tt.expectError {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.errorContains)
+				require.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				require.Equal(t, tt.expectedProof, result.Proof)
+			}
+		})
+	}
 }
 
 // TestCompatibilityConfig verifies the CompatibilityConfig handler
@@ -466,7 +583,7 @@ func TestCompatibilityConfig(t *testing.T) {
 		APIsEnabled:         []string{"api1", "api2"},
 	}
 
-	handlers := NewHandlers(mockEigenDAManager, testLogger, false, nil, expectedConfig)
+	handlers := NewHandlers(mockEigenDAManager, testLogger, false, nil, expectedConfig, nil)
 
 	result, err := handlers.CompatibilityConfig(context.Background())
 	require.NoError(t, err)
@@ -537,7 +654,7 @@ func TestDeserializeCertFromSequencerMsg(t *testing.T) {
 
 			mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
 			compatCfg := proxy_common.CompatibilityConfig{Version: "1.0.0"}
-			handlers := NewHandlers(mockEigenDAManager, testLogger, false, nil, compatCfg).(*Handlers)
+			handlers := NewHandlers(mockEigenDAManager, testLogger, false, nil, compatCfg, nil).(*Handlers)
 
 			cert, err := handlers.deserializeCertFromSequencerMsg(tt.sequencerMsg)
 
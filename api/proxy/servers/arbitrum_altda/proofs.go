@@ -0,0 +1,427 @@
+package arbitrum_altda
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	gomath "math"
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/common/math"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/codec"
+	"github.com/Layr-Labs/eigenda/encoding/v2/fft"
+	"github.com/Layr-Labs/eigenda/encoding/v2/kzg"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+/*
+	READPREIMAGE proof encoding (version 0):
+
+	The CustomDA preimage of a DA Cert is the rollup payload (see CollectPreimages). The payload is stored in EigenDA as
+	an encoded payload in evaluation form, meaning that the i-th field element of the encoded payload is the evaluation
+	of the blob polynomial at the i-th root of unity of the blob's domain. Any field element of the encoded payload can
+	therefore be proven against the blob commitment in the DA Cert with a KZG point opening.
+
+	Encoded payload layout (see coretypes.EncodedPayload):
+		- field element 0: header [0x00, version byte, big-endian uint32 payload length, 0x00, ...]
+		- field element 1 + j/31, byte 1 + j%31: payload byte j
+
+	Proof layout:
+		- [0:1]: proof version byte
+		- [1:257]: opening of field element 0, which proves the payload length
+		- [257:...]: openings of the (zero, one or two) field elements which contain the 32 byte preimage chunk
+		             starting at the requested offset, in ascending order
+
+	Opening layout:
+		- [0:32]: evaluation point z, the root of unity at the field element's index
+		- [32:64]: evaluation p(z), the field element being proven
+		- [64:128]: point opening proof π, uncompressed g1 point (x, y)
+		- [128:256]: [τ - z]₂, uncompressed g2 point (x.A1, x.A0, y.A1, y.A0), the encoding used by the EVM
+		             pairing precompile
+
+	An opening is valid iff e(C - [p(z)]₁, [1]₂) = e(π, [τ - z]₂) where C is the blob commitment. A verifier that
+	cannot compute [τ - z]₂ itself (e.g. the EVM) must also check e([1]₁, [τ - z]₂) = e([τ]₁ - [z]₁, [1]₂).
+
+	Limitations:
+		- Only payloads encoded with PayloadEncodingVersion0 can be proven. Other encodings (e.g. the zstd compressed
+		  PayloadEncodingVersion1) don't store the preimage bytes in the blob's field elements, so a preimage chunk
+		  can't be proven with point openings. Proof generation fails with ErrUnsupportedPayloadEncoding.
+		- Only blobs dispersed in evaluation form can be proven, since a point opening proves an evaluation of the
+		  blob polynomial, not one of its coefficients. Proof generation fails with ErrUnsupportedPolynomialForm for
+		  blobs dispersed in coefficient form.
+*/
+
+// ReadPreimageProofVersion0 is the only supported READPREIMAGE proof encoding version.
+const ReadPreimageProofVersion0 byte = 0x00
+
+var (
+	// ErrUnsupportedPayloadEncoding is returned when a READPREIMAGE proof is requested for a payload that is not
+	// encoded with PayloadEncodingVersion0.
+	ErrUnsupportedPayloadEncoding = errors.New("read preimage proofs are only supported for payload encoding version 0")
+	// ErrUnsupportedPolynomialForm is returned when a READPREIMAGE proof is requested for a blob that was dispersed
+	// in coefficient form.
+	ErrUnsupportedPolynomialForm = errors.New("read preimage proofs are only supported for blobs in evaluation form")
+)
+
+const (
+	// The number of bytes read by a single READPREIMAGE operation.
+	preimageChunkSize = 32
+	// The number of payload bytes stored in each field element of an encoded payload.
+	payloadBytesPerFieldElement = encoding.BYTES_PER_SYMBOL - 1
+	// The size of a single serialized point opening.
+	openingSize = 2*encoding.BYTES_PER_SYMBOL + bn254.SizeOfG1AffineUncompressed + bn254.SizeOfG2AffineUncompressed
+)
+
+// ReadPreimageProver generates and verifies KZG point opening proofs for READPREIMAGE one step proofs.
+type ReadPreimageProver struct {
+	// [1]₁, [τ]₁, [τ²]₁, ...
+	g1SRS []bn254.G1Affine
+	// [τ]₂
+	g2Tau bn254.G2Affine
+}
+
+// NewReadPreimageProver creates a prover from the trusted setup. g1SRS must contain at least as many points as the
+// largest blob that proofs are generated for, and g2SRS must contain at least [1]₂ and [τ]₂.
+func NewReadPreimageProver(g1SRS []bn254.G1Affine, g2SRS []bn254.G2Affine) (*ReadPreimageProver, error) {
+	if len(g1SRS) < 2 {
+		return nil, fmt.Errorf("expected at least 2 g1 SRS points, got %d", len(g1SRS))
+	}
+	if len(g2SRS) < 2 {
+		return nil, fmt.Errorf("expected at least 2 g2 SRS points, got %d", len(g2SRS))
+	}
+
+	return &ReadPreimageProver{
+		g1SRS: g1SRS,
+		g2Tau: g2SRS[1],
+	}, nil
+}
+
+// GenerateProof generates a READPREIMAGE proof for the 32 byte chunk of the payload starting at offset.
+// encodedPayload must be the encoded payload of the blob with the given commitment and length in symbols.
+func (p *ReadPreimageProver) GenerateProof(
+	blobCommitment *encoding.G1Commitment,
+	blobLengthSymbols uint32,
+	encodedPayload []byte,
+	offset uint64,
+) ([]byte, error) {
+	blobLength := uint64(blobLengthSymbols)
+	if !math.IsPowerOfTwo(blobLength) {
+		return nil, fmt.Errorf("blob length %d is not a power of 2", blobLength)
+	}
+	if blobLength > uint64(len(p.g1SRS)) {
+		return nil, fmt.Errorf("blob length %d exceeds the %d loaded g1 SRS points", blobLength, len(p.g1SRS))
+	}
+	if uint64(len(encodedPayload)) != blobLength*encoding.BYTES_PER_SYMBOL {
+		return nil, fmt.Errorf("encoded payload is %d bytes, expected %d bytes for a blob of %d symbols",
+			len(encodedPayload), blobLength*encoding.BYTES_PER_SYMBOL, blobLength)
+	}
+
+	payloadLength, err := readPreimageLength(encodedPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	polynomialForm, coefficients, err := p.blobCoefficients(blobCommitment, encodedPayload)
+	if err != nil {
+		return nil, err
+	}
+	if polynomialForm != codecs.PolynomialFormEval {
+		return nil, ErrUnsupportedPolynomialForm
+	}
+
+	rootsOfUnity := fft.NewFFTSettings(uint8(gomath.Log2(float64(blobLength)))).ExpandedRootsOfUnity
+	indices := preimageChunkFieldElements(offset, payloadLength)
+
+	proof := make([]byte, 0, 1+(1+len(indices))*openingSize)
+	proof = append(proof, ReadPreimageProofVersion0)
+	for _, index := range append([]uint64{0}, indices...) {
+		if index >= blobLength {
+			return nil, fmt.Errorf("field element %d is out of range for a blob of %d symbols", index, blobLength)
+		}
+		opening, err := p.open(coefficients, &rootsOfUnity[index])
+		if err != nil {
+			return nil, fmt.Errorf("open field element %d: %w", index, err)
+		}
+		proof = append(proof, opening...)
+	}
+
+	return proof, nil
+}
+
+// VerifyProof verifies a READPREIMAGE proof against the blob commitment in a DA Cert, and returns the preimage chunk
+// starting at offset that the proof attests to. This mirrors the checks that the one step prover performs.
+func (p *ReadPreimageProver) VerifyProof(
+	blobCommitment *encoding.G1Commitment,
+	blobLengthSymbols uint32,
+	offset uint64,
+	proof []byte,
+) ([]byte, error) {
+	blobLength := uint64(blobLengthSymbols)
+	if !math.IsPowerOfTwo(blobLength) {
+		return nil, fmt.Errorf("blob length %d is not a power of 2", blobLength)
+	}
+	if len(proof) < 1+openingSize || (len(proof)-1)%openingSize != 0 {
+		return nil, fmt.Errorf("malformed proof of %d bytes", len(proof))
+	}
+	if proof[0] != ReadPreimageProofVersion0 {
+		return nil, fmt.Errorf("unsupported proof version %d", proof[0])
+	}
+
+	rootsOfUnity := fft.NewFFTSettings(uint8(gomath.Log2(float64(blobLength)))).ExpandedRootsOfUnity
+	commitment := (*bn254.G1Affine)(blobCommitment)
+
+	header, err := p.verifyOpening(commitment, &rootsOfUnity[0], proof[1:1+openingSize])
+	if err != nil {
+		return nil, fmt.Errorf("verify header opening: %w", err)
+	}
+	payloadLength, err := readPreimageLength(header)
+	if err != nil {
+		return nil, err
+	}
+
+	indices := preimageChunkFieldElements(offset, payloadLength)
+	if uint64(len(proof)) != uint64(1+(1+len(indices))*openingSize) {
+		return nil, fmt.Errorf("expected %d field element openings, got %d", 1+len(indices), (len(proof)-1)/openingSize)
+	}
+
+	var fieldElements []byte
+	for i, index := range indices {
+		if index >= blobLength {
+			return nil, fmt.Errorf("field element %d is out of range for a blob of %d symbols", index, blobLength)
+		}
+		start := 1 + (1+i)*openingSize
+		fieldElement, err := p.verifyOpening(commitment, &rootsOfUnity[index], proof[start:start+openingSize])
+		if err != nil {
+			return nil, fmt.Errorf("verify opening of field element %d: %w", index, err)
+		}
+		fieldElements = append(fieldElements, fieldElement...)
+	}
+
+	if len(indices) == 0 {
+		return []byte{}, nil
+	}
+
+	// Strip the padding byte of each field element, then cut the chunk out of the proven payload bytes.
+	payloadBytes := codec.RemoveEmptyByteFromPaddedBytes(fieldElements)
+	start := offset % payloadBytesPerFieldElement
+	end := start + min(preimageChunkSize, payloadLength-offset)
+	return payloadBytes[start:end], nil
+}
+
+// blobCoefficients returns the polynomial form that the encoded payload was dispersed in, along with the coefficients
+// of the resulting blob polynomial. The polynomial form isn't recorded in the DA Cert, so it is recovered from the
+// cert's blob commitment: only the form that the payload was dispersed in produces a blob matching the commitment.
+// This also guarantees that openings are only generated for the blob that the verifier holds the commitment of.
+func (p *ReadPreimageProver) blobCoefficients(
+	blobCommitment *encoding.G1Commitment,
+	encodedPayload []byte,
+) (codecs.PolynomialForm, []fr.Element, error) {
+	for _, polynomialForm := range []codecs.PolynomialForm{codecs.PolynomialFormEval, codecs.PolynomialFormCoeff} {
+		blob, err := coretypes.DeserializeEncodedPayloadUnchecked(encodedPayload).ToBlob(polynomialForm)
+		if err != nil {
+			return 0, nil, fmt.Errorf("encoded payload to blob: %w", err)
+		}
+		coefficients := blob.GetCoefficients()
+
+		var commitment bn254.G1Affine
+		_, err = commitment.MultiExp(p.g1SRS[:len(coefficients)], coefficients, ecc.MultiExpConfig{})
+		if err != nil {
+			return 0, nil, fmt.Errorf("compute blob commitment: %w", err)
+		}
+		if commitment.Equal((*bn254.G1Affine)(blobCommitment)) {
+			return polynomialForm, coefficients, nil
+		}
+	}
+
+	return 0, nil, errors.New("encoded payload does not match the blob commitment in the DA Cert")
+}
+
+// open computes the serialized point opening of the polynomial at z.
+func (p *ReadPreimageProver) open(coefficients []fr.Element, z *fr.Element) ([]byte, error) {
+	// Divide p(X) - p(z) by (X - z) using synthetic division. The remainder of the division is p(z).
+	n := len(coefficients)
+	quotient := make([]fr.Element, n-1)
+	var evaluation fr.Element
+	evaluation.Set(&coefficients[n-1])
+	for i := n - 2; i >= 0; i-- {
+		quotient[i].Set(&evaluation)
+		evaluation.Mul(&evaluation, z).Add(&evaluation, &coefficients[i])
+	}
+
+	var openingProof bn254.G1Affine
+	if len(quotient) > 0 {
+		_, err := openingProof.MultiExp(p.g1SRS[:len(quotient)], quotient, ecc.MultiExpConfig{})
+		if err != nil {
+			return nil, fmt.Errorf("compute opening proof: %w", err)
+		}
+	}
+
+	tauMinusZ := p.g2TauMinus(z)
+
+	opening := make([]byte, 0, openingSize)
+	zBytes := z.Bytes()
+	opening = append(opening, zBytes[:]...)
+	evaluationBytes := evaluation.Bytes()
+	opening = append(opening, evaluationBytes[:]...)
+	opening = append(opening, serializeG1(&openingProof)...)
+	opening = append(opening, serializeG2(&tauMinusZ)...)
+	return opening, nil
+}
+
+// verifyOpening verifies a serialized point opening at the expected evaluation point, and returns the proven
+// evaluation.
+func (p *ReadPreimageProver) verifyOpening(
+	commitment *bn254.G1Affine,
+	expectedZ *fr.Element,
+	opening []byte,
+) ([]byte, error) {
+	var z, evaluation fr.Element
+	if err := z.SetBytesCanonical(opening[0:32]); err != nil {
+		return nil, fmt.Errorf("invalid evaluation point: %w", err)
+	}
+	if !z.Equal(expectedZ) {
+		return nil, errors.New("evaluation point is not the root of unity at the expected index")
+	}
+	if err := evaluation.SetBytesCanonical(opening[32:64]); err != nil {
+		return nil, fmt.Errorf("invalid evaluation: %w", err)
+	}
+	openingProof, err := deserializeG1(opening[64:128])
+	if err != nil {
+		return nil, fmt.Errorf("invalid opening proof: %w", err)
+	}
+	tauMinusZ, err := deserializeG2(opening[128:256])
+	if err != nil {
+		return nil, fmt.Errorf("invalid [τ - z]₂: %w", err)
+	}
+
+	// Check that [τ - z]₂ was computed honestly: e([1]₁, [τ - z]₂) = e([τ]₁ - [z]₁, [1]₂).
+	var zG1, tauMinusZG1 bn254.G1Affine
+	zG1.ScalarMultiplicationBase(z.BigInt(new(big.Int)))
+	tauMinusZG1.Sub(&p.g1SRS[1], &zG1)
+	ok, err := pairingEqual(&kzg.GenG1, tauMinusZ, &tauMinusZG1, &kzg.GenG2)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("[τ - z]₂ does not match the evaluation point")
+	}
+
+	// Check the opening itself: e(C - [p(z)]₁, [1]₂) = e(π, [τ - z]₂).
+	var evaluationG1, commitmentMinusEvaluation bn254.G1Affine
+	evaluationG1.ScalarMultiplicationBase(evaluation.BigInt(new(big.Int)))
+	commitmentMinusEvaluation.Sub(commitment, &evaluationG1)
+	ok, err = pairingEqual(&commitmentMinusEvaluation, &kzg.GenG2, openingProof, tauMinusZ)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("point opening proof is invalid")
+	}
+
+	evaluationBytes := evaluation.Bytes()
+	return evaluationBytes[:], nil
+}
+
+// g2TauMinus computes [τ - z]₂.
+func (p *ReadPreimageProver) g2TauMinus(z *fr.Element) bn254.G2Affine {
+	var zG2, tauMinusZ bn254.G2Affine
+	zG2.ScalarMultiplication(&kzg.GenG2, z.BigInt(new(big.Int)))
+	tauMinusZ.Sub(&p.g2Tau, &zG2)
+	return tauMinusZ
+}
+
+// pairingEqual checks whether e(a1, a2) = e(b1, b2).
+func pairingEqual(a1 *bn254.G1Affine, a2 *bn254.G2Affine, b1 *bn254.G1Affine, b2 *bn254.G2Affine) (bool, error) {
+	var negA1 bn254.G1Affine
+	negA1.Neg(a1)
+	ok, err := bn254.PairingCheck([]bn254.G1Affine{negA1, *b1}, []bn254.G2Affine{*a2, *b2})
+	if err != nil {
+		return false, fmt.Errorf("pairing check: %w", err)
+	}
+	return ok, nil
+}
+
+// readPreimageLength returns the payload length encoded in the header of an encoded payload. Returns
+// ErrUnsupportedPayloadEncoding for encodings other than PayloadEncodingVersion0.
+func readPreimageLength(encodedPayload []byte) (uint64, error) {
+	if len(encodedPayload) < codec.EncodedPayloadHeaderLenBytes {
+		return 0, fmt.Errorf("encoded payload of %d bytes is too short to contain a header", len(encodedPayload))
+	}
+	if encodedPayload[0] != 0x00 {
+		return 0, fmt.Errorf("encoded payload header first byte must be 0x00, but got %x", encodedPayload[0])
+	}
+	if encodedPayload[1] != byte(codecs.PayloadEncodingVersion0) {
+		return 0, fmt.Errorf("%w: got version %d", ErrUnsupportedPayloadEncoding, encodedPayload[1])
+	}
+	return uint64(binary.BigEndian.Uint32(encodedPayload[2:6])), nil
+}
+
+// preimageChunkFieldElements returns the indices of the encoded payload field elements that contain the 32 byte
+// preimage chunk starting at offset. Chunks are truncated at the end of the payload, and chunks starting at or past
+// the end of the payload are empty.
+func preimageChunkFieldElements(offset uint64, payloadLength uint64) []uint64 {
+	if offset >= payloadLength {
+		return nil
+	}
+	last := min(offset+preimageChunkSize, payloadLength) - 1
+
+	first := codec.EncodedPayloadHeaderLenSymbols + offset/payloadBytesPerFieldElement
+	indices := []uint64{first}
+	if lastIndex := codec.EncodedPayloadHeaderLenSymbols + last/payloadBytesPerFieldElement; lastIndex != first {
+		indices = append(indices, lastIndex)
+	}
+	return indices
+}
+
+// serializeG1 serializes a g1 point as (x, y), with the point at infinity encoded as (0, 0).
+func serializeG1(point *bn254.G1Affine) []byte {
+	x := point.X.Bytes()
+	y := point.Y.Bytes()
+	return append(x[:], y[:]...)
+}
+
+// deserializeG1 is the inverse of serializeG1.
+func deserializeG1(data []byte) (*bn254.G1Affine, error) {
+	var point bn254.G1Affine
+	if err := point.X.SetBytesCanonical(data[0:32]); err != nil {
+		return nil, fmt.Errorf("x: %w", err)
+	}
+	if err := point.Y.SetBytesCanonical(data[32:64]); err != nil {
+		return nil, fmt.Errorf("y: %w", err)
+	}
+	if !point.IsOnCurve() {
+		return nil, errors.New("point is not on the curve")
+	}
+	return &point, nil
+}
+
+// serializeG2 serializes a g2 point as (x.A1, x.A0, y.A1, y.A0), with the point at infinity encoded as all zeros.
+func serializeG2(point *bn254.G2Affine) []byte {
+	serialized := make([]byte, 0, bn254.SizeOfG2AffineUncompressed)
+	for _, element := range []*fp.Element{&point.X.A1, &point.X.A0, &point.Y.A1, &point.Y.A0} {
+		elementBytes := element.Bytes()
+		serialized = append(serialized, elementBytes[:]...)
+	}
+	return serialized
+}
+
+// deserializeG2 is the inverse of serializeG2.
+func deserializeG2(data []byte) (*bn254.G2Affine, error) {
+	var point bn254.G2Affine
+	elements := []*fp.Element{&point.X.A1, &point.X.A0, &point.Y.A1, &point.Y.A0}
+	for i, element := range elements {
+		if err := element.SetBytesCanonical(data[i*fp.Bytes : (i+1)*fp.Bytes]); err != nil {
+			return nil, fmt.Errorf("coordinate %d: %w", i, err)
+		}
+	}
+	if !point.IsOnCurve() || !point.IsInSubGroup() {
+		return nil, errors.New("point is not in the g2 subgroup")
+	}
+	return &point, nil
+}
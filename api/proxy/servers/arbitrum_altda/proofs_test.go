@@ -0,0 +1,283 @@
+package arbitrum_altda
+
+import (
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	proxy_common "github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
+	memstore "github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/v2"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/v2/kzg"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/stretchr/testify/require"
+)
+
+// The number of g1 SRS points generated for tests, which bounds the size of test blobs.
+const testSRSSize = 1024
+
+// newTestSRS generates an insecure SRS from a random τ, which is sufficient for testing proof generation
+// and verification without loading the embedded SRS.
+func newTestSRS(t *testing.T) ([]bn254.G1Affine, []bn254.G2Affine) {
+	var tau fr.Element
+	_, err := tau.SetRandom()
+	require.NoError(t, err)
+
+	g1SRS := make([]bn254.G1Affine, testSRSSize)
+	var power fr.Element
+	power.SetOne()
+	for i := range g1SRS {
+		g1SRS[i].ScalarMultiplication(&kzg.GenG1, power.BigInt(new(big.Int)))
+		power.Mul(&power, &tau)
+	}
+
+	g2SRS := make([]bn254.G2Affine, 2)
+	g2SRS[0] = kzg.GenG2
+	g2SRS[1].ScalarMultiplication(&kzg.GenG2, tau.BigInt(new(big.Int)))
+
+	return g1SRS, g2SRS
+}
+
+// newMemstoreHandlers creates handlers backed by a memstore EigenDA manager and a simulated L1 chain.
+func newMemstoreHandlers(
+	t *testing.T,
+) (*Handlers, *ReadPreimageProver, *memconfig.SafeConfig, *simulated.Backend) {
+	g1SRS, g2SRS := newTestSRS(t)
+
+	memConfig := memconfig.NewSafeConfig(memconfig.Config{
+		MaxBlobSizeBytes: testSRSSize * 32,
+	})
+	memStore, err := memstore.New(t.Context(), testLogger, memConfig, g1SRS)
+	require.NoError(t, err)
+	secondaryManager := secondary.NewSecondaryManager(testLogger, metrics.NoopMetrics, nil, nil, false, false)
+	manager, err := store.NewEigenDAManager(
+		t.Context(), memStore, testLogger, secondaryManager, proxy_common.V2EigenDABackend, store.BatchConfig{})
+	require.NoError(t, err)
+
+	prover, err := NewReadPreimageProver(g1SRS, g2SRS)
+	require.NoError(t, err)
+
+	compatCfg := proxy_common.CompatibilityConfig{Version: "1.0.0", MaxPayloadSizeBytes: testSRSSize * 16}
+	backend := simulated.NewBackend(types.GenesisAlloc{})
+	t.Cleanup(func() {
+		require.NoError(t, backend.Close())
+	})
+
+	handlers := NewHandlers(manager, testLogger, true, backend.Client(), compatCfg, prover).(*Handlers)
+	return handlers, prover, memConfig, backend
+}
+
+// blobFromPayload encodes the payload with the given encoding version, and returns the commitment, length and
+// serialized encoded payload of the blob that results from dispersing it in the given polynomial form.
+func blobFromPayload(
+	t *testing.T,
+	prover *ReadPreimageProver,
+	payload []byte,
+	version codecs.PayloadEncodingVersion,
+	polynomialForm codecs.PolynomialForm,
+) (*encoding.G1Commitment, uint32, []byte) {
+	encodedPayload, err := coretypes.Payload(payload).ToEncodedPayloadWithVersion(version)
+	require.NoError(t, err)
+	blob, err := encodedPayload.ToBlob(polynomialForm)
+	require.NoError(t, err)
+
+	coefficients := blob.GetCoefficients()
+	var commitment bn254.G1Affine
+	_, err = commitment.MultiExp(prover.g1SRS[:len(coefficients)], coefficients, ecc.MultiExpConfig{})
+	require.NoError(t, err)
+
+	blobEncodedPayload := blob.ToEncodedPayloadUnchecked(polynomialForm).Serialize()
+	return (*encoding.G1Commitment)(&commitment), blob.LenSymbols(), blobEncodedPayload
+}
+
+// storePayload stores a payload through the handlers and returns the resulting certificate.
+func storePayload(t *testing.T, handlers *Handlers, payload []byte) hexutil.Bytes {
+	result, err := handlers.Store(t.Context(), payload, 60)
+	require.NoError(t, err)
+	return result.SerializedDACert
+}
+
+func TestGenerateReadPreimageProof(t *testing.T) {
+	rand := random.NewTestRandom()
+	handlers, prover, _, _ := newMemstoreHandlers(t)
+
+	payload := rand.Bytes(1000)
+	certificate := storePayload(t, handlers, payload)
+	certHash := crypto.Keccak256Hash(certificate)
+
+	// The preimage proven by READPREIMAGE is the one recorded by CollectPreimages.
+	messageHeader := make([]byte, MessageHeaderOffset)
+	preimages, err := handlers.CollectPreimages(t.Context(), 1, certHash, append(messageHeader, certificate...))
+	require.NoError(t, err)
+	preimage := preimages.Preimages[CustomDAPreimageType][certHash]
+	require.Equal(t, payload, preimage)
+
+	daCert, err := handlers.deserializeCertFromDACommitment(certificate)
+	require.NoError(t, err)
+	blobCommitment, blobLength, err := blobCommitmentFromCert(daCert)
+	require.NoError(t, err)
+
+	length := uint64(len(payload))
+	offsets := []uint64{0, 1, 30, 31, 32, 61, 62, 64, 500, length - 33, length - 32, length - 1, length, length + 100}
+	for _, offset := range offsets {
+		result, err := handlers.GenerateReadPreimageProof(t.Context(), certHash, hexutil.Uint64(offset), certificate)
+		require.NoError(t, err, "offset %d", offset)
+
+		chunk, err := prover.VerifyProof(blobCommitment, blobLength, offset, result.Proof)
+		require.NoError(t, err, "offset %d", offset)
+
+		expected := []byte{}
+		if offset < length {
+			expected = preimage[offset:min(offset+32, length)]
+		}
+		require.Equal(t, expected, chunk, "offset %d", offset)
+	}
+}
+
+func TestVerifyReadPreimageProofRejectsTampering(t *testing.T) {
+	rand := random.NewTestRandom()
+	handlers, prover, _, _ := newMemstoreHandlers(t)
+
+	certificate := storePayload(t, handlers, rand.Bytes(200))
+	certHash := crypto.Keccak256Hash(certificate)
+	daCert, err := handlers.deserializeCertFromDACommitment(certificate)
+	require.NoError(t, err)
+	blobCommitment, blobLength, err := blobCommitmentFromCert(daCert)
+	require.NoError(t, err)
+
+	offset := uint64(40)
+	result, err := handlers.GenerateReadPreimageProof(t.Context(), certHash, hexutil.Uint64(offset), certificate)
+	require.NoError(t, err)
+	_, err = prover.VerifyProof(blobCommitment, blobLength, offset, result.Proof)
+	require.NoError(t, err)
+
+	// A proof for one offset doesn't prove a different field element.
+	_, err = prover.VerifyProof(blobCommitment, blobLength, offset+100, result.Proof)
+	require.Error(t, err)
+
+	// Tampering with the proven field element of any opening invalidates the proof.
+	for opening := 0; opening < (len(result.Proof)-1)/openingSize; opening++ {
+		tampered := append([]byte{}, result.Proof...)
+		tampered[1+opening*openingSize+63] ^= 0x01
+		_, err = prover.VerifyProof(blobCommitment, blobLength, offset, tampered)
+		require.Error(t, err, "opening %d", opening)
+	}
+
+	// The proof doesn't verify against a different blob commitment.
+	otherCertificate := storePayload(t, handlers, rand.Bytes(200))
+	otherDACert, err := handlers.deserializeCertFromDACommitment(otherCertificate)
+	require.NoError(t, err)
+	otherBlobCommitment, otherBlobLength, err := blobCommitmentFromCert(otherDACert)
+	require.NoError(t, err)
+	_, err = prover.VerifyProof(otherBlobCommitment, otherBlobLength, offset, result.Proof)
+	require.Error(t, err)
+}
+
+func TestGenerateReadPreimageProofErrors(t *testing.T) {
+	rand := random.NewTestRandom()
+	handlers, _, _, _ := newMemstoreHandlers(t)
+	certificate := storePayload(t, handlers, rand.Bytes(100))
+
+	// The cert hash must match the certificate.
+	_, err := handlers.GenerateReadPreimageProof(t.Context(), crypto.Keccak256Hash([]byte("other")), 0, certificate)
+	require.ErrorContains(t, err, "does not match")
+
+	// Proof generation requires a prover.
+	handlers.preimageProver = nil
+	_, err = handlers.GenerateReadPreimageProof(t.Context(), crypto.Keccak256Hash(certificate), 0, certificate)
+	require.ErrorContains(t, err, "not enabled")
+}
+
+func TestGenerateCertificateValidityProofMemstore(t *testing.T) {
+	rand := random.NewTestRandom()
+	handlers, _, memConfig, _ := newMemstoreHandlers(t)
+
+	certificate := storePayload(t, handlers, rand.Bytes(100))
+	daCert, err := handlers.deserializeCertFromDACommitment(certificate)
+	require.NoError(t, err)
+	cert, err := deserializeEigenDACert(daCert)
+	require.NoError(t, err)
+	rbn := binary.BigEndian.AppendUint64(nil, cert.ReferenceBlockNumber())
+
+	result, err := handlers.GenerateCertificateValidityProof(t.Context(), certificate)
+	require.NoError(t, err)
+	require.Equal(t, append(hexutil.Bytes{0x01, certificate[DACommitPrefixBytes], 0x00}, rbn...), result.Proof)
+
+	// Certs which fail derivation are claimed to be invalid.
+	require.NoError(t, memConfig.SetOverwritePutWithDerivationError(coretypes.ErrInvalidCertDerivationError))
+	invalidCertificate := storePayload(t, handlers, rand.Bytes(100))
+	result, err = handlers.GenerateCertificateValidityProof(t.Context(), invalidCertificate)
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Bytes{0x00, invalidCertificate[DACommitPrefixBytes]}, result.Proof[:2])
+	require.Equal(t, coretypes.ErrInvalidCertDerivationError.StatusCode, result.Proof[2])
+}
+
+func TestGenerateReadPreimageProofRejectsPayloadEncodingV1(t *testing.T) {
+	rand := random.NewTestRandom()
+	_, prover, _, _ := newMemstoreHandlers(t)
+
+	blobCommitment, blobLength, encodedPayload := blobFromPayload(
+		t, prover, rand.Bytes(1000), codecs.PayloadEncodingVersion1, codecs.PolynomialFormEval)
+	_, err := prover.GenerateProof(blobCommitment, blobLength, encodedPayload, 0)
+	require.ErrorIs(t, err, ErrUnsupportedPayloadEncoding)
+}
+
+func TestGenerateReadPreimageProofPolynomialForm(t *testing.T) {
+	rand := random.NewTestRandom()
+	_, prover, _, _ := newMemstoreHandlers(t)
+	payload := rand.Bytes(1000)
+
+	// The polynomial form is recovered from the blob commitment, and proofs are generated for eval form blobs.
+	blobCommitment, blobLength, encodedPayload := blobFromPayload(
+		t, prover, payload, codecs.PayloadEncodingVersion0, codecs.PolynomialFormEval)
+	proof, err := prover.GenerateProof(blobCommitment, blobLength, encodedPayload, 0)
+	require.NoError(t, err)
+	chunk, err := prover.VerifyProof(blobCommitment, blobLength, 0, proof)
+	require.NoError(t, err)
+	require.Equal(t, payload[:32], chunk)
+
+	// Coeff form blobs are rejected, since openings prove evaluations rather than coefficients.
+	blobCommitment, blobLength, encodedPayload = blobFromPayload(
+		t, prover, payload, codecs.PayloadEncodingVersion0, codecs.PolynomialFormCoeff)
+	_, err = prover.GenerateProof(blobCommitment, blobLength, encodedPayload, 0)
+	require.ErrorIs(t, err, ErrUnsupportedPolynomialForm)
+
+	// Encoded payloads which don't match the blob commitment in either form are rejected.
+	otherCommitment, _, _ := blobFromPayload(
+		t, prover, rand.Bytes(1000), codecs.PayloadEncodingVersion0, codecs.PolynomialFormEval)
+	_, err = prover.GenerateProof(otherCommitment, blobLength, encodedPayload, 0)
+	require.ErrorContains(t, err, "does not match the blob commitment")
+}
+
+func TestRecoverPayloadSimulatedChain(t *testing.T) {
+	rand := random.NewTestRandom()
+	handlers, _, _, backend := newMemstoreHandlers(t)
+
+	payload := rand.Bytes(500)
+	certificate := storePayload(t, handlers, payload)
+	messageHeader := make([]byte, MessageHeaderOffset)
+	sequencerMsg := append(messageHeader, certificate...)
+
+	// The L1 inclusion block is looked up on the simulated chain.
+	blockHash := backend.Commit()
+	result, err := handlers.RecoverPayload(t.Context(), 1, blockHash, sequencerMsg)
+	require.NoError(t, err)
+	require.Equal(t, payload, result.Payload)
+
+	// Blocks which aren't known to the chain can't be used as the L1 inclusion block.
+	_, err = handlers.RecoverPayload(t.Context(), 1, crypto.Keccak256Hash([]byte("unknown")), sequencerMsg)
+	require.ErrorContains(t, err, "could not read l1 inclusion block number")
+}
@@ -0,0 +1,106 @@
+// handlers_async.go contains the handlers for asynchronous PUTs.
+// Async POST routes accept a payload and immediately return a job, which is dispersed in the background.
+// Clients then poll the status route until the job is certified (or failed) to obtain the commitment.
+//
+// The async POST handlers SHOULD be wrapped in middlewares, like the synchronous POST handlers in handlers_cert.go.
+// The status handler is not, and does its own logging and error handling.
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/gorilla/mux"
+)
+
+const (
+	headerLocation = "Location"
+
+	asyncPutStatusPathPrefix = "/put/status/"
+)
+
+// handlePostAsyncStdCommitment handles async POST requests for std commitments.
+func (svr *Server) handlePostAsyncStdCommitment(w http.ResponseWriter, r *http.Request) error {
+	if !svr.config.APIsEnabled.StandardCommitment {
+		w.WriteHeader(http.StatusForbidden)
+		return fmt.Errorf("standard DA Commitment type detected but `standard` API is not enabled")
+	}
+
+	return svr.handlePostAsyncShared(w, r, commitments.StandardCommitmentMode)
+}
+
+// handlePostAsyncOPGenericCommitment handles async POST requests for optimism generic commitments.
+func (svr *Server) handlePostAsyncOPGenericCommitment(w http.ResponseWriter, r *http.Request) error {
+	if !svr.config.APIsEnabled.OpGenericCommitment {
+		w.WriteHeader(http.StatusForbidden)
+		return fmt.Errorf("op-generic DA Commitment type detected but `op-generic` API is not enabled")
+	}
+
+	return svr.handlePostAsyncShared(w, r, commitments.OptimismGenericCommitmentMode)
+}
+
+// handlePostAsyncBatchedCommitment handles async POST requests for batched commitments.
+func (svr *Server) handlePostAsyncBatchedCommitment(w http.ResponseWriter, r *http.Request) error {
+	if !svr.config.APIsEnabled.BatchedCommitment {
+		w.WriteHeader(http.StatusForbidden)
+		return fmt.Errorf("batched DA Commitment type detected but `batched` API is not enabled")
+	}
+
+	return svr.handlePostAsyncShared(w, r, commitments.BatchedCommitmentMode)
+}
+
+// handlePostAsyncShared submits the request body as an async put job, and responds with 202 Accepted and the job.
+// The Location header points to the job's status route.
+func (svr *Server) handlePostAsyncShared(
+	w http.ResponseWriter,
+	r *http.Request,
+	mode commitments.CommitmentMode,
+) error {
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, common.MaxServerPOSTRequestBodySize))
+	if err != nil {
+		return proxyerrors.NewReadRequestBodyError(err, common.MaxServerPOSTRequestBodySize)
+	}
+
+	job, err := svr.asyncPutMgr.Submit(payload, mode)
+	if err != nil {
+		return fmt.Errorf("async post request failed: %w", err)
+	}
+
+	response, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal async put job %s: %w", job.ID, err)
+	}
+
+	svr.log.Info("Processed request", "method", r.Method, "url", r.URL.Path, "commitmentMode", mode,
+		"jobID", job.ID)
+
+	w.Header().Set(headerContentType, contentTypeJSON)
+	w.Header().Set(headerLocation, asyncPutStatusPathPrefix+job.ID)
+	w.WriteHeader(http.StatusAccepted)
+	_, err = w.Write(response)
+	if err != nil {
+		// If the write fails, we will already have sent a 202 header. But we still return an error
+		// here so that the logging middleware can log it.
+		return fmt.Errorf("failed to write response for async POST job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+// handleGetAsyncPutStatus handles GET requests for the status of an async put job.
+// Once the job is certified, the response contains the commitment, hex encoded.
+func (svr *Server) handleGetAsyncPutStatus(w http.ResponseWriter, r *http.Request) {
+	jobID := mux.Vars(r)[routingVarNameJobID]
+	job, err := svr.asyncPutMgr.GetJob(jobID)
+	if err != nil {
+		svr.log.Info("async put job not found", "method", r.Method, "path", r.URL.Path, "error", err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	svr.writeJSON(w, r, job)
+}
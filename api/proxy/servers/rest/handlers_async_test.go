@@ -0,0 +1,127 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	enabled_apis "github.com/Layr-Labs/eigenda/api/proxy/config/enablement"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/asyncput"
+	"github.com/Layr-Labs/eigenda/api/proxy/test/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newAsyncTestRouter returns a router for a server with async puts enabled.
+func newAsyncTestRouter(t *testing.T, cfg Config, mockEigenDAManager *mocks.MockIEigenDAManager) *mux.Router {
+	asyncPutMgr, err := asyncput.NewManager(t.Context(), testLogger, mockEigenDAManager, asyncput.Config{
+		Dir:           t.TempDir(),
+		Workers:       1,
+		Retention:     time.Hour,
+		MaxQueuedJobs: 16,
+	})
+	require.NoError(t, err)
+	t.Cleanup(asyncPutMgr.Stop)
+
+	r := mux.NewRouter()
+	server := NewServer(cfg, mockEigenDAManager, mocks.NewMockIKeccakManager(gomock.NewController(t)), asyncPutMgr,
+		testLogger, metrics.NoopMetrics)
+	server.RegisterRoutes(r)
+	return r
+}
+
+func TestHandlerAsyncPut(t *testing.T) {
+	tests := []struct {
+		name               string
+		url                string
+		expectedCommitment string
+	}{
+		{
+			name:               "OP Mode Alt-DA",
+			url:                "/put/async",
+			expectedCommitment: opGenericPrefixStr + testCommitStr,
+		},
+		{
+			name:               "Standard Commitment Mode",
+			url:                "/put/async?commitment_mode=standard",
+			expectedCommitment: stdCommitmentPrefix + testCommitStr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+			mockEigenDAManager.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(certs.NewVersionedCert([]byte(testCommitStr), certs.V0VersionByte), nil)
+			r := newAsyncTestRouter(t, testCfg, mockEigenDAManager)
+
+			req := httptest.NewRequest(http.MethodPost, tt.url, bytes.NewReader([]byte("some data")))
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusAccepted, rec.Code)
+			var submitted asyncput.Job
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &submitted))
+			require.Equal(t, asyncput.StatusQueued, submitted.Status)
+			statusURL := rec.Header().Get(headerLocation)
+			require.Equal(t, "/put/status/"+submitted.ID, statusURL)
+
+			var job asyncput.Job
+			require.Eventually(t, func() bool {
+				rec := httptest.NewRecorder()
+				r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, statusURL, nil))
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+				return job.Status.IsTerminal()
+			}, 5*time.Second, 10*time.Millisecond)
+			require.Equal(t, asyncput.StatusCertified, job.Status, job.Error)
+			require.Equal(t, tt.expectedCommitment, string(job.Commitment))
+		})
+	}
+}
+
+func TestHandlerAsyncPutErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+
+	t.Run("Unknown job", func(t *testing.T) {
+		r := newAsyncTestRouter(t, testCfg, mockEigenDAManager)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/put/status/0123456789abcdef0123456789abcdef", nil))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("Commitment mode not enabled", func(t *testing.T) {
+		cfg := testCfg
+		cfg.APIsEnabled = &enabled_apis.RestApisEnabled{OpGenericCommitment: true}
+		r := newAsyncTestRouter(t, cfg, mockEigenDAManager)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/put/async?commitment_mode=standard",
+			bytes.NewReader([]byte("some data"))))
+		require.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("Async puts disabled", func(t *testing.T) {
+		r := mux.NewRouter()
+		server := NewServer(testCfg, mockEigenDAManager, mocks.NewMockIKeccakManager(ctrl), nil, testLogger,
+			metrics.NoopMetrics)
+		server.RegisterRoutes(r)
+		for _, req := range []*http.Request{
+			httptest.NewRequest(http.MethodPost, "/put/async", bytes.NewReader([]byte("some data"))),
+			httptest.NewRequest(http.MethodGet, fmt.Sprintf("/put/status/%032x", 1), nil),
+		} {
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+			require.NotEqual(t, http.StatusAccepted, rec.Code)
+			require.NotEqual(t, http.StatusOK, rec.Code)
+		}
+	})
+}
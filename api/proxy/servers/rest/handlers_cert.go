@@ -62,7 +62,7 @@ func (svr *Server) handleGetOPGenericCommitment(w http.ResponseWriter, r *http.R
 		return fmt.Errorf("op-generic DA Commitment type detected but `op-generic` API is not enabled")
 	}
 
-	return svr.handleGetShared(w, r)
+	return svr.handleGetShared(w, r, nil)
 }
 
 // handleGetStdCommitment handles the GET request for std commitments.
@@ -72,12 +72,38 @@ func (svr *Server) handleGetStdCommitment(w http.ResponseWriter, r *http.Request
 		return fmt.Errorf("standard DA Commitment type detected but `standard` API is not enabled")
 	}
 
-	return svr.handleGetShared(w, r)
+	return svr.handleGetShared(w, r, nil)
 }
 
+// handleGetBatchedCommitment handles the GET request for batched commitments.
+func (svr *Server) handleGetBatchedCommitment(w http.ResponseWriter, r *http.Request) error {
+	if !svr.config.APIsEnabled.BatchedCommitment {
+		w.WriteHeader(http.StatusForbidden)
+		return fmt.Errorf("batched DA Commitment type detected but `batched` API is not enabled")
+	}
+
+	batchEntryHex, ok := mux.Vars(r)[routingVarNameBatchEntryHex]
+	if !ok {
+		return proxyerrors.NewParsingError(fmt.Errorf("batch entry not found in path: %s", r.URL.Path))
+	}
+	encodedBatchEntry, err := hex.DecodeString(batchEntryHex)
+	if err != nil {
+		return proxyerrors.NewParsingError(fmt.Errorf("failed to decode hex batch entry %s: %w", batchEntryHex, err))
+	}
+	batchEntry, err := commitments.DecodeBatchEntry(encodedBatchEntry)
+	if err != nil {
+		return proxyerrors.NewParsingError(fmt.Errorf("decode batch entry: %w", err))
+	}
+
+	return svr.handleGetShared(w, r, &batchEntry)
+}
+
+// handleGetShared is a shared function for handling GET requests for commitments containing a serialized cert.
+// batchEntry is only set for batched commitments, and locates the requested payload within the batch.
 func (svr *Server) handleGetShared(
 	w http.ResponseWriter,
 	r *http.Request,
+	batchEntry *common.BatchEntry,
 ) error {
 	certVersion, err := parseCertVersion(w, r)
 	if err != nil {
@@ -112,6 +138,7 @@ func (svr *Server) handleGetShared(
 		common.GETOpts{
 			L1InclusionBlockNum:  l1InclusionBlockNum,
 			ReturnEncodedPayload: returnEncodedPayload,
+			BatchEntry:           batchEntry,
 		},
 	)
 	if err != nil {
@@ -184,6 +211,42 @@ func (svr *Server) handlePostOPGenericCommitment(w http.ResponseWriter, r *http.
 	return svr.handlePostShared(w, r, commitments.OptimismGenericCommitmentMode)
 }
 
+// handlePostBatchedCommitment handles the POST request for batched commitments.
+// The payload is dispersed together with other payloads POSTed within the batch window,
+// and the returned commitment references the payload's location within the batch.
+func (svr *Server) handlePostBatchedCommitment(w http.ResponseWriter, r *http.Request) error {
+	if !svr.config.APIsEnabled.BatchedCommitment {
+		w.WriteHeader(http.StatusForbidden)
+		return fmt.Errorf("batched DA Commitment type detected but `batched` API is not enabled")
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, common.MaxServerPOSTRequestBodySize))
+	if err != nil {
+		return proxyerrors.NewReadRequestBodyError(err, common.MaxServerPOSTRequestBodySize)
+	}
+
+	versionedCert, batchEntry, err := svr.certMgr.PutBatched(r.Context(), payload, coretypes.CertSerializationRLP)
+	if err != nil {
+		return fmt.Errorf("batched post request failed: %w", err)
+	}
+
+	responseCommit := commitments.NewBatchedCommitment(*versionedCert, batchEntry).Encode()
+
+	svr.log.Info("Processed request", "method", r.Method, "url", r.URL.Path,
+		"commitmentMode", commitments.BatchedCommitmentMode, "certVersion", versionedCert.Version,
+		"batchOffset", batchEntry.Offset, "batchLength", batchEntry.Length,
+		"cert", hex.EncodeToString(versionedCert.SerializedCert))
+
+	_, err = w.Write(responseCommit)
+	if err != nil {
+		// If the write fails, we will already have sent a 200 header. But we still return an error
+		// here so that the logging middleware can log it.
+		return fmt.Errorf("failed to write response for batched POST serializedCert (version %v) %x: %w",
+			versionedCert.Version, versionedCert.SerializedCert, err)
+	}
+	return nil
+}
+
 // This is a shared function for handling POST requests for
 func (svr *Server) handlePostShared(
 	w http.ResponseWriter,
@@ -39,6 +39,7 @@ var (
 			OpGenericCommitment: true,
 			OpKeccakCommitment:  true,
 			StandardCommitment:  true,
+			BatchedCommitment:   true,
 		},
 	}
 )
@@ -49,6 +50,9 @@ const (
 	// [alt-da, da layer, cert version]
 	opGenericPrefixStr = "\x01\x00\x00"
 
+	// [cert version, uint32 offset=42, uint32 length=5]
+	batchedPrefixStr = "\x00" + "\x00\x00\x00\x2a" + "\x00\x00\x00\x05"
+
 	testCommitStr = "9a7d4f1c3e5b8a09d1c0fa4b3f8e1d7c6b29f1e6d8c4a7b3c2d4e5f6a7b8c9d0"
 )
 
@@ -120,6 +124,26 @@ func TestHandlerGet(t *testing.T) {
 			expectedCode: http.StatusOK,
 			expectedBody: testCommitStr,
 		},
+		{
+			// make sure that the batch entry is parsed from the commitment and passed to the storage's GET call.
+			name: "Success - Batched",
+			url:  fmt.Sprintf("/get/0x010000002a00000005%s?commitment_mode=batched", testCommitStr),
+			mockBehavior: func() {
+				mockEigenDAManager.EXPECT().
+					Get(gomock.Any(), gomock.Any(), gomock.Any(),
+						gomock.Eq(common.GETOpts{BatchEntry: &common.BatchEntry{Offset: 42, Length: 5}})).
+					Return([]byte("hello"), nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "hello",
+		},
+		{
+			name:         "Failure - Batched commitment without batch entry",
+			url:          "/get/0x0100?commitment_mode=batched",
+			mockBehavior: func() {},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -134,7 +158,7 @@ func TestHandlerGet(t *testing.T) {
 			// we need to create a router through which we can pass the request.
 			r := mux.NewRouter()
 			// enable this logger to help debug tests
-			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, testLogger, metrics.NoopMetrics)
 			server.RegisterRoutes(r)
 			r.ServeHTTP(rec, req)
 
@@ -202,6 +226,22 @@ func TestHandlerPutSuccess(t *testing.T) {
 			expectedCode: http.StatusOK,
 			expectedBody: stdCommitmentPrefix + testCommitStr,
 		},
+		{
+			name: "Success Batched Commitment Mode",
+			url:  "/put?commitment_mode=batched",
+			body: []byte("some data that will successfully be written to EigenDA"),
+			mockBehavior: func() {
+				mockEigenDAManager.EXPECT().PutBatched(
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any()).Return(
+					certs.NewVersionedCert([]byte(testCommitStr), certs.V0VersionByte),
+					common.BatchEntry{Offset: 42, Length: 5},
+					nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: batchedPrefixStr + testCommitStr,
+		},
 	}
 
 	for _, tt := range tests {
@@ -216,7 +256,7 @@ func TestHandlerPutSuccess(t *testing.T) {
 			// we need to create a router through which we can pass the request.
 			r := mux.NewRouter()
 			// enable this logger to help debug tests
-			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, testLogger, metrics.NoopMetrics)
 			server.RegisterRoutes(r)
 			r.ServeHTTP(rec, req)
 
@@ -306,7 +346,7 @@ func TestHandlerPutErrors(t *testing.T) {
 				// we need to create a router through which we can pass the request.
 				r := mux.NewRouter()
 				// enable this logger to help debug tests
-				server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+				server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, testLogger, metrics.NoopMetrics)
 				server.RegisterRoutes(r)
 				r.ServeHTTP(rec, req)
 
@@ -367,7 +407,7 @@ func TestHandlerPutKeccakErrors(t *testing.T) {
 				// we need to create a router through which we can pass the request.
 				r := mux.NewRouter()
 				// enable this logger to help debug tests
-				server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+				server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, testLogger, metrics.NoopMetrics)
 				server.RegisterRoutes(r)
 				r.ServeHTTP(rec, req)
 
@@ -426,6 +466,24 @@ func TestHandlersReturn403WhenAPIDisabled(t *testing.T) {
 				OpGenericCommitment: true,
 			},
 		},
+		{
+			name:   "GET batched: 403 when BatchedCommitment disabled",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/get/0x010000002a00000005%s?commitment_mode=batched", testCommitStr),
+			enabled: &enabled_apis.RestApisEnabled{
+				OpGenericCommitment: true,
+				StandardCommitment:  true,
+			},
+		},
+		{
+			name:   "POST /put?commitment_mode=batched: 403 when BatchedCommitment disabled",
+			method: http.MethodPost,
+			url:    "/put?commitment_mode=batched",
+			enabled: &enabled_apis.RestApisEnabled{
+				OpGenericCommitment: true,
+				StandardCommitment:  true,
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -439,7 +497,7 @@ func TestHandlersReturn403WhenAPIDisabled(t *testing.T) {
 				Port:        0,
 				APIsEnabled: tc.enabled,
 			}
-			server := NewServer(cfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+			server := NewServer(cfg, mockEigenDAManager, mockKeccakManager, nil, testLogger, metrics.NoopMetrics)
 			server.RegisterRoutes(r)
 
 			r.ServeHTTP(rec, req)
@@ -0,0 +1,191 @@
+// handlers_inspect.go contains the admin handler that decodes and verifies a commitment without retrieving its payload.
+// It is meant as the first stop when debugging why a rollup node fails to derive a commitment.
+//
+// Like the handlers in handlers_misc.go, it is not wrapped in middlewares, and does its own logging and error handling.
+package rest
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/gorilla/mux"
+)
+
+const routingVarNameCommitmentHex = "commitment_hex"
+
+// Results of the verification of an inspected cert.
+const (
+	// The cert is valid, and a GET request would return its payload (if it is still retrievable).
+	CertVerificationValid = "valid"
+	// The cert is invalid, and a GET request would return a 418 with the derivation error, which tells the
+	// rollup to drop the commitment.
+	CertVerificationDerivationError = "derivation_error"
+	// The cert couldn't be verified, e.g. because the eth-call to the CertVerifier failed.
+	// A GET request would return a 500, and the rollup would retry.
+	CertVerificationError = "error"
+)
+
+// CertInspectionJSON is the response of the GET /admin/inspect route.
+type CertInspectionJSON struct {
+	CommitmentMode commitments.CommitmentMode `json:"commitment_mode"`
+	// Version byte of the commitment, which determines the cert version.
+	VersionByte uint8 `json:"version_byte"`
+	// Location of the payload within its batch. Only set for batched commitments.
+	BatchEntry *common.BatchEntry `json:"batch_entry,omitempty"`
+	// Fields of the decoded cert. Unset if the cert couldn't be decoded.
+	CertVersion               coretypes.CertificateVersion         `json:"cert_version,omitempty"`
+	BlobKey                   string                               `json:"blob_key,omitempty"`
+	QuorumNumbers             []uint32                             `json:"quorum_numbers,omitempty"`
+	ReferenceBlockNumber      uint64                               `json:"reference_block_number,omitempty"`
+	RelayKeys                 []uint32                             `json:"relay_keys,omitempty"`
+	BlobLengthSymbols         uint32                               `json:"blob_length_symbols,omitempty"`
+	OffchainDerivationVersion *coretypes.OffchainDerivationVersion `json:"offchain_derivation_version,omitempty"`
+	// L1 inclusion block number the recency check was run against. 0 if the check was skipped.
+	L1InclusionBlockNumber uint64 `json:"l1_inclusion_block_number"`
+	// One of CertVerificationValid, CertVerificationDerivationError or CertVerificationError.
+	Verification string `json:"verification"`
+	// Derivation error that GET requests return for this commitment. Only set if Verification is
+	// CertVerificationDerivationError.
+	DerivationError *coretypes.DerivationError `json:"derivation_error,omitempty"`
+	// Only set if Verification is CertVerificationError.
+	Error string `json:"error,omitempty"`
+}
+
+// handleInspectCommitment handles GET requests that decode a commitment and verify its cert, without retrieving the
+// payload. The commitment mode is selected by the commitment_mode query param, the same way as for the GET routes,
+// and the l1_inclusion_block_number query param enables the recency check.
+//
+// Malformed commitments return a 400. Commitments whose cert is invalid return a 200, and the response reports
+// the derivation error that GET requests return for them.
+func (svr *Server) handleInspectCommitment(w http.ResponseWriter, r *http.Request) {
+	inspection, versionedCert, err := decodeCommitment(
+		mux.Vars(r)[routingVarNameCommitmentHex], r.URL.Query().Get("commitment_mode"))
+	if err != nil {
+		svr.log.Info("failed to decode inspected commitment", "path", r.URL.Path, "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	l1InclusionBlockNum, err := parseCommitmentInclusionL1BlockNumQueryParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	inspection.L1InclusionBlockNumber = l1InclusionBlockNum
+
+	// Certs that can't be decoded are reported as the derivation error returned by GET requests for them,
+	// without verifying them.
+	err = inspection.setCertFields(versionedCert)
+	if err == nil {
+		err = svr.certMgr.VerifyCert(r.Context(), versionedCert, coretypes.CertSerializationRLP, l1InclusionBlockNum)
+	}
+	var derivationError coretypes.DerivationError
+	switch {
+	case err == nil:
+		inspection.Verification = CertVerificationValid
+	case errors.As(err, &derivationError):
+		inspection.Verification = CertVerificationDerivationError
+		inspection.DerivationError = &derivationError
+	default:
+		inspection.Verification = CertVerificationError
+		inspection.Error = err.Error()
+	}
+
+	svr.log.Info("Inspected commitment", "commitmentMode", inspection.CommitmentMode,
+		"certVersion", inspection.CertVersion, "blobKey", inspection.BlobKey, "verification", inspection.Verification)
+	svr.writeJSON(w, r, inspection)
+}
+
+// decodeCommitment splits a hex encoded commitment into its prefix, whose fields are set in the returned inspection,
+// and its versioned cert. commitmentMode is the value of the commitment_mode query param.
+func decodeCommitment(commitmentHex string, commitmentMode string) (*CertInspectionJSON, *certs.VersionedCert, error) {
+	commitment, err := hex.DecodeString(commitmentHex)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decode hex commitment: %w", err)
+	}
+
+	var inspection CertInspectionJSON
+	switch commitments.CommitmentMode(commitmentMode) {
+	case commitments.StandardCommitmentMode:
+		// [version_byte][serialized_cert]
+		inspection.CommitmentMode = commitments.StandardCommitmentMode
+	case commitments.BatchedCommitmentMode:
+		// [version_byte][batch_entry][serialized_cert]
+		inspection.CommitmentMode = commitments.BatchedCommitmentMode
+		if len(commitment) < 1+commitments.BatchEntryLenBytes {
+			return nil, nil, fmt.Errorf("batched commitment too short: %d bytes", len(commitment))
+		}
+		batchEntry, err := commitments.DecodeBatchEntry(commitment[1 : 1+commitments.BatchEntryLenBytes])
+		if err != nil {
+			return nil, nil, fmt.Errorf("decode batch entry: %w", err)
+		}
+		inspection.BatchEntry = &batchEntry
+		commitment = append([]byte{commitment[0]}, commitment[1+commitments.BatchEntryLenBytes:]...)
+	default:
+		// op commitments: [commitment_type_byte][da_layer_byte][version_byte][serialized_cert]
+		if len(commitment) == 0 {
+			return nil, nil, errors.New("empty commitment")
+		}
+		switch commitments.OPCommitmentByte(commitment[0]) {
+		case commitments.OPKeccak256CommitmentByte:
+			return nil, nil, errors.New("op keccak256 commitments don't contain an EigenDA cert")
+		case commitments.OPGenericCommitmentByte:
+		default:
+			return nil, nil, fmt.Errorf("unsupported commitment type %#x", commitment[0])
+		}
+		if len(commitment) < 2 {
+			return nil, nil, errors.New("op generic commitment too short")
+		}
+		if commitment[1] != commitments.EigenDALayerByte {
+			return nil, nil, fmt.Errorf("unsupported da layer byte %#x", commitment[1])
+		}
+		inspection.CommitmentMode = commitments.OptimismGenericCommitmentMode
+		commitment = commitment[2:]
+	}
+
+	if len(commitment) == 0 {
+		return nil, nil, errors.New("commitment has no version byte")
+	}
+	inspection.VersionByte = commitment[0]
+	return &inspection, certs.NewVersionedCert(commitment[1:], certs.VersionByte(commitment[0])), nil
+}
+
+// setCertFields decodes the versioned cert, and sets the fields of the inspection that describe it.
+// Returns the derivation error returned by GET requests for certs that can't be decoded.
+func (inspection *CertInspectionJSON) setCertFields(versionedCert *certs.VersionedCert) error {
+	serializedCertHex := hex.EncodeToString(versionedCert.SerializedCert)
+	certVersion, err := versionedCert.Version.IntoCertVersion()
+	if err != nil {
+		return coretypes.NewCertParsingFailedError(serializedCertHex, err.Error())
+	}
+	inspection.CertVersion = certVersion
+	cert, err := coretypes.DeserializeEigenDACert(versionedCert.SerializedCert, certVersion, coretypes.CertSerializationRLP)
+	if err != nil {
+		return coretypes.NewCertParsingFailedError(serializedCertHex, fmt.Sprintf("deserialize EigenDA cert: %v", err))
+	}
+
+	blobKey, err := cert.ComputeBlobKey()
+	if err != nil {
+		return coretypes.NewCertParsingFailedError(serializedCertHex, fmt.Sprintf("compute blob key: %v", err))
+	}
+	inspection.BlobKey = blobKey.Hex()
+	for _, quorum := range cert.QuorumNumbers() {
+		inspection.QuorumNumbers = append(inspection.QuorumNumbers, uint32(quorum))
+	}
+	inspection.ReferenceBlockNumber = cert.ReferenceBlockNumber()
+	inspection.RelayKeys = cert.RelayKeys()
+	blobCommitments, err := cert.Commitments()
+	if err != nil {
+		return coretypes.NewCertParsingFailedError(serializedCertHex, fmt.Sprintf("blob commitments: %v", err))
+	}
+	inspection.BlobLengthSymbols = blobCommitments.Length
+	if certV4, ok := cert.(*coretypes.EigenDACertV4); ok {
+		inspection.OffchainDerivationVersion = &certV4.OffchainDerivationVersion
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package rest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/test/mocks"
+	certTypesBinding "github.com/Layr-Labs/eigenda/contracts/bindings/IEigenDACertTypeBindings"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// testCertV4 returns a serialized V4 cert whose points are the bn254 generators, so that its blob key can be computed.
+func testCertV4(t *testing.T) []byte {
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	g1Point := certTypesBinding.BN254G1Point{X: g1Gen.X.BigInt(new(big.Int)), Y: g1Gen.Y.BigInt(new(big.Int))}
+	// the binding orders the coordinates of G2 points as [A1, A0]
+	g2Point := certTypesBinding.BN254G2Point{
+		X: [2]*big.Int{g2Gen.X.A1.BigInt(new(big.Int)), g2Gen.X.A0.BigInt(new(big.Int))},
+		Y: [2]*big.Int{g2Gen.Y.A1.BigInt(new(big.Int)), g2Gen.Y.A0.BigInt(new(big.Int))},
+	}
+	cert := coretypes.EigenDACertV4{
+		BlobInclusionInfo: certTypesBinding.EigenDATypesV2BlobInclusionInfo{
+			BlobCertificate: certTypesBinding.EigenDATypesV2BlobCertificate{
+				BlobHeader: certTypesBinding.EigenDATypesV2BlobHeaderV2{
+					QuorumNumbers: []byte{0, 1},
+					Commitment: certTypesBinding.EigenDATypesV2BlobCommitment{
+						Commitment:       g1Point,
+						LengthCommitment: g2Point,
+						LengthProof:      g2Point,
+						Length:           64,
+					},
+				},
+				RelayKeys: []uint32{3, 7},
+			},
+		},
+		BatchHeader: certTypesBinding.EigenDATypesV2BatchHeaderV2{ReferenceBlockNumber: 12345},
+		NonSignerStakesAndSignature: certTypesBinding.EigenDATypesV1NonSignerStakesAndSignature{
+			ApkG2: g2Point,
+			Sigma: g1Point,
+		},
+		SignedQuorumNumbers:       []byte{0, 1},
+		OffchainDerivationVersion: 0,
+	}
+	serializedCert, err := cert.Serialize(coretypes.CertSerializationRLP)
+	require.NoError(t, err)
+	return serializedCert
+}
+
+func TestInspectCommitment(t *testing.T) {
+	serializedCert := testCertV4(t)
+	versionedCert := certs.NewVersionedCert(serializedCert, certs.V3VersionByte)
+	batchEntry := common.BatchEntry{Offset: 4, Length: 16}
+
+	tests := []struct {
+		name                 string
+		commitment           []byte
+		query                string
+		verifies             bool // whether the cert is decoded, and then verified by the manager
+		verifyErr            error
+		expectedStatus       int
+		expectedMode         commitments.CommitmentMode
+		expectedVerification string
+		expectedBatchEntry   *common.BatchEntry
+		expectedDerivation   *coretypes.DerivationError
+	}{
+		{
+			name:                 "valid op generic commitment",
+			commitment:           commitments.NewOPEigenDAGenericCommitment(*versionedCert).Encode(),
+			verifies:             true,
+			expectedStatus:       http.StatusOK,
+			expectedMode:         commitments.OptimismGenericCommitmentMode,
+			expectedVerification: CertVerificationValid,
+		},
+		{
+			name:                 "standard commitment with invalid cert",
+			commitment:           commitments.NewStandardCommitment(*versionedCert).Encode(),
+			query:                "?commitment_mode=standard&l1_inclusion_block_number=20000",
+			verifies:             true,
+			verifyErr:            coretypes.NewRBNRecencyCheckFailedError(12345, 20000, 100),
+			expectedStatus:       http.StatusOK,
+			expectedMode:         commitments.StandardCommitmentMode,
+			expectedVerification: CertVerificationDerivationError,
+			expectedDerivation:   &coretypes.ErrRecencyCheckFailedDerivationError,
+		},
+		{
+			name:                 "batched commitment with failing verification",
+			commitment:           commitments.NewBatchedCommitment(*versionedCert, batchEntry).Encode(),
+			query:                "?commitment_mode=batched",
+			verifies:             true,
+			verifyErr:            errors.New("eth-call failed"),
+			expectedStatus:       http.StatusOK,
+			expectedMode:         commitments.BatchedCommitmentMode,
+			expectedVerification: CertVerificationError,
+			expectedBatchEntry:   &batchEntry,
+		},
+		{
+			name: "undecodable cert",
+			commitment: commitments.NewStandardCommitment(
+				*certs.NewVersionedCert([]byte{0x01, 0x02}, certs.V3VersionByte)).Encode(),
+			query:                "?commitment_mode=standard",
+			expectedStatus:       http.StatusOK,
+			expectedMode:         commitments.StandardCommitmentMode,
+			expectedVerification: CertVerificationDerivationError,
+			expectedDerivation:   &coretypes.ErrCertParsingFailedDerivationError,
+		},
+		{
+			name:           "op keccak commitment",
+			commitment:     commitments.OPKeccak256Commitment(make([]byte, 32)).Encode(),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "truncated batched commitment",
+			commitment:     []byte{byte(certs.V3VersionByte), 0x00},
+			query:          "?commitment_mode=batched",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+			if tt.verifies {
+				mockEigenDAManager.EXPECT().
+					VerifyCert(gomock.Any(), gomock.Any(), coretypes.CertSerializationRLP, gomock.Any()).
+					DoAndReturn(func(_ any, cert *certs.VersionedCert, _ any, _ uint64) error {
+						require.Equal(t, versionedCert, cert)
+						return tt.verifyErr
+					})
+			}
+
+			r := mux.NewRouter()
+			server := NewServer(testCfg, mockEigenDAManager, mocks.NewMockIKeccakManager(ctrl), nil, testLogger,
+				metrics.NoopMetrics)
+			server.RegisterRoutes(r)
+			req := httptest.NewRequest(http.MethodGet, "/admin/inspect/0x"+hex.EncodeToString(tt.commitment)+tt.query, nil)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code, rec.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			var inspection CertInspectionJSON
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &inspection))
+			require.Equal(t, tt.expectedMode, inspection.CommitmentMode)
+			require.Equal(t, byte(certs.V3VersionByte), inspection.VersionByte)
+			require.Equal(t, tt.expectedVerification, inspection.Verification, rec.Body.String())
+			require.Equal(t, tt.expectedBatchEntry, inspection.BatchEntry)
+			if tt.expectedDerivation != nil {
+				require.NotNil(t, inspection.DerivationError)
+				require.Equal(t, tt.expectedDerivation.StatusCode, inspection.DerivationError.StatusCode)
+				return
+			}
+			require.Equal(t, coretypes.CertificateVersion(coretypes.VersionFourCert), inspection.CertVersion)
+			require.NotEmpty(t, inspection.BlobKey)
+			require.Equal(t, []uint32{0, 1}, inspection.QuorumNumbers)
+			require.Equal(t, uint64(12345), inspection.ReferenceBlockNumber)
+			require.Equal(t, []uint32{3, 7}, inspection.RelayKeys)
+			require.Equal(t, uint32(64), inspection.BlobLengthSymbols)
+		})
+	}
+}
@@ -18,6 +18,8 @@ const (
 	routingVarNamePayloadHex          = "payload_hex"
 	routingVarNameVersionByteHex      = "version_byte_hex"
 	routingVarNameCommitTypeByteHex   = "commit_type_byte_hex"
+	routingVarNameBatchEntryHex       = "batch_entry_hex"
+	routingVarNameJobID               = "job_id"
 )
 
 func (svr *Server) RegisterRoutes(r *mux.Router) {
@@ -29,6 +31,14 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 		"{"+routingVarNamePayloadHex+":[0-9a-fA-F]*}",
 		middleware.WithCertMiddlewares(svr.handleGetStdCommitment, svr.log, svr.m, commitments.StandardCommitmentMode),
 	).Queries("commitment_mode", "standard")
+	// batched commitments (a payload within a batch of payloads dispersed in one blob)
+	subrouterGET.HandleFunc("/"+
+		"{optional_prefix:(?:0x)?}"+ // commitments can be prefixed with 0x
+		"{"+routingVarNameVersionByteHex+":[0-9a-fA-F]{2}}"+
+		"{"+routingVarNameBatchEntryHex+":[0-9a-fA-F]{16}}"+ // 4 byte offset + 4 byte length of the payload within the batch
+		"{"+routingVarNamePayloadHex+":[0-9a-fA-F]*}",
+		middleware.WithCertMiddlewares(svr.handleGetBatchedCommitment, svr.log, svr.m, commitments.BatchedCommitmentMode),
+	).Queries("commitment_mode", "batched")
 	// op keccak256 commitments (write to S3)
 	subrouterGET.HandleFunc(
 		"/"+
@@ -70,13 +80,17 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 			commitType := mux.Vars(r)[routingVarNameCommitTypeByteHex]
 			http.Error(w, fmt.Sprintf("unsupported commitment type %s", commitType), http.StatusBadRequest)
 		},
-	).MatcherFunc(notCommitmentModeStandard)
+	).MatcherFunc(notCommitmentModeStandardOrBatched)
 
 	subrouterPOST := r.Methods("POST").PathPrefix("/put").Subrouter()
 	// std commitments (for nitro)
 	subrouterPOST.HandleFunc("", // commitment is calculated by the server using the body data
 		middleware.WithCertMiddlewares(svr.handlePostStdCommitment, svr.log, svr.m, commitments.StandardCommitmentMode),
 	).Queries("commitment_mode", "standard")
+	// batched commitments
+	subrouterPOST.HandleFunc("", // payload is batched with others, and the commitment is calculated by the server
+		middleware.WithCertMiddlewares(svr.handlePostBatchedCommitment, svr.log, svr.m, commitments.BatchedCommitmentMode),
+	).Queries("commitment_mode", "batched")
 	// op keccak256 commitments (write to S3)
 	subrouterPOST.HandleFunc(
 		"/"+
@@ -110,6 +124,23 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 		),
 	)
 
+	// async variants of the above POST routes, which return a job immediately instead of blocking until the
+	// payload is certified. The commitment is then obtained by polling the job's status.
+	if svr.asyncPutMgr != nil {
+		subrouterPOST.HandleFunc("/async",
+			middleware.WithCertMiddlewares(svr.handlePostAsyncStdCommitment, svr.log, svr.m, commitments.StandardCommitmentMode),
+		).Queries("commitment_mode", "standard")
+		subrouterPOST.HandleFunc("/async",
+			middleware.WithCertMiddlewares(svr.handlePostAsyncBatchedCommitment, svr.log, svr.m, commitments.BatchedCommitmentMode),
+		).Queries("commitment_mode", "batched")
+		subrouterPOST.HandleFunc("/async",
+			middleware.WithCertMiddlewares(svr.handlePostAsyncOPGenericCommitment, svr.log, svr.m, commitments.OptimismGenericCommitmentMode),
+		)
+		r.HandleFunc(asyncPutStatusPathPrefix+"{"+routingVarNameJobID+":[0-9a-f]{32}}", // 16 byte hex job ID
+			svr.handleGetAsyncPutStatus,
+		).Methods("GET")
+	}
+
 	// TODO: should prob setup metrics middlewares to also work for the below routes...
 	// right now they only work for the main GET/POST routes.
 	r.HandleFunc("/health", svr.handleHealth).Methods("GET")
@@ -127,15 +158,44 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 		// Admin endpoints to check and set EigenDA backend used for dispersal
 		r.HandleFunc("/admin/eigenda-dispersal-backend", svr.handleGetEigenDADispersalBackend).Methods("GET")
 		r.HandleFunc("/admin/eigenda-dispersal-backend", svr.handleSetEigenDADispersalBackend).Methods("PUT")
+		// Admin endpoint to decode a commitment and verify its cert, without retrieving the payload
+		r.HandleFunc("/admin/inspect/"+
+			"{optional_prefix:(?:0x)?}"+ // commitments can be prefixed with 0x
+			"{"+routingVarNameCommitmentHex+":[0-9a-fA-F]+}",
+			svr.handleInspectCommitment,
+		).Methods("GET")
 	}
 
 	// proxy compatibility config endpoint
 	r.HandleFunc("/config", svr.handleGetCompatibilityConfig).Methods("GET")
 }
 
-func notCommitmentModeStandard(r *http.Request, _ *mux.RouteMatch) bool {
+// NetworkHeader routes a request to the network profile it names, as an alternative to the /networks/<name> path prefix.
+const NetworkHeader = "X-EigenDA-Network"
+
+// RegisterNetworkRoutes routes requests for each network profile to the profile's handler, which serves the same
+// routes as the default network. A request is routed to a profile if its path is prefixed with /networks/<name>,
+// or if it sets the X-EigenDA-Network header to the profile's name. Requests naming an unknown profile in the header
+// are rejected, rather than being served by the default network.
+//
+// It must be called before [Server.RegisterRoutes], so that the profile routes take precedence.
+func RegisterNetworkRoutes(r *mux.Router, handlers map[string]http.Handler) {
+	for network, handler := range handlers {
+		pathPrefix := "/networks/" + network
+		r.PathPrefix(pathPrefix + "/").Handler(http.StripPrefix(pathPrefix, handler))
+		r.Headers(NetworkHeader, network).Handler(handler)
+	}
+	r.MatcherFunc(func(r *http.Request, _ *mux.RouteMatch) bool {
+		return r.Header.Get(NetworkHeader) != ""
+	}).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, fmt.Sprintf("unknown network profile %s", r.Header.Get(NetworkHeader)), http.StatusBadRequest)
+	})
+}
+
+func notCommitmentModeStandardOrBatched(r *http.Request, _ *mux.RouteMatch) bool {
 	commitmentMode := r.URL.Query().Get("commitment_mode")
-	return commitmentMode == "" || commitmentMode != "standard"
+	return commitmentMode != string(commitments.StandardCommitmentMode) &&
+		commitmentMode != string(commitments.BatchedCommitmentMode)
 }
 
 // ================== QUERY PARAMS PARSING FUNCTION ==================================================
@@ -25,7 +25,7 @@ func TestRouting(t *testing.T) {
 	mockKeccakManager := mocks.NewMockIKeccakManager(ctrl)
 
 	m := metrics.NewMetrics(prometheus.NewRegistry())
-	server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, testLogger, m)
+	server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, testLogger, m)
 	r := mux.NewRouter()
 	err := server.Start(r)
 	require.NoError(t, err)
@@ -238,3 +238,49 @@ func TestParseReturnEncodedPayloadQueryParam(t *testing.T) {
 		})
 	}
 }
+
+func TestNetworkRouting(t *testing.T) {
+	// Each handler responds with the network it serves and the path it was called with.
+	networkHandler := func(network string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprintf(w, "%s %s", network, r.URL.Path)
+		})
+	}
+	r := mux.NewRouter()
+	RegisterNetworkRoutes(r, map[string]http.Handler{
+		"sepolia": networkHandler("sepolia"),
+		"hoodi":   networkHandler("hoodi"),
+	})
+	r.PathPrefix("/").Handler(networkHandler("default"))
+
+	tests := []struct {
+		name           string
+		path           string
+		header         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "path prefix", path: "/networks/sepolia/get/0x0102", expectedStatus: http.StatusOK,
+			expectedBody: "sepolia /get/0x0102"},
+		{name: "header", path: "/put?commitment_mode=standard", header: "hoodi", expectedStatus: http.StatusOK,
+			expectedBody: "hoodi /put"},
+		{name: "unknown network header", path: "/put", header: "mainnet", expectedStatus: http.StatusBadRequest},
+		{name: "unknown network path prefix", path: "/networks/mainnet/put", expectedStatus: http.StatusOK,
+			expectedBody: "default /networks/mainnet/put"},
+		{name: "default network", path: "/put", expectedStatus: http.StatusOK, expectedBody: "default /put"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set(NetworkHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				require.Equal(t, tt.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
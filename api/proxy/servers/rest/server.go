@@ -14,6 +14,7 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/config/enablement"
 	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
 	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/asyncput"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/gorilla/mux"
 )
@@ -27,36 +28,39 @@ type Config struct {
 }
 
 type Server struct {
-	log        logging.Logger
-	endpoint   string
-	certMgr    store.IEigenDAManager
-	keccakMgr  store.IKeccakManager
-	m          metrics.Metricer
-	httpServer *http.Server
-	listener   net.Listener
-	config     Config
+	log       logging.Logger
+	endpoint  string
+	certMgr   store.IEigenDAManager
+	keccakMgr store.IKeccakManager
+	// nil if async puts are disabled
+	asyncPutMgr *asyncput.Manager
+	m           metrics.Metricer
+	httpServer  *http.Server
+	listener    net.Listener
+	config      Config
 }
 
 func NewServer(
 	cfg Config,
 	certMgr store.IEigenDAManager,
 	keccakMgr store.IKeccakManager,
+	asyncPutMgr *asyncput.Manager,
 	log logging.Logger,
 	m metrics.Metricer,
 ) *Server {
 	endpoint := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
 	return &Server{
-		m:         m,
-		log:       log,
-		endpoint:  endpoint,
-		certMgr:   certMgr,
-		keccakMgr: keccakMgr,
-		config:    cfg,
+		m:           m,
+		log:         log,
+		endpoint:    endpoint,
+		certMgr:     certMgr,
+		keccakMgr:   keccakMgr,
+		asyncPutMgr: asyncPutMgr,
+		config:      cfg,
 		httpServer: &http.Server{
 			Addr:              endpoint,
 			ReadHeaderTimeout: 10 * time.Second,
-			// aligned with existing blob finalization times
-			WriteTimeout: 40 * time.Minute,
+			WriteTimeout:      store.MaxPutDuration,
 		},
 	}
 }
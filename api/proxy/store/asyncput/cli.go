@@ -0,0 +1,102 @@
+package asyncput
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	DirFlagName           = withFlagPrefix("dir")
+	WorkersFlagName       = withFlagPrefix("workers")
+	RetentionFlagName     = withFlagPrefix("retention")
+	MaxQueuedJobsFlagName = withFlagPrefix("max-queued-jobs")
+)
+
+func withFlagPrefix(s string) string {
+	return "async-put." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_ASYNC_PUT_" + s}
+}
+
+type Config struct {
+	// Directory where jobs are persisted. The async put routes are disabled if empty.
+	Dir string
+	// Maximum number of jobs dispersed concurrently.
+	Workers int
+	// How long certified and failed jobs can still be polled for.
+	Retention time.Duration
+	// Maximum number of jobs that are queued or dispersing. Submissions beyond this are rejected with a 503, which
+	// bounds the goroutines and persisted payloads held by in flight jobs.
+	MaxQueuedJobs int
+}
+
+// Enabled returns true if async puts are enabled.
+func (cfg Config) Enabled() bool {
+	return cfg.Dir != ""
+}
+
+// Check ... verifies that configuration values are adequately set
+func (cfg Config) Check() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	if cfg.Workers <= 0 {
+		return fmt.Errorf("async put workers must be greater than 0, got %d", cfg.Workers)
+	}
+	if cfg.Retention <= 0 {
+		return fmt.Errorf("async put retention must be greater than 0, got %s", cfg.Retention)
+	}
+	if cfg.MaxQueuedJobs <= 0 {
+		return fmt.Errorf("async put max queued jobs must be greater than 0, got %d", cfg.MaxQueuedJobs)
+	}
+	return nil
+}
+
+// CLIFlags ... used for async put configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name: DirFlagName,
+			Usage: "directory where async put jobs are persisted, so that they survive restarts. " +
+				"The async put routes (/put/async and /put/status) are disabled if empty.",
+			EnvVars:  withEnvPrefix(envPrefix, "DIR"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     WorkersFlagName,
+			Usage:    "maximum number of async put jobs dispersed concurrently.",
+			Value:    8,
+			EnvVars:  withEnvPrefix(envPrefix, "WORKERS"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     RetentionFlagName,
+			Usage:    "how long the status of certified and failed async put jobs can be polled for.",
+			Value:    24 * time.Hour,
+			EnvVars:  withEnvPrefix(envPrefix, "RETENTION"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name: MaxQueuedJobsFlagName,
+			Usage: "maximum number of async put jobs that are queued or dispersing. " +
+				"New jobs are rejected with 503 Service Unavailable while the limit is reached.",
+			Value:    1024,
+			EnvVars:  withEnvPrefix(envPrefix, "MAX_QUEUED_JOBS"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Dir:           ctx.String(DirFlagName),
+		Workers:       ctx.Int(WorkersFlagName),
+		Retention:     ctx.Duration(RetentionFlagName),
+		MaxQueuedJobs: ctx.Int(MaxQueuedJobsFlagName),
+	}
+}
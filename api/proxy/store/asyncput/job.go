@@ -0,0 +1,127 @@
+package asyncput
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Status is the state of an asynchronous PUT job.
+type Status string
+
+const (
+	// StatusQueued jobs are waiting for a free dispersal worker.
+	StatusQueued Status = "queued"
+	// StatusDispersing jobs are being dispersed to EigenDA.
+	StatusDispersing Status = "dispersing"
+	// StatusCertified jobs have been dispersed, and their commitment is available.
+	StatusCertified Status = "certified"
+	// StatusFailed jobs could not be dispersed. The job's error describes why.
+	StatusFailed Status = "failed"
+)
+
+// IsTerminal returns true if a job with this status will not change status anymore.
+func (s Status) IsTerminal() bool {
+	return s == StatusCertified || s == StatusFailed
+}
+
+// Job is the state of an asynchronous PUT, as reported to clients polling for its status.
+type Job struct {
+	ID             string                     `json:"id"`
+	Status         Status                     `json:"status"`
+	CommitmentMode commitments.CommitmentMode `json:"commitment_mode"`
+	// The commitment of the dispersed payload, encoded for the job's commitment mode. Only set once certified.
+	Commitment hexutil.Bytes `json:"commitment,omitempty"`
+	// Only set once failed.
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// persistedJob is the on-disk representation of a job. The payload is kept until the job reaches a terminal
+// status, so that in-flight jobs can be resumed after a restart.
+type persistedJob struct {
+	Job
+	Payload []byte `json:"payload,omitempty"`
+}
+
+const jobFileExtension = ".json"
+
+// jobStore persists jobs in a directory, with one file per job. Writes are fsynced, since a job is only
+// acknowledged to the client once it has been persisted.
+type jobStore struct {
+	dir string
+}
+
+// newJobStore creates the directory if needed, and cleans up after any write interrupted by a crash.
+func newJobStore(dir string) (*jobStore, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("create job directory %s: %w", dir, err)
+	}
+	err = util.DeleteOrphanedSwapFiles(dir)
+	if err != nil {
+		return nil, fmt.Errorf("delete orphaned swap files: %w", err)
+	}
+	return &jobStore{dir: dir}, nil
+}
+
+func (s *jobStore) path(id string) string {
+	return filepath.Join(s.dir, id+jobFileExtension)
+}
+
+// put writes the job, replacing any previous version of it.
+func (s *jobStore) put(job *persistedJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("marshal job %s: %w", job.ID, err)
+	}
+	err = util.AtomicWrite(s.path(job.ID), data, true)
+	if err != nil {
+		return fmt.Errorf("write job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+// delete removes the job. Deleting a job that doesn't exist is not an error.
+func (s *jobStore) delete(id string) error {
+	err := os.Remove(s.path(id))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("delete job %s: %w", id, err)
+	}
+	return nil
+}
+
+// loadAll reads all jobs in the directory.
+func (s *jobStore) loadAll() ([]*persistedJob, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("read job directory %s: %w", s.dir, err)
+	}
+
+	jobs := make([]*persistedJob, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), jobFileExtension) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read job file %s: %w", entry.Name(), err)
+		}
+		job := &persistedJob{}
+		err = json.Unmarshal(data, job)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal job file %s: %w", entry.Name(), err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
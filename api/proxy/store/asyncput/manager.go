@@ -0,0 +1,342 @@
+package asyncput
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// ErrJobNotFound is returned when polling a job that doesn't exist, or whose retention period has elapsed.
+var ErrJobNotFound = errors.New("async put job not found")
+
+// ErrQueueFull is returned by Submit when the maximum number of in flight jobs has been reached. It is wrapped in an
+// [api.ErrorFailover], so that the REST server responds with 503 and clients can fail over or retry later.
+var ErrQueueFull = errors.New("async put queue is full")
+
+// ErrStopped is returned by Submit once the manager has been stopped.
+var ErrStopped = errors.New("async put manager is stopped")
+
+// The number of random bytes in a job ID.
+const jobIDBytes = 16
+
+// The maximum interval at which jobs past their retention period are pruned.
+const maxPruneInterval = time.Minute
+
+// Manager runs PUTs asynchronously. Each submitted payload becomes a job, which is dispersed in the background
+// while clients poll for its status.
+//
+// Jobs are persisted to disk from the moment they are submitted until they are pruned, so that a restart of the
+// proxy doesn't lose them. Jobs that were queued or dispersing when the proxy stopped are dispersed again on startup.
+type Manager struct {
+	log      logging.Logger
+	certMgr  store.IEigenDAManager
+	config   Config
+	jobStore *jobStore
+
+	mu sync.Mutex
+	// all jobs that haven't been pruned yet
+	jobs map[string]*Job
+	// the number of jobs that are queued or dispersing, which is bounded by config.MaxQueuedJobs
+	inFlight int
+	// set by Stop, after which no new jobs are accepted
+	stopped bool
+
+	// limits the number of jobs being dispersed concurrently
+	workers chan struct{}
+
+	// canceled by Stop, which interrupts in-flight dispersals
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewManager loads the jobs persisted in the configured directory, and resumes those which were in flight.
+func NewManager(
+	ctx context.Context,
+	log logging.Logger,
+	certMgr store.IEigenDAManager,
+	config Config,
+) (*Manager, error) {
+	err := config.Check()
+	if err != nil {
+		return nil, fmt.Errorf("check config: %w", err)
+	}
+	jobStore, err := newJobStore(config.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("new job store: %w", err)
+	}
+	persistedJobs, err := jobStore.loadAll()
+	if err != nil {
+		return nil, fmt.Errorf("load jobs: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	m := &Manager{
+		log:      log,
+		certMgr:  certMgr,
+		config:   config,
+		jobStore: jobStore,
+		jobs:     make(map[string]*Job, len(persistedJobs)),
+		workers:  make(chan struct{}, config.Workers),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+
+	// The job map is fully built before any job is resumed, since running jobs read and update it.
+	var resumed []*persistedJob
+	for _, persisted := range persistedJobs {
+		job := persisted.Job
+		if !job.Status.IsTerminal() {
+			// The outcome of a dispersal interrupted by the restart is unknown, so it is dispersed again.
+			job.Status = StatusQueued
+			// Resumed jobs were accepted before the restart, so they count towards the limit but are never
+			// rejected.
+			m.inFlight++
+			resumed = append(resumed, persisted)
+		}
+		m.jobs[job.ID] = &job
+	}
+	m.wg.Add(len(resumed))
+	for _, persisted := range resumed {
+		go m.run(persisted.Job.ID, persisted.Job.CommitmentMode, persisted.Payload)
+	}
+	log.Info("Loaded async put jobs", "dir", config.Dir, "jobs", len(persistedJobs), "resumed", len(resumed))
+
+	m.pruneExpired()
+	m.wg.Add(1)
+	go m.pruneLoop()
+
+	return m, nil
+}
+
+// Submit persists a new job for the payload and starts dispersing it in the background. The returned job can be
+// polled with GetJob.
+//
+// Returns an error wrapping ErrQueueFull if config.MaxQueuedJobs jobs are already in flight, and ErrStopped once
+// Stop has been called.
+func (m *Manager) Submit(payload []byte, mode commitments.CommitmentMode) (Job, error) {
+	switch mode {
+	case commitments.StandardCommitmentMode,
+		commitments.OptimismGenericCommitmentMode,
+		commitments.BatchedCommitmentMode:
+	default:
+		return Job{}, fmt.Errorf("commitment mode %s is not supported for async puts", mode)
+	}
+	err := m.reserve()
+	if err != nil {
+		return Job{}, err
+	}
+	submitted := false
+	defer func() {
+		if !submitted {
+			m.release()
+			m.wg.Done()
+		}
+	}()
+
+	idBytes := make([]byte, jobIDBytes)
+	_, err = rand.Read(idBytes)
+	if err != nil {
+		return Job{}, fmt.Errorf("generate job ID: %w", err)
+	}
+	now := time.Now()
+	job := Job{
+		ID:             hex.EncodeToString(idBytes),
+		Status:         StatusQueued,
+		CommitmentMode: mode,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	// The job is persisted before it is acknowledged, so that an accepted payload survives a restart.
+	err = m.jobStore.put(&persistedJob{Job: job, Payload: payload})
+	if err != nil {
+		return Job{}, fmt.Errorf("persist job: %w", err)
+	}
+
+	// The manager's copy of the job is updated as it progresses, so the caller gets its own copy.
+	stored := job
+	m.mu.Lock()
+	m.jobs[job.ID] = &stored
+	m.mu.Unlock()
+
+	submitted = true
+	go m.run(job.ID, mode, payload)
+
+	return job, nil
+}
+
+// reserve claims an in flight slot for a new job, and registers the goroutine that will run it with the wait group.
+// Both are done under the lock, so that Stop can't start waiting for background work before the job is registered.
+// The caller must call release and wg.Done if the job is never run.
+func (m *Manager) reserve() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stopped || m.ctx.Err() != nil {
+		return ErrStopped
+	}
+	if m.inFlight >= m.config.MaxQueuedJobs {
+		return api.NewErrorFailover(fmt.Errorf("%w: %d jobs in flight", ErrQueueFull, m.inFlight))
+	}
+	m.inFlight++
+	m.wg.Add(1)
+	return nil
+}
+
+// release frees the in flight slot claimed by a job.
+func (m *Manager) release() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inFlight--
+}
+
+// GetJob returns the current state of a job, or ErrJobNotFound.
+func (m *Manager) GetJob(id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return Job{}, fmt.Errorf("job %s: %w", id, ErrJobNotFound)
+	}
+	return *job, nil
+}
+
+// Stop interrupts in-flight dispersals and waits for background work to finish. Interrupted jobs remain persisted
+// as in flight, and are resumed by the next Manager created on the same directory.
+func (m *Manager) Stop() {
+	m.mu.Lock()
+	m.stopped = true
+	m.mu.Unlock()
+
+	m.cancel()
+	m.wg.Wait()
+}
+
+// run waits for a free worker and disperses the job's payload.
+func (m *Manager) run(id string, mode commitments.CommitmentMode, payload []byte) {
+	defer m.wg.Done()
+	defer m.release()
+
+	select {
+	case m.workers <- struct{}{}:
+	case <-m.ctx.Done():
+		return
+	}
+	defer func() { <-m.workers }()
+
+	m.update(id, payload, func(job *Job) {
+		job.Status = StatusDispersing
+	})
+
+	commitment, err := m.disperse(m.ctx, payload, mode)
+	if err != nil && m.ctx.Err() != nil {
+		m.log.Info("Async put job interrupted by shutdown, it will be resumed on restart", "jobID", id)
+		return
+	}
+
+	if err != nil {
+		m.log.Warn("Async put job failed", "jobID", id, "err", err)
+		m.update(id, nil, func(job *Job) {
+			job.Status = StatusFailed
+			job.Error = err.Error()
+		})
+		return
+	}
+
+	m.log.Info("Async put job certified", "jobID", id, "commitmentMode", mode)
+	m.update(id, nil, func(job *Job) {
+		job.Status = StatusCertified
+		job.Commitment = commitment
+	})
+}
+
+// disperse puts the payload and returns its commitment, encoded for the given commitment mode.
+func (m *Manager) disperse(ctx context.Context, payload []byte, mode commitments.CommitmentMode) ([]byte, error) {
+	if mode == commitments.BatchedCommitmentMode {
+		versionedCert, batchEntry, err := m.certMgr.PutBatched(ctx, payload, coretypes.CertSerializationRLP)
+		if err != nil {
+			return nil, fmt.Errorf("batched put: %w", err)
+		}
+		return commitments.NewBatchedCommitment(*versionedCert, batchEntry).Encode(), nil
+	}
+
+	versionedCert, err := m.certMgr.Put(ctx, payload, coretypes.CertSerializationRLP)
+	if err != nil {
+		return nil, fmt.Errorf("put: %w", err)
+	}
+	commitment, err := commitments.EncodeCommitment(versionedCert, mode)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s commitment: %w", mode, err)
+	}
+	return commitment, nil
+}
+
+// update applies a change to a job and persists it. The payload is only persisted for jobs that are still in flight.
+//
+// Only the goroutine running a job updates it, so updates of a single job are never persisted out of order.
+func (m *Manager) update(id string, payload []byte, change func(job *Job)) {
+	m.mu.Lock()
+	job, ok := m.jobs[id]
+	if !ok {
+		m.mu.Unlock()
+		return
+	}
+	change(job)
+	job.UpdatedAt = time.Now()
+	persisted := &persistedJob{Job: *job, Payload: payload}
+	m.mu.Unlock()
+
+	err := m.jobStore.put(persisted)
+	if err != nil {
+		// The job can still be polled, but its latest status won't survive a restart.
+		m.log.Error("Failed to persist async put job", "jobID", id, "status", persisted.Status, "err", err)
+	}
+}
+
+// pruneLoop periodically prunes jobs past their retention period, until the manager is stopped.
+func (m *Manager) pruneLoop() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(min(m.config.Retention, maxPruneInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.pruneExpired()
+		case <-m.ctx.Done():
+			return
+		}
+	}
+}
+
+// pruneExpired deletes certified and failed jobs which completed more than the retention period ago.
+func (m *Manager) pruneExpired() {
+	cutoff := time.Now().Add(-m.config.Retention)
+
+	m.mu.Lock()
+	expired := make([]string, 0)
+	for id, job := range m.jobs {
+		if job.Status.IsTerminal() && job.UpdatedAt.Before(cutoff) {
+			expired = append(expired, id)
+			delete(m.jobs, id)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, id := range expired {
+		err := m.jobStore.delete(id)
+		if err != nil {
+			m.log.Error("Failed to delete expired async put job", "jobID", id, "err", err)
+		}
+	}
+}
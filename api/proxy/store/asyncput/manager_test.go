@@ -0,0 +1,267 @@
+package asyncput
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+// fakeCertManager implements the puts of store.IEigenDAManager. Calling any other method panics.
+type fakeCertManager struct {
+	store.IEigenDAManager
+	put func(ctx context.Context, payload []byte) (*certs.VersionedCert, error)
+}
+
+func (f *fakeCertManager) Put(
+	ctx context.Context, payload []byte, _ coretypes.CertSerializationType,
+) (*certs.VersionedCert, error) {
+	return f.put(ctx, payload)
+}
+
+func (f *fakeCertManager) PutBatched(
+	ctx context.Context, payload []byte, _ coretypes.CertSerializationType,
+) (*certs.VersionedCert, common.BatchEntry, error) {
+	cert, err := f.put(ctx, payload)
+	return cert, common.BatchEntry{Offset: 9, Length: uint32(len(payload))}, err
+}
+
+// certFor returns a deterministic fake cert for a payload.
+func certFor(payload []byte) *certs.VersionedCert {
+	return &certs.VersionedCert{Version: certs.V2VersionByte, SerializedCert: append([]byte("cert-"), payload...)}
+}
+
+func newTestManager(t *testing.T, dir string, certMgr store.IEigenDAManager) *Manager {
+	manager, err := NewManager(t.Context(), testLogger, certMgr, Config{Dir: dir, Workers: 2, Retention: time.Hour, MaxQueuedJobs: 16})
+	require.NoError(t, err)
+	t.Cleanup(manager.Stop)
+	return manager
+}
+
+// waitForTerminal polls the job until it is certified or failed.
+func waitForTerminal(t *testing.T, manager *Manager, id string) Job {
+	var job Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = manager.GetJob(id)
+		require.NoError(t, err)
+		return job.Status.IsTerminal()
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestSubmitCertified(t *testing.T) {
+	certMgr := &fakeCertManager{put: func(_ context.Context, payload []byte) (*certs.VersionedCert, error) {
+		return certFor(payload), nil
+	}}
+	manager := newTestManager(t, t.TempDir(), certMgr)
+
+	payload := []byte("payload")
+	for _, mode := range []commitments.CommitmentMode{
+		commitments.StandardCommitmentMode,
+		commitments.OptimismGenericCommitmentMode,
+	} {
+		submitted, err := manager.Submit(payload, mode)
+		require.NoError(t, err)
+		require.Equal(t, StatusQueued, submitted.Status)
+		require.Len(t, submitted.ID, 2*jobIDBytes)
+
+		job := waitForTerminal(t, manager, submitted.ID)
+		require.Equal(t, StatusCertified, job.Status, job.Error)
+		expected, err := commitments.EncodeCommitment(certFor(payload), mode)
+		require.NoError(t, err)
+		require.Equal(t, expected, []byte(job.Commitment))
+	}
+
+	submitted, err := manager.Submit(payload, commitments.BatchedCommitmentMode)
+	require.NoError(t, err)
+	job := waitForTerminal(t, manager, submitted.ID)
+	require.Equal(t, StatusCertified, job.Status, job.Error)
+	expected := commitments.NewBatchedCommitment(
+		*certFor(payload), common.BatchEntry{Offset: 9, Length: uint32(len(payload))}).Encode()
+	require.Equal(t, expected, []byte(job.Commitment))
+
+	// Keccak commitments are computed by the client, so there's nothing to wait for.
+	_, err = manager.Submit(payload, commitments.OptimismKeccakCommitmentMode)
+	require.Error(t, err)
+
+	_, err = manager.GetJob("0123456789abcdef0123456789abcdef")
+	require.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestSubmitFailed(t *testing.T) {
+	certMgr := &fakeCertManager{put: func(context.Context, []byte) (*certs.VersionedCert, error) {
+		return nil, errors.New("disperser unavailable")
+	}}
+	dir := t.TempDir()
+	manager := newTestManager(t, dir, certMgr)
+
+	submitted, err := manager.Submit([]byte("payload"), commitments.StandardCommitmentMode)
+	require.NoError(t, err)
+	job := waitForTerminal(t, manager, submitted.ID)
+	require.Equal(t, StatusFailed, job.Status)
+	require.Contains(t, job.Error, "disperser unavailable")
+	require.Empty(t, job.Commitment)
+
+	// The terminal status is persisted, without the payload. Stopping waits for it to be written.
+	manager.Stop()
+	persisted, err := (&jobStore{dir: dir}).loadAll()
+	require.NoError(t, err)
+	require.Len(t, persisted, 1)
+	require.Equal(t, StatusFailed, persisted[0].Status)
+	require.Nil(t, persisted[0].Payload)
+}
+
+func TestInFlightJobsResumedAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	const jobCount = 4
+
+	// The first manager is stopped while a job is being dispersed and the others are queued behind it.
+	dispersing := make(chan struct{}, jobCount)
+	blockingCertMgr := &fakeCertManager{put: func(ctx context.Context, _ []byte) (*certs.VersionedCert, error) {
+		dispersing <- struct{}{}
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}}
+	manager, err := NewManager(
+		t.Context(), testLogger, blockingCertMgr, Config{Dir: dir, Workers: 1, Retention: time.Hour, MaxQueuedJobs: 16})
+	require.NoError(t, err)
+	submitted := make([]Job, 0, jobCount)
+	payloads := make([][]byte, 0, jobCount)
+	for i := range jobCount {
+		payload := []byte(fmt.Sprintf("payload %d", i))
+		job, err := manager.Submit(payload, commitments.StandardCommitmentMode)
+		require.NoError(t, err)
+		submitted = append(submitted, job)
+		payloads = append(payloads, payload)
+	}
+	<-dispersing
+	manager.Stop()
+
+	for _, s := range submitted {
+		job, err := manager.GetJob(s.ID)
+		require.NoError(t, err)
+		require.False(t, job.Status.IsTerminal())
+	}
+
+	// All jobs are dispersed again by the next manager.
+	var puts atomic.Int32
+	certMgr := &fakeCertManager{put: func(_ context.Context, payload []byte) (*certs.VersionedCert, error) {
+		puts.Add(1)
+		return certFor(payload), nil
+	}}
+	restarted := newTestManager(t, dir, certMgr)
+	for i, s := range submitted {
+		job := waitForTerminal(t, restarted, s.ID)
+		require.Equal(t, StatusCertified, job.Status, job.Error)
+		require.Equal(t, commitments.NewStandardCommitment(*certFor(payloads[i])).Encode(), []byte(job.Commitment))
+		require.Equal(t, s.CreatedAt.UnixNano(), job.CreatedAt.UnixNano())
+	}
+	require.Equal(t, int32(jobCount), puts.Load())
+	// Stopping waits for the final statuses to be persisted.
+	restarted.Stop()
+
+	// Terminal jobs are loaded, but not dispersed again.
+	restartedAgain := newTestManager(t, dir, certMgr)
+	for _, s := range submitted {
+		job, err := restartedAgain.GetJob(s.ID)
+		require.NoError(t, err)
+		require.Equal(t, StatusCertified, job.Status)
+	}
+	require.Equal(t, int32(jobCount), puts.Load())
+}
+
+func TestExpiredJobsPruned(t *testing.T) {
+	certMgr := &fakeCertManager{put: func(_ context.Context, payload []byte) (*certs.VersionedCert, error) {
+		return certFor(payload), nil
+	}}
+	dir := t.TempDir()
+	manager, err := NewManager(
+		t.Context(), testLogger, certMgr, Config{Dir: dir, Workers: 1, Retention: 50 * time.Millisecond, MaxQueuedJobs: 16})
+	require.NoError(t, err)
+	defer manager.Stop()
+
+	submitted, err := manager.Submit([]byte("payload"), commitments.StandardCommitmentMode)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		_, err := manager.GetJob(submitted.ID)
+		return errors.Is(err, ErrJobNotFound)
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoFileExists(t, filepath.Join(dir, submitted.ID+jobFileExtension))
+}
+
+func TestConfigCheck(t *testing.T) {
+	require.NoError(t, Config{}.Check())
+	require.NoError(t, Config{Dir: "jobs", Workers: 1, Retention: time.Hour, MaxQueuedJobs: 1}.Check())
+	require.Error(t, Config{Dir: "jobs", Workers: 0, Retention: time.Hour, MaxQueuedJobs: 1}.Check())
+	require.Error(t, Config{Dir: "jobs", Workers: 1, Retention: 0, MaxQueuedJobs: 1}.Check())
+	require.Error(t, Config{Dir: "jobs", Workers: 1, Retention: time.Hour, MaxQueuedJobs: 0}.Check())
+}
+
+func TestSubmitQueueFull(t *testing.T) {
+	unblock := make(chan struct{})
+	certMgr := &fakeCertManager{put: func(ctx context.Context, payload []byte) (*certs.VersionedCert, error) {
+		select {
+		case <-unblock:
+			return certFor(payload), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}}
+	dir := t.TempDir()
+	manager, err := NewManager(
+		t.Context(), testLogger, certMgr, Config{Dir: dir, Workers: 1, Retention: time.Hour, MaxQueuedJobs: 2})
+	require.NoError(t, err)
+	t.Cleanup(manager.Stop)
+
+	// One job is dispersing and the other is queued behind it, which reaches the limit.
+	first, err := manager.Submit([]byte("first"), commitments.StandardCommitmentMode)
+	require.NoError(t, err)
+	_, err = manager.Submit([]byte("second"), commitments.StandardCommitmentMode)
+	require.NoError(t, err)
+
+	_, err = manager.Submit([]byte("third"), commitments.StandardCommitmentMode)
+	require.ErrorIs(t, err, ErrQueueFull)
+	require.ErrorIs(t, err, &api.ErrorFailover{})
+
+	// Rejected jobs aren't persisted.
+	persisted, err := (&jobStore{dir: dir}).loadAll()
+	require.NoError(t, err)
+	require.Len(t, persisted, 2)
+
+	// Jobs are accepted again once in flight jobs complete.
+	close(unblock)
+	job := waitForTerminal(t, manager, first.ID)
+	require.Equal(t, StatusCertified, job.Status, job.Error)
+	require.Eventually(t, func() bool {
+		_, err = manager.Submit([]byte("third"), commitments.StandardCommitmentMode)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestSubmitAfterStop(t *testing.T) {
+	certMgr := &fakeCertManager{put: func(_ context.Context, payload []byte) (*certs.VersionedCert, error) {
+		return certFor(payload), nil
+	}}
+	manager := newTestManager(t, t.TempDir(), certMgr)
+	manager.Stop()
+
+	_, err := manager.Submit([]byte("payload"), commitments.StandardCommitmentMode)
+	require.ErrorIs(t, err, ErrStopped)
+}
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+)
+
+// batchVersion0 is the only supported batch encoding. A version 0 batch is laid out as:
+//
+//	[0x00 | uint32 number of entries | uint32 length of each entry...] + [entry 0 | entry 1 | ...]
+//
+// All integers are big-endian. Entries are concatenated without padding, directly after the index header, so the
+// offset of each entry can be derived from the lengths of the entries preceding it.
+const batchVersion0 = byte(0x00)
+
+// batchHeaderLen returns the length of the index header of a batch containing the given number of entries.
+func batchHeaderLen(numEntries int) int {
+	return 1 + 4 + 4*numEntries
+}
+
+// encodeBatch packs payloads into a single batch, and returns the batch along with the location of each payload
+// within it.
+func encodeBatch(payloads [][]byte) ([]byte, []common.BatchEntry) {
+	headerLen := batchHeaderLen(len(payloads))
+	size := headerLen
+	for _, payload := range payloads {
+		size += len(payload)
+	}
+
+	batch := make([]byte, headerLen, size)
+	batch[0] = batchVersion0
+	// #nosec G115 - the number of entries is bounded by the max batch size
+	binary.BigEndian.PutUint32(batch[1:5], uint32(len(payloads)))
+
+	entries := make([]common.BatchEntry, len(payloads))
+	for i, payload := range payloads {
+		// #nosec G115 - batches are bounded by the max blob size, which is far below 4GiB
+		entries[i] = common.BatchEntry{Offset: uint32(len(batch)), Length: uint32(len(payload))}
+		binary.BigEndian.PutUint32(batch[5+4*i:9+4*i], entries[i].Length)
+		batch = append(batch, payload...)
+	}
+
+	return batch, entries
+}
+
+// extractBatchEntry returns the payload located by entry within the batch. The entry must match one of the entries
+// recorded in the batch's index header, so that a commitment can't be crafted to return arbitrary bytes of the batch.
+func extractBatchEntry(batch []byte, entry common.BatchEntry) ([]byte, error) {
+	if len(batch) < batchHeaderLen(0) {
+		return nil, fmt.Errorf("batch of %d bytes is too short to contain an index header", len(batch))
+	}
+	if batch[0] != batchVersion0 {
+		return nil, fmt.Errorf("unsupported batch version: %d", batch[0])
+	}
+
+	numEntries := binary.BigEndian.Uint32(batch[1:5])
+	if uint64(numEntries) > uint64(len(batch)-batchHeaderLen(0))/4 {
+		return nil, fmt.Errorf("batch claims %d entries, which don't fit in %d bytes", numEntries, len(batch))
+	}
+
+	offset := uint64(batchHeaderLen(int(numEntries)))
+	for i := uint64(0); i < uint64(numEntries); i++ {
+		length := uint64(binary.BigEndian.Uint32(batch[5+4*i : 9+4*i]))
+		if offset+length > uint64(len(batch)) {
+			return nil, fmt.Errorf("batch entry %d at offset %d with length %d exceeds batch length %d",
+				i, offset, length, len(batch))
+		}
+		if offset == uint64(entry.Offset) && length == uint64(entry.Length) {
+			return batch[offset : offset+length], nil
+		}
+		offset += length
+	}
+
+	return nil, fmt.Errorf("batch does not contain an entry at offset %d with length %d", entry.Offset, entry.Length)
+}
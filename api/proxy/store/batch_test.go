@@ -0,0 +1,44 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeBatchRoundTrip(t *testing.T) {
+	payloads := [][]byte{[]byte("first"), {}, []byte("third payload")}
+
+	batch, entries := encodeBatch(payloads)
+	require.Len(t, entries, len(payloads))
+	require.Equal(t, batchHeaderLen(len(payloads))+len("first")+len("third payload"), len(batch))
+
+	for i, payload := range payloads {
+		extracted, err := extractBatchEntry(batch, entries[i])
+		require.NoError(t, err)
+		require.Equal(t, payload, extracted)
+	}
+}
+
+func TestExtractBatchEntryErrors(t *testing.T) {
+	batch, entries := encodeBatch([][]byte{[]byte("first"), []byte("second")})
+
+	// Entries must match the index header, even if they are within the bounds of the batch.
+	_, err := extractBatchEntry(batch, common.BatchEntry{Offset: entries[0].Offset, Length: 2})
+	require.Error(t, err)
+	_, err = extractBatchEntry(batch, common.BatchEntry{Offset: 0, Length: entries[0].Length})
+	require.Error(t, err)
+	_, err = extractBatchEntry(batch, common.BatchEntry{Offset: entries[1].Offset, Length: 1000})
+	require.Error(t, err)
+
+	// Malformed batches are rejected.
+	_, err = extractBatchEntry([]byte{0x00}, entries[0])
+	require.Error(t, err)
+	_, err = extractBatchEntry(append([]byte{0x01}, batch[1:]...), entries[0])
+	require.Error(t, err)
+	_, err = extractBatchEntry(batch[:len(batch)-1], entries[1])
+	require.Error(t, err)
+	_, err = extractBatchEntry([]byte{0x00, 0xff, 0xff, 0xff, 0xff}, entries[0])
+	require.Error(t, err)
+}
@@ -0,0 +1,207 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// BatchConfig configures the aggregation of payloads into batches for [EigenDAManager.PutBatched].
+type BatchConfig struct {
+	// Payloads that arrive within this window of the first payload of a batch are dispersed together.
+	// Zero disables batching.
+	Window time.Duration
+	// A batch is dispersed as soon as it reaches this size, even if the window hasn't elapsed. The size includes the
+	// batch index header (see [encodeBatch]), so the encoded batch never exceeds it unless a single payload does.
+	MaxSizeBytes uint64
+}
+
+// Enabled returns true if batching is enabled.
+func (cfg BatchConfig) Enabled() bool {
+	return cfg.Window > 0
+}
+
+// pendingBatch is a batch that payloads are being added to, or that is being dispersed.
+type pendingBatch struct {
+	serializationType coretypes.CertSerializationType
+	payloads          [][]byte
+	// the sum of the lengths of the payloads, excluding the batch index header
+	payloadBytes uint64
+	timer        *time.Timer
+
+	// closed once the batch has been dispersed, after which the fields below are set
+	done chan struct{}
+	// the cert, entry and error resulting from the dispersal of each payload, indexed like payloads
+	certs   []*certs.VersionedCert
+	entries []common.BatchEntry
+	errs    []error
+}
+
+// encodedSize returns the size of the batch once encoded, including its index header, if additionalPayloads more
+// payloads with a total length of additionalPayloadBytes were added to it.
+func (batch *pendingBatch) encodedSize(additionalPayloads int, additionalPayloadBytes uint64) uint64 {
+	return uint64(batchHeaderLen(len(batch.payloads)+additionalPayloads)) + batch.payloadBytes + additionalPayloadBytes
+}
+
+// batcher aggregates payloads that arrive within a time window into a single batch, which is dispersed as one blob.
+//
+// If the dispersal of a batch fails, each of its payloads is retried individually in a batch of its own, so that a
+// failure doesn't propagate to every caller that happened to share the batch. Only the callers whose individual
+// retry also fails receive an error.
+type batcher struct {
+	log    logging.Logger
+	config BatchConfig
+	// canceled on shutdown, which interrupts batches that are being dispersed
+	ctx context.Context
+	// bounds each dispersal of a batch
+	putTimeout time.Duration
+
+	// disperses an encoded batch and returns the resulting cert
+	disperse func(
+		ctx context.Context, batch []byte, serializationType coretypes.CertSerializationType,
+	) (*certs.VersionedCert, error)
+
+	mu sync.Mutex
+	// the batch currently accepting payloads, for each serialization type
+	pending map[coretypes.CertSerializationType]*pendingBatch
+}
+
+func newBatcher(
+	ctx context.Context,
+	log logging.Logger,
+	config BatchConfig,
+	disperse func(
+		ctx context.Context, batch []byte, serializationType coretypes.CertSerializationType,
+	) (*certs.VersionedCert, error),
+) *batcher {
+	return &batcher{
+		log:        log,
+		config:     config,
+		ctx:        ctx,
+		putTimeout: MaxPutDuration,
+		disperse:   disperse,
+		pending:    make(map[coretypes.CertSerializationType]*pendingBatch),
+	}
+}
+
+// add adds a payload to the current batch, and blocks until that batch has been dispersed. Returns the cert of the
+// batch, and the location of the payload within it.
+func (b *batcher) add(
+	ctx context.Context, payload []byte, serializationType coretypes.CertSerializationType,
+) (*certs.VersionedCert, common.BatchEntry, error) {
+	b.mu.Lock()
+	batch := b.pending[serializationType]
+	if batch != nil && batch.encodedSize(1, uint64(len(payload))) > b.config.MaxSizeBytes {
+		// the payload doesn't fit in the current batch, so send the current batch off and start a new one
+		b.sealLocked(batch)
+		batch = nil
+	}
+	if batch == nil {
+		batch = &pendingBatch{
+			serializationType: serializationType,
+			done:              make(chan struct{}),
+		}
+		b.pending[serializationType] = batch
+		sealTarget := batch
+		batch.timer = time.AfterFunc(b.config.Window, func() { b.seal(sealTarget) })
+	}
+	index := len(batch.payloads)
+	batch.payloads = append(batch.payloads, payload)
+	batch.payloadBytes += uint64(len(payload))
+	if batch.encodedSize(0, 0) >= b.config.MaxSizeBytes {
+		b.sealLocked(batch)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		// The payload stays in the batch, which is still dispersed for the benefit of the other callers.
+		return nil, common.BatchEntry{}, fmt.Errorf("waiting for batch dispersal: %w", ctx.Err())
+	}
+
+	if batch.errs[index] != nil {
+		return nil, common.BatchEntry{}, batch.errs[index]
+	}
+	return batch.certs[index], batch.entries[index], nil
+}
+
+// seal stops the batch from accepting more payloads and disperses it, unless it has already been sealed.
+func (b *batcher) seal(batch *pendingBatch) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sealLocked(batch)
+}
+
+// sealLocked is the same as seal, but must be called with the lock held.
+func (b *batcher) sealLocked(batch *pendingBatch) {
+	if b.pending[batch.serializationType] != batch {
+		// already sealed
+		return
+	}
+	delete(b.pending, batch.serializationType)
+	batch.timer.Stop()
+
+	go func() {
+		defer close(batch.done)
+		numPayloads := len(batch.payloads)
+		batch.certs = make([]*certs.VersionedCert, numPayloads)
+		batch.errs = make([]error, numPayloads)
+
+		encodedBatch, entries := encodeBatch(batch.payloads)
+		batch.entries = entries
+		b.log.Debug("Dispersing batch", "payloads", numPayloads, "sizeBytes", len(encodedBatch))
+
+		cert, err := b.disperseBatch(encodedBatch, batch.serializationType)
+		if err == nil {
+			for i := range batch.certs {
+				batch.certs[i] = cert
+			}
+			return
+		}
+		if numPayloads == 1 {
+			batch.errs[0] = fmt.Errorf("disperse batch of 1 payload: %w", err)
+			return
+		}
+
+		b.log.Warn("Batch dispersal failed, retrying its payloads individually",
+			"payloads", numPayloads, "err", err)
+		var wg sync.WaitGroup
+		for i, payload := range batch.payloads {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				batch.certs[i], batch.entries[i], batch.errs[i] = b.disperseSingle(payload, batch.serializationType)
+			}()
+		}
+		wg.Wait()
+	}()
+}
+
+// disperseSingle disperses a payload in a batch of its own.
+func (b *batcher) disperseSingle(
+	payload []byte, serializationType coretypes.CertSerializationType,
+) (*certs.VersionedCert, common.BatchEntry, error) {
+	encodedBatch, entries := encodeBatch([][]byte{payload})
+	cert, err := b.disperseBatch(encodedBatch, serializationType)
+	if err != nil {
+		return nil, common.BatchEntry{}, fmt.Errorf("disperse payload individually after batch failure: %w", err)
+	}
+	return cert, entries[0], nil
+}
+
+// disperseBatch disperses an encoded batch. The batch is shared by many requests, so its dispersal isn't bound to any
+// single request's context. Instead, it is bounded by the put timeout, and interrupted by shutdown.
+func (b *batcher) disperseBatch(
+	encodedBatch []byte, serializationType coretypes.CertSerializationType,
+) (*certs.VersionedCert, error) {
+	ctx, cancel := context.WithTimeout(b.ctx, b.putTimeout)
+	defer cancel()
+	return b.disperse(ctx, encodedBatch, serializationType)
+}
@@ -0,0 +1,236 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+// fakeDisperser records dispersed batches, and returns a cert containing the index of the batch.
+type fakeDisperser struct {
+	mu      sync.Mutex
+	batches [][]byte
+	err     error
+	// if set, only batches containing these bytes fail with err
+	failOn []byte
+}
+
+func (d *fakeDisperser) disperse(
+	_ context.Context, batch []byte, _ coretypes.CertSerializationType,
+) (*certs.VersionedCert, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.err != nil && (d.failOn == nil || bytes.Contains(batch, d.failOn)) {
+		return nil, d.err
+	}
+	d.batches = append(d.batches, batch)
+	return certs.NewVersionedCert([]byte(fmt.Sprintf("batch-%d", len(d.batches)-1)), certs.V2VersionByte), nil
+}
+
+func (d *fakeDisperser) batch(cert *certs.VersionedCert) []byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var index int
+	_, err := fmt.Sscanf(string(cert.SerializedCert), "batch-%d", &index)
+	if err != nil {
+		panic(err)
+	}
+	return d.batches[index]
+}
+
+func TestBatcherAggregatesWithinWindow(t *testing.T) {
+	disperser := &fakeDisperser{}
+	b := newBatcher(
+		t.Context(), testLogger, BatchConfig{Window: 100 * time.Millisecond, MaxSizeBytes: 1 << 20}, disperser.disperse)
+
+	const numPayloads = 10
+	certsByPayload := make([]*certs.VersionedCert, numPayloads)
+	payloads := make([][]byte, numPayloads)
+	var wg sync.WaitGroup
+	for i := 0; i < numPayloads; i++ {
+		payloads[i] = []byte(fmt.Sprintf("payload %d", i))
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cert, entry, err := b.add(t.Context(), payloads[i], coretypes.CertSerializationRLP)
+			if !assert.NoError(t, err) {
+				return
+			}
+			certsByPayload[i] = cert
+
+			extracted, err := extractBatchEntry(disperser.batch(cert), entry)
+			assert.NoError(t, err)
+			assert.Equal(t, payloads[i], extracted)
+		}(i)
+	}
+	wg.Wait()
+
+	// All payloads arrived within the window, so they were dispersed in a single batch.
+	require.Len(t, disperser.batches, 1)
+	for _, cert := range certsByPayload {
+		require.Equal(t, certsByPayload[0], cert)
+	}
+}
+
+func TestBatcherSealsWhenFull(t *testing.T) {
+	disperser := &fakeDisperser{}
+	// The window is long enough that the test would time out if batches were only sealed by the window.
+	b := newBatcher(t.Context(), testLogger, BatchConfig{Window: time.Hour, MaxSizeBytes: 10}, disperser.disperse)
+
+	// Reaching the max size seals the batch immediately.
+	cert, entry, err := b.add(t.Context(), make([]byte, 10), coretypes.CertSerializationRLP)
+	require.NoError(t, err)
+	extracted, err := extractBatchEntry(disperser.batch(cert), entry)
+	require.NoError(t, err)
+	require.Equal(t, make([]byte, 10), extracted)
+
+	// Payloads larger than the max size are dispersed in a batch of their own.
+	_, _, err = b.add(t.Context(), make([]byte, 100), coretypes.CertSerializationRLP)
+	require.NoError(t, err)
+	require.Len(t, disperser.batches, 2)
+}
+
+func TestBatcherCountsIndexHeader(t *testing.T) {
+	disperser := &fakeDisperser{}
+	const maxSize = 20
+	b := newBatcher(t.Context(), testLogger, BatchConfig{Window: time.Hour, MaxSizeBytes: maxSize}, disperser.disperse)
+
+	// A 10 byte payload encodes to a 19 byte batch, which leaves room for more payloads.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _, err := b.add(t.Context(), make([]byte, 10), coretypes.CertSerializationRLP)
+		assert.NoError(t, err)
+	}()
+	require.Eventually(t, func() bool {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return b.pending[coretypes.CertSerializationRLP] != nil
+	}, time.Second, time.Millisecond)
+
+	// The payloads only fit in the max size without the index header, so they are dispersed in separate batches.
+	// An 11 byte payload encodes to a 20 byte batch, which is sealed immediately.
+	_, _, err := b.add(t.Context(), make([]byte, 11), coretypes.CertSerializationRLP)
+	require.NoError(t, err)
+	wg.Wait()
+
+	require.Len(t, disperser.batches, 2)
+	for _, batch := range disperser.batches {
+		require.LessOrEqual(t, len(batch), maxSize)
+	}
+}
+
+func TestBatcherSeparatesSerializationTypes(t *testing.T) {
+	disperser := &fakeDisperser{}
+	b := newBatcher(
+		t.Context(), testLogger, BatchConfig{Window: 50 * time.Millisecond, MaxSizeBytes: 1 << 20}, disperser.disperse)
+
+	var wg sync.WaitGroup
+	for _, serializationType := range []coretypes.CertSerializationType{
+		coretypes.CertSerializationRLP, coretypes.CertSerializationABI,
+	} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := b.add(t.Context(), []byte("payload"), serializationType)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, disperser.batches, 2)
+}
+
+func TestBatcherErrors(t *testing.T) {
+	disperser := &fakeDisperser{err: errors.New("dispersal failed")}
+	b := newBatcher(
+		t.Context(), testLogger, BatchConfig{Window: 10 * time.Millisecond, MaxSizeBytes: 1 << 20}, disperser.disperse)
+
+	// Dispersal errors are returned to the caller.
+	_, _, err := b.add(t.Context(), []byte("payload"), coretypes.CertSerializationRLP)
+	require.ErrorContains(t, err, "dispersal failed")
+
+	// Callers stop waiting when their context is cancelled.
+	b = newBatcher(t.Context(), testLogger, BatchConfig{Window: time.Hour, MaxSizeBytes: 1 << 20}, disperser.disperse)
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err = b.add(ctx, []byte("payload"), coretypes.CertSerializationRLP)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestBatcherInterruptsStuckDispersals(t *testing.T) {
+	// The disperser never returns on its own.
+	stuckDisperse := func(
+		ctx context.Context, _ []byte, _ coretypes.CertSerializationType,
+	) (*certs.VersionedCert, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	// Shutdown interrupts the dispersal.
+	lifecycleCtx, shutdown := context.WithCancel(t.Context())
+	b := newBatcher(
+		lifecycleCtx, testLogger, BatchConfig{Window: 10 * time.Millisecond, MaxSizeBytes: 1 << 20}, stuckDisperse)
+	time.AfterFunc(50*time.Millisecond, shutdown)
+	_, _, err := b.add(t.Context(), []byte("payload"), coretypes.CertSerializationRLP)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// So does the put timeout.
+	b = newBatcher(
+		t.Context(), testLogger, BatchConfig{Window: 10 * time.Millisecond, MaxSizeBytes: 1 << 20}, stuckDisperse)
+	b.putTimeout = 50 * time.Millisecond
+	_, _, err = b.add(t.Context(), []byte("payload"), coretypes.CertSerializationRLP)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestBatcherRetriesPayloadsIndividually(t *testing.T) {
+	// Only batches containing the bad payload fail to disperse.
+	disperser := &fakeDisperser{err: errors.New("dispersal failed"), failOn: []byte("bad")}
+	b := newBatcher(
+		t.Context(), testLogger, BatchConfig{Window: 100 * time.Millisecond, MaxSizeBytes: 1 << 20}, disperser.disperse)
+
+	payloads := [][]byte{[]byte("good 0"), []byte("bad"), []byte("good 1"), []byte("good 2")}
+	errs := make([]error, len(payloads))
+	var wg sync.WaitGroup
+	for i, payload := range payloads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var cert *certs.VersionedCert
+			var entry common.BatchEntry
+			cert, entry, errs[i] = b.add(t.Context(), payload, coretypes.CertSerializationRLP)
+			if errs[i] != nil {
+				return
+			}
+			extracted, err := extractBatchEntry(disperser.batch(cert), entry)
+			assert.NoError(t, err)
+			assert.Equal(t, payload, extracted)
+		}()
+	}
+	wg.Wait()
+
+	// The failure of the shared batch only propagates to the caller whose payload also fails on its own.
+	for i, err := range errs {
+		if i == 1 {
+			require.ErrorContains(t, err, "dispersal failed")
+		} else {
+			require.NoError(t, err, "payload %d", i)
+		}
+	}
+	require.Len(t, disperser.batches, 3)
+}
@@ -3,6 +3,7 @@ package builder
 import (
 	"encoding/json"
 	"fmt"
+	"path"
 	"slices"
 	"time"
 
@@ -11,6 +12,8 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/store"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/littdb"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/redis"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
 	"github.com/urfave/cli/v2"
 )
@@ -27,7 +30,9 @@ type Config struct {
 	MemstoreEnabled bool
 
 	// secondary storage cfgs
-	S3Config s3.Config
+	S3Config     s3.Config
+	RedisConfig  redis.Config
+	LittDBConfig littdb.Config
 
 	// eth rpc retry count and delay
 	RetryCount int
@@ -69,12 +74,19 @@ func ReadConfig(ctx *cli.Context) (Config, error) {
 		return Config{}, fmt.Errorf("read memstore config: %w", err)
 	}
 
+	littDBConfig, err := littdb.ReadConfig(ctx)
+	if err != nil {
+		return Config{}, fmt.Errorf("read littdb config: %w", err)
+	}
+
 	cfg := Config{
 		StoreConfig:     storeConfig,
 		ClientConfigV2:  clientConfigV2,
 		MemstoreConfig:  memstoreConfig,
 		MemstoreEnabled: ctx.Bool(memstore.EnabledFlagName),
 		S3Config:        s3.ReadConfig(ctx),
+		RedisConfig:     redis.ReadConfig(ctx),
+		LittDBConfig:    littDBConfig,
 		RetryCount:      ctx.Int(eigendaflags_v2.EthRPCRetryCountFlagName),
 		RetryDelay:      ctx.Duration(eigendaflags_v2.EthRPCRetryDelayIncrementFlagName),
 	}
@@ -82,6 +94,20 @@ func ReadConfig(ctx *cli.Context) (Config, error) {
 	return cfg, nil
 }
 
+// ForNetworkProfile returns the config used to build the managers of the additional network profile with the given
+// name, from the config of the default network. Besides the EigenDA client config, the S3 and Redis keys of the
+// profile are namespaced by its name, so that networks sharing a bucket or a Redis instance don't collide. LittDB
+// can't be opened twice by the same process, and neither can the memstore persistence dir, so both are rejected by
+// the AppConfig check when network profiles are configured. The rest of the memstore config is shared by all
+// networks.
+func (cfg *Config) ForNetworkProfile(name string, clientConfigV2 common.ClientConfigV2) Config {
+	profileConfig := *cfg
+	profileConfig.ClientConfigV2 = clientConfigV2
+	profileConfig.S3Config.Path = path.Join(cfg.S3Config.Path, name)
+	profileConfig.RedisConfig.KeyPrefix = cfg.RedisConfig.KeyPrefix + name + ":"
+	return profileConfig
+}
+
 // Check ... verifies that configuration values are adequately set
 func (cfg *Config) Check() error {
 	v1Enabled := slices.Contains(cfg.StoreConfig.BackendsToEnable, common.V1EigenDABackend)
@@ -106,6 +132,20 @@ func (cfg *Config) Check() error {
 		}
 	}
 
+	for _, target := range slices.Concat(cfg.StoreConfig.CacheTargets, cfg.StoreConfig.FallbackTargets) {
+		switch common.StringToBackendType(target) {
+		case common.RedisBackendType:
+			if cfg.RedisConfig.Endpoint == "" {
+				return fmt.Errorf("redis is used as a cache or fallback target, but redis endpoint is not set")
+			}
+		case common.LittDBBackendType:
+			if len(cfg.LittDBConfig.Paths) == 0 {
+				return fmt.Errorf("littdb is used as a cache or fallback target, but littdb paths are not set")
+			}
+		default:
+		}
+	}
+
 	return cfg.StoreConfig.Check()
 }
 
@@ -121,6 +161,9 @@ func (cfg *Config) ToString() (string, error) {
 	if configCopy.S3Config.AccessKeyID != "" {
 		configCopy.S3Config.AccessKeyID = redacted
 	}
+	if configCopy.RedisConfig.Password != "" {
+		configCopy.RedisConfig.Password = redacted
+	}
 
 	configJSON, err := json.MarshalIndent(configCopy, "", "  ")
 	if err != nil {
@@ -25,6 +25,8 @@ import (
 	memstore_v2 "github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/v2"
 	eigenda_v2 "github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/v2"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/littdb"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/redis"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
 	common_eigenda "github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
@@ -62,6 +64,8 @@ func BuildManagers(
 ) (*store.EigenDAManager, *store.KeccakManager, error) {
 	var err error
 	var s3Store *s3.Store
+	var redisStore *redis.Store
+	var littStore *littdb.Store
 	var eigenDAV2Store common.EigenDAV2Store
 
 	if config.S3Config.Bucket != "" {
@@ -72,6 +76,36 @@ func BuildManagers(
 		}
 	}
 
+	if config.RedisConfig.Endpoint != "" {
+		log.Info("Using Redis storage backend")
+		redisStore, err = redis.NewStore(ctx, config.RedisConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("new Redis store: %w", err)
+		}
+		// Release the Redis connection pool when the proxy shuts down.
+		go func() {
+			<-ctx.Done()
+			if err := redisStore.Close(); err != nil {
+				log.Error("Failed to close Redis store", "err", err)
+			}
+		}()
+	}
+
+	if len(config.LittDBConfig.Paths) > 0 {
+		log.Info("Using LittDB storage backend", "paths", config.LittDBConfig.Paths)
+		littStore, err = littdb.NewStore(log, config.LittDBConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("new LittDB store: %w", err)
+		}
+		// LittDB holds a lock on its directories until it is closed, so release it when the proxy shuts down.
+		go func() {
+			<-ctx.Done()
+			if err := littStore.Close(); err != nil {
+				log.Error("Failed to close LittDB store", "err", err)
+			}
+		}()
+	}
+
 	v1Enabled := slices.Contains(config.StoreConfig.BackendsToEnable, common.V1EigenDABackend)
 	v2Enabled := slices.Contains(config.StoreConfig.BackendsToEnable, common.V2EigenDABackend)
 
@@ -89,7 +123,8 @@ func BuildManagers(
 		log.Info("Building EigenDA v2 storage backend")
 		// kzgVerifier and encoder are only needed when validator retrieval is enabled
 		var kzgVerifier *kzgverifierv2.Verifier
-		if slices.Contains(config.ClientConfigV2.RetrieversToEnable, common.ValidatorRetrieverType) {
+		if slices.Contains(config.ClientConfigV2.RetrieversToEnable, common.ValidatorRetrieverType) ||
+			slices.Contains(config.ClientConfigV2.RetrieversToEnable, common.CompositeRetrieverType) {
 			kzgVerifier = kzgverifierv2.NewVerifierWithSRS(srs.GetG1SRS())
 		}
 		encoder, err := rsv2.NewEncoder(log, nil)
@@ -103,8 +138,8 @@ func BuildManagers(
 		}
 	}
 
-	fallbacks := buildSecondaries(config.StoreConfig.FallbackTargets, s3Store)
-	caches := buildSecondaries(config.StoreConfig.CacheTargets, s3Store)
+	fallbacks := buildSecondaries(config.StoreConfig.FallbackTargets, s3Store, redisStore, littStore)
+	caches := buildSecondaries(config.StoreConfig.CacheTargets, s3Store, redisStore, littStore)
 	secondary := secondary.NewSecondaryManager(
 		log,
 		metrics,
@@ -126,6 +161,8 @@ func BuildManagers(
 		"Created storage backends",
 		"eigenda_v2", eigenDAV2Store != nil,
 		"s3", s3Store != nil,
+		"redis", redisStore != nil,
+		"littdb", littStore != nil,
 		"read_fallback", len(fallbacks) > 0,
 		"caching", len(caches) > 0,
 		"async_secondary_writes", (secondary.Enabled() && config.StoreConfig.AsyncPutWorkers > 0),
@@ -133,10 +170,12 @@ func BuildManagers(
 	)
 
 	certMgr, err := store.NewEigenDAManager(
+		ctx,
 		eigenDAV2Store,
 		log,
 		secondary,
 		config.StoreConfig.DispersalBackend,
+		config.StoreConfig.Batch,
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("new eigenda manager: %w", err)
@@ -154,7 +193,9 @@ func BuildManagers(
 // failover or caching
 func buildSecondaries(
 	targets []string,
-	s3Store common.SecondaryStore,
+	s3Store *s3.Store,
+	redisStore *redis.Store,
+	littStore *littdb.Store,
 ) []common.SecondaryStore {
 	stores := make([]common.SecondaryStore, len(targets))
 
@@ -167,6 +208,18 @@ func buildSecondaries(
 			}
 			stores[i] = s3Store
 
+		case common.RedisBackendType:
+			if redisStore == nil {
+				panic(fmt.Sprintf("Redis backend not configured: %s", target))
+			}
+			stores[i] = redisStore
+
+		case common.LittDBBackendType:
+			if littStore == nil {
+				panic(fmt.Sprintf("LittDB backend not configured: %s", target))
+			}
+			stores[i] = littStore
+
 		default:
 			panic(fmt.Sprintf("Invalid backend target: %s", target))
 		}
@@ -209,7 +262,11 @@ func buildEigenDAV2Backend(
 	}
 
 	if config.MemstoreEnabled {
-		return memstore_v2.New(ctx, log, config.MemstoreConfig, kzgVerifier.G1SRS), nil
+		memStore, err := memstore_v2.New(ctx, log, config.MemstoreConfig, kzgVerifier.G1SRS)
+		if err != nil {
+			return nil, fmt.Errorf("new memstore: %w", err)
+		}
+		return memStore, nil
 	}
 
 	routerOrImmutableVerifierAddr := geth_common.HexToAddress(config.ClientConfigV2.EigenDACertVerifierOrRouterAddress)
@@ -328,6 +385,34 @@ func buildEigenDAV2Backend(
 				return nil, fmt.Errorf("build validator payload retriever: %w", err)
 			}
 			retrievers = append(retrievers, validatorPayloadRetriever)
+		case common.CompositeRetrieverType:
+			log.Info("Initializing composite payload retriever")
+			relayRegistryAddr, err := contractDirectory.GetContractAddress(ctx, directory.RelayRegistry)
+			if err != nil {
+				return nil, fmt.Errorf("get relay registry address: %w", err)
+			}
+			relayPayloadRetriever, err := buildRelayPayloadRetriever(
+				log, config.ClientConfigV2, ethClient, kzgVerifier.G1SRS, relayRegistryAddr, retrievalMetrics)
+			if err != nil {
+				return nil, fmt.Errorf("build relay payload retriever: %w", err)
+			}
+			validatorPayloadRetriever, err := buildValidatorPayloadRetriever(
+				log, config.ClientConfigV2, ethClient,
+				operatorStateRetrieverAddr, eigenDAServiceManagerAddr,
+				encoder, kzgVerifier, kzgVerifier.G1SRS, retrievalMetrics)
+			if err != nil {
+				return nil, fmt.Errorf("build validator payload retriever: %w", err)
+			}
+			compositePayloadRetriever, err := payloadretrieval.NewCompositePayloadRetriever(
+				log,
+				config.ClientConfigV2.CompositePayloadRetrieverCfg,
+				relayPayloadRetriever,
+				validatorPayloadRetriever,
+				retrievalMetrics)
+			if err != nil {
+				return nil, fmt.Errorf("new composite payload retriever: %w", err)
+			}
+			retrievers = append(retrievers, compositePayloadRetriever)
 		default:
 			return nil, fmt.Errorf("unknown retriever type: %s", retrieverType)
 		}
@@ -3,6 +3,7 @@ package store
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
 	"github.com/urfave/cli/v2"
@@ -16,6 +17,8 @@ var (
 	ConcurrentWriteThreads                = withFlagPrefix("concurrent-write-routines")
 	WriteOnCacheMissFlagName              = withFlagPrefix("write-on-cache-miss")
 	ErrorOnSecondaryInsertFailureFlagName = withFlagPrefix("error-on-secondary-insert-failure")
+	BatchWindowFlagName                   = withFlagPrefix("batch-window")
+	BatchMaxSizeFlagName                  = withFlagPrefix("batch-max-size")
 )
 
 func withFlagPrefix(s string) string {
@@ -86,6 +89,22 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			EnvVars:  withEnvPrefix(envPrefix, "ERROR_ON_SECONDARY_INSERT_FAILURE"),
 			Category: category,
 		},
+		&cli.DurationFlag{
+			Name: BatchWindowFlagName,
+			Usage: "Payloads POSTed with the batched commitment mode within this window of the first payload of a batch " +
+				"are dispersed together in a single blob. Only used when the 'batched' API is enabled.",
+			Value:    500 * time.Millisecond,
+			EnvVars:  withEnvPrefix(envPrefix, "BATCH_WINDOW"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: BatchMaxSizeFlagName,
+			Usage: "A batch is dispersed as soon as it reaches this size, including its index header, before the " +
+				"batch window elapses. Must not exceed the max payload size. Example units: '128KiB'.",
+			Value:    "128KiB",
+			EnvVars:  withEnvPrefix(envPrefix, "BATCH_MAX_SIZE"),
+			Category: category,
+		},
 	}
 
 	return flags
@@ -132,6 +151,12 @@ func ReadConfig(ctx *cli.Context) (Config, error) {
 		}
 	}
 
+	batchMaxSize := ctx.String(BatchMaxSizeFlagName)
+	batchMaxSizeBytes, err := common.ParseBytesAmount(batchMaxSize)
+	if err != nil {
+		return Config{}, fmt.Errorf("parse batch max size \"%v\": %w", batchMaxSize, err)
+	}
+
 	return Config{
 		BackendsToEnable:              backends,
 		DispersalBackend:              dispersalBackend,
@@ -140,5 +165,9 @@ func ReadConfig(ctx *cli.Context) (Config, error) {
 		CacheTargets:                  filteredCacheTargets,
 		WriteOnCacheMiss:              ctx.Bool(WriteOnCacheMissFlagName),
 		ErrorOnSecondaryInsertFailure: ctx.Bool(ErrorOnSecondaryInsertFailureFlagName),
+		Batch: BatchConfig{
+			Window:       ctx.Duration(BatchWindowFlagName),
+			MaxSizeBytes: batchMaxSizeBytes,
+		},
 	}, nil
 }
@@ -16,6 +16,9 @@ type Config struct {
 
 	WriteOnCacheMiss              bool
 	ErrorOnSecondaryInsertFailure bool
+
+	// Aggregation of payloads for the batched commitment mode
+	Batch BatchConfig
 }
 
 // checkTargets ... verifies that a backend target slice is constructed correctly
@@ -67,5 +70,12 @@ func (cfg *Config) Check() error {
 			"(i.e, storage.concurrent-write-routines must be 0)")
 	}
 
+	if cfg.Batch.Window < 0 {
+		return fmt.Errorf("batch window cannot be negative")
+	}
+	if cfg.Batch.Enabled() && cfg.Batch.MaxSizeBytes == 0 {
+		return fmt.Errorf("batch max size must be greater than 0 when batching is enabled")
+	}
+
 	return nil
 }
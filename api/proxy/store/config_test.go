@@ -2,6 +2,7 @@ package store
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -41,6 +42,15 @@ func TestConfigVerification(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("RedisCacheS3FallbackTargets", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.CacheTargets = []string{"redis"}
+		cfg.FallbackTargets = []string{"s3"}
+
+		err := cfg.Check()
+		require.NoError(t, err)
+	})
+
 	t.Run("DuplicateCacheTargets", func(t *testing.T) {
 		cfg := validCfg()
 		cfg.CacheTargets = []string{"s3", "s3"}
@@ -75,4 +85,20 @@ func TestConfigVerification(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "requires synchronous writes")
 	})
+
+	t.Run("BatchingWithoutMaxSize", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.Batch = BatchConfig{Window: time.Second}
+
+		err := cfg.Check()
+		require.Error(t, err)
+	})
+
+	t.Run("NegativeBatchWindow", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.Batch = BatchConfig{Window: -time.Second, MaxSizeBytes: 1024}
+
+		err := cfg.Check()
+		require.Error(t, err)
+	})
 }
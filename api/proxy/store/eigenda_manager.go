@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sync/atomic"
+	"time"
 
 	_ "github.com/Layr-Labs/eigenda/api/clients/v2"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
@@ -26,10 +27,20 @@ import (
 
 //go:generate mockgen -package mocks --destination ../test/mocks/eigen_da_manager.go . IEigenDAManager
 
+// MaxPutDuration bounds the time spent on a put, aligned with existing blob finalization times. It is used as the
+// write timeout of the REST server, and bounds the dispersal of batches, which aren't tied to a single request.
+const MaxPutDuration = 40 * time.Minute
+
 // IEigenDAManager handles EigenDA certificate operations
 type IEigenDAManager interface {
 	// See [EigenDAManager.Put]
 	Put(ctx context.Context, value []byte, serializationType coretypes.CertSerializationType) (*certs.VersionedCert, error)
+	// See [EigenDAManager.PutBatched]
+	PutBatched(
+		ctx context.Context,
+		value []byte,
+		serializationType coretypes.CertSerializationType,
+	) (*certs.VersionedCert, common.BatchEntry, error)
 	// See [EigenDAManager.Get]
 	Get(
 		ctx context.Context,
@@ -37,6 +48,13 @@ type IEigenDAManager interface {
 		serializationType coretypes.CertSerializationType,
 		opts common.GETOpts,
 	) ([]byte, error)
+	// See [EigenDAManager.VerifyCert]
+	VerifyCert(
+		ctx context.Context,
+		versionedCert *certs.VersionedCert,
+		serializationType coretypes.CertSerializationType,
+		l1InclusionBlockNum uint64,
+	) error
 	// See [EigenDAManager.SetDispersalBackend]
 	SetDispersalBackend(backend common.EigenDABackend)
 	// See [EigenDAManager.GetDispersalBackend]
@@ -52,16 +70,21 @@ type EigenDAManager struct {
 
 	// secondary storage backends (caching and fallbacks)
 	secondary secondary.ISecondary
+
+	// aggregates payloads for PutBatched, nil if batching is disabled
+	batcher *batcher
 }
 
 var _ IEigenDAManager = &EigenDAManager{}
 
-// NewEigenDAManager creates a new EigenDAManager
+// NewEigenDAManager creates a new EigenDAManager. Canceling ctx interrupts the dispersal of pending batches.
 func NewEigenDAManager(
+	ctx context.Context,
 	eigenDAV2 common.EigenDAV2Store,
 	l logging.Logger,
 	secondary secondary.ISecondary,
 	dispersalBackend common.EigenDABackend,
+	batchConfig BatchConfig,
 ) (*EigenDAManager, error) {
 	// Enforce invariants
 	if dispersalBackend == common.V2EigenDABackend && eigenDAV2 == nil {
@@ -78,6 +101,9 @@ func NewEigenDAManager(
 		secondary: secondary,
 	}
 	manager.dispersalBackend.Store(dispersalBackend)
+	if batchConfig.Enabled() {
+		manager.batcher = newBatcher(ctx, l, batchConfig, manager.Put)
+	}
 	return manager, nil
 }
 
@@ -92,6 +118,12 @@ func (m *EigenDAManager) GetDispersalBackend() common.EigenDABackend {
 	return backend
 }
 
+// EigenDAV2Store returns the store that EigenDA V2 certs are dispersed to and retrieved from,
+// e.g. to expose the memstore admin API when memstore is enabled.
+func (m *EigenDAManager) EigenDAV2Store() common.EigenDAV2Store {
+	return m.eigendaV2
+}
+
 // SetDispersalBackend sets which EigenDA backend to use for dispersal
 func (m *EigenDAManager) SetDispersalBackend(backend common.EigenDABackend) {
 	m.dispersalBackend.Store(backend)
@@ -100,6 +132,8 @@ func (m *EigenDAManager) SetDispersalBackend(backend common.EigenDABackend) {
 // Get fetches a value from a storage backend based on the (commitment mode, type).
 // It also validates the value retrieved and returns an error if the value is invalid.
 // If opts.ReturnEncodedPayload is true, it will return the encoded payload without decoding it.
+// If opts.BatchEntry is set, the cert is expected to refer to a batch created by [EigenDAManager.PutBatched],
+// and only the payload located by the entry is returned.
 func (m *EigenDAManager) Get(ctx context.Context,
 	versionedCert *certs.VersionedCert,
 	serializationType coretypes.CertSerializationType,
@@ -112,12 +146,57 @@ func (m *EigenDAManager) Get(ctx context.Context,
 		if m.eigendaV2 == nil {
 			return nil, errors.New("received EigenDAV2 cert but EigenDA V2 client is not initialized")
 		}
-		return m.getEigenDAV2(ctx, versionedCert, serializationType, opts)
+		if opts.BatchEntry == nil {
+			return m.getEigenDAV2(ctx, versionedCert, serializationType, opts)
+		}
+
+		// The encoded payload contains the whole batch, so there is no way to only return a single entry of it.
+		if opts.ReturnEncodedPayload {
+			return nil, errors.New("encoded payloads can't be returned for batched commitments")
+		}
+		batch, err := m.getEigenDAV2(ctx, versionedCert, serializationType, opts)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := extractBatchEntry(batch, *opts.BatchEntry)
+		if err != nil {
+			// The cert's payload can't contain the entry, so the commitment is invalid and must be dropped.
+			return nil, coretypes.ErrBlobDecodingFailedDerivationError.WithMessage(
+				fmt.Sprintf("extract payload from batch: %v", err))
+		}
+		return payload, nil
 	default:
 		return nil, fmt.Errorf("cert version unknown: %b", versionedCert.Version)
 	}
 }
 
+// VerifyCert verifies a cert the same way [EigenDAManager.Get] does before retrieving its payload, without
+// retrieving the payload. l1InclusionBlockNum is the L1 block at which the cert was included in the rollup's
+// batcher inbox, and 0 skips the recency check.
+// Invalid certs return a [coretypes.DerivationError], and other errors are internal errors.
+func (m *EigenDAManager) VerifyCert(
+	ctx context.Context,
+	versionedCert *certs.VersionedCert,
+	serializationType coretypes.CertSerializationType,
+	l1InclusionBlockNum uint64,
+) error {
+	switch versionedCert.Version {
+	case certs.V0VersionByte:
+		return errors.New("V1 backend has been removed, V0 certs are no longer supported")
+	case certs.V1VersionByte, certs.V2VersionByte, certs.V3VersionByte:
+		if m.eigendaV2 == nil {
+			return errors.New("received EigenDAV2 cert but EigenDA V2 client is not initialized")
+		}
+		err := m.eigendaV2.VerifyCert(ctx, versionedCert, serializationType, l1InclusionBlockNum)
+		if err != nil {
+			return fmt.Errorf("verify EigenDACert: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("cert version unknown: %b", versionedCert.Version)
+	}
+}
+
 // getEigenDAV2 will attempt to retrieve a blob for the given versionedCert
 // from cache, EigenDA V2 relays, EigenDA V2 validators, and fallback storage.
 func (m *EigenDAManager) getEigenDAV2(
@@ -214,6 +293,24 @@ func (m *EigenDAManager) Put(
 	return versionedCert, nil
 }
 
+// PutBatched ... adds a value to a batch of values that are dispersed together in a single blob.
+// Values that arrive within the configured batch window are aggregated, and this method blocks until the batch
+// containing the value has been dispersed. Returns the cert of the batch, along with the location of the value
+// within the batch, both of which are needed to retrieve the value with [EigenDAManager.Get].
+func (m *EigenDAManager) PutBatched(
+	ctx context.Context, value []byte, serializationType coretypes.CertSerializationType,
+) (*certs.VersionedCert, common.BatchEntry, error) {
+	if m.batcher == nil {
+		return nil, common.BatchEntry{}, errors.New("batched dispersal requested but batching is not enabled")
+	}
+
+	versionedCert, entry, err := m.batcher.add(ctx, value, serializationType)
+	if err != nil {
+		return nil, common.BatchEntry{}, fmt.Errorf("batched put: %w", err)
+	}
+	return versionedCert, entry, nil
+}
+
 // putToCorrectEigenDABackend ... disperses blob to EigenDA backend
 func (m *EigenDAManager) putToCorrectEigenDABackend(
 	ctx context.Context, value []byte, serializationType coretypes.CertSerializationType,
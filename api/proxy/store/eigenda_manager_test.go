@@ -0,0 +1,95 @@
+package store
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
+	memstore "github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/v2"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
+	"github.com/Layr-Labs/eigenda/encoding/v2/kzg"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestManager creates an EigenDAManager backed by a memstore, which computes commitments with an insecure SRS.
+func newTestManager(t *testing.T, batchConfig BatchConfig) *EigenDAManager {
+	var tau fr.Element
+	_, err := tau.SetRandom()
+	require.NoError(t, err)
+	g1SRS := make([]bn254.G1Affine, 1024)
+	var power fr.Element
+	power.SetOne()
+	for i := range g1SRS {
+		g1SRS[i].ScalarMultiplication(&kzg.GenG1, power.BigInt(new(big.Int)))
+		power.Mul(&power, &tau)
+	}
+
+	memConfig := memconfig.NewSafeConfig(memconfig.Config{MaxBlobSizeBytes: uint64(len(g1SRS)) * 32})
+	memStore, err := memstore.New(t.Context(), testLogger, memConfig, g1SRS)
+	require.NoError(t, err)
+	secondaryManager := secondary.NewSecondaryManager(testLogger, metrics.NoopMetrics, nil, nil, false, false)
+	manager, err := NewEigenDAManager(t.Context(), memStore, testLogger, secondaryManager, common.V2EigenDABackend, batchConfig)
+	require.NoError(t, err)
+	return manager
+}
+
+func TestPutBatchedGet(t *testing.T) {
+	manager := newTestManager(t, BatchConfig{Window: 100 * time.Millisecond, MaxSizeBytes: 1 << 20})
+
+	const numPayloads = 5
+	payloads := make([][]byte, numPayloads)
+	versionedCerts := make([]*certs.VersionedCert, numPayloads)
+	entries := make([]common.BatchEntry, numPayloads)
+	var wg sync.WaitGroup
+	for i := range payloads {
+		payloads[i] = []byte(fmt.Sprintf("rollup payload %d", i))
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			versionedCerts[i], entries[i], err = manager.PutBatched(
+				t.Context(), payloads[i], coretypes.CertSerializationRLP)
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, payload := range payloads {
+		// All payloads were dispersed in the same blob.
+		require.Equal(t, versionedCerts[0], versionedCerts[i])
+
+		retrieved, err := manager.Get(t.Context(), versionedCerts[i], coretypes.CertSerializationRLP,
+			common.GETOpts{BatchEntry: &entries[i]})
+		require.NoError(t, err)
+		require.Equal(t, payload, retrieved)
+	}
+
+	// Encoded payloads contain the entire batch, so they can't be returned for a single entry.
+	_, err := manager.Get(t.Context(), versionedCerts[0], coretypes.CertSerializationRLP,
+		common.GETOpts{BatchEntry: &entries[0], ReturnEncodedPayload: true})
+	require.Error(t, err)
+
+	// Entries that aren't in the batch are rejected with a derivation error, so the commitment is dropped.
+	_, err = manager.Get(t.Context(), versionedCerts[0], coretypes.CertSerializationRLP,
+		common.GETOpts{BatchEntry: &common.BatchEntry{Offset: 0, Length: 5}})
+	var derivationErr coretypes.DerivationError
+	require.ErrorAs(t, err, &derivationErr)
+	require.Equal(t, coretypes.ErrBlobDecodingFailedDerivationError.StatusCode, derivationErr.StatusCode)
+}
+
+func TestPutBatchedDisabled(t *testing.T) {
+	manager := newTestManager(t, BatchConfig{})
+
+	_, _, err := manager.PutBatched(t.Context(), []byte("payload"), coretypes.CertSerializationRLP)
+	require.Error(t, err)
+}
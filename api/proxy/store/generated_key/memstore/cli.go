@@ -15,6 +15,7 @@ var (
 	PutLatencyFlagName              = withFlagPrefix("put-latency")
 	GetLatencyFlagName              = withFlagPrefix("get-latency")
 	PutReturnsFailoverErrorFlagName = withFlagPrefix("put-returns-failover-error")
+	PersistenceDirFlagName          = withFlagPrefix("persistence-dir")
 )
 
 func withFlagPrefix(s string) string {
@@ -93,6 +94,14 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			EnvVars:  []string{withEnvPrefix(envPrefix, "PUT_RETURNS_FAILOVER_ERROR")},
 			Category: category,
 		},
+		&cli.StringFlag{
+			Name: PersistenceDirFlagName,
+			Usage: "Directory where memstore blobs are persisted, so that certs stay retrievable across restarts " +
+				"(e.g. for long-lived devnets). Blobs are only kept in memory if unset. Can't be used with network " +
+				"profiles.",
+			EnvVars:  []string{withEnvPrefix(envPrefix, "PERSISTENCE_DIR")},
+			Category: category,
+		},
 	}
 }
 
@@ -104,5 +113,6 @@ func ReadConfig(ctx *cli.Context, maxBlobSizeBytes uint64) (*memconfig.SafeConfi
 			PutLatency:              ctx.Duration(PutLatencyFlagName),
 			GetLatency:              ctx.Duration(GetLatencyFlagName),
 			PutReturnsFailoverError: ctx.Bool(PutReturnsFailoverErrorFlagName),
+			PersistenceDir:          ctx.String(PersistenceDirFlagName),
 		}), nil
 }
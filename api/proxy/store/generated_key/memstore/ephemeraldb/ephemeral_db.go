@@ -3,15 +3,21 @@ package ephemeraldb
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
 	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 const (
@@ -22,6 +28,7 @@ const (
 type payloadWithDerivationError struct {
 	payload         []byte
 	derivationError error // the underlying type is [coretypes.DerivationError]
+	insertedAt      time.Time
 }
 
 // DB ... An ephemeral && simple in-memory database used to emulate
@@ -35,10 +42,24 @@ type DB struct {
 	mu        sync.RWMutex
 	keyStarts map[string]time.Time                  // used for managing expiration
 	store     map[string]payloadWithDerivationError // db
+	// on-disk copy of the store, which is reloaded on startup. nil if the db is in-memory only, or once closed.
+	persistent kvstore.Store[[]byte]
+}
+
+// Entry is an entry of the db, as persisted to disk and exported by [DB.Export].
+type Entry struct {
+	Key     hexutil.Bytes `json:"key"`
+	Payload hexutil.Bytes `json:"payload,omitempty"`
+	// set instead of the payload if the entry was inserted while
+	// [memconfig.Config.OverwritePutWithDerivationError] was set
+	DerivationError *coretypes.DerivationError `json:"derivation_error,omitempty"`
+	InsertedAt      time.Time                  `json:"inserted_at"`
 }
 
 // New ... constructor
-func New(ctx context.Context, cfg *memconfig.SafeConfig, log logging.Logger) *DB {
+// If cfg has a persistence dir, the entries are also written to a LevelDB database in that dir, and entries
+// written by a previous process are loaded from it. The database is closed when ctx is done.
+func New(ctx context.Context, cfg *memconfig.SafeConfig, log logging.Logger) (*DB, error) {
 	db := &DB{
 		config:    cfg,
 		keyStarts: make(map[string]time.Time),
@@ -46,13 +67,110 @@ func New(ctx context.Context, cfg *memconfig.SafeConfig, log logging.Logger) *DB
 		log:       log,
 	}
 
+	if dir := cfg.PersistenceDir(); dir != "" {
+		persistent, err := leveldb.NewStore(log, dir, false, true, nil)
+		if err != nil {
+			return nil, fmt.Errorf("open memstore persistence dir %s: %w", dir, err)
+		}
+		db.persistent = persistent
+		loaded, err := db.load()
+		if err != nil {
+			_ = persistent.Shutdown()
+			return nil, fmt.Errorf("load persisted memstore entries: %w", err)
+		}
+		db.log.Info("ephemeral db persistence enabled.", "dir", dir, "loadedEntries", loaded)
+
+		go func() {
+			<-ctx.Done()
+			if err := db.Close(); err != nil {
+				db.log.Error("Failed to close ephemeral db", "err", err)
+			}
+		}()
+	}
+
 	// if no expiration set then blobs will be persisted indefinitely
 	if cfg.BlobExpiration() != 0 {
 		db.log.Info("ephemeral db expiration enabled for payload entries.", "time", cfg.BlobExpiration)
 		go db.pruningLoop(ctx)
 	}
 
-	return db
+	return db, nil
+}
+
+// Close ... closes the on-disk copy of the store, if any. Later insertions are only kept in memory.
+func (db *DB) Close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.persistent == nil {
+		return nil
+	}
+	err := db.persistent.Shutdown()
+	db.persistent = nil
+	if err != nil {
+		return fmt.Errorf("shutdown leveldb: %w", err)
+	}
+	return nil
+}
+
+// load ... loads the entries of the on-disk copy of the store into memory, returning the number of loaded entries.
+func (db *DB) load() (int, error) {
+	it, err := db.persistent.NewIterator(nil)
+	if err != nil {
+		return 0, fmt.Errorf("new iterator: %w", err)
+	}
+	defer it.Release()
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	loaded := 0
+	for it.Next() {
+		var entry Entry
+		err := json.Unmarshal(it.Value(), &entry)
+		if err != nil {
+			return 0, fmt.Errorf("unmarshal entry %s: %w", hex.EncodeToString(it.Key()), err)
+		}
+		db.setEntry(entry)
+		loaded++
+	}
+	if err := it.Error(); err != nil {
+		return 0, fmt.Errorf("iterate: %w", err)
+	}
+	return loaded, nil
+}
+
+// setEntry ... sets an entry in memory, overwriting any entry with the same key. db.mu must be held.
+func (db *DB) setEntry(entry Entry) {
+	strKey := string(entry.Key)
+	value := payloadWithDerivationError{payload: entry.Payload, insertedAt: entry.InsertedAt}
+	if entry.DerivationError != nil {
+		value = payloadWithDerivationError{derivationError: *entry.DerivationError, insertedAt: entry.InsertedAt}
+	}
+	db.store[strKey] = value
+
+	// add expiration if applicable
+	if db.config.BlobExpiration() > 0 {
+		db.keyStarts[strKey] = entry.InsertedAt
+	} else {
+		delete(db.keyStarts, strKey)
+	}
+}
+
+// persistEntry ... writes an entry to the on-disk copy of the store, if any. db.mu must be held.
+func (db *DB) persistEntry(entry Entry) error {
+	if db.persistent == nil {
+		return nil
+	}
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal entry: %w", err)
+	}
+	err = db.persistent.Put(entry.Key, value)
+	if err != nil {
+		return fmt.Errorf("persist entry: %w", err)
+	}
+	return nil
 }
 
 // InsertEntry ... inserts a value into the db provided a key
@@ -82,20 +200,82 @@ func (db *DB) InsertEntry(key []byte, value []byte) error {
 		return fmt.Errorf("payload key already exists in ephemeral db: %s", strKey)
 	}
 
+	entry := Entry{Key: key, InsertedAt: time.Now()}
 	if derivationError == nil {
-		db.store[strKey] = payloadWithDerivationError{payload: value}
+		entry.Payload = value
 	} else {
-		db.store[strKey] = payloadWithDerivationError{derivationError: derivationError}
+		// SetOverwritePutWithDerivationError only ever sets a [coretypes.DerivationError]
+		var typedDerivationError coretypes.DerivationError
+		if !errors.As(derivationError, &typedDerivationError) {
+			return fmt.Errorf("unexpected derivation error type: %w", derivationError)
+		}
+		entry.DerivationError = &typedDerivationError
 	}
 
-	// add expiration if applicable
-	if db.config.BlobExpiration() > 0 {
-		db.keyStarts[strKey] = time.Now()
+	err := db.persistEntry(entry)
+	if err != nil {
+		return err
 	}
+	db.setEntry(entry)
 
 	return nil
 }
 
+// Export ... writes all entries of the db to w, as a JSON array of [Entry].
+func (db *DB) Export(w io.Writer) error {
+	db.mu.RLock()
+	entries := make([]Entry, 0, len(db.store))
+	for strKey, value := range db.store {
+		entry := Entry{Key: []byte(strKey), Payload: value.payload, InsertedAt: value.insertedAt}
+		if value.derivationError != nil {
+			var derivationError coretypes.DerivationError
+			if errors.As(value.derivationError, &derivationError) {
+				entry.DerivationError = &derivationError
+			}
+		}
+		entries = append(entries, entry)
+	}
+	db.mu.RUnlock()
+
+	err := json.NewEncoder(w).Encode(entries)
+	if err != nil {
+		return fmt.Errorf("encode entries: %w", err)
+	}
+	return nil
+}
+
+// Import ... inserts the entries read from r, in the format written by [DB.Export], overwriting entries with the
+// same keys. Unlike InsertEntry, imports aren't affected by the fault injection knobs of the config.
+// Returns the number of imported entries.
+func (db *DB) Import(r io.Reader) (int, error) {
+	var entries []Entry
+	err := json.NewDecoder(r).Decode(&entries)
+	if err != nil {
+		return 0, fmt.Errorf("decode entries: %w", err)
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	now := time.Now()
+	for i := range entries {
+		if len(entries[i].Key) == 0 {
+			return 0, errors.New("entry with empty key")
+		}
+		if entries[i].InsertedAt.IsZero() {
+			entries[i].InsertedAt = now
+		}
+	}
+	for i, entry := range entries {
+		err := db.persistEntry(entry)
+		if err != nil {
+			return i, err
+		}
+		db.setEntry(entry)
+	}
+	return len(entries), nil
+}
+
 // FetchEntry ... looks up a value from the db provided a key
 func (db *DB) FetchEntry(key []byte) ([]byte, error) {
 	time.Sleep(db.config.LatencyGETRoute())
@@ -136,6 +316,14 @@ func (db *DB) pruneExpired() {
 
 	for commit, dur := range db.keyStarts {
 		if time.Since(dur) >= db.config.BlobExpiration() {
+			if db.persistent != nil {
+				err := db.persistent.Delete([]byte(commit))
+				if err != nil {
+					// retried on the next prune
+					db.log.Warn("failed to delete pruned blob from disk", "commit", commit, "err", err)
+					continue
+				}
+			}
 			delete(db.keyStarts, commit)
 			delete(db.store, commit)
 
@@ -1,6 +1,7 @@
 package ephemeraldb
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"testing"
@@ -34,11 +35,12 @@ func testConfig() *memconfig.SafeConfig {
 func TestGetSet(t *testing.T) {
 	t.Parallel()
 
-	db := New(t.Context(), testConfig(), testLogger)
+	db, err := New(t.Context(), testConfig(), testLogger)
+	require.NoError(t, err)
 
 	testKey := []byte("bland")
 	expected := []byte(testPreimage)
-	err := db.InsertEntry(testKey, expected)
+	err = db.InsertEntry(testKey, expected)
 	require.NoError(t, err)
 
 	actual, err := db.FetchEntry(testKey)
@@ -51,12 +53,13 @@ func TestExpiration(t *testing.T) {
 
 	cfg := testConfig()
 	cfg.SetBlobExpiration(10 * time.Millisecond)
-	db := New(t.Context(), cfg, testLogger)
+	db, err := New(t.Context(), cfg, testLogger)
+	require.NoError(t, err)
 
 	preimage := []byte(testPreimage)
 	testKey := []byte("bland")
 
-	err := db.InsertEntry(testKey, preimage)
+	err = db.InsertEntry(testKey, preimage)
 	require.NoError(t, err)
 
 	// sleep 1 second and verify that older blob entries are removed
@@ -75,13 +78,14 @@ func TestLatency(t *testing.T) {
 	config := testConfig()
 	config.SetLatencyPUTRoute(putLatency)
 	config.SetLatencyGETRoute(getLatency)
-	db := New(t.Context(), config, testLogger)
+	db, err := New(t.Context(), config, testLogger)
+	require.NoError(t, err)
 
 	preimage := []byte(testPreimage)
 	testKey := []byte("bland")
 
 	timeBeforePut := time.Now()
-	err := db.InsertEntry(testKey, preimage)
+	err = db.InsertEntry(testKey, preimage)
 	require.NoError(t, err)
 	require.GreaterOrEqual(t, time.Since(timeBeforePut), putLatency)
 
@@ -96,10 +100,11 @@ func TestPutReturnsFailoverErrorConfig(t *testing.T) {
 	t.Parallel()
 
 	config := testConfig()
-	db := New(t.Context(), config, testLogger)
+	db, err := New(t.Context(), config, testLogger)
+	require.NoError(t, err)
 	testKey := []byte("som-key")
 
-	err := db.InsertEntry(testKey, []byte("some-value"))
+	err = db.InsertEntry(testKey, []byte("some-value"))
 	require.NoError(t, err)
 
 	config.SetPUTReturnsFailoverError(true)
@@ -119,11 +124,12 @@ func TestOverwritePutWithDerivationError(t *testing.T) {
 	defer cancel()
 
 	config := testConfig()
-	db := New(ctx, config, testLogger)
+	db, err := New(ctx, config, testLogger)
+	require.NoError(t, err)
 	testKey := []byte("som-key")
 
 	// inject InvalidCertDerivationError
-	err := config.SetOverwritePutWithDerivationError(coretypes.ErrInvalidCertDerivationError)
+	err = config.SetOverwritePutWithDerivationError(coretypes.ErrInvalidCertDerivationError)
 	require.NoError(t, err)
 
 	// write is not affected
@@ -164,3 +170,103 @@ func TestOverwritePutWithDerivationError(t *testing.T) {
 	err = db.InsertEntry(anotherTestKey, []byte("another-value"))
 	require.ErrorContains(t, err, "key already exists")
 }
+
+func TestPersistence(t *testing.T) {
+	t.Parallel()
+
+	config := testConfig()
+	config.Update(memconfig.Config{
+		MaxBlobSizeBytes: 1024 * 1024,
+		PersistenceDir:   t.TempDir(),
+	})
+
+	db, err := New(t.Context(), config, testLogger)
+	require.NoError(t, err)
+	err = db.InsertEntry([]byte("payload-key"), []byte(testPreimage))
+	require.NoError(t, err)
+	err = config.SetOverwritePutWithDerivationError(coretypes.ErrInvalidCertDerivationError)
+	require.NoError(t, err)
+	err = db.InsertEntry([]byte("derivation-error-key"), []byte(testPreimage))
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	// entries survive a restart, including the derivation errors they were inserted with
+	db, err = New(t.Context(), config, testLogger)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	actual, err := db.FetchEntry([]byte("payload-key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte(testPreimage), actual)
+	_, err = db.FetchEntry([]byte("derivation-error-key"))
+	require.ErrorIs(t, err, coretypes.ErrInvalidCertDerivationError)
+
+	err = db.InsertEntry([]byte("payload-key"), []byte(testPreimage))
+	require.ErrorContains(t, err, "key already exists")
+}
+
+func TestPersistenceExpiration(t *testing.T) {
+	t.Parallel()
+
+	config := testConfig()
+	config.Update(memconfig.Config{
+		MaxBlobSizeBytes: 1024 * 1024,
+		BlobExpiration:   10 * time.Millisecond,
+		PersistenceDir:   t.TempDir(),
+	})
+
+	db, err := New(t.Context(), config, testLogger)
+	require.NoError(t, err)
+	err = db.InsertEntry([]byte("bland"), []byte(testPreimage))
+	require.NoError(t, err)
+
+	// pruned entries are also deleted from disk
+	require.Eventually(t, func() bool {
+		_, err := db.FetchEntry([]byte("bland"))
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, db.Close())
+
+	db, err = New(t.Context(), config, testLogger)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close()) }()
+	_, err = db.FetchEntry([]byte("bland"))
+	require.Error(t, err)
+}
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+
+	config := testConfig()
+	source, err := New(t.Context(), config, testLogger)
+	require.NoError(t, err)
+	err = source.InsertEntry([]byte("payload-key"), []byte(testPreimage))
+	require.NoError(t, err)
+	err = config.SetOverwritePutWithDerivationError(coretypes.ErrRecencyCheckFailedDerivationError)
+	require.NoError(t, err)
+	err = source.InsertEntry([]byte("derivation-error-key"), []byte(testPreimage))
+	require.NoError(t, err)
+
+	var exported bytes.Buffer
+	require.NoError(t, source.Export(&exported))
+
+	// imports bypass the fault injection knobs
+	destinationConfig := testConfig()
+	destinationConfig.SetPUTReturnsFailoverError(true)
+	destination, err := New(t.Context(), destinationConfig, testLogger)
+	require.NoError(t, err)
+	imported, err := destination.Import(&exported)
+	require.NoError(t, err)
+	require.Equal(t, 2, imported)
+
+	actual, err := destination.FetchEntry([]byte("payload-key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte(testPreimage), actual)
+	_, err = destination.FetchEntry([]byte("derivation-error-key"))
+	require.ErrorIs(t, err, coretypes.ErrRecencyCheckFailedDerivationError)
+
+	_, err = destination.Import(bytes.NewBufferString(`[{"payload": "0x01"}]`))
+	require.ErrorContains(t, err, "empty key")
+	_, err = destination.Import(bytes.NewBufferString(`[{`))
+	require.Error(t, err)
+}
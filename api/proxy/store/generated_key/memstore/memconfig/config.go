@@ -31,6 +31,9 @@ type Config struct {
 	// Valid values are coretypes.VersionThreeCert (0x3) or coretypes.VersionFourCert (0x4).
 	// Defaults to VersionFourCert if not specified.
 	CertVersion coretypes.CertificateVersion
+	// If set, the memstore entries are also written to a database in this directory, so that certs stay
+	// retrievable across restarts. Can't be updated at runtime.
+	PersistenceDir string
 }
 
 // MarshalJSON implements custom JSON marshaling for Config.
@@ -48,6 +51,7 @@ func (c Config) MarshalJSON() ([]byte, error) {
 		PutReturnsFailoverError         bool
 		OverwritePutWithDerivationError error
 		CertVersion                     coretypes.CertificateVersion
+		PersistenceDir                  string
 	}{
 		MaxBlobSizeBytes:                c.MaxBlobSizeBytes,
 		BlobExpiration:                  c.BlobExpiration.String(),
@@ -56,6 +60,7 @@ func (c Config) MarshalJSON() ([]byte, error) {
 		PutReturnsFailoverError:         c.PutReturnsFailoverError,
 		OverwritePutWithDerivationError: c.OverwritePutWithDerivationError,
 		CertVersion:                     c.CertVersion,
+		PersistenceDir:                  c.PersistenceDir,
 	})
 }
 
@@ -188,6 +193,12 @@ func (sc *SafeConfig) SetCertVersion(version coretypes.CertificateVersion) error
 	return nil
 }
 
+func (sc *SafeConfig) PersistenceDir() string {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.config.PersistenceDir
+}
+
 func (sc *SafeConfig) Config() Config {
 	sc.mu.RLock()
 	defer sc.mu.RUnlock()
@@ -2,6 +2,8 @@ package memconfig
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"time"
 
@@ -40,19 +42,32 @@ type ConfigUpdate struct {
 	NullableDerivationError *NullableDerivationError `json:"NullableDerivationError,omitempty"`
 }
 
+// Snapshotter exports and imports the contents of the memstore, e.g. to move a devnet's blobs to another proxy.
+type Snapshotter interface {
+	// Export writes all entries of the memstore to w.
+	Export(w io.Writer) error
+	// Import inserts the entries read from r, in the format written by Export, and returns how many were imported.
+	Import(r io.Reader) (int, error)
+}
+
 // HandlerHTTP is an admin HandlerHTTP for GETting and PATCHing the memstore configuration.
 // It adds routes to the proxy's main router (to be served on same port as the main proxy routes):
 // - GET /memstore/config: returns the current memstore configuration
 // - PATCH /memstore/config: updates the memstore configuration
+// - GET /memstore/export: returns the contents of the memstore in json format
+// - POST /memstore/import: inserts the contents returned by GET /memstore/export into the memstore
 type HandlerHTTP struct {
 	log        logging.Logger
 	safeConfig *SafeConfig
+	// nil if the export and import routes aren't served
+	snapshotter Snapshotter
 }
 
-func NewHandlerHTTP(log logging.Logger, safeConfig *SafeConfig) HandlerHTTP {
+func NewHandlerHTTP(log logging.Logger, safeConfig *SafeConfig, snapshotter Snapshotter) HandlerHTTP {
 	return HandlerHTTP{
-		log:        log,
-		safeConfig: safeConfig,
+		log:         log,
+		safeConfig:  safeConfig,
+		snapshotter: snapshotter,
 	}
 }
 
@@ -60,6 +75,10 @@ func (api HandlerHTTP) RegisterMemstoreConfigHandlers(r *mux.Router) {
 	memstore := r.PathPrefix("/memstore").Subrouter()
 	memstore.HandleFunc("/config", api.handleGetConfig).Methods("GET")
 	memstore.HandleFunc("/config", api.handleUpdateConfig).Methods("PATCH")
+	if api.snapshotter != nil {
+		memstore.HandleFunc("/export", api.handleExport).Methods("GET")
+		memstore.HandleFunc("/import", api.handleImport).Methods("POST")
+	}
 }
 
 // Returns the config of the memstore in json format.
@@ -141,3 +160,30 @@ func (api HandlerHTTP) handleUpdateConfig(w http.ResponseWriter, r *http.Request
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
+
+func (api HandlerHTTP) handleExport(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	err := api.snapshotter.Export(w)
+	if err != nil {
+		api.log.Error("failed to export memstore", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (api HandlerHTTP) handleImport(w http.ResponseWriter, r *http.Request) {
+	imported, err := api.snapshotter.Import(r.Body)
+	if err != nil {
+		api.log.Info("failed to import memstore entries", "imported", imported, "err", err)
+		http.Error(w, fmt.Sprintf("imported %d entries before failing: %s", imported, err), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(struct {
+		Imported int
+	}{Imported: imported})
+	if err != nil {
+		api.log.Error("failed to encode import response", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -2,6 +2,9 @@ package memconfig
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -21,7 +24,7 @@ var (
 func setup(config Config) (*mux.Router, *SafeConfig) {
 	safeConfig := NewSafeConfig(config)
 	r := mux.NewRouter()
-	api := NewHandlerHTTP(testLogger, safeConfig)
+	api := NewHandlerHTTP(testLogger, safeConfig, nil)
 	api.RegisterMemstoreConfigHandlers(r)
 
 	return r, safeConfig
@@ -221,3 +224,53 @@ func TestHandlersHTTP_PatchConfig(t *testing.T) {
 		})
 	}
 }
+
+// fakeSnapshotter exports the contents it holds, and replaces them with the imported contents.
+type fakeSnapshotter struct {
+	contents []byte
+}
+
+func (f *fakeSnapshotter) Export(w io.Writer) error {
+	_, err := w.Write(f.contents)
+	return err
+}
+
+func (f *fakeSnapshotter) Import(r io.Reader) (int, error) {
+	contents, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	if !json.Valid(contents) {
+		return 0, errors.New("invalid json")
+	}
+	f.contents = contents
+	return 1, nil
+}
+
+func TestHandlersHTTP_ExportImport(t *testing.T) {
+	// the routes are only registered when a snapshotter is provided
+	r, _ := setup(Config{})
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/memstore/export", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	snapshotter := &fakeSnapshotter{contents: []byte(`[{"key":"0x01"}]`)}
+	r = mux.NewRouter()
+	NewHandlerHTTP(testLogger, NewSafeConfig(Config{}), snapshotter).RegisterMemstoreConfigHandlers(r)
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/memstore/export", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, `[{"key":"0x01"}]`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/memstore/import", bytes.NewBufferString(`[{"key":"0x02"}]`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"Imported": 1}`, rec.Body.String())
+	require.Equal(t, `[{"key":"0x02"}]`, string(snapshotter.contents))
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/memstore/import", bytes.NewBufferString(`[{`)))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, `[{"key":"0x02"}]`, string(snapshotter.contents))
+}
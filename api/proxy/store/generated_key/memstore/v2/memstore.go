@@ -67,18 +67,24 @@ type MemStore struct {
 
 var _ common.EigenDAV2Store = (*MemStore)(nil)
 
+var _ memconfig.Snapshotter = (*MemStore)(nil)
+
 // New ... constructor
 func New(
 	ctx context.Context, log logging.Logger, config *memconfig.SafeConfig,
 	g1SRS []bn254.G1Affine,
-) *MemStore {
+) (*MemStore, error) {
+	db, err := ephemeraldb.New(ctx, config, log)
+	if err != nil {
+		return nil, fmt.Errorf("new ephemeral db: %w", err)
+	}
 	return &MemStore{
-		DB:       ephemeraldb.New(ctx, config, log),
+		DB:       db,
 		log:      log,
 		g1SRS:    g1SRS,
 		polyForm: codecs.PolynomialFormEval,
 		config:   config,
-	}
+	}, nil
 }
 
 // generateRandomV4Cert ... generates a pseudo random EigenDA V4 certificate with a offchain derivation version of 0
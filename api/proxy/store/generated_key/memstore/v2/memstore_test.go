@@ -34,12 +34,13 @@ func TestGetSet(t *testing.T) {
 
 	require.NoError(t, err)
 
-	msV2 := New(
+	msV2, err := New(
 		t.Context(),
 		testLogger,
 		getDefaultMemStoreTestConfig(),
 		g1Srs,
 	)
+	require.NoError(t, err)
 
 	expected := []byte(testPreimage)
 	versionedCert, err := msV2.Put(t.Context(), expected, coretypes.CertSerializationRLP)
@@ -64,12 +65,13 @@ func TestGetSetV3Cert(t *testing.T) {
 	err = config.SetCertVersion(coretypes.VersionThreeCert)
 	require.NoError(t, err)
 
-	msV3 := New(
+	msV3, err := New(
 		t.Context(),
 		testLogger,
 		config,
 		g1Srs,
 	)
+	require.NoError(t, err)
 
 	expected := []byte(testPreimage)
 	versionedCert, err := msV3.Put(t.Context(), expected, coretypes.CertSerializationRLP)
@@ -97,12 +99,13 @@ func TestGetSetV4Cert(t *testing.T) {
 	err = config.SetCertVersion(coretypes.VersionFourCert)
 	require.NoError(t, err)
 
-	msV4 := New(
+	msV4, err := New(
 		t.Context(),
 		testLogger,
 		config,
 		g1Srs,
 	)
+	require.NoError(t, err)
 
 	expected := []byte(testPreimage)
 	versionedCert, err := msV4.Put(t.Context(), expected, coretypes.CertSerializationRLP)
@@ -126,12 +129,13 @@ func TestSwitchCertVersion(t *testing.T) {
 	require.NoError(t, err)
 
 	config := getDefaultMemStoreTestConfig()
-	ms := New(
+	ms, err := New(
 		t.Context(),
 		testLogger,
 		config,
 		g1Srs,
 	)
+	require.NoError(t, err)
 
 	expected := []byte(testPreimage)
 
@@ -0,0 +1,79 @@
+package littdb
+
+import (
+	"fmt"
+
+	"github.com/docker/go-units"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	PathsFlagName   = withFlagPrefix("paths")
+	TTLFlagName     = withFlagPrefix("ttl")
+	MaxSizeFlagName = withFlagPrefix("max-size")
+	FsyncFlagName   = withFlagPrefix("fsync")
+)
+
+func withFlagPrefix(s string) string {
+	return "littdb." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_LITTDB_" + s}
+}
+
+// CLIFlags ... used for LittDB backend configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name: PathsFlagName,
+			Usage: "directories where the local LittDB store keeps its data. The store is disabled if empty. " +
+				"Can't be used with network profiles.",
+			EnvVars:  withEnvPrefix(envPrefix, "PATHS"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     TTLFlagName,
+			Usage:    "TTL of entries written to the local LittDB store. 0 means entries never expire.",
+			Value:    0,
+			EnvVars:  withEnvPrefix(envPrefix, "TTL"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: MaxSizeFlagName,
+			Usage: "maximum disk size of the local LittDB store (e.g. 100GiB). The oldest entries are evicted " +
+				"once exceeded. Empty means no limit.",
+			EnvVars:  withEnvPrefix(envPrefix, "MAX_SIZE"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name:     FsyncFlagName,
+			Usage:    "fsync writes to the local LittDB store. Disabling this risks losing recent entries on a crash.",
+			Value:    true,
+			EnvVars:  withEnvPrefix(envPrefix, "FSYNC"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) (Config, error) {
+	var maxSize uint64
+	if maxSizeString := ctx.String(MaxSizeFlagName); maxSizeString != "" {
+		size, err := units.RAMInBytes(maxSizeString)
+		if err != nil {
+			return Config{}, fmt.Errorf("parse --%s: %w", MaxSizeFlagName, err)
+		}
+		if size < 0 {
+			return Config{}, fmt.Errorf("--%s must not be negative: %s", MaxSizeFlagName, maxSizeString)
+		}
+		maxSize = uint64(size)
+	}
+
+	return Config{
+		Paths:        ctx.StringSlice(PathsFlagName),
+		TTL:          ctx.Duration(TTLFlagName),
+		MaxSizeBytes: maxSize,
+		Fsync:        ctx.Bool(FsyncFlagName),
+	}, nil
+}
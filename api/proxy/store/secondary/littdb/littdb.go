@@ -0,0 +1,126 @@
+package littdb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// The name of the LittDB table where secondary entries are stored.
+const tableName = "secondary"
+
+var _ common.SecondaryStore = (*Store)(nil)
+
+type Config struct {
+	// Directories where data is stored. Data can be spread across several drives by providing several paths.
+	// The store is disabled if no paths are provided.
+	Paths []string
+	// Time-to-live of entries. Zero means entries never expire.
+	TTL time.Duration
+	// Maximum number of bytes the store may occupy on disk. When this is exceeded, the oldest entries are
+	// evicted ahead of their TTL. Zero means no limit.
+	MaxSizeBytes uint64
+	// If false, writes are not fsynced to disk. Entries written shortly before an OS or hardware crash may be lost.
+	Fsync bool
+}
+
+// Store ... LittDB backed local disk store.
+// Entries are flushed to disk as they are written, and so they survive proxy restarts.
+type Store struct {
+	db    litt.DB
+	table litt.Table
+
+	// Skips entries that are already present, since a key can't be written twice.
+	writer litt.PutIfAbsentWriter
+}
+
+// NewStore opens (or creates) a LittDB instance at the configured paths.
+func NewStore(log logging.Logger, cfg Config) (*Store, error) {
+	littConfig, err := litt.DefaultConfig(cfg.Paths...)
+	if err != nil {
+		return nil, fmt.Errorf("littdb config: %w", err)
+	}
+	littConfig.Logger = log
+	littConfig.Fsync = cfg.Fsync
+	littConfig.TTL = cfg.TTL
+	if cfg.MaxSizeBytes > 0 {
+		littConfig.DBQuota = cfg.MaxSizeBytes
+		littConfig.QuotaPolicy = types.QuotaPolicyEvict
+	}
+
+	db, err := littbuilder.NewDB(littConfig)
+	if err != nil {
+		return nil, fmt.Errorf("open littdb at %v: %w", cfg.Paths, err)
+	}
+
+	table, err := db.GetTable(tableName)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("get littdb table %s: %w", tableName, err)
+	}
+
+	// The TTL is persisted with the table, so apply the configured value in case it changed since the last run.
+	err = table.SetTTL(cfg.TTL)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("set littdb TTL: %w", err)
+	}
+
+	return &Store{
+		db:    db,
+		table: table,
+	}, nil
+}
+
+// Get returns nil (and no error) if the key is not present, which the SecondaryManager records as a cache miss.
+func (s *Store) Get(_ context.Context, key []byte) ([]byte, error) {
+	value, exists, err := s.table.Get(key)
+	if err != nil {
+		return nil, fmt.Errorf("littdb Get: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+	// LittDB does not permit values it returns to be mutated.
+	return bytes.Clone(value), nil
+}
+
+// Put writes an entry and flushes it to disk. Writing a key that is already present is a no-op, since keys are
+// derived from the commitment that the value belongs to.
+func (s *Store) Put(_ context.Context, key []byte, value []byte) error {
+	written, err := s.writer.PutIfAbsent(s.table, key, value)
+	if err != nil {
+		return err
+	}
+	if !written {
+		return nil
+	}
+
+	err = s.table.Flush()
+	if err != nil {
+		return fmt.Errorf("littdb Flush: %w", err)
+	}
+	return nil
+}
+
+// Verify is a no-op. LittDB is only used to store EigenDA payloads, which the SecondaryManager verifies
+// against their cert after every read.
+func (s *Store) Verify(_ context.Context, _ []byte, _ []byte) error {
+	return nil
+}
+
+func (s *Store) BackendType() common.BackendType {
+	return common.LittDBBackendType
+}
+
+// Close flushes all data and releases the LittDB instance. The store must not be used after it is closed.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
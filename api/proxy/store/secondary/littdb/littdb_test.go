@@ -0,0 +1,129 @@
+package littdb
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+)
+
+func newTestStore(t *testing.T, cfg Config) *Store {
+	if len(cfg.Paths) == 0 {
+		cfg.Paths = []string{t.TempDir()}
+	}
+
+	store, err := NewStore(testLogger, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+
+	return store
+}
+
+func TestPutGet(t *testing.T) {
+	store := newTestStore(t, Config{})
+
+	key := []byte("key")
+	value := []byte("value")
+
+	// A missing key is a cache miss, not an error.
+	data, err := store.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Nil(t, data)
+
+	require.NoError(t, store.Put(t.Context(), key, value))
+
+	data, err = store.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Equal(t, value, data)
+
+	// Writing an existing key again is a no-op.
+	require.NoError(t, store.Put(t.Context(), key, []byte("other value")))
+	data, err = store.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Equal(t, value, data)
+}
+
+func TestSurvivesRestart(t *testing.T) {
+	cfg := Config{
+		Paths: []string{t.TempDir(), t.TempDir()},
+		TTL:   time.Hour,
+		Fsync: true,
+	}
+
+	store, err := NewStore(testLogger, cfg)
+	require.NoError(t, err)
+
+	key := []byte("key")
+	value := []byte("value")
+	require.NoError(t, store.Put(t.Context(), key, value))
+	require.NoError(t, store.Close())
+
+	store = newTestStore(t, cfg)
+	data, err := store.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Equal(t, value, data)
+}
+
+func TestNewStoreWithoutPaths(t *testing.T) {
+	_, err := NewStore(testLogger, Config{})
+	require.Error(t, err)
+}
+
+func TestSecondaryManagerWithLittDBFallback(t *testing.T) {
+	store := newTestStore(t, Config{TTL: time.Hour})
+	metricer := metrics.NewEmulatedMetricer()
+
+	manager := secondary.NewSecondaryManager(
+		testLogger,
+		metricer,
+		nil,
+		[]common.SecondaryStore{store},
+		false,
+		false,
+	)
+	require.True(t, manager.FallbackEnabled())
+
+	commitment := []byte("commitment")
+	payload := []byte("payload")
+	verify := func(context.Context, []byte, []byte) error { return nil }
+
+	// Nothing has been written yet, so the read is a miss.
+	_, err := manager.MultiSourceRead(t.Context(), commitment, true, verify)
+	require.Error(t, err)
+
+	require.NoError(t, manager.HandleRedundantWrites(t.Context(), commitment, payload))
+
+	data, err := manager.MultiSourceRead(t.Context(), commitment, true, verify)
+	require.NoError(t, err)
+	require.Equal(t, payload, data)
+
+	// Entries are keyed by the hash of the commitment.
+	data, err = store.Get(t.Context(), crypto.Keccak256(commitment))
+	require.NoError(t, err)
+	require.Equal(t, payload, data)
+
+	backend := common.LittDBBackendType.String()
+	count, err := metricer.SecondaryRequestsTotal.Get(backend, http.MethodGet, secondary.Miss)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+	count, err = metricer.SecondaryRequestsTotal.Get(backend, http.MethodPut, secondary.Success)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+	count, err = metricer.SecondaryRequestsTotal.Get(backend, http.MethodGet, secondary.Success)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+}
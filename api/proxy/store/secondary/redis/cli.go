@@ -1,17 +1,18 @@
 package redis
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/urfave/cli/v2"
 )
 
 var (
-	EndpointFlagName = withFlagPrefix("endpoint")
-	PasswordFlagName = withFlagPrefix("password")
-	DBFlagName       = withFlagPrefix("db")
-	EvictionFlagName = withFlagPrefix("eviction")
+	EndpointFlagName  = withFlagPrefix("endpoint")
+	PasswordFlagName  = withFlagPrefix("password")
+	DBFlagName        = withFlagPrefix("db")
+	EvictionFlagName  = withFlagPrefix("eviction")
+	KeyPrefixFlagName = withFlagPrefix("key-prefix")
+	EnableTLSFlagName = withFlagPrefix("enable-tls")
 )
 
 func withFlagPrefix(s string) string {
@@ -22,29 +23,21 @@ func withEnvPrefix(envPrefix, s string) []string {
 	return []string{envPrefix + "_REDIS_" + s}
 }
 
-// DeprecatedCLIFlags ... used for Redis backend configuration
+// CLIFlags ... used for Redis backend configuration
 // category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
-func DeprecatedCLIFlags(envPrefix, category string) []cli.Flag {
+func CLIFlags(envPrefix, category string) []cli.Flag {
 	return []cli.Flag{
 		&cli.StringFlag{
 			Name:     EndpointFlagName,
-			Usage:    "Redis endpoint",
+			Usage:    "Redis endpoint (host:port)",
 			EnvVars:  withEnvPrefix(envPrefix, "ENDPOINT"),
 			Category: category,
-			Hidden:   true,
-			Action: func(ctx *cli.Context, s string) error {
-				return fmt.Errorf("redis secondary store is no longer supported: flag --%s is deprecated", EndpointFlagName)
-			},
 		},
 		&cli.StringFlag{
 			Name:     PasswordFlagName,
 			Usage:    "Redis password",
 			EnvVars:  withEnvPrefix(envPrefix, "PASSWORD"),
 			Category: category,
-			Hidden:   true,
-			Action: func(ctx *cli.Context, s string) error {
-				return fmt.Errorf("redis secondary store is no longer supported: flag --%s is deprecated", PasswordFlagName)
-			},
 		},
 		&cli.IntFlag{
 			Name:     DBFlagName,
@@ -52,21 +45,37 @@ func DeprecatedCLIFlags(envPrefix, category string) []cli.Flag {
 			Value:    0,
 			EnvVars:  withEnvPrefix(envPrefix, "DB"),
 			Category: category,
-			Hidden:   true,
-			Action: func(ctx *cli.Context, _ int) error {
-				return fmt.Errorf("redis secondary store is no longer supported: flag --%s is deprecated", DBFlagName)
-			},
 		},
 		&cli.DurationFlag{
 			Name:     EvictionFlagName,
-			Usage:    "Redis eviction time",
+			Usage:    "TTL of entries written to Redis. 0 means entries never expire and are only evicted by the server.",
 			Value:    24 * time.Hour,
 			EnvVars:  withEnvPrefix(envPrefix, "EVICTION"),
 			Category: category,
-			Hidden:   true,
-			Action: func(ctx *cli.Context, _ time.Duration) error {
-				return fmt.Errorf("redis secondary store is no longer supported: flag --%s is deprecated", EvictionFlagName)
-			},
 		},
+		&cli.StringFlag{
+			Name:     KeyPrefixFlagName,
+			Usage:    "prefix prepended to every Redis key, for sharing a Redis instance between proxies",
+			EnvVars:  withEnvPrefix(envPrefix, "KEY_PREFIX"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name:     EnableTLSFlagName,
+			Usage:    "enable TLS connection to Redis endpoint",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "ENABLE_TLS"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Endpoint:  ctx.String(EndpointFlagName),
+		Password:  ctx.String(PasswordFlagName),
+		DB:        ctx.Int(DBFlagName),
+		Eviction:  ctx.Duration(EvictionFlagName),
+		KeyPrefix: ctx.String(KeyPrefixFlagName),
+		EnableTLS: ctx.Bool(EnableTLSFlagName),
 	}
 }
@@ -0,0 +1,110 @@
+package redis
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+var _ common.SecondaryStore = (*Store)(nil)
+
+type Config struct {
+	// Address of the Redis server, in host:port form.
+	Endpoint string
+	Password string
+	DB       int
+	// Time-to-live applied to every entry written to Redis. Zero means that entries never expire, in which case
+	// they are only removed by the server's own eviction policy (see Redis's maxmemory-policy).
+	Eviction time.Duration
+	// Prepended to every key, so that several proxies (e.g. serving different rollups) can share a Redis instance
+	// without colliding.
+	KeyPrefix string
+	EnableTLS bool
+}
+
+// Custom MarshalJSON function to control what gets included in the JSON output
+func (c Config) MarshalJSON() ([]byte, error) {
+	type Alias Config // Use an alias to avoid recursion with MarshalJSON
+	aux := (Alias)(c)
+	if aux.Password != "" {
+		aux.Password = "*****"
+	}
+	return json.Marshal(aux)
+}
+
+// Store ... Redis store
+// client safe for concurrent use: https://redis.uptrace.dev/guide/go-redis.html#connecting-to-redis-server
+type Store struct {
+	cfg    Config
+	client *goredis.Client
+}
+
+// NewStore creates a new Redis store, and verifies that the server is reachable.
+func NewStore(ctx context.Context, cfg Config) (*Store, error) {
+	options := &goredis.Options{
+		Addr:     cfg.Endpoint,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	}
+	if cfg.EnableTLS {
+		options.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	client := goredis.NewClient(options)
+	err := client.Ping(ctx).Err()
+	if err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("ping redis at %s: %w", cfg.Endpoint, err)
+	}
+
+	return &Store{
+		cfg:    cfg,
+		client: client,
+	}, nil
+}
+
+// Get returns nil (and no error) if the key is not present, which the SecondaryManager records as a cache miss.
+func (s *Store) Get(ctx context.Context, key []byte) ([]byte, error) {
+	data, err := s.client.Get(ctx, s.redisKey(key)).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis Get: %w", err)
+	}
+	return data, nil
+}
+
+func (s *Store) Put(ctx context.Context, key []byte, value []byte) error {
+	err := s.client.Set(ctx, s.redisKey(key), value, s.cfg.Eviction).Err()
+	if err != nil {
+		return fmt.Errorf("redis Put: %w", err)
+	}
+	return nil
+}
+
+// Verify is a no-op. Redis is only used to cache EigenDA payloads, which the SecondaryManager verifies
+// against their cert after every read.
+func (s *Store) Verify(_ context.Context, _ []byte, _ []byte) error {
+	return nil
+}
+
+func (s *Store) BackendType() common.BackendType {
+	return common.RedisBackendType
+}
+
+// Close closes the connection pool to the Redis server.
+func (s *Store) Close() error {
+	return s.client.Close()
+}
+
+func (s *Store) redisKey(key []byte) string {
+	return s.cfg.KeyPrefix + hex.EncodeToString(key)
+}
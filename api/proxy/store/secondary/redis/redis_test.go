@@ -0,0 +1,155 @@
+package redis
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+)
+
+func newTestStore(t *testing.T, cfg Config) (*Store, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	cfg.Endpoint = server.Addr()
+
+	store, err := NewStore(t.Context(), cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+
+	return store, server
+}
+
+func TestPutGet(t *testing.T) {
+	store, server := newTestStore(t, Config{
+		Eviction:  time.Hour,
+		KeyPrefix: "rollup-a:",
+	})
+
+	key := []byte("key")
+	value := []byte("value")
+
+	// A missing key is a cache miss, not an error.
+	data, err := store.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Nil(t, data)
+
+	require.NoError(t, store.Put(t.Context(), key, value))
+
+	data, err = store.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Equal(t, value, data)
+
+	redisKey := "rollup-a:" + hex.EncodeToString(key)
+	require.True(t, server.Exists(redisKey))
+	require.Equal(t, time.Hour, server.TTL(redisKey))
+
+	// Entries disappear once their TTL elapses.
+	server.FastForward(time.Hour + time.Second)
+	data, err = store.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Nil(t, data)
+}
+
+func TestNoEviction(t *testing.T) {
+	store, server := newTestStore(t, Config{})
+
+	key := []byte("key")
+	require.NoError(t, store.Put(t.Context(), key, []byte("value")))
+	require.Equal(t, time.Duration(0), server.TTL(hex.EncodeToString(key)))
+}
+
+func TestKeyPrefixIsolation(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	storeA, err := NewStore(t.Context(), Config{Endpoint: server.Addr(), KeyPrefix: "a:"})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, storeA.Close()) }()
+	storeB, err := NewStore(t.Context(), Config{Endpoint: server.Addr(), KeyPrefix: "b:"})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, storeB.Close()) }()
+
+	key := []byte("key")
+	require.NoError(t, storeA.Put(t.Context(), key, []byte("value")))
+
+	data, err := storeB.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Nil(t, data)
+}
+
+func TestServerErrors(t *testing.T) {
+	store, server := newTestStore(t, Config{})
+
+	server.SetError("server unavailable")
+	_, err := store.Get(t.Context(), []byte("key"))
+	require.Error(t, err)
+	require.Error(t, store.Put(t.Context(), []byte("key"), []byte("value")))
+}
+
+func TestNewStoreUnreachable(t *testing.T) {
+	server := miniredis.RunT(t)
+	addr := server.Addr()
+	server.Close()
+
+	_, err := NewStore(t.Context(), Config{Endpoint: addr})
+	require.Error(t, err)
+}
+
+func TestSecondaryManagerWithRedisCache(t *testing.T) {
+	store, _ := newTestStore(t, Config{Eviction: time.Hour})
+	metricer := metrics.NewEmulatedMetricer()
+
+	manager := secondary.NewSecondaryManager(
+		testLogger,
+		metricer,
+		[]common.SecondaryStore{store},
+		nil,
+		false,
+		false,
+	)
+	require.True(t, manager.CachingEnabled())
+
+	commitment := []byte("commitment")
+	payload := []byte("payload")
+	verify := func(context.Context, []byte, []byte) error { return nil }
+
+	// Nothing has been written yet, so the read is a miss.
+	_, err := manager.MultiSourceRead(t.Context(), commitment, false, verify)
+	require.Error(t, err)
+
+	require.NoError(t, manager.HandleRedundantWrites(t.Context(), commitment, payload))
+
+	data, err := manager.MultiSourceRead(t.Context(), commitment, false, verify)
+	require.NoError(t, err)
+	require.Equal(t, payload, data)
+
+	// Entries are keyed by the hash of the commitment.
+	data, err = store.Get(t.Context(), crypto.Keccak256(commitment))
+	require.NoError(t, err)
+	require.Equal(t, payload, data)
+
+	backend := common.RedisBackendType.String()
+	count, err := metricer.SecondaryRequestsTotal.Get(backend, http.MethodGet, secondary.Miss)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+	count, err = metricer.SecondaryRequestsTotal.Get(backend, http.MethodPut, secondary.Success)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+	count, err = metricer.SecondaryRequestsTotal.Get(backend, http.MethodGet, secondary.Success)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+}
@@ -87,6 +87,22 @@ func (mr *MockIEigenDAManagerMockRecorder) Put(ctx, value, serializationType any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockIEigenDAManager)(nil).Put), ctx, value, serializationType)
 }
 
+// PutBatched mocks base method.
+func (m *MockIEigenDAManager) PutBatched(ctx context.Context, value []byte, serializationType coretypes.CertSerializationType) (*certs.VersionedCert, common.BatchEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutBatched", ctx, value, serializationType)
+	ret0, _ := ret[0].(*certs.VersionedCert)
+	ret1, _ := ret[1].(common.BatchEntry)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PutBatched indicates an expected call of PutBatched.
+func (mr *MockIEigenDAManagerMockRecorder) PutBatched(ctx, value, serializationType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutBatched", reflect.TypeOf((*MockIEigenDAManager)(nil).PutBatched), ctx, value, serializationType)
+}
+
 // SetDispersalBackend mocks base method.
 func (m *MockIEigenDAManager) SetDispersalBackend(backend common.EigenDABackend) {
 	m.ctrl.T.Helper()
@@ -98,3 +114,17 @@ func (mr *MockIEigenDAManagerMockRecorder) SetDispersalBackend(backend any) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDispersalBackend", reflect.TypeOf((*MockIEigenDAManager)(nil).SetDispersalBackend), backend)
 }
+
+// VerifyCert mocks base method.
+func (m *MockIEigenDAManager) VerifyCert(ctx context.Context, versionedCert *certs.VersionedCert, serializationType coretypes.CertSerializationType, l1InclusionBlockNum uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyCert", ctx, versionedCert, serializationType, l1InclusionBlockNum)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyCert indicates an expected call of VerifyCert.
+func (mr *MockIEigenDAManagerMockRecorder) VerifyCert(ctx, versionedCert, serializationType, l1InclusionBlockNum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyCert", reflect.TypeOf((*MockIEigenDAManager)(nil).VerifyCert), ctx, versionedCert, serializationType, l1InclusionBlockNum)
+}
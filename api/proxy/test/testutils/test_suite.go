@@ -8,6 +8,7 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
 	"github.com/Layr-Labs/eigenda/api/proxy/config"
 	proxy_metrics "github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	srs "github.com/Layr-Labs/eigenda/api/proxy/resources"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/arbitrum_altda"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/builder"
@@ -127,11 +128,12 @@ func CreateTestSuite(
 	//       & simplify where possible.
 	if appConfig.EnabledServersConfig.RestAPIConfig.DAEndpointEnabled() {
 		appConfig.RestSvrCfg.CompatibilityCfg = compatibilityCfg
-		restServer = rest.NewServer(appConfig.RestSvrCfg, certMgr, keccakMgr, logger, metrics)
+		restServer = rest.NewServer(appConfig.RestSvrCfg, certMgr, keccakMgr, nil, logger, metrics)
 		router := mux.NewRouter()
 		restServer.RegisterRoutes(router)
 		if appConfig.StoreBuilderConfig.MemstoreEnabled {
-			memconfig.NewHandlerHTTP(logger, appConfig.StoreBuilderConfig.MemstoreConfig).
+			snapshotter, _ := certMgr.EigenDAV2Store().(memconfig.Snapshotter)
+			memconfig.NewHandlerHTTP(logger, appConfig.StoreBuilderConfig.MemstoreConfig, snapshotter).
 				RegisterMemstoreConfigHandlers(router)
 		}
 
@@ -142,7 +144,12 @@ func CreateTestSuite(
 
 	if appConfig.EnabledServersConfig.ArbCustomDA {
 		appConfig.ArbCustomDASvrCfg.CompatibilityCfg = compatibilityCfg
-		arbHandlers := arbitrum_altda.NewHandlers(certMgr, logger, true, arbEthClient, compatibilityCfg)
+		preimageProver, err := arbitrum_altda.NewReadPreimageProver(srs.GetG1SRS(), srs.GetG2SRS())
+		if err != nil {
+			panic(fmt.Sprintf("create read preimage prover: %v", err.Error()))
+		}
+		arbHandlers := arbitrum_altda.NewHandlers(
+			certMgr, logger, true, arbEthClient, compatibilityCfg, preimageProver)
 		arbServer, err = arbitrum_altda.NewServer(ctx, &appConfig.ArbCustomDASvrCfg, arbHandlers)
 		if err != nil {
 			panic(fmt.Sprintf("create arbitrum server: %v", err.Error()))
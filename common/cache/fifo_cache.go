@@ -17,7 +17,10 @@ type FIFOCache[K comparable, V any] struct {
 	maxWeight     uint64
 	data          map[K]V
 	evictionQueue *structures.Queue[*insertionRecord]
-	metrics       *CacheMetrics
+	// The most recent insertion record for each key in the cache. Used to recognize stale records in the
+	// eviction queue that were left behind when a key was removed.
+	insertions map[K]*insertionRecord
+	metrics    *CacheMetrics
 }
 
 // insertionRecord is a record of when a key was inserted into the cache, and is used to decide when it should be
@@ -44,6 +47,7 @@ func NewFIFOCache[K comparable, V any](
 		data:             make(map[K]V),
 		weightCalculator: calculator,
 		evictionQueue:    structures.NewQueue[*insertionRecord](1024),
+		insertions:       make(map[K]*insertionRecord),
 		metrics:          metrics,
 	}
 }
@@ -67,10 +71,12 @@ func (f *FIFOCache[K, V]) Put(key K, value V) {
 		oldWeight := f.weightCalculator(key, old)
 		f.currentWeight -= oldWeight
 	} else {
-		f.evictionQueue.Push(&insertionRecord{
+		record := &insertionRecord{
 			key:       key,
 			timestamp: time.Now(),
-		})
+		}
+		f.evictionQueue.Push(record)
+		f.insertions[key] = record
 	}
 
 	if f.currentWeight > f.maxWeight {
@@ -81,14 +87,57 @@ func (f *FIFOCache[K, V]) Put(key K, value V) {
 	f.metrics.reportCurrentSize(len(f.data), f.currentWeight)
 }
 
+func (f *FIFOCache[K, V]) Remove(key K) {
+	value, ok := f.data[key]
+	if !ok {
+		return
+	}
+
+	// The insertion record for this key is left in the eviction queue. It is ignored when it is eventually popped,
+	// or dropped when the queue is compacted.
+	delete(f.data, key)
+	delete(f.insertions, key)
+	f.currentWeight -= f.weightCalculator(key, value)
+
+	// Stale records are only popped during eviction, which may never happen if the cache stays below its maximum
+	// weight. Compact the queue once the majority of its records are stale, so that it doesn't grow without bound.
+	// Each compaction removes at least as many records as there were calls to Remove since the previous one, so the
+	// amortized cost of a Remove is constant.
+	if f.evictionQueue.Size() > 2*uint64(len(f.insertions)) {
+		f.compactEvictionQueue()
+	}
+
+	f.metrics.reportCurrentSize(len(f.data), f.currentWeight)
+}
+
+// compactEvictionQueue drops the stale records from the eviction queue, preserving the order of the others.
+func (f *FIFOCache[K, V]) compactEvictionQueue() {
+	liveRecords := make([]*insertionRecord, 0, len(f.insertions))
+	for _, record := range f.evictionQueue.Iterator() {
+		if f.insertions[record.key.(K)] == record {
+			liveRecords = append(liveRecords, record)
+		}
+	}
+
+	f.evictionQueue.Clear()
+	for _, record := range liveRecords {
+		f.evictionQueue.Push(record)
+	}
+}
+
 func (f *FIFOCache[K, V]) evict() {
 	now := time.Now()
 
 	for f.currentWeight > f.maxWeight {
 		next := f.evictionQueue.Pop()
 		keyToEvict := next.key.(K)
+		if f.insertions[keyToEvict] != next {
+			// This key was removed from the cache (and possibly re-inserted) after this record was created.
+			continue
+		}
 		weightToEvict := f.weightCalculator(keyToEvict, f.data[keyToEvict])
 		delete(f.data, keyToEvict)
+		delete(f.insertions, keyToEvict)
 		f.currentWeight -= weightToEvict
 		f.metrics.reportEviction(now.Sub(next.timestamp))
 	}
@@ -135,3 +135,68 @@ func TestWeightedValues(t *testing.T) {
 		require.Equal(t, v, value)
 	}
 }
+
+func TestRemove(t *testing.T) {
+	random.InitializeRandom()
+
+	maxWeight := uint64(10)
+	c := NewFIFOCache[int, int](maxWeight, nil, nil)
+
+	for i := 0; i < int(maxWeight); i++ {
+		c.Put(i, i)
+	}
+	require.Equal(t, int(maxWeight), c.Size())
+
+	// Removing a key that is not present is a no-op.
+	c.Remove(-1)
+	require.Equal(t, int(maxWeight), c.Size())
+	require.Equal(t, maxWeight, c.Weight())
+
+	// Remove the oldest key, then re-insert it. It is now the youngest key and should not be evicted first.
+	c.Remove(0)
+	_, ok := c.Get(0)
+	require.False(t, ok)
+	require.Equal(t, int(maxWeight)-1, c.Size())
+	require.Equal(t, maxWeight-1, c.Weight())
+
+	c.Put(0, 100)
+	require.Equal(t, int(maxWeight), c.Size())
+
+	// Adding a new key should evict key 1 (the oldest remaining key), not key 0.
+	c.Put(int(maxWeight), int(maxWeight))
+	_, ok = c.Get(1)
+	require.False(t, ok)
+	value, ok := c.Get(0)
+	require.True(t, ok)
+	require.Equal(t, 100, value)
+	require.Equal(t, int(maxWeight), c.Size())
+	require.Equal(t, maxWeight, c.Weight())
+}
+
+func TestRemoveChurn(t *testing.T) {
+	random.InitializeRandom()
+
+	maxWeight := uint64(100)
+	c := NewFIFOCache[int, int](maxWeight, nil, nil)
+	fifo := c.(*FIFOCache[int, int])
+
+	// Keys 0-9 are never removed.
+	for i := 0; i < 10; i++ {
+		c.Put(i, i)
+	}
+
+	// Churn well below the maximum weight, so that nothing is ever evicted.
+	for i := 10; i < 10_000; i++ {
+		c.Put(i, i)
+		c.Remove(i)
+		require.LessOrEqual(t, fifo.evictionQueue.Size(), 2*uint64(c.Size())+1)
+	}
+	require.Equal(t, 10, c.Size())
+
+	// Compaction preserves the insertion order of the remaining keys.
+	c.SetMaxWeight(5)
+	for i := 0; i < 10; i++ {
+		_, ok := c.Get(i)
+		require.Equal(t, i >= 5, ok)
+	}
+}
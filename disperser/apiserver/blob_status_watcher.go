@@ -0,0 +1,178 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/status"
+)
+
+// errTooManyBlobStatusSubscriptions is returned by blobStatusWatcher.subscribe when the subscription limit is reached.
+var errTooManyBlobStatusSubscriptions = errors.New("too many blob status subscriptions")
+
+// blobStatusUpdate is the result of a single status lookup for a watched blob.
+type blobStatusUpdate struct {
+	reply *pb.BlobStatusReply
+	st    *status.Status
+}
+
+// blobStatusSubscription receives status updates for a single blob from a blobStatusWatcher.
+type blobStatusSubscription struct {
+	blobKey corev2.BlobKey
+
+	// Holds at most the latest update. Older updates that haven't been consumed yet are dropped, since subscribers
+	// only care about the current status of the blob.
+	updates chan blobStatusUpdate
+}
+
+// publish replaces any pending update of the subscription with the given update.
+func (s *blobStatusSubscription) publish(update blobStatusUpdate) {
+	select {
+	case <-s.updates:
+	default:
+	}
+	s.updates <- update
+}
+
+// blobStatusWatcher polls the blob metadata store on behalf of all SubscribeBlobStatus streams. Each watched blob is
+// looked up once per poll interval no matter how many streams are subscribed to it, and the result is fanned out to
+// all of them. The polling goroutine only runs while there is at least one subscription.
+type blobStatusWatcher struct {
+	// Looks up the current status of a blob.
+	getBlobStatus func(ctx context.Context, req *pb.BlobStatusRequest) (*pb.BlobStatusReply, *status.Status)
+
+	pollInterval     time.Duration
+	maxSubscriptions int
+	// The maximum number of blobs looked up concurrently during a poll.
+	maxConcurrentLookups int
+
+	mu                sync.Mutex
+	subscriptions     map[corev2.BlobKey]map[*blobStatusSubscription]struct{}
+	subscriptionCount int
+	// Cancels the polling goroutine. Nil while the goroutine isn't running.
+	cancelPolling context.CancelFunc
+}
+
+func newBlobStatusWatcher(
+	getBlobStatus func(ctx context.Context, req *pb.BlobStatusRequest) (*pb.BlobStatusReply, *status.Status),
+	pollInterval time.Duration,
+	maxSubscriptions int,
+	maxConcurrentLookups int,
+) *blobStatusWatcher {
+	return &blobStatusWatcher{
+		getBlobStatus:        getBlobStatus,
+		pollInterval:         pollInterval,
+		maxSubscriptions:     maxSubscriptions,
+		maxConcurrentLookups: maxConcurrentLookups,
+		subscriptions:        make(map[corev2.BlobKey]map[*blobStatusSubscription]struct{}),
+	}
+}
+
+// subscribe starts watching the given blob. The returned subscription must be passed to unsubscribe once the caller
+// is no longer interested in updates.
+func (w *blobStatusWatcher) subscribe(blobKey corev2.BlobKey) (*blobStatusSubscription, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.subscriptionCount >= w.maxSubscriptions {
+		return nil, errTooManyBlobStatusSubscriptions
+	}
+
+	sub := &blobStatusSubscription{
+		blobKey: blobKey,
+		updates: make(chan blobStatusUpdate, 1),
+	}
+	subscribers, ok := w.subscriptions[blobKey]
+	if !ok {
+		subscribers = make(map[*blobStatusSubscription]struct{})
+		w.subscriptions[blobKey] = subscribers
+	}
+	subscribers[sub] = struct{}{}
+	w.subscriptionCount++
+
+	if w.cancelPolling == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		w.cancelPolling = cancel
+		go w.poll(ctx)
+	}
+
+	return sub, nil
+}
+
+// unsubscribe stops delivering updates to the given subscription.
+func (w *blobStatusWatcher) unsubscribe(sub *blobStatusSubscription) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	subscribers, ok := w.subscriptions[sub.blobKey]
+	if !ok {
+		return
+	}
+	if _, ok := subscribers[sub]; !ok {
+		return
+	}
+	delete(subscribers, sub)
+	w.subscriptionCount--
+	if len(subscribers) == 0 {
+		delete(w.subscriptions, sub.blobKey)
+	}
+
+	if w.subscriptionCount == 0 && w.cancelPolling != nil {
+		w.cancelPolling()
+		w.cancelPolling = nil
+	}
+}
+
+// poll looks up the status of every watched blob once per poll interval until the context is cancelled. Up to
+// maxConcurrentLookups blobs are looked up at a time, so that a poll isn't as slow as the sum of its lookups.
+func (w *blobStatusWatcher) poll(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		w.mu.Lock()
+		blobKeys := make([]corev2.BlobKey, 0, len(w.subscriptions))
+		for blobKey := range w.subscriptions {
+			blobKeys = append(blobKeys, blobKey)
+		}
+		w.mu.Unlock()
+
+		runner := errgroup.Group{}
+		runner.SetLimit(w.maxConcurrentLookups)
+		for _, blobKey := range blobKeys {
+			if ctx.Err() != nil {
+				break
+			}
+			runner.Go(func() error {
+				w.lookup(ctx, blobKey)
+				return nil
+			})
+		}
+		_ = runner.Wait()
+	}
+}
+
+// lookup looks up the status of the given blob and publishes it to all of its subscriptions.
+func (w *blobStatusWatcher) lookup(ctx context.Context, blobKey corev2.BlobKey) {
+	reply, st := w.getBlobStatus(ctx, &pb.BlobStatusRequest{BlobKey: blobKey[:]})
+	if ctx.Err() != nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for sub := range w.subscriptions[blobKey] {
+		sub.publish(blobStatusUpdate{reply: reply, st: st})
+	}
+}
@@ -0,0 +1,65 @@
+package apiserver
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/status"
+)
+
+func TestBlobStatusWatcherLooksUpBlobsConcurrently(t *testing.T) {
+	maxConcurrentLookups := 3
+	blobCount := 2 * maxConcurrentLookups
+
+	var inFlight atomic.Int32
+	var maxInFlight atomic.Int32
+	// Lookups block until enough of them are in flight at once, which would never happen if they were sequential.
+	release := make(chan struct{})
+	var releaseOnce sync.Once
+	getBlobStatus := func(ctx context.Context, req *pb.BlobStatusRequest) (*pb.BlobStatusReply, *status.Status) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			previous := maxInFlight.Load()
+			if current <= previous || maxInFlight.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		if current == int32(maxConcurrentLookups) {
+			releaseOnce.Do(func() { close(release) })
+		}
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return &pb.BlobStatusReply{Status: pb.BlobStatus_COMPLETE}, nil
+	}
+
+	watcher := newBlobStatusWatcher(getBlobStatus, time.Millisecond, blobCount, maxConcurrentLookups)
+	subscriptions := make([]*blobStatusSubscription, 0, blobCount)
+	for i := 0; i < blobCount; i++ {
+		blobKey := corev2.BlobKey{byte(i)}
+		sub, err := watcher.subscribe(blobKey)
+		require.NoError(t, err)
+		subscriptions = append(subscriptions, sub)
+	}
+
+	for _, sub := range subscriptions {
+		select {
+		case update := <-sub.updates:
+			require.Nil(t, update.st)
+			require.Equal(t, pb.BlobStatus_COMPLETE, update.reply.GetStatus())
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "timed out waiting for a blob status update")
+		}
+		watcher.unsubscribe(sub)
+	}
+
+	require.Equal(t, int32(maxConcurrentLookups), maxInFlight.Load())
+}
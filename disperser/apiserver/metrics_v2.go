@@ -29,6 +29,7 @@ type metricsV2 struct {
 	validateDispersalRequestLatency   *prometheus.SummaryVec
 	storeBlobLatency                  *prometheus.SummaryVec
 	getBlobStatusLatency              *prometheus.SummaryVec
+	blobStatusSubscriptions           prometheus.Gauge
 	dispersalTimestampRejected        *prometheus.CounterVec
 	dispersalTimestampDrift           *prometheus.HistogramVec
 	dispersalTimestampConfigMaxAge    prometheus.Gauge
@@ -119,6 +120,14 @@ func newAPIServerV2Metrics(registry *prometheus.Registry, metricsConfig disperse
 		[]string{},
 	)
 
+	blobStatusSubscriptions := promauto.With(registry).NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "blob_status_subscriptions",
+			Help:      "The number of open SubscribeBlobStatus streams.",
+		},
+	)
+
 	dispersalTimestampRejected := promauto.With(registry).NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: namespace,
@@ -163,6 +172,7 @@ func newAPIServerV2Metrics(registry *prometheus.Registry, metricsConfig disperse
 		validateDispersalRequestLatency:   validateDispersalRequestLatency,
 		storeBlobLatency:                  storeBlobLatency,
 		getBlobStatusLatency:              getBlobStatusLatency,
+		blobStatusSubscriptions:           blobStatusSubscriptions,
 		dispersalTimestampRejected:        dispersalTimestampRejected,
 		dispersalTimestampDrift:           dispersalTimestampDrift,
 		dispersalTimestampConfigMaxAge:    dispersalTimestampConfigMaxAge,
@@ -219,6 +229,14 @@ func (m *metricsV2) reportGetBlobStatusLatency(duration time.Duration) {
 	m.getBlobStatusLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
 
+func (m *metricsV2) reportBlobStatusSubscriptionStarted() {
+	m.blobStatusSubscriptions.Inc()
+}
+
+func (m *metricsV2) reportBlobStatusSubscriptionEnded() {
+	m.blobStatusSubscriptions.Dec()
+}
+
 func (m *metricsV2) reportDispersalTimestampRejected(reason string) {
 	m.dispersalTimestampRejected.WithLabelValues(reason).Inc()
 }
@@ -103,6 +103,9 @@ type DispersalServerV2 struct {
 	// Tracks signing rates for validators. This data is mirrored from the controller's signing rate tracker,
 	// so that external requests can be serviced without involving the controller.
 	signingRateTracker signingrate.SigningRateTracker
+
+	// Polls the blob metadata store on behalf of all SubscribeBlobStatus streams.
+	blobStatusWatcher *blobStatusWatcher
 }
 
 // NewDispersalServerV2 creates a new Server struct with the provided parameters.
@@ -171,6 +174,22 @@ func NewDispersalServerV2(
 	if maxFutureDispersalTime <= 0 {
 		return nil, fmt.Errorf("maxFutureDispersalTime must be positive (got: %v)", maxFutureDispersalTime)
 	}
+	if serverConfig.BlobStatusSubscriptionPollInterval <= 0 {
+		return nil, fmt.Errorf("BlobStatusSubscriptionPollInterval must be positive (got: %v)",
+			serverConfig.BlobStatusSubscriptionPollInterval)
+	}
+	if serverConfig.BlobStatusSubscriptionTimeout <= 0 {
+		return nil, fmt.Errorf("BlobStatusSubscriptionTimeout must be positive (got: %v)",
+			serverConfig.BlobStatusSubscriptionTimeout)
+	}
+	if serverConfig.MaxBlobStatusSubscriptions <= 0 {
+		return nil, fmt.Errorf("MaxBlobStatusSubscriptions must be positive (got: %d)",
+			serverConfig.MaxBlobStatusSubscriptions)
+	}
+	if serverConfig.MaxConcurrentBlobStatusLookups <= 0 {
+		return nil, fmt.Errorf("MaxConcurrentBlobStatusLookups must be positive (got: %d)",
+			serverConfig.MaxConcurrentBlobStatusLookups)
+	}
 
 	logger := _logger.With("component", "DispersalServerV2")
 
@@ -178,7 +197,7 @@ func NewDispersalServerV2(
 		return nil, errors.New("controller client is required")
 	}
 
-	return &DispersalServerV2{
+	s := &DispersalServerV2{
 		serverConfig:      serverConfig,
 		chainId:           chainId,
 		blobStore:         blobStore,
@@ -205,7 +224,14 @@ func NewDispersalServerV2(
 		listener:                 listener,
 		disableGetBlobCommitment: serverConfig.DisableGetBlobCommitment,
 		signingRateTracker:       signingRateTracker,
-	}, nil
+	}
+	s.blobStatusWatcher = newBlobStatusWatcher(
+		s.getBlobStatus,
+		serverConfig.BlobStatusSubscriptionPollInterval,
+		serverConfig.MaxBlobStatusSubscriptions,
+		serverConfig.MaxConcurrentBlobStatusLookups)
+
+	return s, nil
 }
 
 func (s *DispersalServerV2) Start(ctx context.Context) error {
@@ -230,6 +256,9 @@ func (s *DispersalServerV2) Start(ctx context.Context) error {
 	s.grpcServer = grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			s.metrics.grpcMetrics.UnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			s.metrics.grpcMetrics.StreamServerInterceptor(),
 		), opt, keepAliveConfig)
 	reflection.Register(s.grpcServer)
 	pb.RegisterDisperserServer(s.grpcServer, s)
@@ -44,7 +44,10 @@ import (
 	tmock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -511,6 +514,101 @@ func TestV2GetBlobStatus(t *testing.T) {
 	require.Equal(t, attestationProto, reply.GetSignedBatch().GetAttestation())
 }
 
+// subscribeBlobStatusStream is a pbv2.Disperser_SubscribeBlobStatusServer that forwards sent replies to a channel.
+type subscribeBlobStatusStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	replies chan *pbv2.BlobStatusReply
+}
+
+func (s *subscribeBlobStatusStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *subscribeBlobStatusStream) Send(reply *pbv2.BlobStatusReply) error {
+	s.replies <- reply
+	return nil
+}
+
+func TestV2SubscribeBlobStatus(t *testing.T) {
+	ctx := t.Context()
+	c := newTestServerV2(t)
+	ctx = peer.NewContext(ctx, c.Peer)
+
+	testData := codec.ConvertByPaddingEmptyByte([]byte("test data for blob status subscription"))
+	commitments, err := c.Committer.GetCommitmentsForPaddedLength(testData)
+	require.NoError(t, err)
+
+	blobHeader := &corev2.BlobHeader{
+		BlobVersion:     0,
+		BlobCommitments: commitments,
+		QuorumNumbers:   []core.QuorumID{0},
+		PaymentMetadata: core.PaymentMetadata{
+			AccountID:         gethcommon.HexToAddress("0x1234"),
+			Timestamp:         0,
+			CumulativePayment: big.NewInt(533),
+		},
+	}
+	blobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+	now := time.Now()
+	err = c.BlobMetadataStore.PutBlobMetadata(ctx, &dispv2.BlobMetadata{
+		BlobHeader: blobHeader,
+		BlobStatus: dispv2.Queued,
+		Expiry:     uint64(now.Add(time.Hour).Unix()),
+		NumRetries: 0,
+		UpdatedAt:  uint64(now.UnixNano()),
+	})
+	require.NoError(t, err)
+
+	stream := &subscribeBlobStatusStream{
+		ctx:     ctx,
+		replies: make(chan *pbv2.BlobStatusReply, 10),
+	}
+	subscriptionErr := make(chan error, 1)
+	go func() {
+		subscriptionErr <- c.DispersalServerV2.SubscribeBlobStatus(
+			&pbv2.BlobStatusRequest{BlobKey: blobKey[:]}, stream)
+	}()
+
+	// The current status is sent right away, and then each status transition is sent once.
+	reply := <-stream.replies
+	require.Equal(t, pbv2.BlobStatus_QUEUED, reply.GetStatus())
+
+	// Subscriptions beyond the configured limit are rejected.
+	otherStream := &subscribeBlobStatusStream{
+		ctx:     ctx,
+		replies: make(chan *pbv2.BlobStatusReply, 10),
+	}
+	err = c.DispersalServerV2.SubscribeBlobStatus(&pbv2.BlobStatusRequest{BlobKey: blobKey[:]}, otherStream)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	reply = <-otherStream.replies
+	require.Equal(t, pbv2.BlobStatus_QUEUED, reply.GetStatus())
+
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey, dispv2.Encoded)
+	require.NoError(t, err)
+	reply = <-stream.replies
+	require.Equal(t, pbv2.BlobStatus_ENCODED, reply.GetStatus())
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey, dispv2.Failed)
+	require.NoError(t, err)
+	reply = <-stream.replies
+	require.Equal(t, pbv2.BlobStatus_FAILED, reply.GetStatus())
+
+	// The stream is closed once the blob reaches a terminal status.
+	require.NoError(t, <-subscriptionErr)
+	require.Empty(t, stream.replies)
+
+	// Invalid blob keys are rejected.
+	err = c.DispersalServerV2.SubscribeBlobStatus(&pbv2.BlobStatusRequest{BlobKey: []byte{1, 2, 3}}, stream)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Unknown blobs are rejected right away, rather than once the subscription times out.
+	unknownBlobKey := corev2.BlobKey{1, 2, 3}
+	err = c.DispersalServerV2.SubscribeBlobStatus(&pbv2.BlobStatusRequest{BlobKey: unknownBlobKey[:]}, stream)
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Empty(t, stream.replies)
+}
+
 func TestV2GetBlobCommitment(t *testing.T) {
 	ctx := t.Context()
 	c := newTestServerV2(t)
@@ -669,6 +767,10 @@ func newTestServerV2WithDeprecationFlag(t *testing.T, disableGetBlobCommitment b
 			GrpcPort:                           "51002",
 			GrpcTimeout:                        1 * time.Second,
 			DisableGetBlobCommitment:           disableGetBlobCommitment,
+			BlobStatusSubscriptionPollInterval: 10 * time.Millisecond,
+			BlobStatusSubscriptionTimeout:      time.Minute,
+			MaxBlobStatusSubscriptions:         1,
+			MaxConcurrentBlobStatusLookups:     4,
 			DisperserId:                        0,
 			TolerateMissingAnchorSignature:     false,
 			DisableAnchorSignatureVerification: false,
@@ -0,0 +1,110 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func (s *DispersalServerV2) SubscribeBlobStatus(
+	req *pb.BlobStatusRequest,
+	stream pb.Disperser_SubscribeBlobStatusServer,
+) error {
+	s.metrics.reportBlobStatusSubscriptionStarted()
+	defer s.metrics.reportBlobStatusSubscriptionEnded()
+
+	st := s.subscribeBlobStatus(stream.Context(), req, stream)
+	api.LogResponseStatus(s.logger, st)
+	if st != nil {
+		// nolint:wrapcheck
+		return st.Err()
+	}
+	return nil
+}
+
+// subscribeBlobStatus sends the blob's current BlobStatusReply on the stream, and then sends a new reply each time
+// the reply changes. This includes changes to the attestation of a blob that is gathering signatures, so that clients
+// can stop waiting as soon as their confirmation thresholds are met. Status transitions are picked up by the server's
+// shared blobStatusWatcher.
+//
+// Returns once the blob reaches a terminal status, the client goes away, or the subscription times out. Unknown blobs
+// are rejected right away.
+func (s *DispersalServerV2) subscribeBlobStatus(
+	ctx context.Context,
+	req *pb.BlobStatusRequest,
+	stream pb.Disperser_SubscribeBlobStatusServer,
+) *status.Status {
+	ctx, cancel := context.WithTimeout(ctx, s.serverConfig.BlobStatusSubscriptionTimeout)
+	defer cancel()
+
+	var previousReply *pb.BlobStatusReply
+	reply, st := s.getBlobStatus(ctx, req)
+	switch st.Code() {
+	case codes.OK:
+		err := stream.Send(reply)
+		if err != nil {
+			return status.Convert(err)
+		}
+		if isTerminalBlobStatus(reply.GetStatus()) {
+			return status.New(codes.OK, "")
+		}
+		previousReply = reply
+	case codes.InvalidArgument, codes.NotFound:
+		return st
+	default:
+		// The metadata store may be caught in the middle of a transition (e.g. a blob gathering signatures whose
+		// attestation isn't stored yet), so other errors are retried until the subscription times out.
+		s.logger.Debug("get blob status for subscription", "code", st.Code(), "message", st.Message())
+	}
+
+	// getBlobStatus has already validated the blob key.
+	blobKey, err := corev2.BytesToBlobKey(req.GetBlobKey())
+	if err != nil {
+		return status.New(codes.InvalidArgument, err.Error())
+	}
+	sub, err := s.blobStatusWatcher.subscribe(blobKey)
+	if err != nil {
+		return status.New(codes.ResourceExhausted, err.Error())
+	}
+	defer s.blobStatusWatcher.unsubscribe(sub)
+
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return status.Newf(codes.DeadlineExceeded,
+					"blob did not reach a terminal status within %v, last status was %v",
+					s.serverConfig.BlobStatusSubscriptionTimeout, previousReply.GetStatus())
+			}
+			return status.FromContextError(ctx.Err())
+		case update := <-sub.updates:
+			if update.st.Code() != codes.OK {
+				s.logger.Debug("get blob status for subscription",
+					"code", update.st.Code(), "message", update.st.Message())
+				continue
+			}
+			if proto.Equal(update.reply, previousReply) {
+				continue
+			}
+			err := stream.Send(update.reply)
+			if err != nil {
+				return status.Convert(err)
+			}
+			previousReply = update.reply
+			if isTerminalBlobStatus(previousReply.GetStatus()) {
+				return status.New(codes.OK, "")
+			}
+		}
+	}
+}
+
+// isTerminalBlobStatus returns true if a blob with the given status won't transition to another status.
+func isTerminalBlobStatus(blobStatus pb.BlobStatus) bool {
+	return blobStatus == pb.BlobStatus_COMPLETE || blobStatus == pb.BlobStatus_FAILED
+}
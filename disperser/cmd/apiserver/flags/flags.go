@@ -274,6 +274,34 @@ var (
 		Value:    time.Minute,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SIGNING_RATE_POLL_INTERVAL"),
 	}
+	BlobStatusSubscriptionPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-status-subscription-poll-interval"),
+		Usage:    "The interval at which SubscribeBlobStatus streams check the metadata store for blob status transitions",
+		Required: false,
+		Value:    500 * time.Millisecond,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_STATUS_SUBSCRIPTION_POLL_INTERVAL"),
+	}
+	BlobStatusSubscriptionTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-status-subscription-timeout"),
+		Usage:    "The maximum lifetime of a SubscribeBlobStatus stream",
+		Required: false,
+		Value:    5 * time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_STATUS_SUBSCRIPTION_TIMEOUT"),
+	}
+	MaxBlobStatusSubscriptionsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-blob-status-subscriptions"),
+		Usage:    "The maximum number of concurrent SubscribeBlobStatus streams",
+		Required: false,
+		Value:    10000,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_BLOB_STATUS_SUBSCRIPTIONS"),
+	}
+	MaxConcurrentBlobStatusLookupsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-concurrent-blob-status-lookups"),
+		Usage:    "The maximum number of blob status lookups made concurrently for SubscribeBlobStatus streams",
+		Required: false,
+		Value:    32,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_CONCURRENT_BLOB_STATUS_LOOKUPS"),
+	}
 	DisperserIdFlag = cli.Uint64Flag{
 		Name:     common.PrefixFlag(FlagPrefix, "disperser-id"),
 		Usage:    "Unique identifier for this disperser instance",
@@ -363,6 +391,10 @@ var optionalFlags = []cli.Flag{
 	DisablePerAccountMetricsFlag,
 	SigningRateRetentionPeriodFlag,
 	SigningRatePollIntervalFlag,
+	BlobStatusSubscriptionPollIntervalFlag,
+	BlobStatusSubscriptionTimeoutFlag,
+	MaxBlobStatusSubscriptionsFlag,
+	MaxConcurrentBlobStatusLookupsFlag,
 	TolerateMissingAnchorSignatureFlag,
 	DisableAnchorSignatureVerificationFlag,
 	OperatorStateRetrieverFlag,
@@ -35,6 +35,20 @@ type ServerConfig struct {
 	// The interval at which to poll for signing rate data from the controller.
 	SigningRatePollInterval time.Duration
 
+	// The interval at which SubscribeBlobStatus streams check the blob metadata store for status transitions.
+	BlobStatusSubscriptionPollInterval time.Duration
+
+	// The maximum lifetime of a SubscribeBlobStatus stream. Clients still waiting for a terminal status when the
+	// stream is closed can resubscribe.
+	BlobStatusSubscriptionTimeout time.Duration
+
+	// The maximum number of concurrent SubscribeBlobStatus streams. Streams beyond this limit are rejected with
+	// ResourceExhausted, and clients are expected to fall back to polling GetBlobStatus.
+	MaxBlobStatusSubscriptions int
+
+	// The maximum number of blobs whose status is looked up concurrently on behalf of SubscribeBlobStatus streams.
+	MaxConcurrentBlobStatusLookups int
+
 	// Unique identifier for this disperser instance.
 	DisperserId uint32
 
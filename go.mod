@@ -23,6 +23,7 @@ require (
 	github.com/Layr-Labs/eigenda/api/proxy/clients v0.1.0
 	github.com/Layr-Labs/eigensdk-go v0.2.0-beta.1.0.20250118004418-2a25f31b3b28
 	github.com/Layr-Labs/eigensdk-go/signer v0.0.0-20250118004418-2a25f31b3b28
+	github.com/alicebob/miniredis/v2 v2.35.0
 	github.com/avast/retry-go/v4 v4.6.0
 	github.com/aws/aws-sdk-go-v2 v1.26.1
 	github.com/aws/aws-sdk-go-v2/credentials v1.17.11
@@ -44,10 +45,12 @@ require (
 	github.com/ingonyama-zk/icicle/v3 v3.9.2
 	github.com/jedib0t/go-pretty/v6 v6.5.9
 	github.com/joho/godotenv v1.5.1
+	github.com/klauspost/compress v1.18.0
 	github.com/minio/minio-go/v7 v7.0.85
 	github.com/oracle/oci-go-sdk/v65 v65.78.0
 	github.com/pingcap/errors v0.11.4
 	github.com/prometheus/client_golang v1.21.1
+	github.com/redis/go-redis/v9 v9.7.3
 	github.com/shurcooL/graphql v0.0.0-20230722043721-ed46e5a46466
 	github.com/stretchr/testify v1.11.1
 	github.com/swaggo/swag v1.16.2
@@ -168,7 +171,6 @@ require (
 	github.com/josharian/intern v1.0.0 // indirect
 	github.com/jpillora/backoff v1.0.0 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
-	github.com/klauspost/compress v1.18.0 // indirect
 	github.com/klauspost/cpuid/v2 v2.2.9 // indirect
 	github.com/kr/pretty v0.3.1 // indirect
 	github.com/kr/text v0.2.0 // indirect
@@ -276,4 +278,8 @@ require (
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
 )
 
-require github.com/sony/gobreaker v0.5.0 // indirect
+require (
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/sony/gobreaker v0.5.0 // indirect
+	github.com/yuin/gopher-lua v1.1.1 // indirect
+)
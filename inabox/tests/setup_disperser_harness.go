@@ -1222,6 +1222,10 @@ func startAPIServer(
 		MaxConnectionAge:                   5 * time.Minute,
 		MaxConnectionAgeGrace:              30 * time.Second,
 		MaxIdleConnectionAge:               1 * time.Minute,
+		BlobStatusSubscriptionPollInterval: 100 * time.Millisecond,
+		BlobStatusSubscriptionTimeout:      5 * time.Minute,
+		MaxBlobStatusSubscriptions:         1000,
+		MaxConcurrentBlobStatusLookups:     32,
 		DisperserId:                        0,
 		TolerateMissingAnchorSignature:     false,
 		DisableAnchorSignatureVerification: false,
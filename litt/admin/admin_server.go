@@ -0,0 +1,251 @@
+package admin
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// TableSource returns the tables currently loaded by the database, keyed by table name. The returned map must not
+// be modified by the database after it is returned (i.e. it should be a copy).
+type TableSource func() map[string]litt.ManagedTable
+
+// Server is an HTTP server that exposes read-only information about a running LittDB instance. It is intended for
+// debugging live nodes without needing to stop them (which is required by CLI commands such as 'litt table-info').
+//
+// The following endpoints are supported:
+//
+//   - GET /tables: a list of all loaded tables, along with their sizes and key counts
+//   - GET /tables/{name}: detailed information about a table, including segment boundaries and GC status
+//   - GET /tables/{name}/keys/{key}: a point lookup for a hex encoded key. The value is only returned if the
+//     "value=true" query parameter is provided, otherwise only its size is returned.
+//
+// The server never creates, modifies, or deletes tables or data, and point lookups bypass the table caches.
+//
+// Only HTTP is supported. A gRPC variant was deliberately left out: LittDB is a library without any protobuf
+// definitions of its own, and the endpoints are intended to be queried by operators with tools like curl.
+type Server struct {
+	logger logging.Logger
+
+	// The source of tables to report on.
+	tables TableSource
+
+	// The listener the server is bound to.
+	listener net.Listener
+
+	// The underlying HTTP server.
+	server *http.Server
+}
+
+// TableSummary is the JSON representation of a table in the table listing.
+type TableSummary struct {
+	Name        string `json:"name"`
+	KeyCount    uint64 `json:"keyCount"`
+	Size        uint64 `json:"size"`
+	LogicalSize uint64 `json:"logicalSize"`
+}
+
+// TableListing is the JSON response for the table listing endpoint.
+type TableListing struct {
+	Tables      []*TableSummary `json:"tables"`
+	KeyCount    uint64          `json:"keyCount"`
+	Size        uint64          `json:"size"`
+	LogicalSize uint64          `json:"logicalSize"`
+}
+
+// TableDetails is the JSON response for the table details endpoint.
+type TableDetails struct {
+	Name                  string    `json:"name"`
+	KeyCount              uint64    `json:"keyCount"`
+	Size                  uint64    `json:"size"`
+	LogicalSize           uint64    `json:"logicalSize"`
+	LowestSegmentIndex    uint32    `json:"lowestSegmentIndex"`
+	HighestSegmentIndex   uint32    `json:"highestSegmentIndex"`
+	OldestSegmentSealTime time.Time `json:"oldestSegmentSealTime"`
+	NewestSegmentSealTime time.Time `json:"newestSegmentSealTime"`
+	KeymapType            string    `json:"keymapType"`
+	TTL                   string    `json:"ttl"`
+	ShardingFactor        uint32    `json:"shardingFactor"`
+	Compression           string    `json:"compression"`
+	GCEnabled             bool      `json:"gcEnabled"`
+	LastGCTime            time.Time `json:"lastGCTime"`
+	GCSegmentsDeleted     uint64    `json:"gcSegmentsDeleted"`
+}
+
+// KeyLookup is the JSON response for the key lookup endpoint.
+type KeyLookup struct {
+	Key       string `json:"key"`
+	Exists    bool   `json:"exists"`
+	ValueSize int    `json:"valueSize"`
+	// The hex encoded value. Only populated if requested.
+	Value string `json:"value,omitempty"`
+}
+
+// NewServer creates a new admin server listening on the given address (e.g. "127.0.0.1:9102") and starts serving
+// requests in the background. The caller is responsible for calling Close() when the server is no longer needed.
+func NewServer(logger logging.Logger, address string, tables TableSource) (*Server, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+
+	s := &Server{
+		logger:   logger,
+		tables:   tables,
+		listener: listener,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /tables", s.handleListTables)
+	mux.HandleFunc("GET /tables/{name}", s.handleTableDetails)
+	mux.HandleFunc("GET /tables/{name}/keys/{key}", s.handleKeyLookup)
+
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	logger.Infof("Starting LittDB admin server at %s", listener.Addr())
+	go func() {
+		err := s.server.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Errorf("admin server error: %v", err)
+		}
+	}()
+
+	return s, nil
+}
+
+// Address returns the address the server is listening on.
+func (s *Server) Address() string {
+	return s.listener.Addr().String()
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	err := s.server.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close admin server: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) handleListTables(w http.ResponseWriter, _ *http.Request) {
+	tables := s.tables()
+
+	listing := &TableListing{
+		Tables: make([]*TableSummary, 0, len(tables)),
+	}
+	for name, table := range tables {
+		summary := &TableSummary{
+			Name:        name,
+			KeyCount:    table.KeyCount(),
+			Size:        table.Size(),
+			LogicalSize: table.LogicalSize(),
+		}
+		listing.Tables = append(listing.Tables, summary)
+		listing.KeyCount += summary.KeyCount
+		listing.Size += summary.Size
+		listing.LogicalSize += summary.LogicalSize
+	}
+	sort.Slice(listing.Tables, func(i, j int) bool {
+		return listing.Tables[i].Name < listing.Tables[j].Name
+	})
+
+	s.writeJSON(w, http.StatusOK, listing)
+}
+
+func (s *Server) handleTableDetails(w http.ResponseWriter, r *http.Request) {
+	table, ok := s.getTable(w, r)
+	if !ok {
+		return
+	}
+
+	info, err := table.Info()
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to get table info: %w", err))
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, &TableDetails{
+		Name:                  info.Name,
+		KeyCount:              info.KeyCount,
+		Size:                  info.Size,
+		LogicalSize:           info.LogicalSize,
+		LowestSegmentIndex:    info.LowestSegmentIndex,
+		HighestSegmentIndex:   info.HighestSegmentIndex,
+		OldestSegmentSealTime: info.OldestSegmentSealTime,
+		NewestSegmentSealTime: info.NewestSegmentSealTime,
+		KeymapType:            info.KeymapType,
+		TTL:                   info.TTL.String(),
+		ShardingFactor:        info.ShardingFactor,
+		Compression:           info.Compression.String(),
+		GCEnabled:             info.TTL > 0,
+		LastGCTime:            info.LastGCTime,
+		GCSegmentsDeleted:     info.GCSegmentsDeleted,
+	})
+}
+
+func (s *Server) handleKeyLookup(w http.ResponseWriter, r *http.Request) {
+	table, ok := s.getTable(w, r)
+	if !ok {
+		return
+	}
+
+	key, err := hex.DecodeString(r.PathValue("key"))
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, fmt.Errorf("key must be hex encoded: %w", err))
+		return
+	}
+
+	// Lookups bypass the cache so that inspecting a live node doesn't change what production reads see as hot.
+	value, exists, err := table.UncachedGet(key)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to look up key: %w", err))
+		return
+	}
+
+	lookup := &KeyLookup{
+		Key:       hex.EncodeToString(key),
+		Exists:    exists,
+		ValueSize: len(value),
+	}
+	if exists && r.URL.Query().Get("value") == "true" {
+		lookup.Value = hex.EncodeToString(value)
+	}
+
+	s.writeJSON(w, http.StatusOK, lookup)
+}
+
+// getTable looks up the table named in the request path. If the table does not exist, an error response is written
+// and false is returned.
+func (s *Server) getTable(w http.ResponseWriter, r *http.Request) (litt.ManagedTable, bool) {
+	name := r.PathValue("name")
+	table, ok := s.tables()[name]
+	if !ok {
+		s.writeError(w, http.StatusNotFound, fmt.Errorf("table %s not found", name))
+		return nil, false
+	}
+	return table, true
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		s.logger.Errorf("failed to write admin server response: %v", err)
+	}
+}
+
+func (s *Server) writeError(w http.ResponseWriter, status int, err error) {
+	s.writeJSON(w, status, map[string]string{"error": err.Error()})
+}
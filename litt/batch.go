@@ -0,0 +1,34 @@
+package litt
+
+// Batch is a group of writes and deletions, potentially spanning multiple tables, that is committed as a single unit.
+// A batch is atomic with respect to crash recovery: if the process crashes while a batch is being committed, then
+// when the database is restarted either all operations in the batch are visible, or none of them are.
+//
+// Note that a batch is not isolated from concurrent readers. While Commit() is executing, a concurrent reader may
+// observe some operations in the batch but not others. Once Commit() returns successfully, all operations in the
+// batch are visible and crash durable.
+//
+// A batch is not thread safe, and should only be used by a single goroutine. A batch may only be committed once.
+type Batch interface {
+	// Put adds a write to the batch. The write is not applied until Commit() is called. The same restrictions that
+	// apply to Table.Put() apply here (i.e. a key that is already present in the table may not be written again).
+	//
+	// It is not safe to modify the byte slices passed to this function after the call (both the key and the value).
+	Put(tableName string, key []byte, value []byte)
+
+	// Delete adds a deletion to the batch. The deletion is not applied until Commit() is called. Deleting a key that
+	// does not exist is a no-op.
+	//
+	// It is not safe to modify the key byte slice after it is passed to this method.
+	Delete(tableName string, key []byte)
+
+	// Size returns the number of operations (writes and deletions) in the batch.
+	Size() int
+
+	// Commit applies all operations in the batch, in the order they were added, and makes them crash durable.
+	// Tables referenced by the batch that do not yet exist are created. If the batch is invalid (e.g. it references
+	// an invalid table name, contains a nil key or value, or exceeds a quota), then Commit() returns an error without
+	// applying any operation. If the batch fails while being applied to its tables (e.g. due to an I/O error), then
+	// some of its operations may be visible until the database is restarted, at which point the batch is completed.
+	Commit() error
+}
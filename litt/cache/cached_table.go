@@ -48,6 +48,10 @@ func (c *cachedTable) Size() uint64 {
 	return c.base.Size()
 }
 
+func (c *cachedTable) LogicalSize() uint64 {
+	return c.base.LogicalSize()
+}
+
 func (c *cachedTable) Name() string {
 	return c.base.Name()
 }
@@ -72,6 +76,27 @@ func (c *cachedTable) PutBatch(batch []*types.KVPair) error {
 	return nil
 }
 
+func (c *cachedTable) Delete(key []byte) error {
+	return c.DeleteBatch([][]byte{key})
+}
+
+// Note that a read that is concurrent with a deletion may re-insert the deleted value into the read cache if
+// the read fetches the value from the base table before the deletion and inserts it into the read cache after
+// the deletion. Callers that require a deleted key to be immediately and permanently invisible should not read
+// that key concurrently with its deletion.
+func (c *cachedTable) DeleteBatch(keys [][]byte) error {
+	err := c.base.DeleteBatch(keys)
+	if err != nil {
+		return fmt.Errorf("failed to delete entries from base table: %w", err)
+	}
+	for _, key := range keys {
+		stringKey := util.UnsafeBytesToString(key)
+		c.writeCache.Remove(stringKey)
+		c.readCache.Remove(stringKey)
+	}
+	return nil
+}
+
 func (c *cachedTable) Get(key []byte) (value []byte, exists bool, err error) {
 	value, exists, _, err = c.CacheAwareGet(key, false)
 	return value, exists, err
@@ -156,6 +181,12 @@ func (c *cachedTable) CacheAwareGet(
 	return value, exists, hot, err
 }
 
+// Values are written to the base table before they are written to the write cache, so the base table always has
+// the current value for a key.
+func (c *cachedTable) UncachedGet(key []byte) (value []byte, exists bool, err error) {
+	return c.base.UncachedGet(key)
+}
+
 func (c *cachedTable) Exists(key []byte) (exists bool, err error) {
 	_, exists = c.writeCache.Get(util.UnsafeBytesToString(key))
 	if exists {
@@ -170,6 +201,21 @@ func (c *cachedTable) Exists(key []byte) (exists bool, err error) {
 	return c.base.Exists(key)
 }
 
+// Iteration bypasses the caches entirely. Iterating over a table touches every value in the range, and inserting
+// those values into the read cache would evict data that is likely to be more useful.
+
+func (c *cachedTable) Iterator() (litt.Iterator, error) {
+	return c.base.Iterator()
+}
+
+func (c *cachedTable) PrefixIterator(prefix []byte) (litt.Iterator, error) {
+	return c.base.PrefixIterator(prefix)
+}
+
+func (c *cachedTable) RangeIterator(start []byte, end []byte) (litt.Iterator, error) {
+	return c.base.RangeIterator(start, end)
+}
+
 func (c *cachedTable) Flush() error {
 	return c.base.Flush()
 }
@@ -208,6 +254,18 @@ func (c *cachedTable) SetShardingFactor(shardingFactor uint32) error {
 	return c.base.SetShardingFactor(shardingFactor)
 }
 
+func (c *cachedTable) SetCompression(compression types.CompressionType) error {
+	return c.base.SetCompression(compression)
+}
+
+func (c *cachedTable) Info() (*litt.TableInfo, error) {
+	return c.base.Info()
+}
+
 func (c *cachedTable) RunGC() error {
 	return c.base.RunGC()
 }
+
+func (c *cachedTable) EvictOldestSegments(bytes uint64) (uint64, error) {
+	return c.base.EvictOldestSegments(bytes)
+}
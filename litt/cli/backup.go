@@ -0,0 +1,360 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/s3/aws"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/urfave/cli/v2"
+)
+
+// The directory within a backup target where manifests are stored.
+const backupManifestDirectory = "manifests"
+
+// The directory within a backup target where segment files are stored.
+const backupSegmentDirectory = "segments"
+
+// The directory within a backup target where table metadata files are stored.
+const backupTableMetadataDirectory = "metadata"
+
+// The file extension for backup manifests.
+const backupManifestExtension = ".json"
+
+// backupManifest describes a single incremental backup. Each backup contains only the segments that were sealed
+// since the previous backup, so restoring a database requires the full chain of manifests up to the desired point
+// in time. Manifests are serialized as JSON, since operators may want to inspect them by hand.
+type backupManifest struct {
+	// The sequence number of the backup. The first backup has sequence number 1, and each subsequent backup
+	// increments the sequence number by 1.
+	Sequence uint64 `json:"sequence"`
+
+	// The time when the backup was made, in unix nanoseconds.
+	Timestamp int64 `json:"timestamp"`
+
+	// The tables with new data in this backup, keyed by table name.
+	Tables map[string]*tableBackupManifest `json:"tables"`
+}
+
+// tableBackupManifest describes the data from a single table contained in a backup.
+type tableBackupManifest struct {
+	// The index of the lowest segment in this backup (inclusive).
+	LowestSegmentIndex uint32 `json:"lowestSegmentIndex"`
+
+	// The index of the highest segment in this backup (inclusive).
+	HighestSegmentIndex uint32 `json:"highestSegmentIndex"`
+
+	// The names of all segment files in this backup.
+	Files []string `json:"files"`
+
+	// True if the table's metadata file was included in this backup.
+	HasTableMetadata bool `json:"hasTableMetadata"`
+}
+
+// backupManifestName returns the name of the manifest with the given sequence number. Sequence numbers are zero
+// padded so that lexicographic order matches numeric order.
+func backupManifestName(sequence uint64) string {
+	return path.Join(backupManifestDirectory, fmt.Sprintf("%020d%s", sequence, backupManifestExtension))
+}
+
+// backupSegmentFileName returns the name of a segment file within a backup target.
+func backupSegmentFileName(tableName string, fileName string) string {
+	return path.Join(backupSegmentDirectory, tableName, fileName)
+}
+
+// backupTableMetadataName returns the name of a table metadata file within a backup target.
+func backupTableMetadataName(sequence uint64, tableName string) string {
+	return path.Join(backupTableMetadataDirectory, strconv.FormatUint(sequence, 10), tableName,
+		disktable.TableMetadataFileName)
+}
+
+// loadBackupManifests loads all manifests from a backup target, sorted by sequence number. Returns an error if
+// the chain of manifests is broken (i.e. if any sequence number is missing).
+func loadBackupManifests(target backupTarget) ([]*backupManifest, error) {
+	names, err := target.List(backupManifestDirectory + "/")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list manifests in %s: %w", target, err)
+	}
+
+	manifests := make([]*backupManifest, 0, len(names))
+	for _, name := range names {
+		if !strings.HasSuffix(name, backupManifestExtension) {
+			continue
+		}
+
+		data, err := target.Read(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest %s: %w", name, err)
+		}
+
+		manifest := &backupManifest{}
+		err = json.Unmarshal(data, manifest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse manifest %s: %w", name, err)
+		}
+
+		expectedSequence := uint64(len(manifests) + 1)
+		if manifest.Sequence != expectedSequence {
+			return nil, fmt.Errorf("broken manifest chain in %s: expected sequence %d, found %d (%s)",
+				target, expectedSequence, manifest.Sequence, name)
+		}
+
+		manifests = append(manifests, manifest)
+	}
+
+	return manifests, nil
+}
+
+// buildBackupTarget builds a backup target from a location string. Locations starting with "s3://" are treated as
+// S3 buckets, all other locations are treated as local directories.
+func buildBackupTarget(ctx *cli.Context, logger logging.Logger, location string) (backupTarget, error) {
+	if !strings.HasPrefix(location, s3BackupScheme) {
+		return newLocalBackupTarget(location, true)
+	}
+
+	client, err := aws.NewAwsS3Client(
+		ctx.Context,
+		logger,
+		ctx.String(s3EndpointFlag.Name),
+		ctx.String(s3RegionFlag.Name),
+		0,
+		int(ctx.Uint64("threads")),
+		ctx.String(s3AccessKeyFlag.Name),
+		ctx.String(s3SecretKeyFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	return newS3BackupTarget(ctx.Context, client, location)
+}
+
+// backupCommand makes an incremental backup of a LittDB database/snapshot.
+func backupCommand(ctx *cli.Context) error {
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	sources := ctx.StringSlice("src")
+	if len(sources) == 0 {
+		return fmt.Errorf("no sources provided")
+	}
+	for i, src := range sources {
+		var err error
+		sources[i], err = util.SanitizePath(src)
+		if err != nil {
+			return fmt.Errorf("invalid source path: %s", src)
+		}
+	}
+
+	target, err := buildBackupTarget(ctx, logger, ctx.String("dst"))
+	if err != nil {
+		return fmt.Errorf("failed to build backup target: %w", err)
+	}
+
+	_, err = backup(logger, sources, target, time.Now(), true)
+	return err
+}
+
+// backup writes an incremental backup of a LittDB database/snapshot to the backup target. Only segments that are not
+// already present in the backup are written. Returns the manifest of the new backup, or nil if there was no new
+// data to back up.
+//
+// The manifest is written only after all segment files have been written. If a backup is interrupted, the partially
+// written segment files are not referenced by any manifest, and are overwritten by the next backup.
+func backup(
+	logger logging.Logger,
+	sources []string,
+	target backupTarget,
+	now time.Time,
+	fsync bool) (*backupManifest, error) {
+
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no source paths provided")
+	}
+
+	// Forbid touching tables in active use.
+	releaseLocks, err := util.LockDirectories(logger, sources, util.LockfileName, fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire locks on paths %v: %w", sources, err)
+	}
+	defer releaseLocks()
+
+	manifests, err := loadBackupManifests(target)
+	if err != nil {
+		return nil, err
+	}
+
+	// For each table, determine the highest segment index that has already been backed up.
+	backedUpIndices := make(map[string]uint32)
+	for _, manifest := range manifests {
+		for tableName, tableManifest := range manifest.Tables {
+			backedUpIndices[tableName] = max(backedUpIndices[tableName], tableManifest.HighestSegmentIndex)
+		}
+	}
+
+	tables, err := lsPaths(logger, sources, false, fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables in paths %v: %w", sources, err)
+	}
+
+	sequence := uint64(len(manifests) + 1)
+	manifest := &backupManifest{
+		Sequence:  sequence,
+		Timestamp: now.UnixNano(),
+		Tables:    make(map[string]*tableBackupManifest),
+	}
+
+	for _, tableName := range tables {
+		backedUpIndex, previouslyBackedUp := backedUpIndices[tableName]
+
+		tableManifest, err := backupTable(
+			logger, sources, tableName, target, sequence, backedUpIndex, previouslyBackedUp, now, fsync)
+		if err != nil {
+			return nil, fmt.Errorf("failed to back up table %s: %w", tableName, err)
+		}
+		if tableManifest != nil {
+			manifest.Tables[tableName] = tableManifest
+		}
+	}
+
+	if len(manifest.Tables) == 0 {
+		logger.Infof("No new data to back up to %s", target)
+		return nil, nil
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize manifest: %w", err)
+	}
+	err = target.Write(backupManifestName(sequence), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	logger.Infof("Wrote backup %d to %s", sequence, target)
+
+	return manifest, nil
+}
+
+// backupTable writes the segments of a single table that are not yet present in the backup target. Returns nil if
+// there are no new segments.
+func backupTable(
+	logger logging.Logger,
+	sources []string,
+	tableName string,
+	target backupTarget,
+	sequence uint64,
+	backedUpIndex uint32,
+	previouslyBackedUp bool,
+	now time.Time,
+	fsync bool) (*tableBackupManifest, error) {
+
+	segmentPaths, err := segment.BuildSegmentPaths(sources, "", tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build segment paths for table %s at paths %v: %w",
+			tableName, sources, err)
+	}
+
+	errorMonitor := util.NewErrorMonitor(context.Background(), logger, nil)
+
+	lowestSegmentIndex, highestSegmentIndex, segments, err := segment.GatherSegmentFiles(
+		logger,
+		errorMonitor,
+		segmentPaths,
+		false,
+		now,
+		false,
+		fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to gather segment files for table %s at paths %v: %w",
+			tableName, sources, err)
+	}
+
+	if len(segments) == 0 {
+		return nil, nil
+	}
+
+	// Special handling if we are backing up data from a snapshot.
+	isSnapshot, err := segments[lowestSegmentIndex].IsSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if segment %d is a snapshot: %w", lowestSegmentIndex, err)
+	}
+	if isSnapshot {
+		if len(sources) > 1 {
+			return nil, fmt.Errorf("table %s is a snapshot, but more than one source directory found: %v",
+				tableName, sources)
+		}
+
+		boundaryFile, err := disktable.LoadBoundaryFile(disktable.UpperBound, path.Join(sources[0], tableName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load boundary file for table %s at path %s: %w",
+				tableName, sources[0], err)
+		}
+
+		if boundaryFile.IsDefined() {
+			highestSegmentIndex = min(highestSegmentIndex, boundaryFile.BoundaryIndex())
+		}
+	}
+
+	firstSegmentIndex := lowestSegmentIndex
+	if previouslyBackedUp {
+		if backedUpIndex >= highestSegmentIndex {
+			// Nothing new to back up.
+			return nil, nil
+		}
+		if backedUpIndex+1 < lowestSegmentIndex {
+			logger.Warnf("table %s: segments %d through %d were deleted before they were backed up",
+				tableName, backedUpIndex+1, lowestSegmentIndex-1)
+		}
+		firstSegmentIndex = max(lowestSegmentIndex, backedUpIndex+1)
+	}
+
+	tableManifest := &tableBackupManifest{
+		LowestSegmentIndex:  firstSegmentIndex,
+		HighestSegmentIndex: highestSegmentIndex,
+		Files:               make([]string, 0),
+	}
+
+	for i := firstSegmentIndex; i <= highestSegmentIndex; i++ {
+		for _, filePath := range segments[i].GetFilePaths() {
+			fileName := path.Base(filePath)
+			err = target.Upload(filePath, backupSegmentFileName(tableName, fileName))
+			if err != nil {
+				return nil, fmt.Errorf("failed to upload segment file %s: %w", filePath, err)
+			}
+			tableManifest.Files = append(tableManifest.Files, fileName)
+		}
+	}
+
+	// The table metadata file lives in exactly one of the source directories (it is absent from snapshots).
+	for _, source := range sources {
+		metadataPath := path.Join(source, tableName, disktable.TableMetadataFileName)
+		exists, err := util.Exists(metadataPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if %s exists: %w", metadataPath, err)
+		}
+		if exists {
+			err = target.Upload(metadataPath, backupTableMetadataName(sequence, tableName))
+			if err != nil {
+				return nil, fmt.Errorf("failed to upload table metadata %s: %w", metadataPath, err)
+			}
+			tableManifest.HasTableMetadata = true
+			break
+		}
+	}
+
+	logger.Infof("table %s: backed up segments %d through %d (%d files)",
+		tableName, firstSegmentIndex, highestSegmentIndex, len(tableManifest.Files))
+
+	return tableManifest, nil
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	s3common "github.com/Layr-Labs/eigenda/common/s3"
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+// The URL scheme used to specify an S3 backup target, e.g. "s3://bucket/prefix".
+const s3BackupScheme = "s3://"
+
+// backupTarget is a location where LittDB backups are stored. Objects in a backup target are addressed by
+// slash-separated relative names (e.g. "manifests/00000001.json").
+type backupTarget interface {
+	// Upload copies the local file at localPath into the backup target under the given name.
+	Upload(localPath string, name string) error
+
+	// Download copies the object with the given name from the backup target to the local file at localPath.
+	Download(name string, localPath string) error
+
+	// Write writes a small object into the backup target. The write is atomic.
+	Write(name string, data []byte) error
+
+	// Read reads a small object from the backup target. Returns an error if the object does not exist.
+	Read(name string) ([]byte, error)
+
+	// List returns the names of all objects in the backup target whose names start with the given prefix,
+	// in lexicographic order.
+	List(prefix string) ([]string, error)
+
+	// String returns a human-readable description of the backup target.
+	String() string
+}
+
+var _ backupTarget = &localBackupTarget{}
+
+// localBackupTarget is a backupTarget that stores backups in a local directory (which may be a network mount).
+type localBackupTarget struct {
+	// The root directory of the backup.
+	root string
+
+	// If true, then files written to the backup are synced to disk.
+	fsync bool
+}
+
+// newLocalBackupTarget creates a backup target that stores data in a local directory.
+func newLocalBackupTarget(root string, fsync bool) (*localBackupTarget, error) {
+	root, err := util.SanitizePath(root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup path %s: %w", root, err)
+	}
+
+	err = util.EnsureDirectoryExists(root, fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup directory %s: %w", root, err)
+	}
+
+	return &localBackupTarget{
+		root:  root,
+		fsync: fsync,
+	}, nil
+}
+
+func (t *localBackupTarget) Upload(localPath string, name string) error {
+	destination := path.Join(t.root, name)
+	err := util.EnsureParentDirectoryExists(destination, t.fsync)
+	if err != nil {
+		return fmt.Errorf("failed to create parent directory for %s: %w", destination, err)
+	}
+
+	err = util.CopyRegularFile(localPath, destination, t.fsync)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s to %s: %w", localPath, destination, err)
+	}
+	return nil
+}
+
+func (t *localBackupTarget) Download(name string, localPath string) error {
+	err := util.EnsureParentDirectoryExists(localPath, t.fsync)
+	if err != nil {
+		return fmt.Errorf("failed to create parent directory for %s: %w", localPath, err)
+	}
+
+	source := path.Join(t.root, name)
+	err = util.CopyRegularFile(source, localPath, t.fsync)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s to %s: %w", source, localPath, err)
+	}
+	return nil
+}
+
+func (t *localBackupTarget) Write(name string, data []byte) error {
+	destination := path.Join(t.root, name)
+	err := util.EnsureParentDirectoryExists(destination, t.fsync)
+	if err != nil {
+		return fmt.Errorf("failed to create parent directory for %s: %w", destination, err)
+	}
+
+	err = util.AtomicWrite(destination, data, t.fsync)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", destination, err)
+	}
+	return nil
+}
+
+func (t *localBackupTarget) Read(name string) ([]byte, error) {
+	data, err := os.ReadFile(path.Join(t.root, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return data, nil
+}
+
+func (t *localBackupTarget) List(prefix string) ([]string, error) {
+	names := make([]string, 0)
+
+	err := filepath.WalkDir(t.root, func(filePath string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasSuffix(filePath, util.SwapFileExtension) {
+			return nil
+		}
+
+		name, err := filepath.Rel(t.root, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path of %s: %w", filePath, err)
+		}
+		name = filepath.ToSlash(name)
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files in %s: %w", t.root, err)
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+func (t *localBackupTarget) String() string {
+	return t.root
+}
+
+var _ backupTarget = &s3BackupTarget{}
+
+// s3BackupTarget is a backupTarget that stores backups in an S3 compatible object store.
+type s3BackupTarget struct {
+	ctx    context.Context
+	client s3common.S3Client
+
+	// The bucket where backups are stored.
+	bucket string
+
+	// All objects in the backup are stored under this key prefix. Either empty or ends with a "/".
+	prefix string
+}
+
+// newS3BackupTarget creates a backup target that stores data in S3. The url has the form "s3://bucket/prefix".
+func newS3BackupTarget(ctx context.Context, client s3common.S3Client, url string) (*s3BackupTarget, error) {
+	if !strings.HasPrefix(url, s3BackupScheme) {
+		return nil, fmt.Errorf("invalid S3 URL %s, must start with %s", url, s3BackupScheme)
+	}
+
+	bucket, prefix, _ := strings.Cut(strings.TrimPrefix(url, s3BackupScheme), "/")
+	if bucket == "" {
+		return nil, fmt.Errorf("invalid S3 URL %s, bucket name is empty", url)
+	}
+	prefix = strings.Trim(prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	return &s3BackupTarget{
+		ctx:    ctx,
+		client: client,
+		bucket: bucket,
+		prefix: prefix,
+	}, nil
+}
+
+func (t *s3BackupTarget) Upload(localPath string, name string) error {
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", localPath, err)
+	}
+	return t.Write(name, data)
+}
+
+func (t *s3BackupTarget) Download(name string, localPath string) error {
+	data, err := t.Read(name)
+	if err != nil {
+		return err
+	}
+
+	err = util.EnsureParentDirectoryExists(localPath, true)
+	if err != nil {
+		return fmt.Errorf("failed to create parent directory for %s: %w", localPath, err)
+	}
+
+	err = util.AtomicWrite(localPath, data, true)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", localPath, err)
+	}
+	return nil
+}
+
+func (t *s3BackupTarget) Write(name string, data []byte) error {
+	err := t.client.UploadObject(t.ctx, t.bucket, t.prefix+name, data)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s to bucket %s: %w", t.prefix+name, t.bucket, err)
+	}
+	return nil
+}
+
+func (t *s3BackupTarget) Read(name string) ([]byte, error) {
+	data, found, err := t.client.DownloadObject(t.ctx, t.bucket, t.prefix+name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s from bucket %s: %w", t.prefix+name, t.bucket, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("object %s not found in bucket %s", t.prefix+name, t.bucket)
+	}
+	return data, nil
+}
+
+func (t *s3BackupTarget) List(prefix string) ([]string, error) {
+	objects, err := t.client.ListObjects(t.ctx, t.bucket, t.prefix+prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects in bucket %s: %w", t.bucket, err)
+	}
+
+	names := make([]string, 0, len(objects))
+	for _, object := range objects {
+		names = append(names, strings.TrimPrefix(object.Key, t.prefix))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (t *s3BackupTarget) String() string {
+	return s3BackupScheme + t.bucket + "/" + t.prefix
+}
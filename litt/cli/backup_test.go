@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/s3"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/test"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/stretchr/testify/require"
+)
+
+// writeRandomData writes random data into a LittDB instance and records it in expectedData.
+func writeRandomData(
+	t *testing.T,
+	rand *random.TestRandom,
+	rootPaths []string,
+	tableCount uint64,
+	expectedData map[string]map[string][]byte) {
+
+	config, err := litt.DefaultConfig(rootPaths...)
+	require.NoError(t, err)
+	config.Fsync = false
+	config.DoubleWriteProtection = true
+	config.ShardingFactor = uint32(len(rootPaths))
+	config.TargetSegmentFileSize = 100
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	for i := 0; i < 200; i++ {
+		tableName := fmt.Sprintf("table-%d", rand.Uint64Range(0, tableCount))
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+
+		key := rand.String(32)
+		value := rand.PrintableVariableBytes(1, 100)
+		err = table.Put([]byte(key), value)
+		require.NoError(t, err)
+
+		if _, ok := expectedData[tableName]; !ok {
+			expectedData[tableName] = make(map[string][]byte)
+		}
+		expectedData[tableName][key] = value
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+// checkData verifies that a LittDB instance contains exactly the expected data.
+func checkData(t *testing.T, rootPaths []string, expectedData map[string]map[string][]byte) {
+	config, err := litt.DefaultConfig(rootPaths...)
+	require.NoError(t, err)
+	config.Fsync = false
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	for tableName, tableData := range expectedData {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		require.Equal(t, uint64(len(tableData)), table.KeyCount())
+
+		for key, expectedValue := range tableData {
+			value, ok, err := table.Get([]byte(key))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func copyExpectedData(data map[string]map[string][]byte) map[string]map[string][]byte {
+	dataCopy := make(map[string]map[string][]byte, len(data))
+	for tableName, tableData := range data {
+		dataCopy[tableName] = make(map[string][]byte, len(tableData))
+		for key, value := range tableData {
+			dataCopy[tableName][key] = value
+		}
+	}
+	return dataCopy
+}
+
+func backupAndRestoreTest(t *testing.T, target backupTarget) {
+	logger := test.GetLogger()
+	rand := random.NewTestRandom()
+	testDirectory := t.TempDir()
+
+	rootPathCount := rand.Uint64Range(1, 4)
+	rootPaths := make([]string, rootPathCount)
+	for i := uint64(0); i < rootPathCount; i++ {
+		rootPaths[i] = path.Join(testDirectory, fmt.Sprintf("root-%d", i))
+	}
+	tableCount := rand.Uint64Range(2, 5)
+
+	expectedData := make(map[string]map[string][]byte)
+
+	// Make the first backup.
+	writeRandomData(t, rand, rootPaths, tableCount, expectedData)
+	firstBackupData := copyExpectedData(expectedData)
+
+	manifest, err := backup(logger, rootPaths, target, time.Now(), false)
+	require.NoError(t, err)
+	require.NotNil(t, manifest)
+	require.Equal(t, uint64(1), manifest.Sequence)
+	require.Equal(t, len(expectedData), len(manifest.Tables))
+	for _, tableManifest := range manifest.Tables {
+		require.True(t, tableManifest.HasTableMetadata)
+		require.Equal(t, uint32(0), tableManifest.LowestSegmentIndex)
+	}
+
+	// Nothing has changed, so there should be nothing to back up.
+	manifest, err = backup(logger, rootPaths, target, time.Now(), false)
+	require.NoError(t, err)
+	require.Nil(t, manifest)
+
+	// Make the second backup. It should only contain new segments.
+	writeRandomData(t, rand, rootPaths, tableCount, expectedData)
+
+	manifests, err := loadBackupManifests(target)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(manifests))
+
+	manifest, err = backup(logger, rootPaths, target, time.Now(), false)
+	require.NoError(t, err)
+	require.NotNil(t, manifest)
+	require.Equal(t, uint64(2), manifest.Sequence)
+	for tableName, tableManifest := range manifest.Tables {
+		if previous, ok := manifests[0].Tables[tableName]; ok {
+			require.Equal(t, previous.HighestSegmentIndex+1, tableManifest.LowestSegmentIndex)
+		}
+	}
+
+	// Restore the latest backup.
+	restorePaths := []string{path.Join(testDirectory, "restore-a"), path.Join(testDirectory, "restore-b")}
+	err = restore(logger, target, restorePaths, 0, false)
+	require.NoError(t, err)
+	checkData(t, restorePaths, expectedData)
+
+	// Restoring on top of existing data is not permitted.
+	err = restore(logger, target, restorePaths, 0, false)
+	require.Error(t, err)
+
+	// Restore to the point in time of the first backup.
+	pointInTimePaths := []string{path.Join(testDirectory, "restore-c")}
+	err = restore(logger, target, pointInTimePaths, 1, false)
+	require.NoError(t, err)
+	checkData(t, pointInTimePaths, firstBackupData)
+
+	// Restoring a backup that doesn't exist is an error.
+	err = restore(logger, target, []string{path.Join(testDirectory, "restore-d")}, 3, false)
+	require.Error(t, err)
+}
+
+func TestBackupAndRestoreLocal(t *testing.T) {
+	t.Parallel()
+
+	target, err := newLocalBackupTarget(path.Join(t.TempDir(), "backup"), false)
+	require.NoError(t, err)
+
+	backupAndRestoreTest(t, target)
+}
+
+func TestBackupAndRestoreS3(t *testing.T) {
+	t.Parallel()
+
+	target, err := newS3BackupTarget(t.Context(), s3.NewMockS3Client(), "s3://bucket/litt/backup")
+	require.NoError(t, err)
+	require.Equal(t, "s3://bucket/litt/backup/", target.String())
+
+	backupAndRestoreTest(t, target)
+}
+
+func TestBrokenManifestChain(t *testing.T) {
+	t.Parallel()
+
+	target, err := newLocalBackupTarget(t.TempDir(), false)
+	require.NoError(t, err)
+
+	err = target.Write(backupManifestName(1), []byte(`{"sequence": 1, "tables": {}}`))
+	require.NoError(t, err)
+	err = target.Write(backupManifestName(3), []byte(`{"sequence": 3, "tables": {}}`))
+	require.NoError(t, err)
+
+	_, err = loadBackupManifests(target)
+	require.Error(t, err)
+}
@@ -30,6 +30,27 @@ var (
 		Required: false,
 		Value:    "~/.ssh/known_hosts",
 	}
+	s3EndpointFlag = &cli.StringFlag{
+		Name:    "s3-endpoint",
+		Usage:   "Endpoint URL for an S3 compatible object store. If empty, the default AWS endpoint is used.",
+		EnvVars: []string{"LITT_S3_ENDPOINT"},
+	}
+	s3RegionFlag = &cli.StringFlag{
+		Name:    "s3-region",
+		Usage:   "Region of the S3 bucket.",
+		Value:   "us-east-1",
+		EnvVars: []string{"LITT_S3_REGION"},
+	}
+	s3AccessKeyFlag = &cli.StringFlag{
+		Name:    "s3-access-key",
+		Usage:   "Access key for S3. If empty, the default AWS credential chain is used.",
+		EnvVars: []string{"LITT_S3_ACCESS_KEY"},
+	}
+	s3SecretKeyFlag = &cli.StringFlag{
+		Name:    "s3-secret-key",
+		Usage:   "Secret key for S3. If empty, the default AWS credential chain is used.",
+		EnvVars: []string{"LITT_S3_SECRET_KEY"},
+	}
 )
 
 // buildCliParser creates a command line parser for the LittDB CLI tool.
@@ -152,6 +173,39 @@ func buildCLIParser(logger logging.Logger) *cli.App {
 				},
 				Action: pruneCommand,
 			},
+			{
+				Name: "verify",
+				Usage: "Verify the integrity of the data in a LittDB database/snapshot. " +
+					"Returns an error if corrupt segments are found, unless they are quarantined.",
+				ArgsUsage: "--src <path1> ... --src <pathN> [--table <table1> ... --table <tableN>] " +
+					"[--quarantine] [--throttle <maxMBPerSecond>]",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:     "src",
+						Aliases:  []string{"s"},
+						Usage:    "Source paths where the DB data is found, at least one is required.",
+						Required: true,
+					},
+					&cli.StringSliceFlag{
+						Name:    "table",
+						Aliases: []string{"t"},
+						Usage:   "Verify this table. If not specified, all tables will be verified.",
+					},
+					&cli.BoolFlag{
+						Name:    "quarantine",
+						Aliases: []string{"q"},
+						Usage: "Move corrupt segments into the table's quarantine directory and replace them " +
+							"with empty segments. Not supported for snapshots.",
+					},
+					&cli.Float64Flag{
+						Name:    "throttle",
+						Aliases: []string{"T"},
+						Usage:   "Max disk read rate, in mb/s. If 0, reads are not throttled.",
+						Value:   0,
+					},
+				},
+				Action: verifyCommand,
+			},
 			{
 				Name:  "push",
 				Usage: "Push data to a remote location using ssh and rsync.",
@@ -291,6 +345,74 @@ func buildCLIParser(logger logging.Logger) *cli.App {
 				},
 				Action: syncCommand,
 			},
+			{
+				Name: "backup",
+				Usage: "Make an incremental backup of a LittDB database/snapshot. Only segments that are not " +
+					"already in the backup are written, along with a manifest describing the backup.",
+				ArgsUsage: "--src <path1> ... --src <pathN> --dst <backup-path | s3://bucket/prefix> " +
+					"[--threads <threadCount>] [--s3-endpoint <url>] [--s3-region <region>]",
+				Flags: []cli.Flag{
+					srcFlag,
+					&cli.StringFlag{
+						Name:    "dst",
+						Aliases: []string{"d"},
+						Usage: "Where to write the backup. Either a local directory or an S3 location " +
+							"of the form s3://bucket/prefix.",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:    "threads",
+						Aliases: []string{"t"},
+						Usage:   "Number of parallel S3 upload workers.",
+						Value:   8,
+					},
+					s3EndpointFlag,
+					s3RegionFlag,
+					s3AccessKeyFlag,
+					s3SecretKeyFlag,
+				},
+				Action: backupCommand,
+			},
+			{
+				Name: "restore",
+				Usage: "Rebuild a LittDB database from a chain of incremental backups made by 'litt backup'. " +
+					"Keymaps are rebuilt from the restored data.",
+				ArgsUsage: "--src <backup-path | s3://bucket/prefix> --dst <path1> ... --dst <pathN> " +
+					"[--sequence <backupSequenceNumber>] [--s3-endpoint <url>] [--s3-region <region>]",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "src",
+						Aliases: []string{"s"},
+						Usage: "Where to read the backup from. Either a local directory or an S3 location " +
+							"of the form s3://bucket/prefix.",
+						Required: true,
+					},
+					&cli.StringSliceFlag{
+						Name:     "dst",
+						Aliases:  []string{"d"},
+						Usage:    "Destination paths for the restored database, at least one is required.",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:    "sequence",
+						Aliases: []string{"n"},
+						Usage: "Restore the database as of the backup with this sequence number. " +
+							"If 0, the most recent backup is restored.",
+						Value: 0,
+					},
+					&cli.Uint64Flag{
+						Name:    "threads",
+						Aliases: []string{"t"},
+						Usage:   "Number of parallel S3 download workers.",
+						Value:   8,
+					},
+					s3EndpointFlag,
+					s3RegionFlag,
+					s3AccessKeyFlag,
+					s3SecretKeyFlag,
+				},
+				Action: restoreCommand,
+			},
 			{
 				Name:      "unlock",
 				Usage:     "Manually delete LittDB lock files. Dangerous if used improperly, use with caution.",
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/urfave/cli/v2"
+)
+
+// restoreCommand rebuilds a LittDB database from a chain of incremental backups.
+func restoreCommand(ctx *cli.Context) error {
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	destinations := ctx.StringSlice("dst")
+	if len(destinations) == 0 {
+		return fmt.Errorf("no destinations provided")
+	}
+	for i, dst := range destinations {
+		var err error
+		destinations[i], err = util.SanitizePath(dst)
+		if err != nil {
+			return fmt.Errorf("invalid destination path: %s", dst)
+		}
+	}
+
+	target, err := buildBackupTarget(ctx, logger, ctx.String("src"))
+	if err != nil {
+		return fmt.Errorf("failed to build backup target: %w", err)
+	}
+
+	return restore(logger, target, destinations, ctx.Uint64("sequence"), true)
+}
+
+// restore rebuilds a LittDB database in the destination directories from the chain of manifests in the backup
+// target. If sequence is non-zero, the database is restored to the point in time of the backup with that
+// sequence number, otherwise it is restored from the most recent backup. The destination directories must not
+// already contain any LittDB tables. Keymaps are rebuilt from the restored segments before this method returns.
+func restore(
+	logger logging.Logger,
+	target backupTarget,
+	destinations []string,
+	sequence uint64,
+	fsync bool) error {
+
+	if len(destinations) == 0 {
+		return fmt.Errorf("no destination paths provided")
+	}
+
+	manifests, err := loadBackupManifests(target)
+	if err != nil {
+		return err
+	}
+	if len(manifests) == 0 {
+		return fmt.Errorf("no backups found in %s", target)
+	}
+	if sequence == 0 {
+		sequence = uint64(len(manifests))
+	}
+	if sequence > uint64(len(manifests)) {
+		return fmt.Errorf("backup %d not found in %s, latest backup is %d", sequence, target, len(manifests))
+	}
+	manifests = manifests[:sequence]
+
+	for _, destination := range destinations {
+		err = util.EnsureDirectoryExists(destination, fsync)
+		if err != nil {
+			return fmt.Errorf("failed to create destination directory %s: %w", destination, err)
+		}
+	}
+
+	err = restoreSegments(logger, target, destinations, manifests, fsync)
+	if err != nil {
+		return err
+	}
+
+	// Open the restored database. This rebuilds the keymap of each table from the restored segments.
+	config, err := litt.DefaultConfig(destinations...)
+	if err != nil {
+		return fmt.Errorf("failed to create config: %w", err)
+	}
+	config.Logger = logger
+	config.Fsync = fsync
+
+	db, err := littbuilder.NewDB(config)
+	if err != nil {
+		return fmt.Errorf("failed to open restored database: %w", err)
+	}
+
+	tables, err := lsPaths(logger, destinations, false, fsync)
+	if err != nil {
+		return fmt.Errorf("failed to list restored tables: %w", err)
+	}
+	for _, tableName := range tables {
+		table, err := db.GetTable(tableName)
+		if err != nil {
+			return fmt.Errorf("failed to load restored table %s: %w", tableName, err)
+		}
+		logger.Infof("table %s: restored %d keys", tableName, table.KeyCount())
+	}
+
+	err = db.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close restored database: %w", err)
+	}
+
+	logger.Infof("Restored backup %d from %s", sequence, target)
+
+	return nil
+}
+
+// restoreSegments copies segment files and table metadata files from the backup target into the destination
+// directories.
+func restoreSegments(
+	logger logging.Logger,
+	target backupTarget,
+	destinations []string,
+	manifests []*backupManifest,
+	fsync bool) error {
+
+	// Forbid touching tables in active use.
+	releaseLocks, err := util.LockDirectories(logger, destinations, util.LockfileName, fsync)
+	if err != nil {
+		return fmt.Errorf("failed to acquire locks on paths %v: %w", destinations, err)
+	}
+	defer releaseLocks()
+
+	existingTables, err := lsPaths(logger, destinations, false, fsync)
+	if err != nil {
+		return fmt.Errorf("failed to list tables in paths %v: %w", destinations, err)
+	}
+	if len(existingTables) > 0 {
+		return fmt.Errorf("destination already contains tables %v, refusing to restore", existingTables)
+	}
+
+	// For each table, gather the manifests that contain data for that table, in order.
+	tableManifests := make(map[string][]*tableBackupManifest)
+	// For each table, the sequence number of the most recent backup containing a table metadata file.
+	tableMetadataSequences := make(map[string]uint64)
+	for _, manifest := range manifests {
+		for tableName, tableManifest := range manifest.Tables {
+			tableManifests[tableName] = append(tableManifests[tableName], tableManifest)
+			if tableManifest.HasTableMetadata {
+				tableMetadataSequences[tableName] = manifest.Sequence
+			}
+		}
+	}
+
+	tableNames := make([]string, 0, len(tableManifests))
+	for tableName := range tableManifests {
+		tableNames = append(tableNames, tableName)
+	}
+	sort.Strings(tableNames)
+
+	for _, tableName := range tableNames {
+		if !litt.IsTableNameValid(tableName) {
+			return fmt.Errorf("backup contains invalid table name %s", tableName)
+		}
+
+		manifestsForTable := tableManifests[tableName]
+
+		// LittDB requires that the segments of a table are contiguous. If segments were garbage collected before
+		// they were backed up, only the contiguous run of segments ending with the most recent segment is restored.
+		firstManifest := len(manifestsForTable) - 1
+		for firstManifest > 0 &&
+			manifestsForTable[firstManifest-1].HighestSegmentIndex+1 == manifestsForTable[firstManifest].LowestSegmentIndex {
+			firstManifest--
+		}
+		if firstManifest > 0 {
+			logger.Warnf("table %s: segments %d and below are not contiguous with later segments, "+
+				"they will not be restored", tableName, manifestsForTable[firstManifest-1].HighestSegmentIndex)
+		}
+
+		for _, destination := range destinations {
+			err = util.EnsureDirectoryExists(path.Join(destination, tableName, segment.SegmentDirectory), fsync)
+			if err != nil {
+				return fmt.Errorf("failed to create segment directory for table %s: %w", tableName, err)
+			}
+		}
+
+		fileCount := 0
+		for _, tableManifest := range manifestsForTable[firstManifest:] {
+			for _, fileName := range tableManifest.Files {
+				destination, err := determineDestination(fileName, destinations)
+				if err != nil {
+					return fmt.Errorf("failed to determine destination for file %s: %w", fileName, err)
+				}
+
+				localPath := path.Join(destination, tableName, segment.SegmentDirectory, fileName)
+				err = target.Download(backupSegmentFileName(tableName, fileName), localPath)
+				if err != nil {
+					return fmt.Errorf("failed to download segment file %s: %w", fileName, err)
+				}
+				fileCount++
+			}
+		}
+
+		if metadataSequence, ok := tableMetadataSequences[tableName]; ok {
+			localPath := path.Join(destinations[0], tableName, disktable.TableMetadataFileName)
+			err = target.Download(backupTableMetadataName(metadataSequence, tableName), localPath)
+			if err != nil {
+				return fmt.Errorf("failed to download table metadata for table %s: %w", tableName, err)
+			}
+		}
+
+		logger.Infof("table %s: restored segments %d through %d (%d files)", tableName,
+			manifestsForTable[firstManifest].LowestSegmentIndex,
+			manifestsForTable[len(manifestsForTable)-1].HighestSegmentIndex,
+			fileCount)
+	}
+
+	return nil
+}
@@ -16,27 +16,6 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
-// TableInfo contains high level information about a table in LittDB.
-type TableInfo struct {
-	// The number of key-value pairs in the table.
-	KeyCount uint64
-	// The size of the table in bytes.
-	Size uint64
-	// If true, the table at the specified path is a snapshot of another table.
-	IsSnapshot bool
-	// The time when the oldest segment was sealed.
-	OldestSegmentSealTime time.Time
-	// The time when the newest segment was sealed.
-	NewestSegmentSealTime time.Time
-	// The index of the oldest segment in the table.
-	LowestSegmentIndex uint32
-	// The index of the newest segment in the table.
-	HighestSegmentIndex uint32
-	// The type of the keymap used by the table. If "", then this table doesn't have a keymap (i.e. it will rebuild
-	// a keymap the next time it is loaded).
-	KeymapType string
-}
-
 // tableInfoCommand is the CLI command handler for the "table-info" command.
 func tableInfoCommand(ctx *cli.Context) error {
 	if ctx.NArg() != 1 {
@@ -76,6 +55,7 @@ func tableInfoCommand(ctx *cli.Context) error {
 	logger.Infof("Table:                       %s", tableName)
 	logger.Infof("Key count:                   %s", common.CommaOMatic(info.KeyCount))
 	logger.Infof("Size:                        %s", common.PrettyPrintBytes(info.Size))
+	logger.Infof("Logical (uncompressed) size: %s", common.PrettyPrintBytes(info.LogicalSize))
 	logger.Infof("Is snapshot:                 %t", info.IsSnapshot)
 	logger.Infof("Oldest segment age:          %s", common.PrettyPrintTime(oldestSegmentAge))
 	logger.Infof("Oldest segment seal time:    %s", info.OldestSegmentSealTime.Format(time.RFC3339))
@@ -90,7 +70,7 @@ func tableInfoCommand(ctx *cli.Context) error {
 }
 
 // tableInfo retrieves information about a table at the specified path.
-func tableInfo(logger logging.Logger, tableName string, paths []string, fsync bool) (*TableInfo, error) {
+func tableInfo(logger logging.Logger, tableName string, paths []string, fsync bool) (*litt.TableInfo, error) {
 	if !litt.IsTableNameValid(tableName) {
 		return nil, fmt.Errorf("table name '%s' is invalid, "+
 			"must be at least one character long and contain only letters, numbers, underscores, and dashes",
@@ -165,6 +145,7 @@ func tableInfo(logger logging.Logger, tableName string, paths []string, fsync bo
 
 	keyCount := uint64(0)
 	size := uint64(0)
+	logicalSize := uint64(0)
 	for _, seg := range segments {
 		if seg.SegmentIndex() > highestSegmentIndex {
 			// Do not attempt to read segments outside the limit set by the boundary file.
@@ -173,6 +154,7 @@ func tableInfo(logger logging.Logger, tableName string, paths []string, fsync bo
 
 		keyCount += uint64(seg.KeyCount())
 		size += seg.Size()
+		logicalSize += seg.LogicalSize()
 	}
 
 	_, _, keymapTypeFile, err := littbuilder.FindKeymapLocation(paths, tableName)
@@ -186,9 +168,11 @@ func tableInfo(logger logging.Logger, tableName string, paths []string, fsync bo
 		keymapType = (string)(keymapTypeFile.Type())
 	}
 
-	return &TableInfo{
+	return &litt.TableInfo{
+		Name:                  tableName,
 		KeyCount:              keyCount,
 		Size:                  size,
+		LogicalSize:           logicalSize,
 		IsSnapshot:            isSnapshot,
 		OldestSegmentSealTime: segments[lowestSegmentIndex].GetSealTime(),
 		NewestSegmentSealTime: segments[highestSegmentIndex].GetSealTime(),
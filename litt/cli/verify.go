@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/docker/go-units"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/time/rate"
+)
+
+// verifyCommand checks the integrity of the data in a LittDB instance/snapshot.
+func verifyCommand(ctx *cli.Context) error {
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	sources := ctx.StringSlice("src")
+	if len(sources) == 0 {
+		return fmt.Errorf("no sources provided")
+	}
+	for i, src := range sources {
+		var err error
+		sources[i], err = util.SanitizePath(src)
+		if err != nil {
+			return fmt.Errorf("invalid source path: %s", src)
+		}
+	}
+
+	tables := ctx.StringSlice("table")
+	quarantine := ctx.Bool("quarantine")
+	throttleMB := ctx.Float64("throttle")
+
+	corruptSegments, err := verify(logger, sources, tables, quarantine, throttleMB, true)
+	if err != nil {
+		return err
+	}
+
+	corruptCount := 0
+	for _, indices := range corruptSegments {
+		corruptCount += len(indices)
+	}
+	if corruptCount > 0 && !quarantine {
+		return fmt.Errorf("found %d corrupt segment(s)", corruptCount)
+	}
+
+	return nil
+}
+
+// verify checks the integrity of each segment in a LittDB database/snapshot. Returns a map from table name to the
+// indices of the corrupt segments in that table. If quarantine is true, corrupt segments are moved into the
+// quarantine directory and replaced by empty segments. The throttle is the maximum rate at which data is read
+// from disk in MB/s, or 0 for no limit.
+func verify(
+	logger logging.Logger,
+	sources []string,
+	allowedTables []string,
+	quarantine bool,
+	throttleMB float64,
+	fsync bool) (map[string][]uint32, error) {
+
+	allowedTablesSet := make(map[string]struct{})
+	for _, table := range allowedTables {
+		allowedTablesSet[table] = struct{}{}
+	}
+
+	// Forbid touching tables in active use.
+	releaseLocks, err := util.LockDirectories(logger, sources, util.LockfileName, fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire locks on paths %v: %w", sources, err)
+	}
+	defer releaseLocks()
+
+	// Determine which tables to verify.
+	var tables []string
+	foundTables, err := lsPaths(logger, sources, false, fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables in paths %v: %w", sources, err)
+	}
+	if len(allowedTables) == 0 {
+		tables = foundTables
+	} else {
+		for _, table := range foundTables {
+			if _, ok := allowedTablesSet[table]; ok {
+				tables = append(tables, table)
+			}
+		}
+	}
+
+	throttle := func(uint64) error {
+		return nil
+	}
+	if throttleMB > 0 {
+		bytesPerSecond := throttleMB * units.MiB
+		limiter := rate.NewLimiter(rate.Limit(bytesPerSecond), int(max(bytesPerSecond/10, 1)))
+		throttle = func(bytes uint64) error {
+			burst := uint64(limiter.Burst())
+			for bytes > 0 {
+				chunk := min(bytes, burst)
+				bytes -= chunk
+				err := limiter.WaitN(context.Background(), int(chunk))
+				if err != nil {
+					return fmt.Errorf("failed to wait for rate limiter: %w", err)
+				}
+			}
+			return nil
+		}
+	}
+
+	corruptSegments := make(map[string][]uint32)
+	for _, table := range tables {
+		corrupt, err := verifyTable(logger, sources, table, quarantine, throttle, fsync)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify table %s in paths %v: %w", table, sources, err)
+		}
+
+		if len(corrupt) == 0 {
+			logger.Infof("Table '%s' has no corrupt segments.", table)
+		} else {
+			logger.Errorf("Table '%s' has %d corrupt segment(s): %v", table, len(corrupt), corrupt)
+			corruptSegments[table] = corrupt
+		}
+	}
+
+	return corruptSegments, nil
+}
+
+// verifyTable checks the integrity of each segment in a table, returning the indices of corrupt segments.
+func verifyTable(
+	logger logging.Logger,
+	sources []string,
+	tableName string,
+	quarantine bool,
+	throttle func(bytes uint64) error,
+	fsync bool) ([]uint32, error) {
+
+	errorMonitor := util.NewErrorMonitor(context.Background(), logger, nil)
+
+	segmentPaths, err := segment.BuildSegmentPaths(sources, "", tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build segment paths for table %s at paths %v: %w",
+			tableName, sources, err)
+	}
+
+	lowestSegmentIndex, highestSegmentIndex, segments, err := segment.GatherSegmentFiles(
+		logger,
+		errorMonitor,
+		segmentPaths,
+		false,
+		time.Now(),
+		false,
+		fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to gather segment files for table %s at paths %v: %w",
+			tableName, sources, err)
+	}
+
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("no segments found for table %s at paths %v", tableName, sources)
+	}
+
+	isSnapshot, err := segments[lowestSegmentIndex].IsSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if segment %d is a snapshot: %w", lowestSegmentIndex, err)
+	}
+
+	if isSnapshot {
+		if quarantine {
+			return nil, fmt.Errorf("table %s is a snapshot, segments in a snapshot cannot be quarantined",
+				tableName)
+		}
+
+		// If we are dealing with a snapshot, respect the snapshot upper bound specified by LittDB.
+		if len(sources) > 1 {
+			return nil, fmt.Errorf("this is a symlinked snapshot directory, " +
+				"snapshot directory cannot be spread across multiple sources")
+		}
+		upperBoundFile, err := disktable.LoadBoundaryFile(disktable.UpperBound, path.Join(sources[0], tableName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load boundary file for table %s at path %s: %w",
+				tableName, sources[0], err)
+		}
+		if upperBoundFile.IsDefined() {
+			highestSegmentIndex = upperBoundFile.BoundaryIndex()
+		}
+	}
+
+	corrupt := make([]uint32, 0)
+	for segmentIndex := lowestSegmentIndex; segmentIndex <= highestSegmentIndex; segmentIndex++ {
+		seg := segments[segmentIndex]
+		if !seg.IsSealed() {
+			logger.Warnf("Segment %d in table '%s' is not sealed, skipping.", segmentIndex, tableName)
+			continue
+		}
+
+		err = seg.Verify(throttle)
+		if err == nil {
+			continue
+		}
+
+		var corruptionError *segment.CorruptionError
+		if !errors.As(err, &corruptionError) {
+			return nil, fmt.Errorf("failed to verify segment %d: %w", segmentIndex, err)
+		}
+
+		logger.Errorf("Segment %d in table '%s' is corrupt: %v", segmentIndex, tableName, err)
+		corrupt = append(corrupt, segmentIndex)
+
+		if quarantine {
+			err = seg.Quarantine()
+			if err != nil {
+				return nil, fmt.Errorf("failed to quarantine segment %d: %w", segmentIndex, err)
+			}
+			logger.Infof("Segment %d in table '%s' has been quarantined.", segmentIndex, tableName)
+		}
+	}
+
+	if ok, err := errorMonitor.IsOk(); !ok {
+		return nil, fmt.Errorf("error monitor reports errors: %w", err)
+	}
+
+	if quarantine && len(corrupt) > 0 {
+		// The keymap and snapshots may reference data that has been quarantined. Delete them, the DB will
+		// automatically rebuild the snapshots directory & keymap on the next startup.
+		err = deleteSnapshots(sources, tableName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete snapshots/keymap for table %s at paths %v: %w",
+				tableName, sources, err)
+		}
+	}
+
+	return corrupt, nil
+}
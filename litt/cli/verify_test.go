@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigenda/test"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	logger := test.GetLogger()
+	rand := random.NewTestRandom()
+	testDirectory := t.TempDir()
+
+	errorMonitor := util.NewErrorMonitor(ctx, logger, nil)
+
+	rootPathCount := rand.Uint64Range(2, 5)
+	rootPaths := make([]string, rootPathCount)
+	for i := uint64(0); i < rootPathCount; i++ {
+		rootPaths[i] = path.Join(testDirectory, fmt.Sprintf("root-%d", i))
+	}
+
+	// Use a standard test configuration for LittDB.
+	config, err := litt.DefaultConfig(rootPaths...)
+	require.NoError(t, err)
+	config.Fsync = false
+	config.DoubleWriteProtection = true
+	config.ShardingFactor = uint32(rand.Uint64Range(rootPathCount, 2*rootPathCount))
+	config.TargetSegmentFileSize = 100
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	tableCount := rand.Uint64Range(2, 5)
+	tables := make(map[string]litt.Table, tableCount)
+	for i := uint64(0); i < tableCount; i++ {
+		tableName := fmt.Sprintf("table-%d", i)
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		tables[tableName] = table
+	}
+
+	// map from table name to keys to values
+	expectedData := make(map[string]map[string][]byte)
+	for _, table := range tables {
+		expectedData[table.Name()] = make(map[string][]byte)
+	}
+
+	for i := 0; i < 1000; i++ {
+		tableIndex := rand.Uint64Range(0, tableCount)
+		tableName := fmt.Sprintf("table-%d", tableIndex)
+		table := tables[tableName]
+
+		key := rand.String(32)
+		value := rand.PrintableVariableBytes(1, 100)
+
+		err = table.Put([]byte(key), value)
+		require.NoError(t, err)
+
+		expectedData[tableName][key] = value
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	// Initially, there should be no corruption.
+	corruptSegments, err := verify(logger, rootPaths, []string{}, false, 0, false)
+	require.NoError(t, err)
+	require.Empty(t, corruptSegments)
+
+	// Corrupt a value in a segment in table-0.
+	corruptTable := "table-0"
+	segmentPaths, err := segment.BuildSegmentPaths(rootPaths, "", corruptTable)
+	require.NoError(t, err)
+	lowSegmentIndex, highSegmentIndex, segments, err := segment.GatherSegmentFiles(
+		logger,
+		errorMonitor,
+		segmentPaths,
+		false,
+		time.Now(),
+		false,
+		false)
+	require.NoError(t, err)
+
+	// Choose a segment with at least one key.
+	var corruptSegmentIndex uint32
+	var corruptSegmentKeys []*types.ScopedKey
+	for i := lowSegmentIndex; i <= highSegmentIndex; i++ {
+		keys, err := segments[i].GetKeys()
+		require.NoError(t, err)
+		if len(keys) > 0 {
+			corruptSegmentIndex = i
+			corruptSegmentKeys = keys
+			break
+		}
+	}
+	require.NotEmpty(t, corruptSegmentKeys)
+
+	seg := segments[corruptSegmentIndex]
+	firstKey := corruptSegmentKeys[0]
+	valueFilePath := seg.GetValueFilePaths()[seg.GetShard(firstKey.Key)]
+	data, err := os.ReadFile(valueFilePath)
+	require.NoError(t, err)
+	data[firstKey.Address.Offset()+4] ^= 1
+	err = os.WriteFile(valueFilePath, data, 0644)
+	require.NoError(t, err)
+
+	// Without quarantine, the corruption should be reported but nothing should change.
+	corruptSegments, err = verify(logger, rootPaths, []string{}, false, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]uint32{corruptTable: {corruptSegmentIndex}}, corruptSegments)
+
+	// Restricting verification to other tables should not find the corruption.
+	corruptSegments, err = verify(logger, rootPaths, []string{"table-1"}, false, 0, false)
+	require.NoError(t, err)
+	require.Empty(t, corruptSegments)
+
+	// Quarantine the corrupt segment.
+	corruptSegments, err = verify(logger, rootPaths, []string{}, true, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]uint32{corruptTable: {corruptSegmentIndex}}, corruptSegments)
+
+	// Once quarantined, the corruption is no longer visible.
+	corruptSegments, err = verify(logger, rootPaths, []string{}, false, 0, false)
+	require.NoError(t, err)
+	require.Empty(t, corruptSegments)
+
+	// Reopen the DB. Keys in the quarantined segment should be gone, all other keys should be present.
+	quarantinedKeys := make(map[string]struct{})
+	for _, key := range corruptSegmentKeys {
+		quarantinedKeys[string(key.Key)] = struct{}{}
+	}
+
+	db, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	for tableName := range tables {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		tables[tableName] = table
+	}
+
+	for tableName, expected := range expectedData {
+		for key, value := range expected {
+			actual, ok, err := tables[tableName].Get([]byte(key))
+			require.NoError(t, err)
+
+			if _, quarantined := quarantinedKeys[key]; quarantined && tableName == corruptTable {
+				require.False(t, ok)
+			} else {
+				require.True(t, ok)
+				require.Equal(t, value, actual)
+			}
+		}
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+}
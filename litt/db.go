@@ -8,6 +8,8 @@ package litt
 // Supported features:
 //   - writing values
 //   - reading values
+//   - deleting values (via tombstones, disk space is reclaimed lazily by garbage collection)
+//   - ordered iteration over keys (full scans, prefix scans, and key range scans)
 //   - TTLs and automatic (lazy) deletion of expired values
 //   - tables with non-overlapping namespaces
 //   - thread safety: all methods are safe to call concurrently, and all key-value pair modifications are
@@ -15,12 +17,11 @@ package litt
 //   - dynamic multi-drive support (data can be spread across multiple physical volumes, and
 //     volume membership can be changed at runtime without stopping the DB)
 //   - incremental backups (both local and remote)
+//   - multi-table write batches that are atomic with respect to crash recovery
 //
 // Unsupported features:
 // - mutating existing values (once a value is written, it cannot be changed)
-// - multi-entity atomicity (there is no supported way to atomically write multiple key-value pairs as a group)
-// - deleting values (values only leave the DB when they expire via a TTL)
-// - transactions (individual operations are atomic, but there is no way to group operations atomically)
+// - transactions (batches are atomic with respect to crash recovery, but are not isolated from concurrent readers)
 // - fine granularity for TTL (all data in the same table must have the same TTL)
 type DB interface {
 	// GetTable gets a table by name, creating one if it does not exist.
@@ -39,12 +40,19 @@ type DB interface {
 	// The table returned by GetTable() before DropTable() is called must not be used once DropTable() is called.
 	DropTable(name string) error
 
+	// NewBatch creates a new batch of writes and deletions that can span multiple tables. When committed, the
+	// operations in the batch are atomic with respect to crash recovery. See Batch for details.
+	NewBatch() Batch
+
 	// Size returns the on-disk size of the database in bytes.
 	//
 	// Note that this size may not accurately reflect the size of the keymap. This is because some third party
 	// libraries used for certain keymap implementations do not provide an accurate way to measure size.
 	Size() uint64
 
+	// LogicalSize returns the size that the database would have on disk if no values were compressed.
+	LogicalSize() uint64
+
 	// KeyCount returns the number of keys in the database.
 	KeyCount() uint64
 
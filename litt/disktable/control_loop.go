@@ -10,6 +10,7 @@ import (
 	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
 	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
 	"github.com/Layr-Labs/eigenda/litt/metrics"
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
@@ -55,6 +56,10 @@ type controlLoop struct {
 	// and in the control loop.
 	immutableSegmentSize uint64
 
+	// The logical size of the immutable segments, i.e. the size they would have if values were not compressed.
+	// For thread safety, this variable may only be read/written in the constructor and in the control loop.
+	immutableSegmentLogicalSize uint64
+
 	// The target size for value files.
 	targetFileSize uint32
 
@@ -67,6 +72,9 @@ type controlLoop struct {
 	// The size of the disk table is stored here.
 	size *atomic.Uint64
 
+	// The logical size of the disk table (i.e. the size if values were not compressed) is stored here.
+	logicalSize *atomic.Uint64
+
 	// The number of keys in the table.
 	keyCount *atomic.Int64
 
@@ -108,6 +116,17 @@ type controlLoop struct {
 
 	// garbageCollectionPeriod is the period at which garbage collection is run.
 	garbageCollectionPeriod time.Duration
+
+	// The number of tombstones contained within all segments, including the mutable segment. For thread safety,
+	// this variable may only be read/written in the constructor and in the control loop.
+	tombstoneCount uint64
+
+	// The time when garbage collection last completed, in unix nanoseconds. Zero if garbage collection has not
+	// yet run. Thread safe to read from external goroutines.
+	lastGCTime atomic.Int64
+
+	// The total number of segments deleted by garbage collection. Thread safe to read from external goroutines.
+	gcSegmentsDeleted atomic.Uint64
 }
 
 // enqueue enqueues a request to the control loop. Returns an error if the request could not be sent due to the
@@ -131,16 +150,22 @@ func (c *controlLoop) run() {
 		case message := <-c.controllerChannel:
 			if req, ok := message.(*controlLoopWriteRequest); ok {
 				c.handleWriteRequest(req)
+			} else if req, ok := message.(*controlLoopDeleteRequest); ok {
+				c.handleDeleteRequest(req)
 			} else if req, ok := message.(*controlLoopFlushRequest); ok {
 				c.handleFlushRequest(req)
 			} else if req, ok := message.(*controlLoopSetShardingFactorRequest); ok {
 				c.handleControlLoopSetShardingFactorRequest(req)
+			} else if req, ok := message.(*controlLoopSetCompressionRequest); ok {
+				c.handleControlLoopSetCompressionRequest(req)
 			} else if req, ok := message.(*controlLoopShutdownRequest); ok {
 				c.handleShutdownRequest(req)
 				return
 			} else if req, ok := message.(*controlLoopGCRequest); ok {
 				c.doGarbageCollection()
 				req.completionChan <- struct{}{}
+			} else if req, ok := message.(*controlLoopEvictRequest); ok {
+				c.handleEvictRequest(req)
 			} else {
 				c.errorMonitor.Panic(fmt.Errorf("unknown control message type %T", message))
 				return
@@ -161,12 +186,13 @@ func (c *controlLoop) doGarbageCollection() {
 	}
 
 	defer func() {
+		end := c.clock()
 		if c.metrics != nil {
-			end := c.clock()
 			delta := end.Sub(start)
 			c.metrics.ReportGarbageCollectionLatency(c.name, delta)
 
 		}
+		c.lastGCTime.Store(end.UnixNano())
 		c.updateCurrentSize()
 	}()
 
@@ -185,40 +211,136 @@ func (c *controlLoop) doGarbageCollection() {
 		}
 
 		// Segment is old enough to be deleted.
-		keys, err := seg.GetKeys()
-		if err != nil {
-			c.errorMonitor.Panic(fmt.Errorf("failed to get keys: %w", err))
+		if !c.deleteLowestSegment() {
 			return
 		}
+		c.gcSegmentsDeleted.Add(1)
+	}
+}
 
-		for keyIndex := uint64(0); keyIndex < uint64(len(keys)); keyIndex += c.gcBatchSize {
-			lastIndex := keyIndex + c.gcBatchSize
-			if lastIndex > uint64(len(keys)) {
-				lastIndex = uint64(len(keys))
-			}
-			err = c.keymap.Delete(keys[keyIndex:lastIndex])
-			if err != nil {
-				c.errorMonitor.Panic(fmt.Errorf("failed to delete keys: %w", err))
-				return
-			}
+// handleEvictRequest deletes the oldest sealed segments, regardless of their age, until at least the requested
+// number of bytes have been freed or there are no sealed segments left to delete.
+func (c *controlLoop) handleEvictRequest(req *controlLoopEvictRequest) {
+	freed := uint64(0)
+	defer func() {
+		if freed > 0 {
+			c.updateCurrentSize()
 		}
+		req.responseChan <- freed
+	}()
 
-		if seg.Size() > c.immutableSegmentSize {
-			c.logger.Errorf("segment %d size %d is larger than immutable segment size %d, "+
-				"reported DB size will not be accurate", index, seg.Size(), c.immutableSegmentSize)
+	for freed < req.bytes && c.lowestSegmentIndex < c.highestSegmentIndex {
+		seg := c.segments[c.lowestSegmentIndex]
+		if !seg.IsSealed() {
+			// We can't delete an unsealed segment.
+			return
 		}
 
-		c.immutableSegmentSize -= seg.Size()
-		c.keyCount.Add(-1 * int64(seg.KeyCount()))
+		size := seg.Size()
+		c.logger.Warnf("table %s: evicting segment %d (%d bytes) to enforce quota",
+			c.name, c.lowestSegmentIndex, size)
+
+		if !c.deleteLowestSegment() {
+			return
+		}
+		freed += size
+	}
+}
 
-		// Deletion of segment files will happen when the segment is released by all reservation holders.
-		seg.Release()
-		c.segmentLock.Lock()
-		delete(c.segments, index)
-		c.segmentLock.Unlock()
+// deleteLowestSegment removes the lowest numbered segment from the table, along with all of its keys in the
+// keymap. The caller is responsible for ensuring that the segment is sealed. Returns false if the segment could
+// not be deleted, in which case the database is now in a panicked state.
+func (c *controlLoop) deleteLowestSegment() bool {
+	index := c.lowestSegmentIndex
+	seg := c.segments[index]
 
-		c.lowestSegmentIndex++
+	keys, err := seg.GetKeys()
+	if err != nil {
+		c.errorMonitor.Panic(fmt.Errorf("failed to get keys: %w", err))
+		return false
 	}
+
+	keys, err = c.filterKeysForGarbageCollection(index, keys)
+	if err != nil {
+		c.errorMonitor.Panic(fmt.Errorf("failed to filter keys: %w", err))
+		return false
+	}
+
+	for keyIndex := uint64(0); keyIndex < uint64(len(keys)); keyIndex += c.gcBatchSize {
+		lastIndex := keyIndex + c.gcBatchSize
+		if lastIndex > uint64(len(keys)) {
+			lastIndex = uint64(len(keys))
+		}
+		err = c.keymap.Delete(keys[keyIndex:lastIndex])
+		if err != nil {
+			c.errorMonitor.Panic(fmt.Errorf("failed to delete keys: %w", err))
+			return false
+		}
+	}
+
+	if seg.Size() > c.immutableSegmentSize {
+		c.logger.Errorf("segment %d size %d is larger than immutable segment size %d, "+
+			"reported DB size will not be accurate", index, seg.Size(), c.immutableSegmentSize)
+	}
+
+	c.immutableSegmentSize -= seg.Size()
+	c.immutableSegmentLogicalSize -= min(seg.LogicalSize(), c.immutableSegmentLogicalSize)
+	// Values that were deleted were subtracted from the key count at deletion time, so only subtract
+	// the keys that were actually removed from the keymap.
+	c.keyCount.Add(-1 * int64(len(keys)))
+	c.tombstoneCount -= uint64(seg.TombstoneCount())
+
+	// Deletion of segment files will happen when the segment is released by all reservation holders.
+	seg.Release()
+	c.segmentLock.Lock()
+	delete(c.segments, index)
+	c.segmentLock.Unlock()
+
+	c.lowestSegmentIndex++
+
+	return true
+}
+
+// filterKeysForGarbageCollection determines which keys from a segment that is being garbage collected should be
+// removed from the keymap. Tombstones are never removed from the keymap (since they are never stored in the
+// keymap). If tombstones exist anywhere in the table, then a key that was deleted may have been written again, and so
+// a key is only removed from the keymap if the keymap still maps it to the segment being garbage collected. In that
+// case, each key is returned at most once, even if it appears in the segment multiple times.
+func (c *controlLoop) filterKeysForGarbageCollection(
+	segmentIndex uint32,
+	keys []*types.ScopedKey) ([]*types.ScopedKey, error) {
+
+	filteredKeys := make([]*types.ScopedKey, 0, len(keys))
+	var seen map[string]struct{}
+	if c.tombstoneCount > 0 {
+		seen = make(map[string]struct{})
+	}
+
+	for _, key := range keys {
+		if key.Tombstone {
+			continue
+		}
+
+		if c.tombstoneCount > 0 {
+			if _, ok := seen[util.UnsafeBytesToString(key.Key)]; ok {
+				continue
+			}
+			seen[util.UnsafeBytesToString(key.Key)] = struct{}{}
+
+			address, ok, err := c.keymap.Get(key.Key)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get address for key %x: %w", key.Key, err)
+			}
+			if !ok || address.Index() != segmentIndex {
+				// The key was deleted, and possibly written again to a newer segment.
+				continue
+			}
+		}
+
+		filteredKeys = append(filteredKeys, key)
+	}
+
+	return filteredKeys, nil
 }
 
 // getReservedSegment returns the segment with the given index. Segment is reserved, and it is the caller's
@@ -242,6 +364,22 @@ func (c *controlLoop) getReservedSegment(index uint32) (*segment.Segment, bool)
 	return seg, true
 }
 
+// getReservedSegments reserves and returns all segments currently in use. It is the caller's responsibility to
+// release each returned segment when done. Segments that are in the process of being deleted are not returned.
+func (c *controlLoop) getReservedSegments() map[uint32]*segment.Segment {
+	c.segmentLock.RLock()
+	defer c.segmentLock.RUnlock()
+
+	reserved := make(map[uint32]*segment.Segment, len(c.segments))
+	for index, seg := range c.segments {
+		if seg.Reserve() {
+			reserved[index] = seg
+		}
+	}
+
+	return reserved
+}
+
 // getSegments returns the segments of the disk table. It is only legal to call this after the control loop has been
 // stopped.
 func (c *controlLoop) getSegments() (map[uint32]*segment.Segment, error) {
@@ -257,7 +395,12 @@ func (c *controlLoop) updateCurrentSize() {
 		c.segments[c.highestSegmentIndex].Size() +
 		c.metadata.Size()
 
+	logicalSize := c.immutableSegmentLogicalSize +
+		c.segments[c.highestSegmentIndex].LogicalSize() +
+		c.metadata.Size()
+
 	c.size.Store(size)
+	c.logicalSize.Store(logicalSize)
 }
 
 // handleWriteRequest handles a controlLoopWriteRequest control message.
@@ -287,6 +430,31 @@ func (c *controlLoop) handleWriteRequest(req *controlLoopWriteRequest) {
 	c.updateCurrentSize()
 }
 
+// handleDeleteRequest handles a controlLoopDeleteRequest control message.
+func (c *controlLoop) handleDeleteRequest(req *controlLoopDeleteRequest) {
+	for _, key := range req.keys {
+		seg := c.segments[c.highestSegmentIndex]
+		recordCount, keyFileSize, err := seg.WriteTombstone(key)
+		if err != nil {
+			c.errorMonitor.Panic(
+				fmt.Errorf("failed to write tombstone to segment %d: %w", c.highestSegmentIndex, err))
+			return
+		}
+		c.tombstoneCount++
+
+		// Check to see if the tombstone caused the mutable segment to become full.
+		if recordCount >= c.maxKeyCount || keyFileSize >= c.targetKeyFileSize {
+			err = c.expandSegments()
+			if err != nil {
+				c.errorMonitor.Panic(fmt.Errorf("failed to expand segments: %w", err))
+				return
+			}
+		}
+	}
+
+	c.updateCurrentSize()
+}
+
 // expandSegments seals the latest segment and creates a new mutable segment.
 func (c *controlLoop) expandSegments() error {
 	now := c.clock()
@@ -313,6 +481,7 @@ func (c *controlLoop) expandSegments() error {
 
 	// Record the size of the segment.
 	c.immutableSegmentSize += c.segments[c.highestSegmentIndex].Size()
+	c.immutableSegmentLogicalSize += c.segments[c.highestSegmentIndex].LogicalSize()
 
 	// Create a new segment.
 	salt := [16]byte{}
@@ -328,6 +497,7 @@ func (c *controlLoop) expandSegments() error {
 		c.snapshottingEnabled,
 		c.metadata.GetShardingFactor(),
 		salt,
+		c.metadata.GetCompression(),
 		c.fsync)
 	if err != nil {
 		return err
@@ -392,6 +562,29 @@ func (c *controlLoop) handleControlLoopSetShardingFactorRequest(req *controlLoop
 	}
 }
 
+// handleControlLoopSetCompressionRequest updates the compression type of the disk table. If the requested
+// compression type is the same as before, no action is taken. If it is different, the compression type is updated,
+// the current mutable segment is sealed, and a new mutable segment is created.
+func (c *controlLoop) handleControlLoopSetCompressionRequest(req *controlLoopSetCompressionRequest) {
+
+	if req.compression == c.metadata.GetCompression() {
+		// No action necessary.
+		return
+	}
+	err := c.metadata.SetCompression(req.compression)
+	if err != nil {
+		c.errorMonitor.Panic(fmt.Errorf("failed to set compression: %w", err))
+		return
+	}
+
+	// This seals the current mutable segment and creates a new one. The new segment will use the new compression.
+	err = c.expandSegments()
+	if err != nil {
+		c.errorMonitor.Panic(fmt.Errorf("failed to expand segments: %w", err))
+		return
+	}
+}
+
 // handleShutdownRequest performs tasks necessary to cleanly shut down the disk table.
 func (c *controlLoop) handleShutdownRequest(req *controlLoopShutdownRequest) {
 	// Instruct the flush loop to stop.
@@ -27,6 +27,14 @@ type controlLoopWriteRequest struct {
 	values []*types.KVPair
 }
 
+// controlLoopDeleteRequest is a request to delete keys that is sent to the control loop.
+type controlLoopDeleteRequest struct {
+	controlLoopMessage
+
+	// keys is a slice of keys to delete.
+	keys [][]byte
+}
+
 // controlLoopSetShardingFactorRequest is a request to set the sharding factor that is sent to the control loop.
 type controlLoopSetShardingFactorRequest struct {
 	controlLoopMessage
@@ -35,6 +43,14 @@ type controlLoopSetShardingFactorRequest struct {
 	shardingFactor uint32
 }
 
+// controlLoopSetCompressionRequest is a request to set the compression type that is sent to the control loop.
+type controlLoopSetCompressionRequest struct {
+	controlLoopMessage
+
+	// compression is the new compression type to set.
+	compression types.CompressionType
+}
+
 // controlLoopShutdownRequest is a request to shut down the table that is sent to the control loop.
 type controlLoopShutdownRequest struct {
 	controlLoopMessage
@@ -51,3 +67,15 @@ type controlLoopGCRequest struct {
 	// completionChan produces a value when the garbage collection is complete.
 	completionChan chan struct{}
 }
+
+// controlLoopEvictRequest is a request to delete the oldest segments ahead of their TTL that is sent to the
+// control loop.
+type controlLoopEvictRequest struct {
+	controlLoopMessage
+
+	// bytes is the minimum number of bytes to free.
+	bytes uint64
+
+	// responseChan produces the number of bytes actually freed when the eviction is complete.
+	responseChan chan uint64
+}
@@ -63,6 +63,24 @@ type DiskTable struct {
 	// lookup table when data is requested from the table before it has been flushed to disk.
 	unflushedDataCache sync.Map
 
+	// unflushedTombstones is a set of keys that have been deleted, but where the deletion may not yet have been
+	// flushed to disk (and therefore may not yet be reflected in the keymap). Keys in this set are treated as absent
+	// unless they are also present in unflushedDataCache (i.e. they have been written again since being deleted).
+	unflushedTombstones sync.Map
+
+	// writeLock serializes writes and deletions, so that the order in which they update unflushedDataCache and
+	// unflushedTombstones is the same as the order in which they are sent to the control loop. Without it, a write
+	// and a deletion of the same key could be applied to the in-memory state in one order and to disk in the other.
+	writeLock sync.Mutex
+
+	// unflushedLock protects unflushedOperations. It is never held while blocking, since the flush path acquires it.
+	unflushedLock sync.Mutex
+
+	// unflushedOperations counts the writes and deletions of each key that have been sent to the control loop but not
+	// yet flushed to the keymap. A key is only removed from unflushedDataCache and unflushedTombstones once all of its
+	// operations are flushed, so that flushing an old write doesn't hide a newer write or deletion of the same key.
+	unflushedOperations map[string]int
+
 	// clock is the time source used by the disk table.
 	clock func() time.Time
 
@@ -70,6 +88,9 @@ type DiskTable struct {
 	// bytes that are on disk, not bytes in memory.
 	size atomic.Uint64
 
+	// The size that all segments would have if no values were compressed.
+	logicalSize atomic.Uint64
+
 	// The number of keys in the table.
 	keyCount atomic.Int64
 
@@ -79,6 +100,10 @@ type DiskTable struct {
 	// The flush loop is a goroutine responsible for blocking on flush operations.
 	flushLoop *flushLoop
 
+	// The scrubber is a goroutine responsible for verifying the integrity of data on disk. Nil if scrubbing is
+	// disabled.
+	scrubber *scrubber
+
 	// Encapsulates metrics for the database.
 	metrics *metrics.LittDBMetrics
 
@@ -159,7 +184,13 @@ func NewDiskTable(
 		// No metadata file exists yet. Create a new one in the first root.
 		var err error
 		metadataDir := qualifiedRoots[0]
-		metadata, err = newTableMetadata(config.Logger, metadataDir, config.TTL, config.ShardingFactor, config.Fsync)
+		metadata, err = newTableMetadata(
+			config.Logger,
+			metadataDir,
+			config.TTL,
+			config.ShardingFactor,
+			config.Compression,
+			config.Fsync)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create table metadata: %w", err)
 		}
@@ -188,6 +219,8 @@ func NewDiskTable(
 		keymapTypeFile: keymapTypeFile,
 		metrics:        metrics,
 		fsync:          config.Fsync,
+
+		unflushedOperations: make(map[string]int),
 	}
 	table.flushCoordinator = newFlushCoordinator(errorMonitor, table.flushInternal, config.MinimumFlushInterval)
 
@@ -207,15 +240,21 @@ func NewDiskTable(
 		return nil, fmt.Errorf("failed to gather segment files: %w", err)
 	}
 
+	// Each tombstone is assumed to cancel out exactly one key. This may undercount slightly if a tombstone outlives
+	// the segment containing the value it deleted, but such tombstones are garbage collected soon after.
 	keyCount := int64(0)
+	tombstoneCount := uint64(0)
 	for _, seg := range segments {
-		keyCount += int64(seg.KeyCount())
+		keyCount += int64(seg.KeyCount()) - int64(seg.TombstoneCount())
+		tombstoneCount += uint64(seg.TombstoneCount())
 	}
-	table.keyCount.Store(keyCount)
+	table.keyCount.Store(max(keyCount, 0))
 
 	immutableSegmentSize := uint64(0)
+	immutableSegmentLogicalSize := uint64(0)
 	for _, seg := range segments {
 		immutableSegmentSize += seg.Size()
+		immutableSegmentLogicalSize += seg.LogicalSize()
 	}
 
 	// Create the mutable segment
@@ -241,6 +280,7 @@ func NewDiskTable(
 		snapshottingEnabled,
 		metadata.GetShardingFactor(),
 		salt,
+		metadata.GetCompression(),
 		config.Fsync)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create mutable segment: %w", err)
@@ -257,6 +297,11 @@ func NewDiskTable(
 		if err != nil {
 			return nil, fmt.Errorf("failed to load keymap from segments: %w", err)
 		}
+	} else if tombstoneCount > 0 {
+		err = table.replayTombstones(segments, lowestSegmentIndex, highestSegmentIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay tombstones: %w", err)
+		}
 	}
 
 	tableSaltShaker := rand.New(rand.NewSource(config.SaltShaker.Int63()))
@@ -290,37 +335,52 @@ func NewDiskTable(
 
 	// Start the control loop.
 	cLoop := &controlLoop{
-		logger:                  config.Logger,
-		diskTable:               table,
-		errorMonitor:            errorMonitor,
-		controllerChannel:       make(chan any, config.ControlChannelSize),
-		lowestSegmentIndex:      lowestSegmentIndex,
-		highestSegmentIndex:     highestSegmentIndex,
-		segments:                segments,
-		size:                    &table.size,
-		keyCount:                &table.keyCount,
-		targetFileSize:          config.TargetSegmentFileSize,
-		targetKeyFileSize:       config.TargetSegmentKeyFileSize,
-		maxKeyCount:             config.MaxSegmentKeyCount,
-		clock:                   config.Clock,
-		segmentPaths:            segmentPaths,
-		snapshottingEnabled:     snapshottingEnabled,
-		saltShaker:              tableSaltShaker,
-		metadata:                metadata,
-		fsync:                   config.Fsync,
-		metrics:                 metrics,
-		name:                    name,
-		gcBatchSize:             config.GCBatchSize,
-		keymap:                  keymap,
-		flushLoop:               fLoop,
-		garbageCollectionPeriod: config.GCPeriod,
-		immutableSegmentSize:    immutableSegmentSize,
+		logger:                      config.Logger,
+		diskTable:                   table,
+		errorMonitor:                errorMonitor,
+		controllerChannel:           make(chan any, config.ControlChannelSize),
+		lowestSegmentIndex:          lowestSegmentIndex,
+		highestSegmentIndex:         highestSegmentIndex,
+		segments:                    segments,
+		size:                        &table.size,
+		logicalSize:                 &table.logicalSize,
+		keyCount:                    &table.keyCount,
+		targetFileSize:              config.TargetSegmentFileSize,
+		targetKeyFileSize:           config.TargetSegmentKeyFileSize,
+		maxKeyCount:                 config.MaxSegmentKeyCount,
+		clock:                       config.Clock,
+		segmentPaths:                segmentPaths,
+		snapshottingEnabled:         snapshottingEnabled,
+		saltShaker:                  tableSaltShaker,
+		metadata:                    metadata,
+		fsync:                       config.Fsync,
+		metrics:                     metrics,
+		name:                        name,
+		gcBatchSize:                 config.GCBatchSize,
+		keymap:                      keymap,
+		flushLoop:                   fLoop,
+		garbageCollectionPeriod:     config.GCPeriod,
+		immutableSegmentSize:        immutableSegmentSize,
+		immutableSegmentLogicalSize: immutableSegmentLogicalSize,
+		tombstoneCount:              tombstoneCount,
 	}
 	cLoop.threadsafeHighestSegmentIndex.Store(highestSegmentIndex)
 	table.controlLoop = cLoop
 	cLoop.updateCurrentSize()
 	go cLoop.run()
 
+	if config.ScrubBytesPerSecond > 0 {
+		table.scrubber = newScrubber(
+			config.Logger,
+			errorMonitor,
+			name,
+			cLoop,
+			config.ScrubBytesPerSecond,
+			config.GCPeriod,
+			metrics)
+		go table.scrubber.run()
+	}
+
 	return table, nil
 }
 
@@ -332,6 +392,10 @@ func (d *DiskTable) Size() uint64 {
 	return d.size.Load()
 }
 
+func (d *DiskTable) LogicalSize() uint64 {
+	return d.logicalSize.Load()
+}
+
 // repairSnapshot is responsible for making any required repairs to the snapshot directories. This is needed
 // if there is a crash, resulting in a segment not being fully snapshotted. It is also needed if LittDB has
 // been rebased (which breaks symlinks) or manually modified (e.g. by the LittDB cli). Returns the new upper bound
@@ -444,17 +508,50 @@ func (d *DiskTable) reloadKeymap(
 		if err != nil {
 			return fmt.Errorf("failed to get keys from segment %d: %w", i, err)
 		}
-		for keyIndex := len(keys) - 1; keyIndex >= 0; keyIndex-- {
-			key := keys[keyIndex]
 
-			batch = append(batch, key)
-			if len(batch) == keymapReloadBatchSize {
+		if segments[i].TombstoneCount() == 0 {
+			for keyIndex := len(keys) - 1; keyIndex >= 0; keyIndex-- {
+				key := keys[keyIndex]
+
+				batch = append(batch, key)
+				if len(batch) == keymapReloadBatchSize {
+					err = d.keymap.Put(batch)
+					if err != nil {
+						return fmt.Errorf("failed to put keys for segment %d: %w", i, err)
+					}
+					batch = make([]*types.ScopedKey, 0, keymapReloadBatchSize)
+				}
+			}
+			continue
+		}
+
+		// This segment contains tombstones, so the order in which records are applied matters.
+		// Apply the records in the order in which they were written.
+		for _, key := range keys {
+			if !key.Tombstone {
+				batch = append(batch, key)
+				if len(batch) == keymapReloadBatchSize {
+					err = d.keymap.Put(batch)
+					if err != nil {
+						return fmt.Errorf("failed to put keys for segment %d: %w", i, err)
+					}
+					batch = make([]*types.ScopedKey, 0, keymapReloadBatchSize)
+				}
+				continue
+			}
+
+			// Before applying a tombstone, make sure all previously encountered keys are in the keymap.
+			if len(batch) > 0 {
 				err = d.keymap.Put(batch)
 				if err != nil {
 					return fmt.Errorf("failed to put keys for segment %d: %w", i, err)
 				}
 				batch = make([]*types.ScopedKey, 0, keymapReloadBatchSize)
 			}
+			err = d.keymap.Delete([]*types.ScopedKey{key})
+			if err != nil {
+				return fmt.Errorf("failed to delete key for segment %d: %w", i, err)
+			}
 		}
 	}
 
@@ -485,6 +582,64 @@ func (d *DiskTable) reloadKeymap(
 	return nil
 }
 
+// replayTombstones applies the tombstones found in segments to the keymap. This is necessary when the keymap is
+// persistent (and therefore not reloaded), since the process may have crashed after a tombstone was made durable in
+// a segment but before the deletion was applied to the keymap. Replaying a tombstone is idempotent.
+func (d *DiskTable) replayTombstones(
+	segments map[uint32]*segment.Segment,
+	lowestSegmentIndex uint32,
+	highestSegmentIndex uint32) error {
+
+	start := d.clock()
+	defer func() {
+		d.logger.Infof("spent %v replaying tombstones", d.clock().Sub(start))
+	}()
+
+	for i := lowestSegmentIndex; i <= highestSegmentIndex; i++ {
+		if !segments[i].IsSealed() || segments[i].TombstoneCount() == 0 {
+			continue
+		}
+
+		keys, err := segments[i].GetKeys()
+		if err != nil {
+			return fmt.Errorf("failed to get keys from segment %d: %w", i, err)
+		}
+
+		// Only the last record for each key in this segment matters. If a key was deleted and then written again
+		// within this segment, then the tombstone must not be replayed.
+		lastRecords := make(map[string]*types.ScopedKey)
+		for _, key := range keys {
+			lastRecords[util.UnsafeBytesToString(key.Key)] = key
+		}
+
+		toDelete := make([]*types.ScopedKey, 0)
+		for _, record := range lastRecords {
+			if !record.Tombstone {
+				continue
+			}
+
+			address, ok, err := d.keymap.Get(record.Key)
+			if err != nil {
+				return fmt.Errorf("failed to get address for key %x: %w", record.Key, err)
+			}
+
+			// If the keymap points to a newer segment, then the key was written again after this tombstone.
+			if ok && address.Index() <= i {
+				toDelete = append(toDelete, record)
+			}
+		}
+
+		if len(toDelete) > 0 {
+			err = d.keymap.Delete(toDelete)
+			if err != nil {
+				return fmt.Errorf("failed to delete keys for segment %d: %w", i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (d *DiskTable) Name() string {
 	return d.name
 }
@@ -645,6 +800,27 @@ func (d *DiskTable) SetShardingFactor(shardingFactor uint32) error {
 	return nil
 }
 
+func (d *DiskTable) SetCompression(compression types.CompressionType) error {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return fmt.Errorf(
+			"cannot process SetCompression() request, DB is in panicked state due to error: %w", err)
+	}
+
+	if !compression.IsValid() {
+		return fmt.Errorf("unsupported compression type: %s", compression)
+	}
+
+	request := &controlLoopSetCompressionRequest{
+		compression: compression,
+	}
+	err := d.controlLoop.enqueue(request)
+	if err != nil {
+		return fmt.Errorf("failed to send compression request: %w", err)
+	}
+
+	return nil
+}
+
 func (d *DiskTable) Get(key []byte) (value []byte, exists bool, err error) {
 	if ok, err := d.errorMonitor.IsOk(); !ok {
 		return nil, false, fmt.Errorf(
@@ -658,6 +834,11 @@ func (d *DiskTable) Get(key []byte) (value []byte, exists bool, err error) {
 		return bytes, true, nil
 	}
 
+	// Next, check if the key has been deleted but the deletion has not yet been flushed.
+	if _, ok := d.unflushedTombstones.Load(util.UnsafeBytesToString(key)); ok {
+		return nil, false, nil
+	}
+
 	// Look up the address of the data.
 	address, ok, err := d.keymap.Get(key)
 	if err != nil {
@@ -683,6 +864,12 @@ func (d *DiskTable) Get(key []byte) (value []byte, exists bool, err error) {
 	return data, true, nil
 }
 
+// The disk table does not cache values (unflushed data is held in memory until it is written, but is not a cache),
+// so this is identical to Get.
+func (d *DiskTable) UncachedGet(key []byte) (value []byte, exists bool, err error) {
+	return d.Get(key)
+}
+
 func (d *DiskTable) CacheAwareGet(
 	key []byte,
 	onlyReadFromCache bool,
@@ -702,6 +889,11 @@ func (d *DiskTable) CacheAwareGet(
 		return value, true, true, nil
 	}
 
+	// Next, check if the key has been deleted but the deletion has not yet been flushed.
+	if _, deleted := d.unflushedTombstones.Load(util.UnsafeBytesToString(key)); deleted {
+		return nil, false, false, nil
+	}
+
 	// Look up the address of the data.
 	var address types.Address
 	address, exists, err = d.keymap.Get(key)
@@ -770,9 +962,18 @@ func (d *DiskTable) PutBatch(batch []*types.KVPair) error {
 		if kv.Value == nil {
 			return fmt.Errorf("nil values are not supported")
 		}
+	}
+
+	d.writeLock.Lock()
+	defer d.writeLock.Unlock()
 
+	d.unflushedLock.Lock()
+	for _, kv := range batch {
 		d.unflushedDataCache.Store(util.UnsafeBytesToString(kv.Key), kv.Value)
+		d.unflushedTombstones.Delete(util.UnsafeBytesToString(kv.Key))
+		d.unflushedOperations[util.UnsafeBytesToString(kv.Key)]++
 	}
+	d.unflushedLock.Unlock()
 
 	request := &controlLoopWriteRequest{
 		values: batch,
@@ -787,12 +988,86 @@ func (d *DiskTable) PutBatch(batch []*types.KVPair) error {
 	return nil
 }
 
+func (d *DiskTable) Delete(key []byte) error {
+	return d.DeleteBatch([][]byte{key})
+}
+
+func (d *DiskTable) DeleteBatch(keys [][]byte) error {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return fmt.Errorf("cannot process DeleteBatch() request, DB is in panicked state due to error: %w", err)
+	}
+
+	for _, key := range keys {
+		if key == nil {
+			return fmt.Errorf("nil keys are not supported")
+		}
+		if len(key) > math.MaxUint32 {
+			return fmt.Errorf("key is too large, length must not exceed 2^32 bytes: %d bytes", len(key))
+		}
+	}
+
+	// Holding the write lock ensures that no write of these keys lands between checking their existence and
+	// sending the deletion to the control loop.
+	d.writeLock.Lock()
+	defer d.writeLock.Unlock()
+
+	// Only write tombstones for keys that are actually present. Deleting a key that does not exist is a no-op.
+	keysToDelete := make([][]byte, 0, len(keys))
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if _, ok := seen[util.UnsafeBytesToString(key)]; ok {
+			// The key appears more than once in this batch.
+			continue
+		}
+		seen[util.UnsafeBytesToString(key)] = struct{}{}
+
+		exists, err := d.Exists(key)
+		if err != nil {
+			return fmt.Errorf("failed to check if key exists: %w", err)
+		}
+		if exists {
+			keysToDelete = append(keysToDelete, key)
+		}
+	}
+
+	d.unflushedLock.Lock()
+	for _, key := range keysToDelete {
+		// Record the tombstone before removing the value from the unflushed data cache. This ensures that there is
+		// no window of time where the value is visible via the keymap.
+		d.unflushedTombstones.Store(util.UnsafeBytesToString(key), struct{}{})
+		d.unflushedDataCache.Delete(util.UnsafeBytesToString(key))
+		d.unflushedOperations[util.UnsafeBytesToString(key)]++
+	}
+	d.unflushedLock.Unlock()
+
+	if len(keysToDelete) == 0 {
+		return nil
+	}
+
+	request := &controlLoopDeleteRequest{
+		keys: keysToDelete,
+	}
+	err := d.controlLoop.enqueue(request)
+	if err != nil {
+		return fmt.Errorf("failed to send delete request: %w", err)
+	}
+
+	d.keyCount.Add(-int64(len(keysToDelete)))
+
+	return nil
+}
+
 func (d *DiskTable) Exists(key []byte) (bool, error) {
 	_, ok := d.unflushedDataCache.Load(util.UnsafeBytesToString(key))
 	if ok {
 		return true, nil
 	}
 
+	_, ok = d.unflushedTombstones.Load(util.UnsafeBytesToString(key))
+	if ok {
+		return false, nil
+	}
+
 	_, ok, err := d.keymap.Get(key)
 	if err != nil {
 		return false, fmt.Errorf("failed to get address: %w", err)
@@ -863,6 +1138,56 @@ func (d *DiskTable) SetReadCacheSize(size uint64) error {
 	return nil
 }
 
+func (d *DiskTable) Info() (*litt.TableInfo, error) {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return nil, fmt.Errorf(
+			"cannot process Info() request, DB is in panicked state due to error: %w", err)
+	}
+
+	info := &litt.TableInfo{
+		Name:              d.name,
+		KeyCount:          d.KeyCount(),
+		Size:              d.Size(),
+		LogicalSize:       d.LogicalSize(),
+		TTL:               d.metadata.GetTTL(),
+		ShardingFactor:    d.metadata.GetShardingFactor(),
+		Compression:       d.metadata.GetCompression(),
+		GCSegmentsDeleted: d.controlLoop.gcSegmentsDeleted.Load(),
+	}
+
+	if d.keymapTypeFile != nil {
+		info.KeymapType = string(d.keymapTypeFile.Type())
+	}
+
+	if lastGCTime := d.controlLoop.lastGCTime.Load(); lastGCTime != 0 {
+		info.LastGCTime = time.Unix(0, lastGCTime)
+	}
+
+	segments := d.controlLoop.getReservedSegments()
+	defer func() {
+		for _, seg := range segments {
+			seg.Release()
+		}
+	}()
+
+	first := true
+	for index, seg := range segments {
+		if first || index < info.LowestSegmentIndex {
+			info.LowestSegmentIndex = index
+			info.OldestSegmentSealTime = seg.GetSealTime()
+		}
+		if first || index > info.HighestSegmentIndex {
+			info.HighestSegmentIndex = index
+		}
+		if seg.IsSealed() && seg.GetSealTime().After(info.NewestSegmentSealTime) {
+			info.NewestSegmentSealTime = seg.GetSealTime()
+		}
+		first = false
+	}
+
+	return info, nil
+}
+
 func (d *DiskTable) RunGC() error {
 	if ok, err := d.errorMonitor.IsOk(); !ok {
 		return fmt.Errorf(
@@ -886,8 +1211,32 @@ func (d *DiskTable) RunGC() error {
 	return nil
 }
 
+func (d *DiskTable) EvictOldestSegments(bytes uint64) (uint64, error) {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return 0, fmt.Errorf(
+			"cannot process EvictOldestSegments() request, DB is in panicked state due to error: %w", err)
+	}
+
+	request := &controlLoopEvictRequest{
+		bytes:        bytes,
+		responseChan: make(chan uint64, 1),
+	}
+
+	err := d.controlLoop.enqueue(request)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send evict request: %w", err)
+	}
+
+	freed, err := util.Await(d.errorMonitor, request.responseChan)
+	if err != nil {
+		return 0, fmt.Errorf("failed to await eviction completion: %w", err)
+	}
+
+	return freed, nil
+}
+
 // writeKeysToKeymap flushes all keys to the keymap. Once they are flushed, it also removes the keys from the
-// unflushedDataCache.
+// unflushedDataCache (or, for tombstones, from unflushedTombstones).
 func (d *DiskTable) writeKeysToKeymap(keys []*types.ScopedKey) error {
 	if len(keys) == 0 {
 		// Nothing to flush.
@@ -903,15 +1252,45 @@ func (d *DiskTable) writeKeysToKeymap(keys []*types.ScopedKey) error {
 		}()
 	}
 
-	err := d.keymap.Put(keys)
-	if err != nil {
-		return fmt.Errorf("failed to flush keys: %w", err)
+	// Tombstones must be applied in the order they were written relative to regular keys, since a key may be
+	// written, deleted, and written again. Apply each contiguous run of keys/tombstones as a single batch.
+	runStart := 0
+	for runStart < len(keys) {
+		runEnd := runStart + 1
+		for runEnd < len(keys) && keys[runEnd].Tombstone == keys[runStart].Tombstone {
+			runEnd++
+		}
+		run := keys[runStart:runEnd]
+
+		var err error
+		if run[0].Tombstone {
+			err = d.keymap.Delete(run)
+		} else {
+			err = d.keymap.Put(run)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to flush keys: %w", err)
+		}
+
+		runStart = runEnd
 	}
 
-	// Keys are now durably written to both the segment and the keymap. It is therefore safe to remove them from the
-	// unflushed data cache.
+	// Keys are now durably written to both the segment and the keymap. Once all pending operations on a key have
+	// been flushed, the keymap reflects the latest of them, and it is safe to remove the key from the unflushed data
+	// cache and the unflushed tombstones. A key with pending operations is left alone, since its cache entry or
+	// tombstone belongs to a newer operation than the one that was just flushed.
+	d.unflushedLock.Lock()
+	defer d.unflushedLock.Unlock()
 	for _, ka := range keys {
-		d.unflushedDataCache.Delete(util.UnsafeBytesToString(ka.Key))
+		key := util.UnsafeBytesToString(ka.Key)
+		remaining := d.unflushedOperations[key] - 1
+		if remaining > 0 {
+			d.unflushedOperations[key] = remaining
+			continue
+		}
+		delete(d.unflushedOperations, key)
+		d.unflushedTombstones.Delete(key)
+		d.unflushedDataCache.Delete(key)
 	}
 
 	return nil
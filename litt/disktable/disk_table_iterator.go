@@ -0,0 +1,240 @@
+package disktable
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+var _ litt.Iterator = &diskTableIterator{}
+
+// diskTableIterator iterates over the data in a disk table. It merges two sorted sources of keys: a snapshot of the
+// keymap (data that has been flushed), and a snapshot of the unflushed data cache (data that has been written but
+// not yet flushed). All segments that existed when the iterator was created are reserved for the lifetime of the
+// iterator, which prevents the garbage collector from deleting data that the iterator may still need to read.
+type diskTableIterator struct {
+	// The table being iterated over.
+	table *DiskTable
+
+	// Segments reserved by this iterator, keyed by segment index. Released when the iterator is released.
+	segments map[uint32]*segment.Segment
+
+	// Iterates over a snapshot of the keymap.
+	keymapIterator keymap.Iterator
+
+	// If true, then keymapIterator is positioned at an entry that has not yet been consumed.
+	keymapHasEntry bool
+
+	// If true, then keymapIterator must be advanced before its current entry can be inspected.
+	keymapNeedsAdvance bool
+
+	// A sorted snapshot of the data that had not been flushed when the iterator was created.
+	unflushed []*types.KVPair
+
+	// The index of the next unconsumed entry in unflushed.
+	unflushedIndex int
+
+	// Keys that had been deleted (but where the deletion had not yet been flushed) when the iterator was created.
+	// Keymap entries for these keys are skipped.
+	tombstones map[string]struct{}
+
+	// The key at the current position.
+	key []byte
+
+	// The value at the current position, if the current position refers to unflushed data.
+	value []byte
+
+	// The address of the value at the current position, if the current position refers to flushed data.
+	address types.Address
+
+	// If true, the current position refers to unflushed data and the value is held in memory.
+	inMemory bool
+
+	// The first error encountered, if any.
+	err error
+
+	// Set to true when the iterator is released.
+	released bool
+}
+
+func (d *DiskTable) Iterator() (litt.Iterator, error) {
+	return d.RangeIterator(nil, nil)
+}
+
+func (d *DiskTable) PrefixIterator(prefix []byte) (litt.Iterator, error) {
+	start, end := util.PrefixToRange(prefix)
+	return d.RangeIterator(start, end)
+}
+
+func (d *DiskTable) RangeIterator(start []byte, end []byte) (litt.Iterator, error) {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return nil, fmt.Errorf(
+			"cannot process RangeIterator() request, DB is in panicked state due to error: %w", err)
+	}
+
+	// Order matters here. Segments must be reserved before the keymap snapshot is taken, otherwise the garbage
+	// collector could delete a segment that is referenced by the snapshot. The unflushed data cache must be
+	// captured before the keymap snapshot, otherwise a key could be flushed (i.e. moved from the cache to the
+	// keymap) between the two snapshots and be missed by both.
+	segments := d.controlLoop.getReservedSegments()
+
+	unflushed := make([]*types.KVPair, 0)
+	d.unflushedDataCache.Range(func(key, value any) bool {
+		keyBytes := []byte(key.(string))
+		if util.IsKeyInRange(keyBytes, start, end) {
+			unflushed = append(unflushed, &types.KVPair{Key: keyBytes, Value: value.([]byte)})
+		}
+		return true
+	})
+	sort.Slice(unflushed, func(i, j int) bool {
+		return bytes.Compare(unflushed[i].Key, unflushed[j].Key) < 0
+	})
+
+	// Similar to the unflushed data cache, pending tombstones must be captured before the keymap snapshot,
+	// otherwise a deletion could be flushed between the two snapshots and a deleted key would be missed by both.
+	tombstones := make(map[string]struct{})
+	d.unflushedTombstones.Range(func(key, _ any) bool {
+		if util.IsKeyInRange([]byte(key.(string)), start, end) {
+			tombstones[key.(string)] = struct{}{}
+		}
+		return true
+	})
+
+	keymapIterator, err := d.keymap.Iterator(start, end)
+	if err != nil {
+		for _, seg := range segments {
+			seg.Release()
+		}
+		return nil, fmt.Errorf("failed to create keymap iterator: %w", err)
+	}
+
+	return &diskTableIterator{
+		table:              d,
+		segments:           segments,
+		keymapIterator:     keymapIterator,
+		keymapNeedsAdvance: true,
+		unflushed:          unflushed,
+		tombstones:         tombstones,
+	}, nil
+}
+
+func (i *diskTableIterator) Next() bool {
+	if i.released || i.err != nil {
+		return false
+	}
+
+	for {
+		if i.keymapNeedsAdvance {
+			i.keymapHasEntry = i.keymapIterator.Next()
+			i.keymapNeedsAdvance = false
+			if !i.keymapHasEntry {
+				if err := i.keymapIterator.Error(); err != nil {
+					i.err = fmt.Errorf("failed to iterate over keymap: %w", err)
+					return false
+				}
+			}
+		}
+
+		unflushedHasEntry := i.unflushedIndex < len(i.unflushed)
+
+		if !i.keymapHasEntry && !unflushedHasEntry {
+			return false
+		}
+
+		if unflushedHasEntry {
+			unflushedEntry := i.unflushed[i.unflushedIndex]
+
+			comparison := -1
+			if i.keymapHasEntry {
+				comparison = bytes.Compare(unflushedEntry.Key, i.keymapIterator.Key())
+			}
+
+			if comparison <= 0 {
+				// The unflushed entry comes next. If the same key is also in the keymap (i.e. it was flushed
+				// after the unflushed data snapshot was taken), skip the keymap entry to avoid a duplicate.
+				i.unflushedIndex++
+				if comparison == 0 {
+					i.keymapNeedsAdvance = true
+				}
+
+				i.key = unflushedEntry.Key
+				i.value = unflushedEntry.Value
+				i.inMemory = true
+				return true
+			}
+		}
+
+		// The keymap entry comes next.
+		i.keymapNeedsAdvance = true
+
+		if _, deleted := i.tombstones[util.UnsafeBytesToString(i.keymapIterator.Key())]; deleted {
+			// This key was deleted before the iterator was created.
+			continue
+		}
+
+		address := i.keymapIterator.Address()
+
+		if _, ok := i.segments[address.Index()]; !ok {
+			// This segment was created after the iterator was created. Reserve it now.
+			seg, ok := i.table.controlLoop.getReservedSegment(address.Index())
+			if !ok {
+				// The segment was garbage collected, so the value is no longer available.
+				continue
+			}
+			i.segments[address.Index()] = seg
+		}
+
+		i.key = i.keymapIterator.Key()
+		i.value = nil
+		i.address = address
+		i.inMemory = false
+		return true
+	}
+}
+
+func (i *diskTableIterator) Key() []byte {
+	return i.key
+}
+
+func (i *diskTableIterator) Value() ([]byte, error) {
+	if i.released {
+		return nil, fmt.Errorf("iterator has been released")
+	}
+
+	if i.inMemory {
+		return i.value, nil
+	}
+
+	seg := i.segments[i.address.Index()]
+	value, err := seg.Read(i.key, i.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read value for key %x: %w", i.key, err)
+	}
+
+	return value, nil
+}
+
+func (i *diskTableIterator) Error() error {
+	return i.err
+}
+
+func (i *diskTableIterator) Release() {
+	if i.released {
+		return
+	}
+	i.released = true
+
+	i.keymapIterator.Release()
+	for _, seg := range i.segments {
+		seg.Release()
+	}
+	i.segments = nil
+	i.unflushed = nil
+	i.tombstones = nil
+}
@@ -1,11 +1,13 @@
 package disktable
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -382,6 +384,104 @@ func TestRestart(t *testing.T) {
 	}
 }
 
+// Verifies that deletions survive a restart, and that deleted keys may be written again afterwards.
+func deleteRestartTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(time.Now, tableName, []string{directory})
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	deletedKeys := make(map[string]struct{})
+
+	verify := func() {
+		for expectedKey, expectedValue := range expectedValues {
+			value, ok, err := table.Get([]byte(expectedKey))
+			require.NoError(t, err)
+			require.True(t, ok, "key %s not found", expectedKey)
+			require.Equal(t, expectedValue, value)
+		}
+		for deletedKey := range deletedKeys {
+			ok, err := table.Exists([]byte(deletedKey))
+			require.NoError(t, err)
+			require.False(t, ok, "deleted key %s found", deletedKey)
+		}
+		require.Equal(t, uint64(len(expectedValues)), table.KeyCount())
+	}
+
+	iterations := 1000
+	restartIteration := iterations/2 + int(rand.Int64Range(-10, 10))
+
+	for i := 0; i < iterations; i++ {
+
+		// Somewhere in the middle of the test, restart the table.
+		if i == restartIteration {
+			err = table.Close()
+			require.NoError(t, err)
+
+			table, err = tableBuilder.builder(time.Now, tableName, []string{directory})
+			require.NoError(t, err)
+
+			verify()
+		}
+
+		if rand.BoolWithProbability(0.6) || len(expectedValues) == 0 {
+			key := rand.PrintableVariableBytes(32, 64)
+			value := rand.PrintableVariableBytes(1, 128)
+			err = table.Put(key, value)
+			require.NoError(t, err)
+			expectedValues[string(key)] = value
+		} else if rand.BoolWithProbability(0.75) {
+			for key := range expectedValues {
+				err = table.Delete([]byte(key))
+				require.NoError(t, err)
+				delete(expectedValues, key)
+				deletedKeys[key] = struct{}{}
+				break
+			}
+		} else {
+			for key := range deletedKeys {
+				value := rand.PrintableVariableBytes(1, 128)
+				err = table.Put([]byte(key), value)
+				require.NoError(t, err)
+				expectedValues[key] = value
+				delete(deletedKeys, key)
+				break
+			}
+		}
+
+		// Once in a while, flush the table.
+		if rand.BoolWithProbability(0.1) {
+			err = table.Flush()
+			require.NoError(t, err)
+		}
+	}
+
+	verify()
+
+	ok, _ := table.(*DiskTable).errorMonitor.IsOk()
+	require.True(t, ok)
+	err = table.Destroy()
+	require.NoError(t, err)
+
+	// ensure that the test directory is empty
+	entries, err := os.ReadDir(directory)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestDeleteRestart(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			deleteRestartTest(t, tb)
+		})
+	}
+}
+
 // This test deletes a random file from a middle segment. This is considered unrecoverable corruption, and should
 // cause the table to fail to restart.
 func middleFileMissingTest(t *testing.T, tableBuilder *tableBuilder, typeToDelete string) {
@@ -1251,8 +1351,8 @@ func truncatedValueFileTest(t *testing.T, tableBuilder *tableBuilder) {
 		offset := key.Address.Offset()
 		valueSize := len(expectedValues[string(key.Key)])
 		// If there are not at least this many bytes remaining in the value file, the value is missing.
-		requiredLength := offset + uint32(valueSize) + 4
-		if requiredLength > uint32(len(valueFileBytes)) {
+		requiredLength := uint64(offset) + segment.ValueFileRecordSize(segment.LatestSegmentVersion, valueSize)
+		if requiredLength > uint64(len(valueFileBytes)) {
 			missingKeys[string(key.Key)] = struct{}{}
 		}
 	}
@@ -2303,3 +2403,114 @@ func TestTableSize(t *testing.T) {
 		})
 	}
 }
+
+// Writes a key, deletes it, and writes it again while the first write is being flushed, and while other goroutines
+// read the key. Once the second write returns, the key must never be observed with any value other than the one it
+// was last written with.
+func rewriteDeletedKeyTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	table, err := tableBuilder.builder(time.Now, rand.String(8), []string{directory})
+	require.NoError(t, err)
+
+	const iterations = 100
+	const readers = 2
+
+	// the index of the last key that has been written, deleted, and written again
+	var lastRewritten atomic.Int64
+	lastRewritten.Store(-1)
+	keys := make([][]byte, iterations)
+	values := make([][]byte, iterations)
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("key-%d", i))
+		values[i] = rand.PrintableVariableBytes(1, 64)
+	}
+
+	done := make(chan struct{})
+	errs := make(chan error, readers)
+	var wg sync.WaitGroup
+
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				time.Sleep(100 * time.Microsecond)
+				index := lastRewritten.Load()
+				if index < 0 {
+					continue
+				}
+				value, ok, err := table.Get(keys[index])
+				if err != nil {
+					errs <- err
+					return
+				}
+				if !ok || !bytes.Equal(values[index], value) {
+					errs <- fmt.Errorf("key %s: expected %q, got %q (present: %v)", keys[index], values[index], value, ok)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := range keys {
+		require.NoError(t, table.Put(keys[i], rand.PrintableVariableBytes(1, 64)))
+
+		// Flush the first write while the key is deleted and written again.
+		flushed := make(chan error, 1)
+		go func() {
+			flushed <- table.Flush()
+		}()
+
+		require.NoError(t, table.Delete(keys[i]))
+		require.NoError(t, table.Put(keys[i], values[i]))
+		lastRewritten.Store(int64(i))
+
+		for flushing := true; flushing; {
+			select {
+			case err := <-flushed:
+				require.NoError(t, err)
+				flushing = false
+			default:
+			}
+			value, ok, err := table.Get(keys[i])
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, values[i], value)
+		}
+	}
+
+	close(done)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	// Every key holds its final value once all operations are flushed.
+	require.NoError(t, table.Flush())
+	for i := range keys {
+		value, ok, err := table.Get(keys[i])
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, values[i], value)
+	}
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestRewriteDeletedKey(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			rewriteDeletedKeyTest(t, tb)
+		})
+	}
+}
@@ -42,6 +42,14 @@ type Keymap interface {
 	// This includes the byte slices containing the keys.
 	Delete(keys []*types.ScopedKey) error
 
+	// Iterator returns an iterator over the key-address pairs with keys in the range [start, end), in ascending
+	// lexicographic key order. A nil start means the range is unbounded below, and a nil end means the range is
+	// unbounded above. The iterator observes a consistent snapshot of the keymap taken when this method is called.
+	// The caller is responsible for calling Release() on the returned iterator when done with it.
+	//
+	// It is not safe to modify the start or end byte slices after they are passed to this method.
+	Iterator(start []byte, end []byte) (Iterator, error)
+
 	// Stop stops the keymap.
 	Stop() error
 
@@ -51,3 +59,22 @@ type Keymap interface {
 
 // BuildKeymap is a function that builds a Keymap.
 type BuildKeymap func(logger logging.Logger, keymapPath string, doubleWriteProtection bool) (Keymap, bool, error)
+
+// Iterator walks over the key-address pairs in a keymap in ascending lexicographic key order. Iterators are not
+// thread safe.
+type Iterator interface {
+	// Next advances the iterator. Returns false if there are no more pairs or if an error was encountered.
+	Next() bool
+
+	// Key returns the key at the iterator's current position. The returned slice is owned by the caller.
+	Key() []byte
+
+	// Address returns the address at the iterator's current position.
+	Address() types.Address
+
+	// Error returns the first error encountered while advancing the iterator, if any.
+	Error() error
+
+	// Release releases the resources held by the iterator. It is safe to call Release() multiple times.
+	Release()
+}
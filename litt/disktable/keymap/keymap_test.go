@@ -1,8 +1,10 @@
 package keymap
 
 import (
+	"bytes"
 	"os"
 	"path"
+	"sort"
 	"testing"
 
 	"github.com/Layr-Labs/eigenda/litt/types"
@@ -283,3 +285,108 @@ func TestRestart(t *testing.T) {
 	err = keymap.Destroy()
 	require.NoError(t, err)
 }
+
+func testIterator(t *testing.T, keymap Keymap) {
+	rand := random.NewTestRandom()
+
+	expected := make(map[string]types.Address)
+	for i := 0; i < 1000; i++ {
+		key := rand.VariableBytes(1, 8)
+		address := types.Address(rand.Uint64())
+		if _, ok := expected[string(key)]; ok {
+			continue
+		}
+		err := keymap.Put([]*types.ScopedKey{{Key: key, Address: address}})
+		require.NoError(t, err)
+		expected[string(key)] = address
+	}
+
+	sortedKeys := make([]string, 0, len(expected))
+	for key := range expected {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	// Full scan.
+	iterator, err := keymap.Iterator(nil, nil)
+	require.NoError(t, err)
+	index := 0
+	for iterator.Next() {
+		require.Equal(t, sortedKeys[index], string(iterator.Key()))
+		require.Equal(t, expected[sortedKeys[index]], iterator.Address())
+		index++
+	}
+	require.NoError(t, iterator.Error())
+	require.Equal(t, len(sortedKeys), index)
+	iterator.Release()
+
+	// Range scans.
+	for i := 0; i < 10; i++ {
+		start := rand.VariableBytes(1, 4)
+		end := rand.VariableBytes(1, 4)
+		if bytes.Compare(start, end) > 0 {
+			start, end = end, start
+		}
+
+		expectedKeys := make([]string, 0)
+		for _, key := range sortedKeys {
+			if util.IsKeyInRange([]byte(key), start, end) {
+				expectedKeys = append(expectedKeys, key)
+			}
+		}
+
+		iterator, err = keymap.Iterator(start, end)
+		require.NoError(t, err)
+		actualKeys := make([]string, 0)
+		for iterator.Next() {
+			actualKeys = append(actualKeys, string(iterator.Key()))
+		}
+		require.NoError(t, iterator.Error())
+		require.Equal(t, expectedKeys, actualKeys)
+		iterator.Release()
+	}
+
+	// An iterator should not observe changes made after it was created.
+	iterator, err = keymap.Iterator(nil, nil)
+	require.NoError(t, err)
+	toDelete := make([]*types.ScopedKey, 0, len(sortedKeys))
+	for _, key := range sortedKeys {
+		toDelete = append(toDelete, &types.ScopedKey{Key: []byte(key)})
+	}
+	err = keymap.Delete(toDelete)
+	require.NoError(t, err)
+	index = 0
+	for iterator.Next() {
+		require.Equal(t, sortedKeys[index], string(iterator.Key()))
+		index++
+	}
+	require.NoError(t, iterator.Error())
+	require.Equal(t, len(sortedKeys), index)
+	iterator.Release()
+
+	// Releasing an iterator multiple times is harmless.
+	iterator.Release()
+
+	// A new iterator should observe the deletions.
+	iterator, err = keymap.Iterator(nil, nil)
+	require.NoError(t, err)
+	require.False(t, iterator.Next())
+	require.NoError(t, iterator.Error())
+	iterator.Release()
+
+	err = keymap.Destroy()
+	require.NoError(t, err)
+}
+
+func TestIterator(t *testing.T) {
+	t.Parallel()
+	testDir := t.TempDir()
+	dbDir := path.Join(testDir, "keymap")
+
+	logger := test.GetLogger()
+	for _, builder := range builders {
+		keymap, err := builder(logger, dbDir)
+		require.NoError(t, err)
+		testIterator(t, keymap)
+	}
+}
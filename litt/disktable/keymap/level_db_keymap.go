@@ -10,7 +10,9 @@ import (
 	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	leveldbutil "github.com/syndtr/goleveldb/leveldb/util"
 )
 
 var _ Keymap = &LevelDBKeymap{}
@@ -148,6 +150,18 @@ func (l *LevelDBKeymap) Delete(keys []*types.ScopedKey) error {
 	return nil
 }
 
+func (l *LevelDBKeymap) Iterator(start []byte, end []byte) (Iterator, error) {
+	snapshot, err := l.db.GetSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LevelDB snapshot: %w", err)
+	}
+
+	return &levelDBKeymapIterator{
+		snapshot: snapshot,
+		iterator: snapshot.NewIterator(&leveldbutil.Range{Start: start, Limit: end}, nil),
+	}, nil
+}
+
 func (l *LevelDBKeymap) Stop() error {
 	alive := l.alive.Swap(false)
 	if !alive {
@@ -175,3 +189,68 @@ func (l *LevelDBKeymap) Destroy() error {
 
 	return nil
 }
+
+var _ Iterator = &levelDBKeymapIterator{}
+
+// levelDBKeymapIterator iterates over a LevelDB snapshot.
+type levelDBKeymapIterator struct {
+	// The snapshot being iterated over. Must be released when iteration is complete.
+	snapshot *leveldb.Snapshot
+	// The underlying LevelDB iterator.
+	iterator iterator.Iterator
+	// The key at the current position.
+	key []byte
+	// The address at the current position.
+	address types.Address
+	// The first error encountered, if any.
+	err error
+}
+
+func (i *levelDBKeymapIterator) Next() bool {
+	if i.err != nil || i.iterator == nil {
+		return false
+	}
+
+	if !i.iterator.Next() {
+		if err := i.iterator.Error(); err != nil {
+			i.err = fmt.Errorf("failed to iterate over LevelDB: %w", err)
+		}
+		return false
+	}
+
+	address, err := types.DeserializeAddress(i.iterator.Value())
+	if err != nil {
+		i.err = fmt.Errorf("failed to deserialize address: %w", err)
+		return false
+	}
+
+	// LevelDB reuses the buffer backing the key, so we need to copy it.
+	key := make([]byte, len(i.iterator.Key()))
+	copy(key, i.iterator.Key())
+
+	i.key = key
+	i.address = address
+	return true
+}
+
+func (i *levelDBKeymapIterator) Key() []byte {
+	return i.key
+}
+
+func (i *levelDBKeymapIterator) Address() types.Address {
+	return i.address
+}
+
+func (i *levelDBKeymapIterator) Error() error {
+	return i.err
+}
+
+func (i *levelDBKeymapIterator) Release() {
+	if i.iterator == nil {
+		return
+	}
+	i.iterator.Release()
+	i.snapshot.Release()
+	i.iterator = nil
+	i.snapshot = nil
+}
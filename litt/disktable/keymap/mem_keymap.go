@@ -1,7 +1,9 @@
 package keymap
 
 import (
+	"bytes"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/Layr-Labs/eigenda/litt/types"
@@ -77,6 +79,31 @@ func (m *memKeymap) Delete(keys []*types.ScopedKey) error {
 	return nil
 }
 
+func (m *memKeymap) Iterator(start []byte, end []byte) (Iterator, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	// Go maps are unordered, so the only way to provide a consistent and ordered view of the data is to copy
+	// the matching entries and sort them.
+	pairs := make([]*types.ScopedKey, 0)
+	for key, address := range m.data {
+		keyBytes := []byte(key)
+		if !util.IsKeyInRange(keyBytes, start, end) {
+			continue
+		}
+		pairs = append(pairs, &types.ScopedKey{Key: keyBytes, Address: address})
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		return bytes.Compare(pairs[i].Key, pairs[j].Key) < 0
+	})
+
+	return &memKeymapIterator{
+		pairs: pairs,
+		index: -1,
+	}, nil
+}
+
 func (m *memKeymap) Stop() error {
 	// nothing to do here
 	return nil
@@ -88,3 +115,38 @@ func (m *memKeymap) Destroy() error {
 	m.data = nil
 	return nil
 }
+
+var _ Iterator = &memKeymapIterator{}
+
+// memKeymapIterator iterates over a sorted copy of the entries in a memKeymap.
+type memKeymapIterator struct {
+	// The key-address pairs to iterate over, sorted by key.
+	pairs []*types.ScopedKey
+	// The index of the current pair.
+	index int
+}
+
+func (i *memKeymapIterator) Next() bool {
+	if i.index >= len(i.pairs) {
+		return false
+	}
+	i.index++
+	return i.index < len(i.pairs)
+}
+
+func (i *memKeymapIterator) Key() []byte {
+	return i.pairs[i.index].Key
+}
+
+func (i *memKeymapIterator) Address() types.Address {
+	return i.pairs[i.index].Address
+}
+
+func (i *memKeymapIterator) Error() error {
+	return nil
+}
+
+func (i *memKeymapIterator) Release() {
+	i.pairs = nil
+	i.index = 0
+}
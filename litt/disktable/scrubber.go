@@ -0,0 +1,179 @@
+package disktable
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/metrics"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"golang.org/x/time/rate"
+)
+
+// scrubber runs a goroutine that periodically verifies the integrity of the sealed segments in a disk table, in order
+// to detect disk corruption before the corrupt data is requested by a reader. Disk reads performed by the scrubber
+// are rate limited so that scrubbing does not starve foreground reads and writes of disk bandwidth.
+//
+// The scrubber only reports corruption (via logs and metrics). It does not attempt to repair or quarantine corrupt
+// segments, since doing so is not safe while the database is running. Corrupt segments can be quarantined offline
+// with the 'litt verify' command.
+type scrubber struct {
+	logger logging.Logger
+
+	// Used to detect when the table is shutting down.
+	errorMonitor *util.ErrorMonitor
+
+	// The table's name.
+	name string
+
+	// The control loop for the table, used to find and reserve segments.
+	controlLoop *controlLoop
+
+	// Limits the rate at which data is read from disk, in bytes per second.
+	rateLimiter *rate.Limiter
+
+	// The time between scrub passes. A scrub pass verifies each sealed segment once.
+	period time.Duration
+
+	// Encapsulates metrics for the database.
+	metrics *metrics.LittDBMetrics
+
+	// Segments that have already been reported as corrupt. Corrupt segments are only reported once.
+	// Only accessed by the scrubber goroutine.
+	corruptSegments map[uint32]struct{}
+}
+
+// newScrubber creates a new scrubber. The scrubber does not start until run() is called.
+func newScrubber(
+	logger logging.Logger,
+	errorMonitor *util.ErrorMonitor,
+	name string,
+	controlLoop *controlLoop,
+	bytesPerSecond uint64,
+	period time.Duration,
+	metrics *metrics.LittDBMetrics) *scrubber {
+
+	// The burst size only determines the granularity at which reads are throttled, since large reads are broken
+	// into burst-sized pieces.
+	burst := int(max(bytesPerSecond/10, 1))
+
+	return &scrubber{
+		logger:          logger,
+		errorMonitor:    errorMonitor,
+		name:            name,
+		controlLoop:     controlLoop,
+		rateLimiter:     rate.NewLimiter(rate.Limit(bytesPerSecond), burst),
+		period:          period,
+		metrics:         metrics,
+		corruptSegments: make(map[uint32]struct{}),
+	}
+}
+
+// run performs scrub passes until the table is shut down.
+func (s *scrubber) run() {
+	ticker := time.NewTicker(s.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.errorMonitor.ImmediateShutdownRequired():
+			return
+		case <-ticker.C:
+			_, err := s.scrub()
+			if err != nil {
+				if ok, _ := s.errorMonitor.IsOk(); !ok {
+					// The table is shutting down.
+					return
+				}
+				s.logger.Errorf("table %s: scrub failed: %v", s.name, err)
+			}
+		}
+	}
+}
+
+// scrub verifies each sealed segment in the table, returning the indices of segments found to be corrupt.
+func (s *scrubber) scrub() ([]uint32, error) {
+	// Determine which segments exist. Do not hold reservations on segments we are not actively verifying,
+	// since that would delay garbage collection for the duration of the scrub pass.
+	reserved := s.controlLoop.getReservedSegments()
+	indices := make([]uint32, 0, len(reserved))
+	for index, seg := range reserved {
+		indices = append(indices, index)
+		seg.Release()
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
+
+	corrupt := make([]uint32, 0)
+	for _, index := range indices {
+		isCorrupt, err := s.scrubSegment(index)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scrub segment %d: %w", index, err)
+		}
+		if isCorrupt {
+			corrupt = append(corrupt, index)
+		}
+	}
+
+	return corrupt, nil
+}
+
+// scrubSegment verifies a single segment. Returns true if the segment is corrupt.
+func (s *scrubber) scrubSegment(index uint32) (bool, error) {
+	seg, ok := s.controlLoop.getReservedSegment(index)
+	if !ok {
+		// The segment was garbage collected.
+		return false, nil
+	}
+	defer seg.Release()
+
+	if !seg.IsSealed() {
+		// The mutable segment can't be verified.
+		return false, nil
+	}
+
+	err := seg.Verify(s.throttle)
+	if err == nil {
+		return false, nil
+	}
+
+	var corruptionError *segment.CorruptionError
+	if !errors.As(err, &corruptionError) {
+		return false, err
+	}
+
+	if _, alreadyReported := s.corruptSegments[index]; !alreadyReported {
+		s.corruptSegments[index] = struct{}{}
+		s.logger.Errorf("table %s: segment %d is corrupt: %v", s.name, index, err)
+		s.metrics.ReportSegmentCorruption(s.name)
+	}
+
+	return true, nil
+}
+
+// throttle blocks until the rate limiter permits the given number of bytes to be read.
+func (s *scrubber) throttle(bytes uint64) error {
+	s.metrics.ReportScrubbedBytes(s.name, bytes)
+
+	burst := uint64(s.rateLimiter.Burst())
+	for bytes > 0 {
+		chunk := min(bytes, burst)
+		bytes -= chunk
+
+		reservation := s.rateLimiter.ReserveN(time.Now(), int(chunk))
+		timer := time.NewTimer(reservation.Delay())
+		select {
+		case <-s.errorMonitor.ImmediateShutdownRequired():
+			timer.Stop()
+			reservation.Cancel()
+			return fmt.Errorf("table %s is shutting down", s.name)
+		case <-timer.C:
+		}
+	}
+
+	return nil
+}
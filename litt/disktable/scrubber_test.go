@@ -0,0 +1,75 @@
+package disktable
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/test"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScrubber(t *testing.T) {
+	t.Parallel()
+
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := buildMemKeyDiskTableSingleShard(time.Now, tableName, []string{directory})
+	require.NoError(t, err)
+	diskTable := table.(*DiskTable)
+
+	for i := 0; i < 100; i++ {
+		err = table.Put(rand.PrintableVariableBytes(32, 64), rand.PrintableVariableBytes(1, 128))
+		require.NoError(t, err)
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	scrub := newScrubber(
+		test.GetLogger(), diskTable.errorMonitor, tableName, diskTable.controlLoop, 1<<30, time.Hour, nil)
+
+	corrupt, err := scrub.scrub()
+	require.NoError(t, err)
+	require.Empty(t, corrupt)
+
+	// Corrupt the first value in the lowest segment.
+	lowestSegmentIndex := diskTable.controlLoop.lowestSegmentIndex
+	seg, ok := diskTable.controlLoop.getReservedSegment(lowestSegmentIndex)
+	require.True(t, ok)
+	require.True(t, seg.IsSealed())
+	keys, err := seg.GetKeys()
+	require.NoError(t, err)
+	require.NotEmpty(t, keys)
+
+	valueFilePath := seg.GetValueFilePaths()[seg.GetShard(keys[0].Key)]
+	data, err := os.ReadFile(valueFilePath)
+	require.NoError(t, err)
+	data[keys[0].Address.Offset()+4] ^= 1
+	err = os.WriteFile(valueFilePath, data, 0644)
+	require.NoError(t, err)
+	seg.Release()
+
+	// Reading the corrupt value should fail.
+	_, _, err = table.Get(keys[0].Key)
+	require.Error(t, err)
+	var corruptionError *segment.CorruptionError
+	require.True(t, errors.As(err, &corruptionError))
+	require.Equal(t, lowestSegmentIndex, corruptionError.SegmentIndex)
+
+	// The scrubber should detect the corruption.
+	corrupt, err = scrub.scrub()
+	require.NoError(t, err)
+	require.Equal(t, []uint32{lowestSegmentIndex}, corrupt)
+
+	// Corruption does not put the table into a panicked state.
+	ok, _ = diskTable.errorMonitor.IsOk()
+	require.True(t, ok)
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
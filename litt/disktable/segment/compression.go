@@ -0,0 +1,71 @@
+package segment
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// zstdEncoder returns a shared zstd encoder. EncodeAll() is safe to call concurrently.
+var zstdEncoder = sync.OnceValue(func() *zstd.Encoder {
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		// This only fails if given invalid options.
+		panic(fmt.Sprintf("failed to create zstd encoder: %v", err))
+	}
+	return encoder
+})
+
+// maxDecompressedValueBytes is the maximum size of a decompressed value, which is the maximum size of a value. It
+// bounds the memory used to decode corrupted values that claim an extreme decompressed size.
+const maxDecompressedValueBytes = math.MaxUint32
+
+// zstdDecoder returns a shared zstd decoder. DecodeAll() is safe to call concurrently.
+var zstdDecoder = sync.OnceValue(func() *zstd.Decoder {
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxDecompressedValueBytes))
+	if err != nil {
+		// This only fails if given invalid options.
+		panic(fmt.Sprintf("failed to create zstd decoder: %v", err))
+	}
+	return decoder
+})
+
+// compress compresses a value using the given compression type.
+func compress(compression types.CompressionType, value []byte) ([]byte, error) {
+	switch compression {
+	case types.NoCompression:
+		return value, nil
+	case types.SnappyCompression:
+		return snappy.Encode(nil, value), nil
+	case types.ZstdCompression:
+		return zstdEncoder().EncodeAll(value, nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression type: %s", compression)
+	}
+}
+
+// decompress decompresses a value that was compressed using the given compression type.
+func decompress(compression types.CompressionType, data []byte) ([]byte, error) {
+	switch compression {
+	case types.NoCompression:
+		return data, nil
+	case types.SnappyCompression:
+		value, err := snappy.Decode(nil, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode snappy value: %w", err)
+		}
+		return value, nil
+	case types.ZstdCompression:
+		value, err := zstdDecoder().DecodeAll(data, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode zstd value: %w", err)
+		}
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unsupported compression type: %s", compression)
+	}
+}
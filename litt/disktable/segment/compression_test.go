@@ -0,0 +1,157 @@
+package segment
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigenda/test"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressRoundTrip(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	for _, compression := range []types.CompressionType{
+		types.NoCompression, types.SnappyCompression, types.ZstdCompression} {
+
+		for _, value := range [][]byte{{}, rand.Bytes(1), rand.VariableBytes(1, 1024), make([]byte, 1024)} {
+			compressed, err := compress(compression, value)
+			require.NoError(t, err)
+			decompressed, err := decompress(compression, compressed)
+			require.NoError(t, err)
+			require.True(t, bytes.Equal(value, decompressed))
+		}
+	}
+
+	_, err := compress(types.CompressionType(99), []byte("foo"))
+	require.Error(t, err)
+}
+
+func TestZstdDecompressedSizeBounded(t *testing.T) {
+	t.Parallel()
+
+	// A zstd frame with a small window that claims a decompressed size one byte over the maximum size of a value,
+	// followed by an empty raw block. It must be rejected before any memory is allocated for its content.
+	frame := []byte{0x28, 0xb5, 0x2f, 0xfd, 0xc0, 0x00}
+	frame = binary.LittleEndian.AppendUint64(frame, maxDecompressedValueBytes+1)
+	frame = append(frame, 0x01, 0x00, 0x00)
+
+	_, err := decompress(types.ZstdCompression, frame)
+	require.ErrorIs(t, err, zstd.ErrDecoderSizeExceeded)
+}
+
+func testCompressedSegment(t *testing.T, compression types.CompressionType, seal bool) {
+	ctx := t.Context()
+	rand := random.NewTestRandom()
+	logger := test.GetLogger()
+	directory := t.TempDir()
+
+	segmentPath, err := NewSegmentPath(directory, "", "table")
+	require.NoError(t, err)
+	err = segmentPath.MakeDirectories(false)
+	require.NoError(t, err)
+
+	index := rand.Uint32()
+	seg, err := CreateSegment(
+		logger,
+		util.NewErrorMonitor(ctx, logger, nil),
+		index,
+		[]*SegmentPath{segmentPath},
+		false,
+		rand.Uint32Range(1, 4),
+		([16]byte)(rand.Bytes(16)),
+		compression,
+		false)
+	require.NoError(t, err)
+	require.Equal(t, compression, seg.Compression())
+
+	// Use highly compressible values.
+	expectedValues := make(map[string][]byte)
+	logicalValueBytes := uint64(0)
+	valueCount := rand.Int32Range(100, 200)
+	for i := 0; i < int(valueCount); i++ {
+		key := []byte(rand.String(32))
+		value := bytes.Repeat(rand.PrintableBytes(1), int(rand.Int32Range(100, 1000)))
+		expectedValues[string(key)] = value
+		logicalValueBytes += uint64(len(value))
+
+		_, _, err = seg.Write(&types.KVPair{Key: key, Value: value})
+		require.NoError(t, err)
+	}
+
+	var flushedKeys []*types.ScopedKey
+	if seal {
+		flushedKeys, err = seg.Seal(rand.Time())
+		require.NoError(t, err)
+	} else {
+		// Simulate a crash by flushing but not sealing the segment.
+		flushFunction, err := seg.Flush()
+		require.NoError(t, err)
+		flushedKeys, err = flushFunction()
+		require.NoError(t, err)
+	}
+	require.Equal(t, int(valueCount), len(flushedKeys))
+
+	for _, key := range flushedKeys {
+		value, err := seg.Read(key.Key, key.Address)
+		require.NoError(t, err)
+		require.Equal(t, expectedValues[string(key.Key)], value)
+	}
+
+	if compression == types.NoCompression {
+		require.Equal(t, seg.Size(), seg.LogicalSize())
+	} else {
+		require.Less(t, seg.Size(), seg.LogicalSize())
+	}
+	logicalSize := seg.LogicalSize()
+
+	// Reload the segment. The compression type and the logical size should be preserved.
+	seg2, err := LoadSegment(
+		logger,
+		util.NewErrorMonitor(ctx, logger, nil),
+		index,
+		[]*SegmentPath{segmentPath},
+		false,
+		time.Now(),
+		false)
+	require.NoError(t, err)
+	require.True(t, seg2.IsSealed())
+	require.Equal(t, compression, seg2.Compression())
+	require.Equal(t, logicalValueBytes, seg2.logicalValueBytes)
+	if seal {
+		require.Equal(t, logicalSize, seg2.LogicalSize())
+	}
+
+	for _, key := range flushedKeys {
+		value, err := seg2.Read(key.Key, key.Address)
+		require.NoError(t, err)
+		require.Equal(t, expectedValues[string(key.Key)], value)
+	}
+
+	err = seg2.Verify(noThrottle)
+	require.NoError(t, err)
+}
+
+func TestCompressedSegment(t *testing.T) {
+	t.Parallel()
+
+	for _, compression := range []types.CompressionType{
+		types.NoCompression, types.SnappyCompression, types.ZstdCompression} {
+
+		t.Run(compression.String(), func(t *testing.T) {
+			t.Parallel()
+			testCompressedSegment(t, compression, true)
+		})
+		t.Run(compression.String()+"-crash", func(t *testing.T) {
+			t.Parallel()
+			testCompressedSegment(t, compression, false)
+		})
+	}
+}
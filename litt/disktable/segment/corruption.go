@@ -0,0 +1,46 @@
+package segment
+
+import (
+	"fmt"
+	"hash/crc32"
+)
+
+// checksumSize is the size of a record checksum, in bytes.
+const checksumSize = 4
+
+// checksumTable is the CRC-32 table used to compute record checksums. The Castagnoli polynomial is hardware
+// accelerated on most modern CPUs.
+var checksumTable = crc32.MakeTable(crc32.Castagnoli)
+
+// computeChecksum computes the checksum of a record.
+func computeChecksum(data []byte) uint32 {
+	return crc32.Checksum(data, checksumTable)
+}
+
+// CorruptionError is returned when data read from a segment file is detected to be corrupt (e.g. due to bit rot on
+// the underlying disk). Use errors.As() to detect this error type.
+type CorruptionError struct {
+	// The index of the segment containing the corrupt data.
+	SegmentIndex uint32
+	// The path of the file containing the corrupt data.
+	Path string
+	// The offset within the file where the corrupt record begins.
+	Offset uint64
+	// A human readable description of the corruption.
+	Reason string
+}
+
+// newCorruptionError creates a new CorruptionError.
+func newCorruptionError(segmentIndex uint32, path string, offset uint64, reason string) *CorruptionError {
+	return &CorruptionError{
+		SegmentIndex: segmentIndex,
+		Path:         path,
+		Offset:       offset,
+		Reason:       reason,
+	}
+}
+
+func (e *CorruptionError) Error() string {
+	return fmt.Sprintf("corrupt data in segment %d, file %s at offset %d: %s",
+		e.SegmentIndex, e.Path, e.Offset, e.Reason)
+}
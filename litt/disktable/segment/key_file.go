@@ -4,6 +4,9 @@ import (
 	"bufio"
 	"encoding/binary"
 	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
 	"os"
 	"path"
 	"strconv"
@@ -21,6 +24,9 @@ const KeyFileExtension = ".keys"
 // update key files.
 const KeyFileSwapExtension = KeyFileExtension + util.SwapFileExtension
 
+// tombstoneFlag is set in the flags byte of a key file record if the record is a tombstone.
+const tombstoneFlag byte = 1
+
 // keyFile tracks the keys in a segment. It is used to do garbage collection on the keymap.
 //
 // This struct is NOT goroutine safe. It is unsafe to concurrently call write, flush, or seal on the same key file.
@@ -49,19 +55,24 @@ type keyFile struct {
 	swap bool
 }
 
-// newKeyFile creates a new key file.
+// newKeyFile creates a new key file. The segment version determines the serialization format of the file.
 func createKeyFile(
 	logger logging.Logger,
 	index uint32,
 	segmentPath *SegmentPath,
+	segmentVersion SegmentVersion,
 	swap bool,
 ) (*keyFile, error) {
 
+	if segmentVersion < ValueSizeSegmentVersion {
+		return nil, fmt.Errorf("creating key files at segment version %d is not supported", segmentVersion)
+	}
+
 	keys := &keyFile{
 		logger:         logger,
 		index:          index,
 		segmentPath:    segmentPath,
-		segmentVersion: ValueSizeSegmentVersion,
+		segmentVersion: segmentVersion,
 		swap:           swap,
 	}
 
@@ -175,39 +186,81 @@ func (k *keyFile) write(scopedKey *types.ScopedKey) error {
 		return fmt.Errorf("key file is sealed")
 	}
 
+	// If this key file contains checksums, then everything written to the record is also fed into the checksum.
+	var writer io.Writer = k.writer
+	var checksum hash.Hash32
+	if k.segmentVersion >= ChecksumSegmentVersion {
+		checksum = crc32.New(checksumTable)
+		writer = io.MultiWriter(k.writer, checksum)
+	}
+
 	// Write the length of the key.
-	err := binary.Write(k.writer, binary.BigEndian, uint32(len(scopedKey.Key)))
+	err := binary.Write(writer, binary.BigEndian, uint32(len(scopedKey.Key)))
 	if err != nil {
 		return fmt.Errorf("failed to write key length to key file: %w", err)
 	}
 
 	// Write the key itself.
-	_, err = k.writer.Write(scopedKey.Key)
+	_, err = writer.Write(scopedKey.Key)
 	if err != nil {
 		return fmt.Errorf("failed to write key to key file: %w", err)
 	}
 
 	// Write the address.
-	err = binary.Write(k.writer, binary.BigEndian, scopedKey.Address)
+	err = binary.Write(writer, binary.BigEndian, scopedKey.Address)
 	if err != nil {
 		return fmt.Errorf("failed to write address to key file: %w", err)
 	}
 
 	// Write the size of the value.
-	err = binary.Write(k.writer, binary.BigEndian, scopedKey.ValueSize)
+	err = binary.Write(writer, binary.BigEndian, scopedKey.ValueSize)
 	if err != nil {
 		return fmt.Errorf("failed to write value size to key file: %w", err)
 	}
 
-	k.size += uint64(
-		4 /* uint32 size of key */ +
-			len(scopedKey.Key) +
-			8 /* uint64 address */ +
-			4 /* uint32 size of value */)
+	if k.segmentVersion >= TombstoneSegmentVersion {
+		// Write the flags.
+		var flags byte
+		if scopedKey.Tombstone {
+			flags |= tombstoneFlag
+		}
+		_, err = writer.Write([]byte{flags})
+		if err != nil {
+			return fmt.Errorf("failed to write flags to key file: %w", err)
+		}
+	} else if scopedKey.Tombstone {
+		return fmt.Errorf("segment version %d does not support tombstones", k.segmentVersion)
+	}
+
+	if checksum != nil {
+		// Write the checksum of the record.
+		err = binary.Write(k.writer, binary.BigEndian, checksum.Sum32())
+		if err != nil {
+			return fmt.Errorf("failed to write checksum to key file: %w", err)
+		}
+	}
+
+	k.size += KeyFileRecordSize(k.segmentVersion, len(scopedKey.Key))
 
 	return nil
 }
 
+// KeyFileRecordSize returns the number of bytes required to store a single key in a key file at the given segment
+// version.
+func KeyFileRecordSize(segmentVersion SegmentVersion, keyLength int) uint64 {
+	size := uint64(4 /* uint32 size of key */ + keyLength + 8 /* uint64 address */)
+	if segmentVersion >= ValueSizeSegmentVersion {
+		size += 4 /* uint32 size of value */
+	}
+	if segmentVersion >= TombstoneSegmentVersion {
+		size += 1 /* flags */
+	}
+	if segmentVersion >= ChecksumSegmentVersion {
+		size += checksumSize
+	}
+	return size
+}
+
 // getKeyFileIndex returns the index of the key file from the file name. Key file names have the form "X.keys",
 // where X is the segment index.
 func getKeyFileIndex(fileName string) (uint32, error) {
@@ -248,7 +301,8 @@ func (k *keyFile) seal() error {
 // readKeys reads all keys from the key file. This method returns an error if the key file is not sealed.
 // If there are keys that were only partially written (i.e. keys being written when the process crashed), then
 // those keys may not be returned. If a key is returned, it is guaranteed to be "whole" (i.e. a partial key will
-// never be returned).
+// never be returned). If the key file contains checksums and a record does not match its checksum, then a
+// *CorruptionError is returned.
 func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
 	if !k.isSealed() {
 		return nil, fmt.Errorf("key file is not sealed")
@@ -280,21 +334,13 @@ func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
 			break
 		}
 		keyLength := int(binary.BigEndian.Uint32(keyBytes[index : index+4]))
-		index += 4
 
-		if k.segmentVersion < ValueSizeSegmentVersion {
-			// We need to read the key, as well as the 8 byte address.
-			if index+keyLength+8 > len(keyBytes) {
-				// There are insufficient bytes left in the file to read the key and address.
-				break
-			}
-		} else {
-			// We need to read the key, as well as the 8 byte address and 4 byte value size.
-			if index+keyLength+12 > len(keyBytes) {
-				// There are insufficient bytes left in the file to read the key, address, and value size.
-				break
-			}
+		// Make sure the entire record is present (it may not be if we crashed while writing it).
+		if uint64(index)+KeyFileRecordSize(k.segmentVersion, keyLength) > uint64(len(keyBytes)) {
+			break
 		}
+		recordStart := index
+		index += 4
 
 		key := keyBytes[index : index+keyLength]
 		index += keyLength
@@ -308,10 +354,25 @@ func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
 			index += 4
 		}
 
+		var tombstone bool
+		if k.segmentVersion >= TombstoneSegmentVersion {
+			tombstone = keyBytes[index]&tombstoneFlag != 0
+			index += 1
+		}
+
+		if k.segmentVersion >= ChecksumSegmentVersion {
+			checksum := binary.BigEndian.Uint32(keyBytes[index : index+checksumSize])
+			if checksum != computeChecksum(keyBytes[recordStart:index]) {
+				return nil, newCorruptionError(k.index, k.path(), uint64(recordStart), "checksum mismatch")
+			}
+			index += checksumSize
+		}
+
 		keys = append(keys, &types.ScopedKey{
 			Key:       key,
 			Address:   address,
 			ValueSize: valueSize,
+			Tombstone: tombstone,
 		})
 	}
 
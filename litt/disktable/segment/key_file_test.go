@@ -25,14 +25,15 @@ func TestReadWriteKeys(t *testing.T) {
 		key := rand.VariableBytes(1, 100)
 		address := types.Address(rand.Uint64())
 		valueSize := rand.Uint32()
-		keys[i] = &types.ScopedKey{Key: key, Address: address, ValueSize: valueSize}
+		tombstone := rand.BoolWithProbability(0.1)
+		keys[i] = &types.ScopedKey{Key: key, Address: address, ValueSize: valueSize, Tombstone: tombstone}
 	}
 
 	segmentPath, err := NewSegmentPath(directory, "", "table")
 	require.NoError(t, err)
 	err = segmentPath.MakeDirectories(false)
 	require.NoError(t, err)
-	file, err := createKeyFile(logger, index, segmentPath, false)
+	file, err := createKeyFile(logger, index, segmentPath, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	for _, key := range keys {
@@ -63,7 +64,7 @@ func TestReadWriteKeys(t *testing.T) {
 	}
 
 	// Create a new in-memory instance from the on-disk file and verify that it behaves the same.
-	file2, err := loadKeyFile(logger, index, []*SegmentPath{segmentPath}, ValueSizeSegmentVersion)
+	file2, err := loadKeyFile(logger, index, []*SegmentPath{segmentPath}, LatestSegmentVersion)
 	require.NoError(t, err)
 	require.Equal(t, file.Size(), file2.Size())
 
@@ -106,7 +107,7 @@ func TestReadingTruncatedKeyFile(t *testing.T) {
 	require.NoError(t, err)
 	err = segmentPath.MakeDirectories(false)
 	require.NoError(t, err)
-	file, err := createKeyFile(logger, index, segmentPath, false)
+	file, err := createKeyFile(logger, index, segmentPath, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	for _, key := range keys {
@@ -188,7 +189,7 @@ func TestSwappingKeyFile(t *testing.T) {
 	require.NoError(t, err)
 	err = segmentPath.MakeDirectories(false)
 	require.NoError(t, err)
-	file, err := createKeyFile(logger, index, segmentPath, false)
+	file, err := createKeyFile(logger, index, segmentPath, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	for _, key := range keys {
@@ -219,7 +220,7 @@ func TestSwappingKeyFile(t *testing.T) {
 	}
 
 	// Create a new in-memory instance from the on-disk file and verify that it behaves the same.
-	file2, err := loadKeyFile(logger, index, []*SegmentPath{segmentPath}, ValueSizeSegmentVersion)
+	file2, err := loadKeyFile(logger, index, []*SegmentPath{segmentPath}, LatestSegmentVersion)
 	require.NoError(t, err)
 	require.Equal(t, file.Size(), file2.Size())
 
@@ -231,7 +232,7 @@ func TestSwappingKeyFile(t *testing.T) {
 
 	// Create a new version of the key file that only contains the keys at even indices. The intention is to replace
 	// the on-disk file with this new version.
-	swapFile, err := createKeyFile(logger, index, segmentPath, true)
+	swapFile, err := createKeyFile(logger, index, segmentPath, LatestSegmentVersion, true)
 	require.NoError(t, err)
 	for i := 0; i < int(keyCount); i += 2 {
 		err := swapFile.write(keys[i])
@@ -269,7 +270,7 @@ func TestSwappingKeyFile(t *testing.T) {
 	require.Equal(t, actualSize, reportedSize)
 
 	// Verify the contents of the new file. Reload it from disk just to ensure that we aren't "cheating" somehow.
-	file2, err = loadKeyFile(logger, index, []*SegmentPath{segmentPath}, ValueSizeSegmentVersion)
+	file2, err = loadKeyFile(logger, index, []*SegmentPath{segmentPath}, LatestSegmentVersion)
 	require.NoError(t, err)
 	readKeys, err = file2.readKeys()
 	require.NoError(t, err)
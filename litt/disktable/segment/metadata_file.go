@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
 )
 
@@ -49,6 +50,32 @@ const (
 	// - 4 bytes for keyCount
 	// - and 1 byte for sealed.
 	V2MetadataSize = 37
+
+	// V3MetadataSize is the size of the metadata file at version 3 (aka TombstoneSegmentVersion). Version 4
+	// (aka ChecksumSegmentVersion) uses the same metadata format.
+	// This is a constant, so it's convenient to have it here.
+	// - 4 bytes for version
+	// - 4 bytes for the sharding factor
+	// - 16 bytes for salt
+	// - 8 bytes for lastValueTimestamp
+	// - 4 bytes for keyCount
+	// - 4 bytes for tombstoneCount
+	// - and 1 byte for sealed.
+	V3MetadataSize = 41
+
+	// V5MetadataSize is the size of the metadata file at version 5 (aka CompressionSegmentVersion).
+	// This is a constant, so it's convenient to have it here.
+	// - 4 bytes for version
+	// - 4 bytes for the sharding factor
+	// - 16 bytes for salt
+	// - 8 bytes for lastValueTimestamp
+	// - 4 bytes for keyCount
+	// - 4 bytes for tombstoneCount
+	// - 1 byte for compression
+	// - 8 bytes for valueBytes
+	// - 8 bytes for logicalValueBytes
+	// - and 1 byte for sealed.
+	V5MetadataSize = 58
 )
 
 // metadataFile contains metadata about a segment. This file contains metadata about the data segment, such as
@@ -83,6 +110,23 @@ type metadataFile struct {
 	// This value is encoded in the file.
 	keyCount uint32
 
+	// The number of tombstones in the segment. This value is undefined if the segment is not yet sealed.
+	// This value is encoded in the file (as of TombstoneSegmentVersion, always zero for earlier versions).
+	tombstoneCount uint32
+
+	// The algorithm used to compress values in this segment. This value is encoded in the file (as of
+	// CompressionSegmentVersion, always NoCompression for earlier versions).
+	compression types.CompressionType
+
+	// The total number of value bytes written to the value files, not including per-record overhead. This value is
+	// undefined if the segment is not yet sealed. This value is encoded in the file (as of CompressionSegmentVersion,
+	// always zero for earlier versions).
+	valueBytes uint64
+
+	// The total number of value bytes prior to compression. This value is undefined if the segment is not yet sealed.
+	// This value is encoded in the file (as of CompressionSegmentVersion, always zero for earlier versions).
+	logicalValueBytes uint64
+
 	// If true, the segment is sealed and no more data can be written to it. If false, then data can still be written
 	// to this segment. This value is encoded in the file.
 	sealed bool
@@ -101,6 +145,7 @@ func createMetadataFile(
 	index uint32,
 	shardingFactor uint32,
 	salt [16]byte,
+	compression types.CompressionType,
 	path *SegmentPath,
 	fsync bool,
 ) (*metadataFile, error) {
@@ -114,6 +159,7 @@ func createMetadataFile(
 	file.segmentVersion = LatestSegmentVersion
 	file.shardingFactor = shardingFactor
 	file.salt = salt
+	file.compression = compression
 	err := file.write()
 	if err != nil {
 		return nil, fmt.Errorf("failed to write metadata file: %v", err)
@@ -173,8 +219,12 @@ func (m *metadataFile) Size() uint64 {
 		return V0MetadataSize
 	case SipHashSegmentVersion:
 		return V1MetadataSize
-	default:
+	case ValueSizeSegmentVersion:
 		return V2MetadataSize
+	case TombstoneSegmentVersion, ChecksumSegmentVersion:
+		return V3MetadataSize
+	default:
+		return V5MetadataSize
 	}
 }
 
@@ -190,10 +240,25 @@ func (m *metadataFile) path() string {
 
 // Seal seals the segment. This action will atomically write the metadata file to disk one final time,
 // and should only be performed when all data that will be written to the key/value files has been made durable.
-func (m *metadataFile) seal(now time.Time, keyCount uint32) error {
+func (m *metadataFile) seal(
+	now time.Time,
+	keyCount uint32,
+	tombstoneCount uint32,
+	valueBytes uint64,
+	logicalValueBytes uint64) error {
+
+	if tombstoneCount > 0 && m.segmentVersion < TombstoneSegmentVersion {
+		return fmt.Errorf("segment version %d does not support tombstones", m.segmentVersion)
+	}
+
 	m.sealed = true
 	m.lastValueTimestamp = uint64(now.UnixNano())
 	m.keyCount = keyCount
+	m.tombstoneCount = tombstoneCount
+	if m.segmentVersion >= CompressionSegmentVersion {
+		m.valueBytes = valueBytes
+		m.logicalValueBytes = logicalValueBytes
+	}
 	err := m.write()
 	if err != nil {
 		return fmt.Errorf("failed to write sealed metadata file: %v", err)
@@ -251,15 +316,78 @@ func (m *metadataFile) serializeV1Legacy() []byte {
 	return data
 }
 
+func (m *metadataFile) serializeV2Legacy() []byte {
+	data := make([]byte, V2MetadataSize)
+
+	// Write the version
+	binary.BigEndian.PutUint32(data[0:4], uint32(m.segmentVersion))
+
+	// Write the sharding factor
+	binary.BigEndian.PutUint32(data[4:8], m.shardingFactor)
+
+	// Write the salt
+	copy(data[8:24], m.salt[:])
+
+	// Write the lastValueTimestamp
+	binary.BigEndian.PutUint64(data[24:32], m.lastValueTimestamp)
+
+	// Write the key count
+	binary.BigEndian.PutUint32(data[32:36], m.keyCount)
+
+	// Write the sealed flag
+	if m.sealed {
+		data[36] = 1
+	} else {
+		data[36] = 0
+	}
+
+	return data
+}
+
+func (m *metadataFile) serializeV3Legacy() []byte {
+	data := make([]byte, V3MetadataSize)
+
+	// Write the version
+	binary.BigEndian.PutUint32(data[0:4], uint32(m.segmentVersion))
+
+	// Write the sharding factor
+	binary.BigEndian.PutUint32(data[4:8], m.shardingFactor)
+
+	// Write the salt
+	copy(data[8:24], m.salt[:])
+
+	// Write the lastValueTimestamp
+	binary.BigEndian.PutUint64(data[24:32], m.lastValueTimestamp)
+
+	// Write the key count
+	binary.BigEndian.PutUint32(data[32:36], m.keyCount)
+
+	// Write the tombstone count
+	binary.BigEndian.PutUint32(data[36:40], m.tombstoneCount)
+
+	// Write the sealed flag
+	if m.sealed {
+		data[40] = 1
+	} else {
+		data[40] = 0
+	}
+
+	return data
+}
+
 // serialize serializes the metadata file to a byte array.
 func (m *metadataFile) serialize() []byte {
 	if m.segmentVersion == OldHashFunctionSegmentVersion {
 		return m.serializeV0Legacy()
 	} else if m.segmentVersion == SipHashSegmentVersion {
 		return m.serializeV1Legacy()
+	} else if m.segmentVersion == ValueSizeSegmentVersion {
+		return m.serializeV2Legacy()
+	} else if m.segmentVersion == TombstoneSegmentVersion || m.segmentVersion == ChecksumSegmentVersion {
+		return m.serializeV3Legacy()
 	}
 
-	data := make([]byte, V2MetadataSize)
+	data := make([]byte, V5MetadataSize)
 
 	// Write the version
 	binary.BigEndian.PutUint32(data[0:4], uint32(m.segmentVersion))
@@ -276,11 +404,21 @@ func (m *metadataFile) serialize() []byte {
 	// Write the key count
 	binary.BigEndian.PutUint32(data[32:36], m.keyCount)
 
+	// Write the tombstone count
+	binary.BigEndian.PutUint32(data[36:40], m.tombstoneCount)
+
+	// Write the compression type
+	data[40] = byte(m.compression)
+
+	// Write the value byte counts
+	binary.BigEndian.PutUint64(data[41:49], m.valueBytes)
+	binary.BigEndian.PutUint64(data[49:57], m.logicalValueBytes)
+
 	// Write the sealed flag
 	if m.sealed {
-		data[36] = 1
+		data[57] = 1
 	} else {
-		data[36] = 0
+		data[57] = 0
 	}
 
 	return data
@@ -314,6 +452,35 @@ func (m *metadataFile) deserializeV1Legacy(data []byte) error {
 	return nil
 }
 
+func (m *metadataFile) deserializeV2Legacy(data []byte) error {
+	if len(data) != V2MetadataSize {
+		return fmt.Errorf("metadata file is not the correct size, expected %d, got %d",
+			V2MetadataSize, len(data))
+	}
+
+	m.shardingFactor = binary.BigEndian.Uint32(data[4:8])
+	m.salt = [16]byte(data[8:24])
+	m.lastValueTimestamp = binary.BigEndian.Uint64(data[24:32])
+	m.keyCount = binary.BigEndian.Uint32(data[32:36])
+	m.sealed = data[36] == 1
+	return nil
+}
+
+func (m *metadataFile) deserializeV3Legacy(data []byte) error {
+	if len(data) != V3MetadataSize {
+		return fmt.Errorf("metadata file is not the correct size, expected %d, got %d",
+			V3MetadataSize, len(data))
+	}
+
+	m.shardingFactor = binary.BigEndian.Uint32(data[4:8])
+	m.salt = [16]byte(data[8:24])
+	m.lastValueTimestamp = binary.BigEndian.Uint64(data[24:32])
+	m.keyCount = binary.BigEndian.Uint32(data[32:36])
+	m.tombstoneCount = binary.BigEndian.Uint32(data[36:40])
+	m.sealed = data[40] == 1
+	return nil
+}
+
 // deserialize deserializes the metadata file from a byte array.
 func (m *metadataFile) deserialize(data []byte) error {
 	if len(data) < 4 {
@@ -329,18 +496,29 @@ func (m *metadataFile) deserialize(data []byte) error {
 		return m.deserializeV0Legacy(data)
 	} else if m.segmentVersion == SipHashSegmentVersion {
 		return m.deserializeV1Legacy(data)
+	} else if m.segmentVersion == ValueSizeSegmentVersion {
+		return m.deserializeV2Legacy(data)
+	} else if m.segmentVersion == TombstoneSegmentVersion || m.segmentVersion == ChecksumSegmentVersion {
+		return m.deserializeV3Legacy(data)
 	}
 
-	if len(data) != V2MetadataSize {
+	if len(data) != V5MetadataSize {
 		return fmt.Errorf("metadata file is not the correct size, expected %d, got %d",
-			V2MetadataSize, len(data))
+			V5MetadataSize, len(data))
 	}
 
 	m.shardingFactor = binary.BigEndian.Uint32(data[4:8])
 	m.salt = [16]byte(data[8:24])
 	m.lastValueTimestamp = binary.BigEndian.Uint64(data[24:32])
 	m.keyCount = binary.BigEndian.Uint32(data[32:36])
-	m.sealed = data[36] == 1
+	m.tombstoneCount = binary.BigEndian.Uint32(data[36:40])
+	m.compression = types.CompressionType(data[40])
+	if !m.compression.IsValid() {
+		return fmt.Errorf("unsupported compression type: %s", m.compression)
+	}
+	m.valueBytes = binary.BigEndian.Uint64(data[41:49])
+	m.logicalValueBytes = binary.BigEndian.Uint64(data[49:57])
+	m.sealed = data[57] == 1
 
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/test/random"
 	"github.com/stretchr/testify/require"
 )
@@ -74,6 +75,11 @@ func TestSealedSerialization(t *testing.T) {
 		shardingFactor:     shardingFactor,
 		salt:               salt,
 		lastValueTimestamp: timestamp,
+		keyCount:           rand.Uint32(),
+		tombstoneCount:     rand.Uint32(),
+		compression:        types.CompressionType(rand.Intn(3)),
+		valueBytes:         rand.Uint64(),
+		logicalValueBytes:  rand.Uint64(),
 		sealed:             true,
 		segmentPath:        segmentPath,
 	}
@@ -114,7 +120,7 @@ func TestFreshFileSerialization(t *testing.T) {
 	require.NoError(t, err)
 	err = segmentPath.MakeDirectories(false)
 	require.NoError(t, err)
-	m, err := createMetadataFile(index, 1234, salt, segmentPath, false)
+	m, err := createMetadataFile(index, 1234, salt, types.ZstdCompression, segmentPath, false)
 	require.NoError(t, err)
 
 	require.Equal(t, index, m.index)
@@ -156,12 +162,12 @@ func TestSealing(t *testing.T) {
 	require.NoError(t, err)
 	err = segmentPath.MakeDirectories(false)
 	require.NoError(t, err)
-	m, err := createMetadataFile(index, 1234, salt, segmentPath, false)
+	m, err := createMetadataFile(index, 1234, salt, types.ZstdCompression, segmentPath, false)
 	require.NoError(t, err)
 
 	// seal the file
 	sealTime := rand.Time()
-	err = m.seal(sealTime, 987)
+	err = m.seal(sealTime, 987, 654, 321, 1000)
 	require.NoError(t, err)
 
 	require.Equal(t, index, m.index)
@@ -171,6 +177,10 @@ func TestSealing(t *testing.T) {
 	require.Equal(t, salt, m.salt)
 	require.Equal(t, uint32(1234), m.shardingFactor)
 	require.Equal(t, uint32(987), m.keyCount)
+	require.Equal(t, uint32(654), m.tombstoneCount)
+	require.Equal(t, types.ZstdCompression, m.compression)
+	require.Equal(t, uint64(321), m.valueBytes)
+	require.Equal(t, uint64(1000), m.logicalValueBytes)
 
 	// load the file
 	deserialized, err := loadMetadataFile(index, []*SegmentPath{segmentPath}, false)
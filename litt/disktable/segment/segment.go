@@ -56,9 +56,20 @@ type Segment struct {
 	// The maximum size of all shards in this segment.
 	maxShardSize uint64
 
-	// The number of keys written to this segment.
+	// The number of keys written to this segment. Does not include tombstones.
 	keyCount uint32
 
+	// The number of tombstones written to this segment.
+	tombstoneCount uint32
+
+	// The number of value bytes written to the value files (i.e. after compression), not including per-record
+	// overhead. Always zero for segments written prior to CompressionSegmentVersion.
+	valueBytes uint64
+
+	// The number of value bytes prior to compression. Always zero for segments written prior to
+	// CompressionSegmentVersion.
+	logicalValueBytes uint64
+
 	// shardChannels is a list of channels used to send messages to the goroutine responsible for writing to
 	// each shard. Indexed by shard number.
 	shardChannels []chan any
@@ -103,18 +114,22 @@ func CreateSegment(
 	snapshottingEnabled bool,
 	shardingFactor uint32,
 	salt [16]byte,
+	compression types.CompressionType,
 	fsync bool) (*Segment, error) {
 
 	if len(segmentPaths) == 0 {
 		return nil, errors.New("no segment paths provided")
 	}
+	if !compression.IsValid() {
+		return nil, fmt.Errorf("unsupported compression type: %s", compression)
+	}
 
-	metadata, err := createMetadataFile(index, shardingFactor, salt, segmentPaths[0], fsync)
+	metadata, err := createMetadataFile(index, shardingFactor, salt, compression, segmentPaths[0], fsync)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open metadata file: %v", err)
 	}
 
-	keys, err := createKeyFile(logger, index, segmentPaths[0], false)
+	keys, err := createKeyFile(logger, index, segmentPaths[0], metadata.segmentVersion, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open key file: %v", err)
 	}
@@ -129,7 +144,7 @@ func CreateSegment(
 		// use it for value files too.
 		segmentPath := segmentPaths[int(shard+1)%len(segmentPaths)]
 
-		values, err := createValueFile(logger, index, shard, segmentPath, fsync)
+		values, err := createValueFile(logger, index, shard, segmentPath, metadata.segmentVersion, fsync)
 		if err != nil {
 			return nil, fmt.Errorf("failed to open value file: %v", err)
 		}
@@ -208,7 +223,7 @@ func LoadSegment(logger logging.Logger,
 	// Look for the value files. There should be one for each shard.
 	shards := make([]*valueFile, metadata.shardingFactor)
 	for shard := uint32(0); shard < metadata.shardingFactor; shard++ {
-		values, err := loadValueFile(logger, index, shard, segmentPaths)
+		values, err := loadValueFile(logger, index, shard, segmentPaths, metadata.segmentVersion)
 		if err != nil {
 			return nil, fmt.Errorf("failed to open value file: %v", err)
 		}
@@ -224,6 +239,9 @@ func LoadSegment(logger logging.Logger,
 		shards:              shards,
 		keyFileSize:         keyFileSize,
 		keyCount:            metadata.keyCount,
+		tombstoneCount:      metadata.tombstoneCount,
+		valueBytes:          metadata.valueBytes,
+		logicalValueBytes:   metadata.logicalValueBytes,
 		deletionChannel:     make(chan struct{}, 1),
 		snapshottingEnabled: snapshottingEnabled,
 		fsync:               fsync,
@@ -263,17 +281,44 @@ func (s *Segment) sealLoadedSegment(now time.Time) error {
 	// keys with values that weren't flushed out to the value files before the DB crashed
 	badKeys := make([]*types.ScopedKey, 0, len(scopedKeys))
 
+	tombstoneCount := uint32(0)
+	valueBytes := uint64(0)
+
 	for _, scopedKey := range scopedKeys {
+		if scopedKey.Tombstone {
+			// Tombstones have no value, so if the tombstone made it into the key file then it is whole.
+			goodKeys = append(goodKeys, scopedKey)
+			tombstoneCount++
+			continue
+		}
+
 		shard := s.GetShard(scopedKey.Key)
 
 		requiredValueFileLength := uint64(scopedKey.Address.Offset()) +
-			4 /* value size uint32 */ +
-			uint64(scopedKey.ValueSize)
+			ValueFileRecordSize(s.metadata.segmentVersion, int(scopedKey.ValueSize))
 
 		if s.shards[shard].Size() < requiredValueFileLength {
 			badKeys = append(badKeys, scopedKey)
 		} else {
 			goodKeys = append(goodKeys, scopedKey)
+			valueBytes += uint64(scopedKey.ValueSize)
+		}
+	}
+
+	// The uncompressed size of each value is not recorded in the key file. If values are compressed, then
+	// each value must be read and decompressed in order to determine the logical size of the segment.
+	logicalValueBytes := valueBytes
+	if s.metadata.compression != types.NoCompression {
+		logicalValueBytes = 0
+		for _, scopedKey := range goodKeys {
+			if scopedKey.Tombstone {
+				continue
+			}
+			value, err := s.Read(scopedKey.Key, scopedKey.Address)
+			if err != nil {
+				return fmt.Errorf("failed to read value for key %x: %w", scopedKey.Key, err)
+			}
+			logicalValueBytes += uint64(len(value))
 		}
 	}
 
@@ -282,7 +327,7 @@ func (s *Segment) sealLoadedSegment(now time.Time) error {
 		s.logger.Warnf("segment %d has %d unflushed value(s)",
 			s.index, len(badKeys))
 
-		swapFile, err := createKeyFile(s.logger, s.index, s.keys.segmentPath, true)
+		swapFile, err := createKeyFile(s.logger, s.index, s.keys.segmentPath, s.metadata.segmentVersion, true)
 		if err != nil {
 			return fmt.Errorf("failed to create swap key file: %w", err)
 		}
@@ -306,11 +351,15 @@ func (s *Segment) sealLoadedSegment(now time.Time) error {
 		s.keys = swapFile
 	}
 
-	err = s.metadata.seal(now, uint32(len(goodKeys)))
+	keyCount := uint32(len(goodKeys)) - tombstoneCount
+	err = s.metadata.seal(now, keyCount, tombstoneCount, valueBytes, logicalValueBytes)
 	if err != nil {
 		return fmt.Errorf("failed to seal metadata file: %w", err)
 	}
-	s.keyCount = uint32(len(goodKeys))
+	s.keyCount = keyCount
+	s.tombstoneCount = tombstoneCount
+	s.valueBytes = valueBytes
+	s.logicalValueBytes = logicalValueBytes
 
 	return nil
 }
@@ -337,11 +386,28 @@ func (s *Segment) Size() uint64 {
 	return size
 }
 
-// KeyCount returns the number of keys in the segment.
+// LogicalSize returns the size that the segment would have if its values were not compressed. For segments without
+// compression, this is equal to Size(). This method is not thread safe, and should not be called concurrently with
+// methods that modify the segment.
+func (s *Segment) LogicalSize() uint64 {
+	return s.Size() + s.logicalValueBytes - s.valueBytes
+}
+
+// Compression returns the compression type used for values in this segment.
+func (s *Segment) Compression() types.CompressionType {
+	return s.metadata.compression
+}
+
+// KeyCount returns the number of keys in the segment. Tombstones are not counted.
 func (s *Segment) KeyCount() uint32 {
 	return s.keyCount
 }
 
+// TombstoneCount returns the number of tombstones in the segment.
+func (s *Segment) TombstoneCount() uint32 {
+	return s.tombstoneCount
+}
+
 // lookForFile looks for a file in a list of directories. It returns an error if the file appears
 // in more than one directory, and nil if the file is not found. If the file is found and
 // there are no errors, this method returns the SegmentPath where the file was found.
@@ -409,19 +475,28 @@ func (s *Segment) Write(data *types.KVPair) (keyCount uint32, keyFileSize uint64
 		return 0, 0,
 			fmt.Errorf("value file already contains %d bytes, cannot add a new value", currentSize)
 	}
+	value, err := compress(s.metadata.compression, data.Value)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to compress value: %w", err)
+	}
+
 	s.unflushedKeyCount.Add(1)
 	firstByteIndex := uint32(currentSize)
 
-	s.shardSizes[shard] += uint64(len(data.Value)) + 4 /* uint32 length */
+	s.shardSizes[shard] += ValueFileRecordSize(s.metadata.segmentVersion, len(value))
 	if s.shardSizes[shard] > s.maxShardSize {
 		s.maxShardSize = s.shardSizes[shard]
 	}
 	s.keyCount++
-	s.keyFileSize += uint64(len(data.Key)) + 4 /* uint32 length */ + 8 /* uint64 Address */ + 4 /* uint32 ValueSize */
+	s.keyFileSize += KeyFileRecordSize(s.metadata.segmentVersion, len(data.Key))
+	if s.metadata.segmentVersion >= CompressionSegmentVersion {
+		s.valueBytes += uint64(len(value))
+		s.logicalValueBytes += uint64(len(data.Value))
+	}
 
 	// Forward the value to the shard control loop, which asynchronously writes it to the value file.
 	shardRequest := &valueToWrite{
-		value:                  data.Value,
+		value:                  value,
 		expectedFirstByteIndex: firstByteIndex,
 	}
 	err = util.Send(s.errorMonitor, s.shardChannels[shard], shardRequest)
@@ -434,7 +509,7 @@ func (s *Segment) Write(data *types.KVPair) (keyCount uint32, keyFileSize uint64
 	keyRequest := &types.ScopedKey{
 		Key:       data.Key,
 		Address:   types.NewAddress(s.index, firstByteIndex),
-		ValueSize: uint32(len(data.Value)),
+		ValueSize: uint32(len(value)),
 	}
 
 	err = util.Send(s.errorMonitor, s.keyFileChannel, keyRequest)
@@ -446,6 +521,40 @@ func (s *Segment) Write(data *types.KVPair) (keyCount uint32, keyFileSize uint64
 	return s.keyCount, s.keyFileSize, nil
 }
 
+// WriteTombstone records the deletion of a key in the data segment. A tombstone is only written to the key file,
+// and does not consume any space in the value files. Returns the number of keys and tombstones in this segment,
+// and the size of the key file.
+//
+// This method does not ensure that the tombstone is actually written to disk, only that it will eventually be
+// written to disk. Flush must be called to ensure that all tombstones previously passed to WriteTombstone are written
+// to disk.
+func (s *Segment) WriteTombstone(key []byte) (recordCount uint32, keyFileSize uint64, err error) {
+	if s.metadata.sealed {
+		return 0, 0, fmt.Errorf("segment is sealed, cannot write tombstone")
+	}
+	if s.metadata.segmentVersion < TombstoneSegmentVersion {
+		return 0, 0, fmt.Errorf("segment version %d does not support tombstones", s.metadata.segmentVersion)
+	}
+
+	s.unflushedKeyCount.Add(1)
+	s.tombstoneCount++
+	s.keyFileSize += KeyFileRecordSize(s.metadata.segmentVersion, len(key))
+
+	keyRequest := &types.ScopedKey{
+		Key:       key,
+		Address:   types.NewAddress(s.index, 0),
+		Tombstone: true,
+	}
+
+	err = util.Send(s.errorMonitor, s.keyFileChannel, keyRequest)
+	if err != nil {
+		return 0, 0,
+			fmt.Errorf("failed to send tombstone to key file control loop: %v", err)
+	}
+
+	return s.keyCount + s.tombstoneCount, s.keyFileSize, nil
+}
+
 // GetMaxShardSize returns the maximum size of all shards in this segment.
 func (s *Segment) GetMaxShardSize() uint64 {
 	return s.maxShardSize
@@ -458,14 +567,20 @@ func (s *Segment) Read(key []byte, dataAddress types.Address) ([]byte, error) {
 	shard := s.GetShard(key)
 	values := s.shards[shard]
 
-	value, err := values.read(dataAddress.Offset())
+	data, err := values.read(dataAddress.Offset())
 	if err != nil {
 		return nil, fmt.Errorf("failed to read value: %w", err)
 	}
+
+	value, err := decompress(s.metadata.compression, data)
+	if err != nil {
+		return nil, newCorruptionError(s.index, values.path(), uint64(dataAddress.Offset()),
+			fmt.Sprintf("failed to decompress value: %v", err))
+	}
 	return value, nil
 }
 
-// GetKeys returns all keys in the data segment. Only permitted to be called after the segment has been sealed.
+// GetKeys returns all keys in the data segment, including tombstones. Only permitted to be called after the segment has been sealed.
 func (s *Segment) GetKeys() ([]*types.ScopedKey, error) {
 	if !s.metadata.sealed {
 		return nil, fmt.Errorf("segment is not sealed, cannot read keys")
@@ -588,7 +703,7 @@ func (s *Segment) Seal(now time.Time) ([]*types.ScopedKey, error) {
 	}
 
 	// Seal the metadata file.
-	err = s.metadata.seal(now, s.keyCount)
+	err = s.metadata.seal(now, s.keyCount, s.tombstoneCount, s.valueBytes, s.logicalValueBytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to seal metadata file: %w", err)
 	}
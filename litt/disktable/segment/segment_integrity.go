@@ -0,0 +1,159 @@
+package segment
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+// QuarantineDirectory is the name of the directory where corrupt segment files are moved when a segment is
+// quarantined. The quarantine directory is created at "$STORAGE_PATH/$TABLE_NAME/quarantine". Files in this directory
+// are never read or deleted by LittDB, it is up to the operator to inspect and clean up quarantined files.
+const QuarantineDirectory = "quarantine"
+
+// Verify checks the integrity of the data in this segment. Every record in the key file and every value in the value
+// files is read from disk and checked against its checksum (for segments written with ChecksumSegmentVersion or
+// later). For all segment versions, this method also checks that the key file and value files are consistent with
+// each other, i.e. that each key in the key file references a whole value in a value file.
+//
+// The throttle function is called prior to reading data from a value file with the number of bytes about to be read,
+// and can be used to limit the rate at which data is read from disk. If the throttle function returns an error,
+// verification is aborted and the error is returned.
+//
+// If corruption is detected, a *CorruptionError is returned. Only permitted to be called after the segment has been
+// sealed.
+func (s *Segment) Verify(throttle func(bytes uint64) error) error {
+	if !s.metadata.sealed {
+		return fmt.Errorf("segment %d is not sealed, cannot verify", s.index)
+	}
+
+	keys, err := s.keys.readKeys()
+	if err != nil {
+		return fmt.Errorf("failed to read keys: %w", err)
+	}
+
+	// For each shard, a map from the offset of each value to the length of that value.
+	shardRecords := make([]map[uint32]uint32, len(s.shards))
+	for shard, values := range s.shards {
+		shardRecords[shard], err = values.scan(throttle)
+		if err != nil {
+			return fmt.Errorf("failed to scan value file for shard %d: %w", shard, err)
+		}
+	}
+
+	for _, key := range keys {
+		if key.Tombstone {
+			continue
+		}
+
+		if key.Address.Index() != s.index {
+			return newCorruptionError(s.index, s.keys.path(), 0,
+				fmt.Sprintf("key %x references segment %d", key.Key, key.Address.Index()))
+		}
+
+		shard := s.GetShard(key.Key)
+		length, ok := shardRecords[shard][key.Address.Offset()]
+		if !ok {
+			return newCorruptionError(s.index, s.keys.path(), 0,
+				fmt.Sprintf("key %x references offset %d in shard %d, but no value begins at that offset",
+					key.Key, key.Address.Offset(), shard))
+		}
+
+		if s.metadata.segmentVersion >= ValueSizeSegmentVersion && length != key.ValueSize {
+			return newCorruptionError(s.index, s.keys.path(), 0,
+				fmt.Sprintf("key %x has value size %d, but the value in shard %d has length %d",
+					key.Key, key.ValueSize, shard, length))
+		}
+	}
+
+	return nil
+}
+
+// Quarantine moves all files belonging to this segment into the quarantine directory, and replaces them with an empty
+// segment that has the same index, sharding factor, salt, and seal time. Replacing the segment (as opposed to simply
+// removing it) preserves the invariant that there are no gaps in the sequence of segment indices.
+//
+// After a segment is quarantined, any keymap that references data in the segment is stale, and it is the caller's
+// responsibility to ensure that the keymap is rebuilt. This method must not be called on a segment that is in use by
+// a running LittDB instance, and may only be called on a sealed segment that is not a snapshot.
+func (s *Segment) Quarantine() error {
+	if !s.metadata.sealed {
+		return fmt.Errorf("segment %d is not sealed, cannot quarantine", s.index)
+	}
+
+	isSnapshot, err := s.IsSnapshot()
+	if err != nil {
+		return fmt.Errorf("failed to check if segment %d is a snapshot: %w", s.index, err)
+	}
+	if isSnapshot {
+		return fmt.Errorf("segment %d is a snapshot, cannot quarantine", s.index)
+	}
+
+	for _, filePath := range s.GetFilePaths() {
+		// Segment files live in "$STORAGE_PATH/$TABLE_NAME/segments", the quarantine directory is a sibling.
+		quarantinePath := path.Join(path.Dir(path.Dir(filePath)), QuarantineDirectory)
+		err = util.EnsureDirectoryExists(quarantinePath, s.fsync)
+		if err != nil {
+			return fmt.Errorf("failed to create quarantine directory %s: %w", quarantinePath, err)
+		}
+
+		err = util.AtomicRename(filePath, path.Join(quarantinePath, path.Base(filePath)), s.fsync)
+		if err != nil {
+			return fmt.Errorf("failed to move %s to quarantine directory: %w", filePath, err)
+		}
+	}
+
+	// Replace the quarantined files with empty ones. The replacement is always written using the latest segment
+	// version, regardless of the version of the segment being replaced.
+	keys, err := createKeyFile(s.logger, s.index, s.keys.segmentPath, LatestSegmentVersion, false)
+	if err != nil {
+		return fmt.Errorf("failed to create replacement key file: %w", err)
+	}
+	err = keys.seal()
+	if err != nil {
+		return fmt.Errorf("failed to seal replacement key file: %w", err)
+	}
+
+	shards := make([]*valueFile, len(s.shards))
+	for shard, oldValues := range s.shards {
+		values, err := createValueFile(
+			s.logger, s.index, uint32(shard), oldValues.segmentPath, LatestSegmentVersion, s.fsync)
+		if err != nil {
+			return fmt.Errorf("failed to create replacement value file for shard %d: %w", shard, err)
+		}
+		err = values.seal()
+		if err != nil {
+			return fmt.Errorf("failed to seal replacement value file for shard %d: %w", shard, err)
+		}
+		shards[shard] = values
+	}
+
+	// The metadata file is written last. If there is a crash before this point, the segment will fail to load,
+	// and the quarantine operation can be completed by hand using the files in the quarantine directory.
+	metadata := &metadataFile{
+		index:              s.index,
+		segmentVersion:     LatestSegmentVersion,
+		shardingFactor:     s.metadata.shardingFactor,
+		salt:               s.metadata.salt,
+		compression:        s.metadata.compression,
+		lastValueTimestamp: s.metadata.lastValueTimestamp,
+		sealed:             true,
+		segmentPath:        s.metadata.segmentPath,
+		fsync:              s.fsync,
+	}
+	err = metadata.write()
+	if err != nil {
+		return fmt.Errorf("failed to write replacement metadata file: %w", err)
+	}
+
+	s.metadata = metadata
+	s.keys = keys
+	s.shards = shards
+	s.keyCount = 0
+	s.tombstoneCount = 0
+	s.valueBytes = 0
+	s.logicalValueBytes = 0
+
+	return nil
+}
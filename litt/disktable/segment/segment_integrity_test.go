@@ -0,0 +1,183 @@
+package segment
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigenda/test"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/stretchr/testify/require"
+)
+
+// noThrottle is a throttle function that never blocks.
+func noThrottle(uint64) error {
+	return nil
+}
+
+// buildSealedSegment creates a sealed segment containing random data. Returns the segment and a map from keys to
+// the addresses of the corresponding values.
+func buildSealedSegment(
+	t *testing.T,
+	rand *random.TestRandom,
+	index uint32,
+	segmentPath *SegmentPath) (*Segment, map[string]types.Address) {
+
+	ctx := t.Context()
+	logger := test.GetLogger()
+
+	seg, err := CreateSegment(
+		logger,
+		util.NewErrorMonitor(ctx, logger, nil),
+		index,
+		[]*SegmentPath{segmentPath},
+		false,
+		uint32(rand.Int32Range(1, 4)),
+		([16]byte)(rand.Bytes(16)),
+		types.NoCompression,
+		false)
+	require.NoError(t, err)
+
+	valueCount := rand.Int32Range(100, 200)
+	for i := 0; i < int(valueCount); i++ {
+		_, _, err = seg.Write(&types.KVPair{
+			Key:   rand.PrintableVariableBytes(1, 100),
+			Value: rand.PrintableVariableBytes(1, 100),
+		})
+		require.NoError(t, err)
+	}
+
+	flushedKeys, err := seg.Seal(rand.Time())
+	require.NoError(t, err)
+
+	addresses := make(map[string]types.Address)
+	for _, key := range flushedKeys {
+		addresses[string(key.Key)] = key.Address
+	}
+
+	return seg, addresses
+}
+
+func TestVerifyCorruptValue(t *testing.T) {
+	t.Parallel()
+
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	segmentPath, err := NewSegmentPath(directory, "", "table")
+	require.NoError(t, err)
+	err = segmentPath.MakeDirectories(false)
+	require.NoError(t, err)
+
+	index := rand.Uint32()
+	seg, addresses := buildSealedSegment(t, rand, index, segmentPath)
+
+	err = seg.Verify(noThrottle)
+	require.NoError(t, err)
+
+	// Pick a key, and flip a bit in its value.
+	var corruptKey string
+	var corruptAddress types.Address
+	for key, address := range addresses {
+		corruptKey = key
+		corruptAddress = address
+		break
+	}
+	valueFilePath := seg.shards[seg.GetShard([]byte(corruptKey))].path()
+	data, err := os.ReadFile(valueFilePath)
+	require.NoError(t, err)
+	data[corruptAddress.Offset()+4] ^= 1 << rand.Intn(8)
+	err = os.WriteFile(valueFilePath, data, 0644)
+	require.NoError(t, err)
+
+	// Reading the corrupt value should return a corruption error. Other values should still be readable.
+	var corruptionError *CorruptionError
+	for key, address := range addresses {
+		_, err = seg.Read([]byte(key), address)
+		if key == corruptKey {
+			require.Error(t, err)
+			require.True(t, errors.As(err, &corruptionError))
+			require.Equal(t, index, corruptionError.SegmentIndex)
+			require.Equal(t, uint64(corruptAddress.Offset()), corruptionError.Offset)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+
+	err = seg.Verify(noThrottle)
+	require.Error(t, err)
+	require.True(t, errors.As(err, &corruptionError))
+	require.Equal(t, valueFilePath, corruptionError.Path)
+
+	// Quarantine the segment. The corrupt files should be moved, and replaced by an empty segment.
+	originalPaths := seg.GetFilePaths()
+	err = seg.Quarantine()
+	require.NoError(t, err)
+
+	for _, originalPath := range originalPaths {
+		quarantinedPath := path.Join(directory, "table", QuarantineDirectory, path.Base(originalPath))
+		exists, err := util.Exists(quarantinedPath)
+		require.NoError(t, err)
+		require.True(t, exists)
+	}
+
+	logger := test.GetLogger()
+	seg2, err := LoadSegment(
+		logger,
+		util.NewErrorMonitor(t.Context(), logger, nil),
+		index,
+		[]*SegmentPath{segmentPath},
+		false,
+		time.Now(),
+		false)
+	require.NoError(t, err)
+	require.True(t, seg2.IsSealed())
+	require.Equal(t, seg.GetSealTime(), seg2.GetSealTime())
+	require.Equal(t, uint32(0), seg2.KeyCount())
+	keys, err := seg2.GetKeys()
+	require.NoError(t, err)
+	require.Empty(t, keys)
+	err = seg2.Verify(noThrottle)
+	require.NoError(t, err)
+}
+
+func TestVerifyCorruptKey(t *testing.T) {
+	t.Parallel()
+
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	segmentPath, err := NewSegmentPath(directory, "", "table")
+	require.NoError(t, err)
+	err = segmentPath.MakeDirectories(false)
+	require.NoError(t, err)
+
+	index := rand.Uint32()
+	seg, _ := buildSealedSegment(t, rand, index, segmentPath)
+
+	// Flip a bit somewhere in the first key record. The key length is left alone so that the
+	// record boundaries are not disturbed.
+	keyFilePath := seg.keys.path()
+	data, err := os.ReadFile(keyFilePath)
+	require.NoError(t, err)
+	firstRecordSize := KeyFileRecordSize(LatestSegmentVersion, int(data[3]))
+	data[4+rand.Intn(int(firstRecordSize)-4)] ^= 1 << rand.Intn(8)
+	err = os.WriteFile(keyFilePath, data, 0644)
+	require.NoError(t, err)
+
+	var corruptionError *CorruptionError
+
+	_, err = seg.GetKeys()
+	require.Error(t, err)
+	require.True(t, errors.As(err, &corruptionError))
+	require.Equal(t, keyFilePath, corruptionError.Path)
+	require.Equal(t, uint64(0), corruptionError.Offset)
+
+	err = seg.Verify(noThrottle)
+	require.Error(t, err)
+	require.True(t, errors.As(err, &corruptionError))
+}
@@ -60,6 +60,7 @@ func TestWriteAndReadSegmentSingleShard(t *testing.T) {
 		false,
 		1,
 		salt,
+		types.NoCompression,
 		false)
 
 	require.NoError(t, err)
@@ -70,7 +71,7 @@ func TestWriteAndReadSegmentSingleShard(t *testing.T) {
 		value := values[i]
 		expectedValues[string(key)] = value
 
-		expectedLargestShardSize += uint64(len(value)) + 4 /* uint32 length */
+		expectedLargestShardSize += ValueFileRecordSize(LatestSegmentVersion, len(value))
 
 		_, _, err := seg.Write(&types.KVPair{Key: key, Value: value})
 		largestShardSize := seg.GetMaxShardSize()
@@ -211,6 +212,7 @@ func TestWriteAndReadSegmentMultiShard(t *testing.T) {
 		false,
 		shardCount,
 		salt,
+		types.CompressionType(rand.Intn(3)),
 		false)
 
 	require.NoError(t, err)
@@ -371,6 +373,7 @@ func TestWriteAndReadColdShard(t *testing.T) {
 		false,
 		shardCount,
 		salt,
+		types.CompressionType(rand.Intn(3)),
 		false)
 
 	require.NoError(t, err)
@@ -482,6 +485,7 @@ func TestGetFilePaths(t *testing.T) {
 		false,
 		shardingFactor,
 		([16]byte)(salt),
+		types.NoCompression,
 		false)
 	require.NoError(t, err)
 
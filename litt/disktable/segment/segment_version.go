@@ -14,7 +14,20 @@ const (
 	// ValueSizeSegmentVersion adds the length of values to the key file. Previously, only the key and the address were
 	// stored in the key file. It also adds the key count to the segment metadata file.
 	ValueSizeSegmentVersion SegmentVersion = 2
+
+	// TombstoneSegmentVersion adds a flags byte to each record in the key file, used to mark tombstones (i.e. records
+	// of deleted keys). It also adds the tombstone count to the segment metadata file.
+	TombstoneSegmentVersion SegmentVersion = 3
+
+	// ChecksumSegmentVersion adds a checksum to each record in the value files and to each record in the key file.
+	// The format of the segment metadata file is unchanged.
+	ChecksumSegmentVersion SegmentVersion = 4
+
+	// CompressionSegmentVersion adds the compression type, the number of value bytes, and the number of
+	// uncompressed value bytes to the segment metadata file. Values may be compressed prior to being written to
+	// the value files.
+	CompressionSegmentVersion SegmentVersion = 5
 )
 
 // LatestSegmentVersion always refers to the latest version of the segment serialization format.
-const LatestSegmentVersion = ValueSizeSegmentVersion
+const LatestSegmentVersion = CompressionSegmentVersion
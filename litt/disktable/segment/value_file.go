@@ -46,6 +46,9 @@ type valueFile struct {
 	// The current size of the file, only including flushed data. Protects against reads of partially written values.
 	flushedSize atomic.Uint64
 
+	// The segment version. Determines serialization format.
+	segmentVersion SegmentVersion
+
 	// Whether fsync mode is enabled. If fsync mode is enabled, then each flush operation will invoke the OS fsync
 	// operation before returning. An fsync operation is required to ensure that data is not sitting in OS level
 	// in-memory buffers (otherwise, an OS crash may lead to data loss). This option is provided for testing,
@@ -60,15 +63,17 @@ func createValueFile(
 	index uint32,
 	shard uint32,
 	segmentPath *SegmentPath,
+	segmentVersion SegmentVersion,
 	fsync bool,
 ) (*valueFile, error) {
 
 	values := &valueFile{
-		logger:      logger,
-		index:       index,
-		shard:       shard,
-		segmentPath: segmentPath,
-		fsync:       fsync,
+		logger:         logger,
+		index:          index,
+		shard:          shard,
+		segmentPath:    segmentPath,
+		segmentVersion: segmentVersion,
+		fsync:          fsync,
 	}
 
 	filePath := values.path()
@@ -99,7 +104,8 @@ func loadValueFile(
 	logger logging.Logger,
 	index uint32,
 	shard uint32,
-	segmentPaths []*SegmentPath) (*valueFile, error) {
+	segmentPaths []*SegmentPath,
+	segmentVersion SegmentVersion) (*valueFile, error) {
 
 	valuesFileName := fmt.Sprintf("%d-%d%s", index, shard, ValuesFileExtension)
 	valuesPath, err := lookForFile(segmentPaths, valuesFileName)
@@ -111,11 +117,12 @@ func loadValueFile(
 	}
 
 	values := &valueFile{
-		logger:      logger,
-		index:       index,
-		shard:       shard,
-		segmentPath: valuesPath,
-		fsync:       false,
+		logger:         logger,
+		index:          index,
+		shard:          shard,
+		segmentPath:    valuesPath,
+		segmentVersion: segmentVersion,
+		fsync:          false,
 	}
 
 	filePath := values.path()
@@ -174,6 +181,16 @@ func getValueFileShard(fileName string) (uint32, error) {
 	return uint32(shard), nil
 }
 
+// ValueFileRecordSize returns the number of bytes required to store a single value in a value file at the given
+// segment version.
+func ValueFileRecordSize(segmentVersion SegmentVersion, valueLength int) uint64 {
+	size := uint64(4 /* uint32 length */ + valueLength)
+	if segmentVersion >= ChecksumSegmentVersion {
+		size += checksumSize
+	}
+	return size
+}
+
 // Size returns the size of the value file in bytes.
 func (v *valueFile) Size() uint64 {
 	return v.size
@@ -189,7 +206,8 @@ func (v *valueFile) path() string {
 	return path.Join(v.segmentPath.SegmentDirectory(), v.name())
 }
 
-// read reads a value from the value file.
+// read reads a value from the value file. If the value file contains checksums, the checksum of the value is verified,
+// and a *CorruptionError is returned if the value does not match its checksum.
 func (v *valueFile) read(firstByteIndex uint32) ([]byte, error) {
 	flushedSize := v.flushedSize.Load()
 	if uint64(firstByteIndex) >= flushedSize {
@@ -218,6 +236,13 @@ func (v *valueFile) read(firstByteIndex uint32) ([]byte, error) {
 		return nil, fmt.Errorf("failed to read value length from value file: %v", err)
 	}
 
+	// A corrupted length would otherwise cause us to read data belonging to other values (or past the end of the file).
+	if uint64(firstByteIndex)+ValueFileRecordSize(v.segmentVersion, int(length)) > flushedSize {
+		return nil, newCorruptionError(v.index, v.path(), uint64(firstByteIndex),
+			fmt.Sprintf("value length %d extends beyond the end of the file (flushed size is %d)",
+				length, flushedSize))
+	}
+
 	// Read the value itself.
 	value := make([]byte, length)
 	bytesRead, err := io.ReadFull(reader, value)
@@ -229,9 +254,99 @@ func (v *valueFile) read(firstByteIndex uint32) ([]byte, error) {
 		return nil, fmt.Errorf("failed to read value from value file: read %d bytes, expected %d", bytesRead, length)
 	}
 
+	if v.segmentVersion >= ChecksumSegmentVersion {
+		var checksum uint32
+		err = binary.Read(reader, binary.BigEndian, &checksum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read checksum from value file: %v", err)
+		}
+
+		if checksum != computeChecksum(value) {
+			return nil, newCorruptionError(v.index, v.path(), uint64(firstByteIndex), "checksum mismatch")
+		}
+	}
+
 	return value, nil
 }
 
+// scan reads the entire value file sequentially, verifying the checksum of each value (if the value file contains
+// checksums). Returns a map from the offset of each value to the length of that value. The throttle function is
+// called prior to reading each value with the number of bytes about to be read, and can be used to limit the rate at
+// which data is read. If corruption is detected, a *CorruptionError is returned.
+//
+// It is only safe to call this method on a value file that is sealed.
+func (v *valueFile) scan(throttle func(bytes uint64) error) (map[uint32]uint32, error) {
+	if v.writer != nil {
+		return nil, fmt.Errorf("value file %s is not sealed, cannot scan", v.path())
+	}
+
+	file, err := os.Open(v.path())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open value file: %v", err)
+	}
+	defer func() {
+		err = file.Close()
+		if err != nil {
+			v.logger.Errorf("failed to close value file: %v", err)
+		}
+	}()
+	reader := bufio.NewReader(file)
+
+	fileSize := v.flushedSize.Load()
+	records := make(map[uint32]uint32)
+
+	offset := uint64(0)
+	for offset < fileSize {
+		if offset+4 > fileSize {
+			return nil, newCorruptionError(v.index, v.path(), offset,
+				fmt.Sprintf("%d trailing bytes do not form a complete value", fileSize-offset))
+		}
+
+		var length uint32
+		err = binary.Read(reader, binary.BigEndian, &length)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read value length from value file: %v", err)
+		}
+
+		recordSize := ValueFileRecordSize(v.segmentVersion, int(length))
+		if offset+recordSize > fileSize {
+			return nil, newCorruptionError(v.index, v.path(), offset,
+				fmt.Sprintf("value length %d extends beyond the end of the file (file size is %d)",
+					length, fileSize))
+		}
+
+		err = throttle(recordSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to throttle value file scan: %w", err)
+		}
+
+		value := make([]byte, length)
+		_, err = io.ReadFull(reader, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read value from value file: %v", err)
+		}
+
+		if v.segmentVersion >= ChecksumSegmentVersion {
+			var checksum uint32
+			err = binary.Read(reader, binary.BigEndian, &checksum)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read checksum from value file: %v", err)
+			}
+
+			if checksum != computeChecksum(value) {
+				return nil, newCorruptionError(v.index, v.path(), offset, "checksum mismatch")
+			}
+		}
+
+		if offset <= math.MaxUint32 {
+			records[uint32(offset)] = length
+		}
+		offset += recordSize
+	}
+
+	return records, nil
+}
+
 // write writes a value to the value file, returning the index of the first byte written.
 func (v *valueFile) write(value []byte) (uint32, error) {
 	if v.writer == nil {
@@ -258,7 +373,15 @@ func (v *valueFile) write(value []byte) (uint32, error) {
 		return 0, fmt.Errorf("failed to write value to value file: %v", err)
 	}
 
-	v.size += uint64(len(value) + 4)
+	if v.segmentVersion >= ChecksumSegmentVersion {
+		// Finally, write the checksum of the value.
+		err = binary.Write(v.writer, binary.BigEndian, computeChecksum(value))
+		if err != nil {
+			return 0, fmt.Errorf("failed to write checksum to value file: %v", err)
+		}
+	}
+
+	v.size += ValueFileRecordSize(v.segmentVersion, len(value))
 
 	return firstByteIndex, nil
 }
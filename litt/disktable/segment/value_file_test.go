@@ -22,7 +22,7 @@ func TestWriteThenReadValues(t *testing.T) {
 	expectedFileSize := uint64(0)
 	for i := 0; i < int(valueCount); i++ {
 		values[i] = rand.VariableBytes(1, 100)
-		expectedFileSize += uint64(len(values[i])) + 4 /* length uint32 */
+		expectedFileSize += ValueFileRecordSize(LatestSegmentVersion, len(values[i]))
 	}
 
 	// A map from the first byte index of the value to the value itself.
@@ -32,7 +32,7 @@ func TestWriteThenReadValues(t *testing.T) {
 	require.NoError(t, err)
 	err = segmentPath.MakeDirectories(false)
 	require.NoError(t, err)
-	file, err := createValueFile(logger, index, shard, segmentPath, false)
+	file, err := createValueFile(logger, index, shard, segmentPath, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	for _, value := range values {
@@ -74,7 +74,7 @@ func TestWriteThenReadValues(t *testing.T) {
 	require.Equal(t, actualFileSize, reportedFileSize)
 
 	// Create a new in-memory instance from the on-disk file and verify that it behaves the same.
-	file2, err := loadValueFile(logger, index, shard, []*SegmentPath{segmentPath})
+	file2, err := loadValueFile(logger, index, shard, []*SegmentPath{segmentPath}, LatestSegmentVersion)
 	require.NoError(t, err)
 	require.Equal(t, file.size, file2.size)
 	for key, val := range addressMap {
@@ -116,7 +116,7 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	require.NoError(t, err)
 	err = segmentPath.MakeDirectories(false)
 	require.NoError(t, err)
-	file, err := createValueFile(logger, index, shard, segmentPath, false)
+	file, err := createValueFile(logger, index, shard, segmentPath, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	var lastAddress uint32
@@ -144,7 +144,7 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	err = os.WriteFile(filePath, bytes, 0644)
 	require.NoError(t, err)
 
-	file, err = loadValueFile(logger, index, shard, []*SegmentPath{segmentPath})
+	file, err = loadValueFile(logger, index, shard, []*SegmentPath{segmentPath}, LatestSegmentVersion)
 	require.NoError(t, err)
 
 	// We should be able to read all values except for the last one.
@@ -161,12 +161,12 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 
 	// Truncate the file. Corrupt the length prefix of the last value.
 	prefixBytesToRemove := rand.Int32Range(1, 4)
-	bytes = originalBytes[:len(originalBytes)-int(prefixBytesToRemove)]
+	bytes = originalBytes[:len(originalBytes)-checksumSize-lastValueLength-int(prefixBytesToRemove)]
 
 	err = os.WriteFile(filePath, bytes, 0644)
 	require.NoError(t, err)
 
-	file, err = loadValueFile(logger, index, shard, []*SegmentPath{segmentPath})
+	file, err = loadValueFile(logger, index, shard, []*SegmentPath{segmentPath}, LatestSegmentVersion)
 	require.NoError(t, err)
 
 	// We should be able to read all values except for the last one.
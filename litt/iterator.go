@@ -0,0 +1,35 @@
+package litt
+
+// Iterator walks over the key-value pairs in a table in ascending lexicographic key order.
+//
+// An iterator observes a consistent snapshot of the table's keys taken at the moment the iterator is created.
+// Keys written after the iterator is created may or may not be observed. Data that was present when the iterator
+// was created will remain readable through the iterator until Release() is called, even if that data expires
+// via TTL in the meantime (i.e. an open iterator delays garbage collection of the data it references).
+//
+// Iterators are not thread safe. Each iterator should only be used by a single goroutine at a time. It is safe
+// to use multiple iterators concurrently, and to use iterators concurrently with other table operations.
+type Iterator interface {
+	// Next advances the iterator to the next key-value pair. Returns false if there are no more pairs or if an
+	// error was encountered (call Error() to distinguish between these cases). Next() must be called before
+	// the first pair can be accessed.
+	Next() bool
+
+	// Key returns the key at the iterator's current position. It is not safe to modify the returned byte slice.
+	Key() []byte
+
+	// Value returns the value at the iterator's current position. Values are loaded lazily, so iterating over keys
+	// without calling Value() is significantly cheaper than iterating over both keys and values.
+	//
+	// For the sake of performance, the returned data is NOT safe to mutate. If you need to modify the data,
+	// make a copy of it first.
+	Value() ([]byte, error)
+
+	// Error returns the first error encountered while advancing the iterator, if any.
+	Error() error
+
+	// Release releases the resources held by the iterator. The iterator must not be used after this method is
+	// called. It is safe to call Release() multiple times. Failing to release an iterator will prevent the data it
+	// references from being garbage collected.
+	Release()
+}
@@ -0,0 +1,215 @@
+package littbuilder
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+// BatchJournalExtension is the file extension for batch journal files. A batch journal file is written to the
+// first root path of the database before a batch is applied to its tables, and is deleted once all tables touched
+// by the batch have been flushed. If the database crashes while a batch is being committed, the journal is replayed
+// the next time the database is started.
+const BatchJournalExtension = ".journal"
+
+// batchJournalChecksumTable is the CRC-32 table used to compute the checksum of a batch journal.
+var batchJournalChecksumTable = crc32.MakeTable(crc32.Castagnoli)
+
+// batchOperationType describes the type of operation in a batch.
+type batchOperationType byte
+
+const (
+	// batchPut is a write operation.
+	batchPut batchOperationType = 0
+	// batchDelete is a delete operation.
+	batchDelete batchOperationType = 1
+)
+
+// batchOperation is a single operation in a batch.
+type batchOperation struct {
+	// The type of the operation.
+	operationType batchOperationType
+	// The name of the table the operation applies to.
+	tableName string
+	// The key of the operation.
+	key []byte
+	// The value of the operation. Always nil for deletions.
+	value []byte
+}
+
+// serializeBatchJournal serializes a batch of operations into the journal file format.
+//
+// Format:
+//   - operation count (4 bytes)
+//   - for each operation:
+//   - operation type (1 byte)
+//   - table name length (4 bytes), followed by the table name
+//   - key length (4 bytes), followed by the key
+//   - if the operation is a write: value length (4 bytes), followed by the value
+//   - a CRC-32 (Castagnoli) checksum of all preceding bytes (4 bytes)
+func serializeBatchJournal(operations []*batchOperation) []byte {
+	size := 4 + 4
+	for _, operation := range operations {
+		size += 1 + 4 + len(operation.tableName) + 4 + len(operation.key)
+		if operation.operationType == batchPut {
+			size += 4 + len(operation.value)
+		}
+	}
+
+	data := make([]byte, 0, size)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(operations)))
+	for _, operation := range operations {
+		data = append(data, byte(operation.operationType))
+		data = binary.BigEndian.AppendUint32(data, uint32(len(operation.tableName)))
+		data = append(data, operation.tableName...)
+		data = binary.BigEndian.AppendUint32(data, uint32(len(operation.key)))
+		data = append(data, operation.key...)
+		if operation.operationType == batchPut {
+			data = binary.BigEndian.AppendUint32(data, uint32(len(operation.value)))
+			data = append(data, operation.value...)
+		}
+	}
+	data = binary.BigEndian.AppendUint32(data, crc32.Checksum(data, batchJournalChecksumTable))
+
+	return data
+}
+
+// deserializeBatchJournal deserializes a batch of operations from the journal file format.
+func deserializeBatchJournal(data []byte) ([]*batchOperation, error) {
+	if len(data) < 8 {
+		return nil, fmt.Errorf("batch journal is too short: %d bytes", len(data))
+	}
+
+	body := data[:len(data)-4]
+	expectedChecksum := binary.BigEndian.Uint32(data[len(data)-4:])
+	actualChecksum := crc32.Checksum(body, batchJournalChecksumTable)
+	if expectedChecksum != actualChecksum {
+		return nil, fmt.Errorf("batch journal checksum mismatch: expected %x, got %x",
+			expectedChecksum, actualChecksum)
+	}
+
+	offset := 0
+	readBytes := func() ([]byte, error) {
+		if offset+4 > len(body) {
+			return nil, fmt.Errorf("batch journal truncated at offset %d", offset)
+		}
+		length := int(binary.BigEndian.Uint32(body[offset:]))
+		offset += 4
+		if offset+length > len(body) {
+			return nil, fmt.Errorf("batch journal truncated at offset %d", offset)
+		}
+		bytes := body[offset : offset+length]
+		offset += length
+		return bytes, nil
+	}
+
+	count := binary.BigEndian.Uint32(body)
+	offset += 4
+
+	operations := make([]*batchOperation, 0, count)
+	for i := uint32(0); i < count; i++ {
+		if offset+1 > len(body) {
+			return nil, fmt.Errorf("batch journal truncated at offset %d", offset)
+		}
+		operation := &batchOperation{
+			operationType: batchOperationType(body[offset]),
+		}
+		offset++
+		if operation.operationType != batchPut && operation.operationType != batchDelete {
+			return nil, fmt.Errorf("unknown batch operation type %d", operation.operationType)
+		}
+
+		tableName, err := readBytes()
+		if err != nil {
+			return nil, err
+		}
+		operation.tableName = string(tableName)
+
+		operation.key, err = readBytes()
+		if err != nil {
+			return nil, err
+		}
+
+		if operation.operationType == batchPut {
+			operation.value, err = readBytes()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		operations = append(operations, operation)
+	}
+
+	if offset != len(body) {
+		return nil, fmt.Errorf("batch journal has %d unexpected trailing bytes", len(body)-offset)
+	}
+
+	return operations, nil
+}
+
+// batchJournalPath returns the path of the journal file for the batch with the given ID.
+func batchJournalPath(rootPath string, id uint64) string {
+	return path.Join(rootPath, fmt.Sprintf("%d%s", id, BatchJournalExtension))
+}
+
+// findBatchJournals finds all batch journal files in the given root paths, sorted by batch ID. Swap files left
+// behind by a crash while a journal was being written are deleted, since the batches they describe were never
+// applied.
+func findBatchJournals(rootPaths []string) ([]string, error) {
+	type journal struct {
+		id   uint64
+		path string
+	}
+	journals := make([]*journal, 0)
+
+	for _, rootPath := range rootPaths {
+		entries, err := os.ReadDir(rootPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read directory %s: %w", rootPath, err)
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			name := entry.Name()
+			entryPath := path.Join(rootPath, name)
+
+			if strings.HasSuffix(name, BatchJournalExtension+util.SwapFileExtension) {
+				err = os.Remove(entryPath)
+				if err != nil {
+					return nil, fmt.Errorf("failed to remove incomplete batch journal %s: %w", entryPath, err)
+				}
+				continue
+			}
+
+			if !strings.HasSuffix(name, BatchJournalExtension) {
+				continue
+			}
+
+			id, err := strconv.ParseUint(strings.TrimSuffix(name, BatchJournalExtension), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse batch ID from journal file %s: %w", entryPath, err)
+			}
+			journals = append(journals, &journal{id: id, path: entryPath})
+		}
+	}
+
+	sort.Slice(journals, func(i, j int) bool {
+		return journals[i].id < journals[j].id
+	})
+
+	paths := make([]string, len(journals))
+	for i, j := range journals {
+		paths[i] = j.path
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,279 @@
+package littbuilder
+
+import (
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+var _ litt.Batch = &batch{}
+
+// batch is an implementation of litt.Batch.
+//
+// A batch is committed in three phases. First, the operations in the batch are written to a journal file, and the
+// journal file is made crash durable. Second, the operations are applied to their tables, and each table is flushed.
+// Finally, the journal file is deleted. If the database crashes before the journal file is durable, then no
+// operation in the batch was applied. If the database crashes after the journal file is durable but before it
+// is deleted, then the journal is replayed when the database is restarted.
+//
+// Everything that can be checked ahead of time (table names, quotas, keys and values) is validated before the journal
+// is written, so that an invalid batch is rejected without applying any of its operations. If applying the batch
+// fails regardless (e.g. due to an I/O error), then the journal is kept, and the batch is rolled forward when the
+// database is restarted.
+type batch struct {
+	// The database the batch is committed to.
+	db *db
+
+	// The operations in the batch, in the order they were added.
+	operations []*batchOperation
+
+	// True if Commit() has been called.
+	committed bool
+}
+
+func (d *db) NewBatch() litt.Batch {
+	return &batch{
+		db:         d,
+		operations: make([]*batchOperation, 0),
+	}
+}
+
+func (b *batch) Put(tableName string, key []byte, value []byte) {
+	b.operations = append(b.operations, &batchOperation{
+		operationType: batchPut,
+		tableName:     tableName,
+		key:           key,
+		value:         value,
+	})
+}
+
+func (b *batch) Delete(tableName string, key []byte) {
+	b.operations = append(b.operations, &batchOperation{
+		operationType: batchDelete,
+		tableName:     tableName,
+		key:           key,
+	})
+}
+
+func (b *batch) Size() int {
+	return len(b.operations)
+}
+
+func (b *batch) Commit() error {
+	if b.committed {
+		return fmt.Errorf("batch has already been committed")
+	}
+	b.committed = true
+
+	if b.db.stopped.Load() {
+		return fmt.Errorf("database has been stopped")
+	}
+
+	if len(b.operations) == 0 {
+		return nil
+	}
+
+	// Resolve all tables before writing the journal. This ensures that a batch referencing an invalid table name
+	// is rejected without leaving anything on disk.
+	tables, err := b.db.getBatchTables(b.operations)
+	if err != nil {
+		return err
+	}
+
+	err = validateBatch(b.operations)
+	if err != nil {
+		return err
+	}
+
+	// Quotas are enforced before the journal is written, so that a batch is never partially applied due to a quota.
+	err = b.db.enforceBatchQuotas(tables, b.operations)
+	if err != nil {
+		return err
+	}
+
+	journalPath := batchJournalPath(b.db.paths[0], b.db.batchCounter.Add(1))
+	err = util.AtomicWrite(journalPath, serializeBatchJournal(b.operations), b.db.fsync)
+	if err != nil {
+		return fmt.Errorf("failed to write batch journal %s: %w", journalPath, err)
+	}
+
+	err = b.db.applyBatch(tables, b.operations, false)
+	if err != nil {
+		// Some operations may already have been applied, so the journal is kept in order to roll the batch forward
+		// when the database is restarted.
+		return fmt.Errorf("failed to apply batch, it will be completed when the database is restarted: %w", err)
+	}
+
+	err = b.db.retireBatchJournal(journalPath)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateBatch checks the keys and values of a batch against the restrictions enforced by the tables, so that a
+// batch that would be rejected by a table is rejected before any of its operations are applied.
+func validateBatch(operations []*batchOperation) error {
+	for _, operation := range operations {
+		if operation.key == nil {
+			return fmt.Errorf("nil keys are not supported (table %s)", operation.tableName)
+		}
+		if len(operation.key) > math.MaxUint32 {
+			return fmt.Errorf("key is too large, length must not exceed 2^32 bytes: %d bytes (table %s)",
+				len(operation.key), operation.tableName)
+		}
+		if operation.operationType != batchPut {
+			continue
+		}
+		if operation.value == nil {
+			return fmt.Errorf("nil values are not supported (table %s)", operation.tableName)
+		}
+		if len(operation.value) > math.MaxUint32 {
+			return fmt.Errorf("value is too large, length must not exceed 2^32 bytes: %d bytes (table %s)",
+				len(operation.value), operation.tableName)
+		}
+	}
+	return nil
+}
+
+// getBatchTables gets (creating if necessary) all tables referenced by a batch. The returned tables are not
+// subject to quotas, since quotas for a batch are enforced before the batch is applied.
+func (d *db) getBatchTables(operations []*batchOperation) (map[string]litt.ManagedTable, error) {
+	tables := make(map[string]litt.ManagedTable)
+	for _, operation := range operations {
+		if _, ok := tables[operation.tableName]; ok {
+			continue
+		}
+		table, err := d.GetTable(operation.tableName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get table %s: %w", operation.tableName, err)
+		}
+		tables[operation.tableName] = unwrapQuotaTable(table.(litt.ManagedTable))
+	}
+	return tables, nil
+}
+
+// enforceBatchQuotas enforces quotas for all writes in a batch.
+func (d *db) enforceBatchQuotas(tables map[string]litt.ManagedTable, operations []*batchOperation) error {
+	writeSizes := make(map[string]uint64)
+	for _, operation := range operations {
+		if operation.operationType == batchPut {
+			writeSizes[operation.tableName] += uint64(len(operation.key) + len(operation.value))
+		}
+	}
+
+	for name, writeSize := range writeSizes {
+		err := d.quotas.enforce(tables[name], writeSize)
+		if err != nil {
+			return fmt.Errorf("failed to write batch to table %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// applyBatch applies the operations in a batch to their tables and then flushes each table. If recovering is true,
+// then the batch is being replayed from a journal after a crash, and writes that are already present in the table
+// are skipped.
+func (d *db) applyBatch(tables map[string]litt.ManagedTable, operations []*batchOperation, recovering bool) error {
+	for _, operation := range operations {
+		table := tables[operation.tableName]
+
+		switch operation.operationType {
+		case batchPut:
+			if recovering {
+				exists, err := table.Exists(operation.key)
+				if err != nil {
+					return fmt.Errorf("failed to check existence of key in table %s: %w",
+						operation.tableName, err)
+				}
+				if exists {
+					continue
+				}
+			}
+			err := table.Put(operation.key, operation.value)
+			if err != nil {
+				return fmt.Errorf("failed to put key in table %s: %w", operation.tableName, err)
+			}
+		case batchDelete:
+			err := table.Delete(operation.key)
+			if err != nil {
+				return fmt.Errorf("failed to delete key in table %s: %w", operation.tableName, err)
+			}
+		default:
+			return fmt.Errorf("unknown batch operation type %d", operation.operationType)
+		}
+	}
+
+	for name, table := range tables {
+		err := table.Flush()
+		if err != nil {
+			return fmt.Errorf("failed to flush table %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// retireBatchJournal deletes a batch journal once all operations in the batch are crash durable.
+func (d *db) retireBatchJournal(journalPath string) error {
+	err := os.Remove(journalPath)
+	if err != nil {
+		return fmt.Errorf("failed to remove batch journal %s: %w", journalPath, err)
+	}
+
+	if d.fsync {
+		// If the removal is not durable, the batch may be replayed after a crash. Replaying the batch could
+		// resurrect keys that were deleted after the batch was committed.
+		err = util.SyncParentPath(journalPath)
+		if err != nil {
+			return fmt.Errorf("failed to sync parent directory of batch journal %s: %w", journalPath, err)
+		}
+	}
+
+	return nil
+}
+
+// recoverBatches replays any batch journals left behind by a crash. This must be called before the database is
+// made available to callers.
+func (d *db) recoverBatches() error {
+	journalPaths, err := findBatchJournals(d.paths)
+	if err != nil {
+		return fmt.Errorf("failed to find batch journals: %w", err)
+	}
+
+	for _, journalPath := range journalPaths {
+		data, err := os.ReadFile(journalPath)
+		if err != nil {
+			return fmt.Errorf("failed to read batch journal %s: %w", journalPath, err)
+		}
+
+		operations, err := deserializeBatchJournal(data)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize batch journal %s: %w", journalPath, err)
+		}
+
+		d.logger.Infof("Replaying batch journal %s containing %d operations", journalPath, len(operations))
+
+		tables, err := d.getBatchTables(operations)
+		if err != nil {
+			return err
+		}
+
+		err = d.applyBatch(tables, operations, true)
+		if err != nil {
+			return fmt.Errorf("failed to replay batch journal %s: %w", journalPath, err)
+		}
+
+		err = d.retireBatchJournal(journalPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
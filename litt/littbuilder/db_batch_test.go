@@ -0,0 +1,303 @@
+package littbuilder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/metrics"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigenda/test"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func buildBatchTestDB(t *testing.T, directory string) litt.DB {
+	config, err := litt.DefaultConfig(directory)
+	require.NoError(t, err)
+	config.Logger = test.GetLogger()
+	config.KeymapType = keymap.UnsafeLevelDBKeymapType
+	config.Fsync = false
+
+	db, err := NewDB(config)
+	require.NoError(t, err)
+	return db
+}
+
+func TestBatchJournalSerialization(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	operations := make([]*batchOperation, 0)
+	for i := 0; i < 100; i++ {
+		operation := &batchOperation{
+			operationType: batchPut,
+			tableName:     rand.String(8),
+			key:           rand.PrintableVariableBytes(1, 64),
+		}
+		if rand.Bool() {
+			operation.value = rand.PrintableVariableBytes(0, 128)
+		} else {
+			operation.operationType = batchDelete
+		}
+		operations = append(operations, operation)
+	}
+
+	data := serializeBatchJournal(operations)
+	deserialized, err := deserializeBatchJournal(data)
+	require.NoError(t, err)
+	require.Equal(t, len(operations), len(deserialized))
+	for i := range operations {
+		require.Equal(t, operations[i].operationType, deserialized[i].operationType)
+		require.Equal(t, operations[i].tableName, deserialized[i].tableName)
+		require.Equal(t, operations[i].key, deserialized[i].key)
+		if operations[i].operationType == batchPut {
+			require.Equal(t, operations[i].value, deserialized[i].value)
+		}
+	}
+
+	// Any corruption should be detected.
+	data[rand.Intn(len(data))] ^= 0xFF
+	_, err = deserializeBatchJournal(data)
+	require.Error(t, err)
+
+	_, err = deserializeBatchJournal(data[:len(data)/2])
+	require.Error(t, err)
+}
+
+// Simulates a crash that happens after a batch journal is written but before the batch is applied to its tables.
+func TestBatchRecovery(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	db := buildBatchTestDB(t, directory)
+
+	tableA, err := db.GetTable("a")
+	require.NoError(t, err)
+	tableB, err := db.GetTable("b")
+	require.NoError(t, err)
+
+	// Write some data that predates the batch.
+	preexistingKey := rand.PrintableBytes(32)
+	preexistingValue := rand.PrintableBytes(32)
+	err = tableA.Put(preexistingKey, preexistingValue)
+	require.NoError(t, err)
+	deletedKey := rand.PrintableBytes(32)
+	err = tableB.Put(deletedKey, rand.PrintableBytes(32))
+	require.NoError(t, err)
+	err = tableA.Flush()
+	require.NoError(t, err)
+	err = tableB.Flush()
+	require.NoError(t, err)
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	// Write a journal. The batch includes a key that is already present, which must not be written twice.
+	expectedValues := map[string]map[string][]byte{
+		"a": {string(preexistingKey): preexistingValue},
+		"b": {},
+		"c": {},
+	}
+	operations := []*batchOperation{
+		{operationType: batchPut, tableName: "a", key: preexistingKey, value: preexistingValue},
+		{operationType: batchDelete, tableName: "b", key: deletedKey},
+	}
+	for i := 0; i < 10; i++ {
+		for _, tableName := range []string{"a", "b", "c"} {
+			key := rand.PrintableBytes(32)
+			value := rand.PrintableBytes(32)
+			operations = append(operations,
+				&batchOperation{operationType: batchPut, tableName: tableName, key: key, value: value})
+			expectedValues[tableName][string(key)] = value
+		}
+	}
+	err = util.AtomicWrite(batchJournalPath(directory, 1), serializeBatchJournal(operations), false)
+	require.NoError(t, err)
+
+	// Simulate a crash while writing a second journal. This batch was never committed, and should be discarded.
+	uncommittedKey := rand.PrintableBytes(32)
+	uncommitted := serializeBatchJournal([]*batchOperation{
+		{operationType: batchPut, tableName: "a", key: uncommittedKey, value: rand.PrintableBytes(32)},
+	})
+	err = os.WriteFile(batchJournalPath(directory, 2)+util.SwapFileExtension, uncommitted, 0644)
+	require.NoError(t, err)
+
+	db = buildBatchTestDB(t, directory)
+
+	for tableName, tableValues := range expectedValues {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		require.Equal(t, uint64(len(tableValues)), table.KeyCount())
+
+		for expectedKey, expectedValue := range tableValues {
+			value, ok, err := table.Get([]byte(expectedKey))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+
+	tableA, err = db.GetTable("a")
+	require.NoError(t, err)
+	ok, err := tableA.Exists(uncommittedKey)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// All journals should have been cleaned up.
+	journals, err := findBatchJournals([]string{directory})
+	require.NoError(t, err)
+	require.Empty(t, journals)
+	for _, suffix := range []string{"", util.SwapFileExtension} {
+		exists, err := util.Exists(path.Join(directory, "2"+BatchJournalExtension+suffix))
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
+
+// failingTable is a table that fails to write a particular key.
+type failingTable struct {
+	litt.ManagedTable
+	failingKey []byte
+}
+
+func (t *failingTable) Put(key []byte, value []byte) error {
+	if bytes.Equal(key, t.failingKey) {
+		return errors.New("injected put failure")
+	}
+	return t.ManagedTable.Put(key, value)
+}
+
+// A batch that fails while being applied is rolled forward when the database is restarted.
+func TestBatchApplyFailureRolledForward(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	config, err := litt.DefaultConfig(directory)
+	require.NoError(t, err)
+	config.Logger = test.GetLogger()
+	config.KeymapType = keymap.UnsafeLevelDBKeymapType
+	config.Fsync = false
+
+	failingKey := rand.PrintableBytes(32)
+	db, err := NewDBUnsafe(config, func(
+		ctx context.Context,
+		logger logging.Logger,
+		name string,
+		metrics *metrics.LittDBMetrics,
+	) (litt.ManagedTable, error) {
+		table, err := buildTable(config, logger, name, metrics)
+		if err != nil {
+			return nil, err
+		}
+		return &failingTable{ManagedTable: table, failingKey: failingKey}, nil
+	})
+	require.NoError(t, err)
+
+	// The failing write comes last, so every other operation in the batch is applied before the failure.
+	expected := make(map[string][]byte)
+	batch := db.NewBatch()
+	for i := 0; i < 10; i++ {
+		key := rand.PrintableBytes(32)
+		value := rand.PrintableBytes(32)
+		batch.Put("a", key, value)
+		expected[string(key)] = value
+	}
+	failingValue := rand.PrintableBytes(32)
+	batch.Put("a", failingKey, failingValue)
+	expected[string(failingKey)] = failingValue
+	err = batch.Commit()
+	require.ErrorContains(t, err, "injected put failure")
+
+	// The journal of the failed batch is kept, so that the batch can be completed.
+	journals, err := findBatchJournals([]string{directory})
+	require.NoError(t, err)
+	require.Len(t, journals, 1)
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	// Every operation in the batch is visible once the database is restarted.
+	db = buildBatchTestDB(t, directory)
+	table, err := db.GetTable("a")
+	require.NoError(t, err)
+	for key, expectedValue := range expected {
+		value, ok, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expectedValue, value)
+	}
+
+	journals, err = findBatchJournals([]string{directory})
+	require.NoError(t, err)
+	require.Empty(t, journals)
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
+
+// A batch that fails validation is rejected before any of its operations are applied.
+func TestInvalidBatchNotApplied(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	db := buildBatchTestDB(t, directory)
+
+	// The invalid write comes last, after writes to two different tables.
+	keys := make([][]byte, 0)
+	batch := db.NewBatch()
+	for i := 0; i < 10; i++ {
+		key := rand.PrintableBytes(32)
+		keys = append(keys, key)
+		batch.Put("a", key, rand.PrintableBytes(32))
+		batch.Put("b", key, rand.PrintableBytes(32))
+	}
+	batch.Put("b", rand.PrintableBytes(32), nil)
+	err := batch.Commit()
+	require.ErrorContains(t, err, "nil values are not supported")
+
+	// Nothing was written, not even a journal.
+	journals, err := findBatchJournals([]string{directory})
+	require.NoError(t, err)
+	require.Empty(t, journals)
+
+	for _, tableName := range []string{"a", "b"} {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		for _, key := range keys {
+			ok, err := table.Exists(key)
+			require.NoError(t, err)
+			require.False(t, ok)
+		}
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	// Nothing becomes visible after a restart either.
+	db = buildBatchTestDB(t, directory)
+	for _, tableName := range []string{"a", "b"} {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		for _, key := range keys {
+			ok, err := table.Exists(key)
+			require.NoError(t, err)
+			require.False(t, ok)
+		}
+	}
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/admin"
 	"github.com/Layr-Labs/eigenda/litt/disktable"
 	"github.com/Layr-Labs/eigenda/litt/metrics"
 	"github.com/Layr-Labs/eigenda/litt/util"
@@ -57,11 +58,26 @@ type db struct {
 	// The HTTP server for metrics. nil if metrics are disabled or if an external party is managing the server.
 	metricsServer *http.Server
 
+	// The admin server. nil if the admin server is disabled.
+	adminServer *admin.Server
+
 	// A function that releases file locks.
 	releaseLocks func()
 
 	// Set to true when the database is closed.
 	closed bool
+
+	// The root paths of the database. Batch journals are written to the first path.
+	paths []string
+
+	// If true, then batch journals are fsynced to disk.
+	fsync bool
+
+	// Used to assign a unique ID to each committed batch.
+	batchCounter atomic.Uint64
+
+	// Enforces byte quotas on writes. nil if no quotas are configured.
+	quotas *quotaEnforcer
 }
 
 // NewDB creates a new DB instance. After this method is called, the config object should not be modified.
@@ -156,6 +172,29 @@ func NewDBUnsafe(config *litt.Config, tableBuilder TableBuilderFunc) (litt.DB, e
 		metrics:       dbMetrics,
 		metricsServer: metricsServer,
 		releaseLocks:  releaseLocks,
+		paths:         config.Paths,
+		fsync:         config.Fsync,
+	}
+	database.quotas = newQuotaEnforcer(config, database.tablesCopy, &database.stopped, dbMetrics)
+
+	err = database.recoverBatches()
+	if err != nil {
+		closeErr := database.Close()
+		if closeErr != nil {
+			config.Logger.Errorf("error closing database after failed batch recovery: %v", closeErr)
+		}
+		return nil, fmt.Errorf("error recovering batches: %w", err)
+	}
+
+	if config.AdminServerEnabled {
+		database.adminServer, err = admin.NewServer(config.Logger, config.AdminServerAddress, database.tablesCopy)
+		if err != nil {
+			closeErr := database.Close()
+			if closeErr != nil {
+				config.Logger.Errorf("error closing database after failed admin server start: %v", closeErr)
+			}
+			return nil, fmt.Errorf("error starting admin server: %w", err)
+		}
 	}
 
 	if config.MetricsEnabled {
@@ -193,6 +232,18 @@ func (d *db) lockFreeSize() uint64 {
 	return size
 }
 
+func (d *db) LogicalSize() uint64 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	size := uint64(0)
+	for _, table := range d.tables {
+		size += table.LogicalSize()
+	}
+
+	return size
+}
+
 func (d *db) GetTable(name string) (litt.Table, error) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
@@ -214,6 +265,10 @@ func (d *db) GetTable(name string) (litt.Table, error) {
 			"Table '%s' initialized, table contains %d key-value pairs and has a size of %s.",
 			name, table.KeyCount(), common.PrettyPrintBytes(table.Size()))
 
+		if d.quotas.appliesTo(name) {
+			table = newQuotaTable(table, d.quotas)
+		}
+
 		d.tables[name] = table
 	}
 
@@ -256,6 +311,13 @@ func (d *db) closeUnsafe() error {
 	d.logger.Infof("Stopping LittDB, estimated data size: %d", d.lockFreeSize())
 	d.stopped.Store(true)
 
+	if d.adminServer != nil {
+		err := d.adminServer.Close()
+		if err != nil {
+			d.logger.Errorf("error closing admin server: %v", err)
+		}
+	}
+
 	for name, table := range d.tables {
 		err := table.Close()
 		if err != nil {
@@ -306,14 +368,32 @@ func (d *db) gatherMetrics(interval time.Duration) {
 		case <-d.ctx.Done():
 			return
 		case <-ticker.C:
-			d.lock.Lock()
-			tablesCopy := make(map[string]litt.ManagedTable, len(d.tables))
-			for name, table := range d.tables {
-				tablesCopy[name] = table
-			}
-			d.lock.Unlock()
-
-			d.metrics.CollectPeriodicMetrics(tablesCopy)
+			tables := d.tablesCopy()
+			d.metrics.CollectPeriodicMetrics(tables)
+			d.quotas.reportUtilization(tables)
 		}
 	}
 }
+
+// tablesCopy returns a copy of the map of all tables in the database.
+func (d *db) tablesCopy() map[string]litt.ManagedTable {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	tablesCopy := make(map[string]litt.ManagedTable, len(d.tables))
+	for name, table := range d.tables {
+		tablesCopy[name] = table
+	}
+	return tablesCopy
+}
+
+// AdminServerAddress returns the address the admin server of a DB created by this package is listening on, or an
+// empty string if the admin server is disabled. This is useful when the admin server is configured to listen on
+// an ephemeral port.
+func AdminServerAddress(database litt.DB) string {
+	d, ok := database.(*db)
+	if !ok || d.adminServer == nil {
+		return ""
+	}
+	return d.adminServer.Address()
+}
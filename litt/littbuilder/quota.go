@@ -0,0 +1,344 @@
+package littbuilder
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/metrics"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// The interval at which a write blocked by a quota re-checks whether space has become available.
+const quotaPollInterval = 100 * time.Millisecond
+
+// quotaEnforcer enforces the per-table and database-wide byte quotas described by litt.Config.
+//
+// Quotas are checked against the size reported by each table before a write is handed to that table. Since table
+// sizes lag slightly behind writes and concurrent writers are checked independently, quotas are a soft limit that
+// may be overshot by the size of in-flight writes.
+type quotaEnforcer struct {
+	ctx    context.Context
+	logger logging.Logger
+
+	// What to do when a write would exceed a quota.
+	policy types.QuotaPolicy
+
+	// The quota for tables that do not have an entry in tableQuotas. Zero means no quota.
+	defaultTableQuota uint64
+
+	// Per-table quotas, keyed by table name. Zero means no quota.
+	tableQuotas map[string]uint64
+
+	// The database-wide quota. Zero means no quota.
+	dbQuota uint64
+
+	// The maximum amount of time a write may block under types.QuotaPolicyBlock. Zero means no limit.
+	blockTimeout time.Duration
+
+	// Returns all tables currently in the database.
+	tables func() map[string]litt.ManagedTable
+
+	// True if the database has been stopped.
+	stopped *atomic.Bool
+
+	// Metrics for the database, may be nil.
+	metrics *metrics.LittDBMetrics
+
+	// Serializes evictions, so that concurrent writers do not evict more data than is needed.
+	evictionLock sync.Mutex
+}
+
+// newQuotaEnforcer creates a new quota enforcer. Returns nil if the config does not specify any quotas.
+func newQuotaEnforcer(
+	config *litt.Config,
+	tables func() map[string]litt.ManagedTable,
+	stopped *atomic.Bool,
+	dbMetrics *metrics.LittDBMetrics) *quotaEnforcer {
+
+	tableQuotas := make(map[string]uint64, len(config.TableQuotas))
+	anyTableQuota := config.TableQuota > 0
+	for name, quota := range config.TableQuotas {
+		tableQuotas[name] = quota
+		anyTableQuota = anyTableQuota || quota > 0
+	}
+
+	if !anyTableQuota && config.DBQuota == 0 {
+		return nil
+	}
+
+	return &quotaEnforcer{
+		ctx:               config.CTX,
+		logger:            config.Logger,
+		policy:            config.QuotaPolicy,
+		defaultTableQuota: config.TableQuota,
+		tableQuotas:       tableQuotas,
+		dbQuota:           config.DBQuota,
+		blockTimeout:      config.QuotaBlockTimeout,
+		tables:            tables,
+		stopped:           stopped,
+		metrics:           dbMetrics,
+	}
+}
+
+// tableQuota returns the quota for the table with the given name, or zero if the table has no quota.
+func (q *quotaEnforcer) tableQuota(tableName string) uint64 {
+	if quota, ok := q.tableQuotas[tableName]; ok {
+		return quota
+	}
+	return q.defaultTableQuota
+}
+
+// appliesTo returns true if writes to the table with the given name are subject to any quota.
+func (q *quotaEnforcer) appliesTo(tableName string) bool {
+	return q != nil && (q.dbQuota > 0 || q.tableQuota(tableName) > 0)
+}
+
+// enforce is called before writeSize bytes are written to a table. Returns nil if the write may proceed. Depending
+// on the quota policy, this method may block or evict data before returning. If the write may not proceed, the
+// returned error wraps a *litt.QuotaExceededError.
+func (q *quotaEnforcer) enforce(table litt.ManagedTable, writeSize uint64) error {
+	if !q.appliesTo(table.Name()) {
+		return nil
+	}
+
+	violation := q.check(table, writeSize)
+	if violation == nil {
+		return nil
+	}
+
+	switch q.policy {
+	case types.QuotaPolicyBlock:
+		return q.block(table, writeSize, violation)
+	case types.QuotaPolicyEvict:
+		return q.evict(table, writeSize)
+	default:
+		q.metrics.ReportQuotaRejection(table.Name())
+		return violation
+	}
+}
+
+// check returns a non-nil error if writing writeSize bytes to the table would exceed a quota.
+func (q *quotaEnforcer) check(table litt.ManagedTable, writeSize uint64) *litt.QuotaExceededError {
+	if quota := q.tableQuota(table.Name()); quota > 0 {
+		usage := table.Size()
+		if usage+writeSize > quota {
+			return &litt.QuotaExceededError{
+				TableName: table.Name(),
+				Quota:     quota,
+				Usage:     usage,
+				WriteSize: writeSize,
+			}
+		}
+	}
+
+	if q.dbQuota > 0 {
+		usage := uint64(0)
+		for _, t := range q.tables() {
+			usage += t.Size()
+		}
+		if usage+writeSize > q.dbQuota {
+			return &litt.QuotaExceededError{
+				TableName: table.Name(),
+				DBWide:    true,
+				Quota:     q.dbQuota,
+				Usage:     usage,
+				WriteSize: writeSize,
+			}
+		}
+	}
+
+	return nil
+}
+
+// block waits until the write fits within all quotas, the block timeout elapses, or the database is stopped.
+func (q *quotaEnforcer) block(
+	table litt.ManagedTable,
+	writeSize uint64,
+	violation *litt.QuotaExceededError) error {
+
+	if writeSize > violation.Quota {
+		// Waiting will never help, the write is larger than the quota.
+		q.metrics.ReportQuotaRejection(table.Name())
+		return violation
+	}
+
+	start := time.Now()
+	defer func() {
+		q.metrics.ReportQuotaBlockLatency(table.Name(), time.Since(start))
+	}()
+
+	var timeout <-chan time.Time
+	if q.blockTimeout > 0 {
+		timer := time.NewTimer(q.blockTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	ticker := time.NewTicker(quotaPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.ctx.Done():
+			return fmt.Errorf("context cancelled while waiting for quota: %w", violation)
+		case <-timeout:
+			q.metrics.ReportQuotaRejection(table.Name())
+			return violation
+		case <-ticker.C:
+			if q.stopped.Load() {
+				return fmt.Errorf("database stopped while waiting for quota: %w", violation)
+			}
+			violation = q.check(table, writeSize)
+			if violation == nil {
+				return nil
+			}
+		}
+	}
+}
+
+// evict deletes the oldest segments until the write fits within all quotas. If the table quota is exceeded, data
+// is evicted from the table being written to. If the database quota is exceeded, data is evicted from whichever
+// table has the oldest segment.
+func (q *quotaEnforcer) evict(table litt.ManagedTable, writeSize uint64) error {
+	q.evictionLock.Lock()
+	defer q.evictionLock.Unlock()
+
+	// Tables that have no more segments that can be evicted.
+	exhausted := make(map[string]bool)
+
+	for {
+		// Another writer may have freed space while we were waiting for the lock, so always re-check.
+		violation := q.check(table, writeSize)
+		if violation == nil {
+			return nil
+		}
+		if writeSize > violation.Quota {
+			q.metrics.ReportQuotaRejection(table.Name())
+			return violation
+		}
+
+		victim := table
+		bytesToFree := violation.Usage + writeSize - violation.Quota
+		if violation.DBWide {
+			var err error
+			victim, err = q.oldestTable(exhausted)
+			if err != nil {
+				return fmt.Errorf("failed to select table for eviction: %w", err)
+			}
+			if victim == nil {
+				q.metrics.ReportQuotaRejection(table.Name())
+				return violation
+			}
+			// Evict one segment at a time, since the next oldest segment may belong to a different table.
+			bytesToFree = 1
+		} else if exhausted[victim.Name()] {
+			q.metrics.ReportQuotaRejection(table.Name())
+			return violation
+		}
+
+		freed, err := victim.EvictOldestSegments(bytesToFree)
+		if err != nil {
+			return fmt.Errorf("failed to evict segments from table %s: %w", victim.Name(), err)
+		}
+		if freed == 0 {
+			exhausted[victim.Name()] = true
+			continue
+		}
+
+		q.logger.Warnf("Evicted %d bytes from table %s to enforce quota", freed, victim.Name())
+		q.metrics.ReportQuotaEviction(victim.Name(), freed)
+	}
+}
+
+// oldestTable returns the table whose oldest segment was sealed the longest time ago, ignoring tables in the
+// exclusion set and tables without any sealed segments. Returns nil if there is no such table.
+func (q *quotaEnforcer) oldestTable(exclude map[string]bool) (litt.ManagedTable, error) {
+	var oldest litt.ManagedTable
+	var oldestSealTime time.Time
+
+	for name, table := range q.tables() {
+		if exclude[name] {
+			continue
+		}
+
+		info, err := table.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get info for table %s: %w", name, err)
+		}
+		if info.OldestSegmentSealTime.IsZero() {
+			// The oldest segment is not sealed, so nothing can be evicted from this table.
+			continue
+		}
+
+		if oldest == nil || info.OldestSegmentSealTime.Before(oldestSealTime) {
+			oldest = table
+			oldestSealTime = info.OldestSegmentSealTime
+		}
+	}
+
+	return oldest, nil
+}
+
+// reportUtilization reports quota utilization metrics.
+func (q *quotaEnforcer) reportUtilization(tables map[string]litt.ManagedTable) {
+	if q == nil {
+		return
+	}
+
+	dbUsage := uint64(0)
+	for name, table := range tables {
+		usage := table.Size()
+		dbUsage += usage
+		q.metrics.ReportTableQuotaUtilization(name, usage, q.tableQuota(name))
+	}
+	q.metrics.ReportDBQuotaUtilization(dbUsage, q.dbQuota)
+}
+
+var _ litt.ManagedTable = &quotaTable{}
+
+// quotaTable is a wrapper around a table that enforces byte quotas on writes.
+type quotaTable struct {
+	litt.ManagedTable
+
+	// Enforces quotas for this table.
+	quotas *quotaEnforcer
+}
+
+// newQuotaTable wraps a table so that writes to it are subject to quotas.
+func newQuotaTable(base litt.ManagedTable, quotas *quotaEnforcer) *quotaTable {
+	return &quotaTable{
+		ManagedTable: base,
+		quotas:       quotas,
+	}
+}
+
+// unwrapQuotaTable returns the table wrapped by a quotaTable, or the table itself if it is not a quotaTable.
+func unwrapQuotaTable(table litt.ManagedTable) litt.ManagedTable {
+	if wrapper, ok := table.(*quotaTable); ok {
+		return wrapper.ManagedTable
+	}
+	return table
+}
+
+func (t *quotaTable) Put(key []byte, value []byte) error {
+	return t.PutBatch([]*types.KVPair{{Key: key, Value: value}})
+}
+
+func (t *quotaTable) PutBatch(batch []*types.KVPair) error {
+	writeSize := uint64(0)
+	for _, kv := range batch {
+		writeSize += uint64(len(kv.Key) + len(kv.Value))
+	}
+
+	err := t.quotas.enforce(t.ManagedTable, writeSize)
+	if err != nil {
+		return fmt.Errorf("failed to write to table %s: %w", t.Name(), err)
+	}
+
+	return t.ManagedTable.PutBatch(batch)
+}
@@ -0,0 +1,227 @@
+package littbuilder
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/test"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/stretchr/testify/require"
+)
+
+func buildQuotaTestConfig(t *testing.T, directory string, policy types.QuotaPolicy) *litt.Config {
+	config, err := litt.DefaultConfig(directory)
+	require.NoError(t, err)
+	config.Logger = test.GetLogger()
+	config.KeymapType = keymap.MemKeymapType
+	config.Fsync = false
+	config.ShardingFactor = 1
+	config.TargetSegmentFileSize = 1024
+	config.QuotaPolicy = policy
+	return config
+}
+
+// writeUntilError writes values to a table until a write fails, and returns the keys that were written along with
+// the error. Fails the test if maxWrites values are written without error.
+func writeUntilError(
+	t *testing.T,
+	rand *random.TestRandom,
+	table litt.Table,
+	maxWrites int) ([][]byte, error) {
+
+	keys := make([][]byte, 0)
+	for i := 0; i < maxWrites; i++ {
+		key := rand.PrintableBytes(32)
+		err := table.Put(key, rand.PrintableBytes(100))
+		if err != nil {
+			return keys, err
+		}
+		keys = append(keys, key)
+		require.NoError(t, table.Flush())
+	}
+	require.Fail(t, "expected quota to be exceeded")
+	return nil, nil
+}
+
+func TestQuotaReject(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	config := buildQuotaTestConfig(t, t.TempDir(), types.QuotaPolicyReject)
+	config.TableQuota = 4096
+	config.TableQuotas = map[string]uint64{"unlimited": 0}
+
+	db, err := NewDB(config)
+	require.NoError(t, err)
+
+	table, err := db.GetTable("limited")
+	require.NoError(t, err)
+
+	keys, err := writeUntilError(t, rand, table, 1000)
+	var quotaErr *litt.QuotaExceededError
+	require.True(t, errors.As(err, &quotaErr))
+	require.Equal(t, "limited", quotaErr.TableName)
+	require.False(t, quotaErr.DBWide)
+	require.Equal(t, uint64(4096), quotaErr.Quota)
+	require.LessOrEqual(t, table.Size(), uint64(4096))
+
+	// Data written before the quota was reached is untouched.
+	for _, key := range keys {
+		_, ok, err := table.Get(key)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+
+	// Tables exempt from the quota can still be written to.
+	unlimited, err := db.GetTable("unlimited")
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, unlimited.Put(rand.PrintableBytes(32), rand.PrintableBytes(100)))
+	}
+	require.NoError(t, unlimited.Flush())
+
+	// A batch that would exceed a quota is rejected without applying any of its operations.
+	batch := db.NewBatch()
+	batchKey := rand.PrintableBytes(32)
+	batch.Put("unlimited", batchKey, rand.PrintableBytes(100))
+	batch.Put("limited", rand.PrintableBytes(32), rand.PrintableBytes(100))
+	err = batch.Commit()
+	require.True(t, errors.As(err, &quotaErr))
+	exists, err := unlimited.Exists(batchKey)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	require.NoError(t, db.Destroy())
+}
+
+func TestQuotaEvictTable(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	config := buildQuotaTestConfig(t, t.TempDir(), types.QuotaPolicyEvict)
+	config.TableQuota = 4096
+
+	db, err := NewDB(config)
+	require.NoError(t, err)
+
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+
+	keys := make([][]byte, 0)
+	for i := 0; i < 200; i++ {
+		key := rand.PrintableBytes(32)
+		require.NoError(t, table.Put(key, rand.PrintableBytes(100)))
+		require.NoError(t, table.Flush())
+		keys = append(keys, key)
+	}
+
+	// Enough data was written to exceed the quota several times over, so old data must have been evicted.
+	require.LessOrEqual(t, table.Size(), uint64(4096))
+
+	exists, err := table.Exists(keys[0])
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	exists, err = table.Exists(keys[len(keys)-1])
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	require.NoError(t, db.Destroy())
+}
+
+func TestQuotaEvictDBWide(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	config := buildQuotaTestConfig(t, t.TempDir(), types.QuotaPolicyEvict)
+	config.DBQuota = 8192
+
+	db, err := NewDB(config)
+	require.NoError(t, err)
+
+	tableA, err := db.GetTable("a")
+	require.NoError(t, err)
+	tableB, err := db.GetTable("b")
+	require.NoError(t, err)
+
+	// Fill most of the quota with data in table A.
+	keysA := make([][]byte, 0)
+	for tableA.Size() < 6000 {
+		key := rand.PrintableBytes(32)
+		require.NoError(t, tableA.Put(key, rand.PrintableBytes(100)))
+		require.NoError(t, tableA.Flush())
+		keysA = append(keysA, key)
+	}
+
+	// Writing to table B should evict the oldest data, which lives in table A.
+	for i := 0; i < 50; i++ {
+		require.NoError(t, tableB.Put(rand.PrintableBytes(32), rand.PrintableBytes(100)))
+		require.NoError(t, tableB.Flush())
+	}
+
+	require.LessOrEqual(t, db.Size(), uint64(8192))
+	exists, err := tableA.Exists(keysA[0])
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	require.NoError(t, db.Destroy())
+}
+
+func TestQuotaBlock(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	config := buildQuotaTestConfig(t, t.TempDir(), types.QuotaPolicyBlock)
+	config.DBQuota = 4096
+	config.QuotaBlockTimeout = 200 * time.Millisecond
+
+	db, err := NewDB(config)
+	require.NoError(t, err)
+
+	tableA, err := db.GetTable("a")
+	require.NoError(t, err)
+	tableB, err := db.GetTable("b")
+	require.NoError(t, err)
+
+	// Once the quota is full, writes block until the timeout and then fail.
+	start := time.Now()
+	_, err = writeUntilError(t, rand, tableA, 1000)
+	var quotaErr *litt.QuotaExceededError
+	require.True(t, errors.As(err, &quotaErr))
+	require.True(t, quotaErr.DBWide)
+	require.GreaterOrEqual(t, time.Since(start), config.QuotaBlockTimeout)
+
+	// A blocked write proceeds once space is freed.
+	key := rand.PrintableBytes(32)
+	value := rand.PrintableBytes(100)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- tableB.Put(key, value)
+	}()
+
+	select {
+	case err = <-errChan:
+		require.Fail(t, "write should have blocked", "error: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	require.NoError(t, db.DropTable("a"))
+
+	select {
+	case err = <-errChan:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "write should have been unblocked")
+	}
+
+	readValue, ok, err := tableB.Get(key)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, value, readValue)
+
+	require.NoError(t, db.Destroy())
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/docker/go-units"
@@ -72,6 +73,12 @@ type Config struct {
 	// The size of the keymap deletion batch for garbage collection. The default is 10,000.
 	GCBatchSize uint64
 
+	// If non-zero, each table runs a background scrubber that verifies the integrity (i.e. the checksums) of data
+	// on disk, reading at most this many bytes per second. Each scrub pass verifies every immutable segment once,
+	// and passes are started once per GCPeriod. Corrupt segments are reported via logs and metrics. The default is
+	// 0 (scrubbing disabled).
+	ScrubBytesPerSecond uint64
+
 	// The sharding factor for the database. If the sharding factor is greater than 1, then values will be spread
 	// out across multiple files. (Note that individual values will always be written to a single file, but two
 	// different values may be written to different files.) These shard files are spead evenly across the paths
@@ -82,10 +89,43 @@ type Config struct {
 	// The default is 8. Must be at least 1.
 	ShardingFactor uint32
 
+	// The algorithm used to compress values before they are written to disk, for tables that have not had their
+	// compression set. Compression can be set individually on each table by calling Table.SetCompression().
+	// Changing the compression type does not affect data already on disk. The default is types.NoCompression.
+	Compression types.CompressionType
+
 	// The random number generator used for generating sharding salts. The default is a standard rand.New()
 	// seeded by the current time.
 	SaltShaker *rand.Rand
 
+	// The maximum number of bytes each table may occupy on disk. If zero (the default), tables are not subject to a
+	// per-table quota unless they appear in TableQuotas. When a write would cause a table to exceed its quota, the
+	// QuotaPolicy determines what happens.
+	//
+	// Quotas are enforced against Table.Size(), which may lag slightly behind the true size of the table, and
+	// concurrent writers may each be admitted before the other's data is counted. Quotas should therefore be
+	// configured with some headroom below the capacity of the underlying disk.
+	TableQuota uint64
+
+	// Per-table byte quotas, keyed by table name. Entries in this map take precedence over TableQuota. A value
+	// of zero exempts the table from per-table quotas.
+	TableQuotas map[string]uint64
+
+	// The maximum number of bytes that all tables in the database may occupy on disk, combined. If zero
+	// (the default), there is no database-wide quota.
+	DBQuota uint64
+
+	// Determines what happens when a write would exceed a table quota or the database quota. The default is
+	// types.QuotaPolicyReject. With types.QuotaPolicyEvict, the granularity of eviction is a segment, and only
+	// sealed segments can be evicted. A table whose data is entirely contained within its mutable segment cannot
+	// free space by eviction, and writes to it will fail with a QuotaExceededError.
+	QuotaPolicy types.QuotaPolicy
+
+	// The maximum amount of time a write may block when QuotaPolicy is types.QuotaPolicyBlock. If the write still
+	// does not fit within the quota after this amount of time, it fails with a QuotaExceededError. If zero
+	// (the default), writes block until space becomes available or the database context is cancelled.
+	QuotaBlockTimeout time.Duration
+
 	// The size of the cache for tables that have not had their write cache size set. A write cache is used
 	// to store recently written values for fast access. The default is 0 (no cache).
 	// Cache size is in bytes, and includes the size of both the key and the value. Cache size can be set
@@ -165,6 +205,15 @@ type Config struct {
 	// If Flush() is called more frequently than this interval, the flushes may be batched together to improve
 	// performance. If this is set to zero, then no batching is performed and all flushes are executed immediately.
 	MinimumFlushInterval time.Duration
+
+	// If enabled, the database runs an in-process HTTP server that exposes read-only information about the
+	// database (table listings, sizes, key counts, segment boundaries, GC status, and point lookups for keys).
+	// This server never modifies data, and is safe to use on a live node. By default, this is false.
+	AdminServerEnabled bool
+
+	// The address the admin server listens on. Ignored if AdminServerEnabled is false. Since point lookups
+	// expose raw values, this should not be reachable from untrusted networks. The default is "127.0.0.1:9102".
+	AdminServerAddress string
 }
 
 // DefaultConfig returns a Config with default values.
@@ -207,6 +256,7 @@ func DefaultConfigNoPaths() *Config {
 		MetricsPort:              9101,
 		MetricsUpdateInterval:    time.Second,
 		PurgeLocks:               false,
+		AdminServerAddress:       "127.0.0.1:9102",
 	}
 }
 
@@ -252,6 +302,9 @@ func (c *Config) SanityCheck() error {
 	if c.ShardingFactor == 0 {
 		return fmt.Errorf("sharding factor must be at least 1")
 	}
+	if !c.Compression.IsValid() {
+		return fmt.Errorf("unsupported compression type: %s", c.Compression)
+	}
 	if c.ControlChannelSize == 0 {
 		return fmt.Errorf("control channel size must be at least 1")
 	}
@@ -270,9 +323,15 @@ func (c *Config) SanityCheck() error {
 	if c.SaltShaker == nil {
 		return fmt.Errorf("salt shaker cannot be nil")
 	}
+	if !c.QuotaPolicy.IsValid() {
+		return fmt.Errorf("unsupported quota policy: %s", c.QuotaPolicy)
+	}
 	if (c.MetricsEnabled || c.MetricsRegistry != nil) && c.MetricsUpdateInterval == 0 {
 		return fmt.Errorf("metrics update interval must be at least 1 if metrics are enabled")
 	}
+	if c.AdminServerEnabled && c.AdminServerAddress == "" {
+		return fmt.Errorf("admin server address must be provided if the admin server is enabled")
+	}
 
 	return nil
 }
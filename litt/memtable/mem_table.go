@@ -1,7 +1,9 @@
 package memtable
 
 import (
+	"bytes"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -9,6 +11,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/structures"
 	"github.com/Layr-Labs/eigenda/litt"
 	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
 )
 
 var _ litt.ManagedTable = &memTable{}
@@ -38,6 +41,11 @@ type memTable struct {
 	// Keeps track of when data should be deleted.
 	expirationQueue *structures.Queue[*expirationRecord]
 
+	// The expiration record for each key currently in the table. If a key is deleted and then re-written, the
+	// expiration record for the old value is left in the expiration queue, and this map is used to recognize it
+	// as stale.
+	expirationRecords map[string]*expirationRecord
+
 	// Protects access to data and expirationQueue.
 	//
 	// This implementation could be made with smaller granularity locks to improve multithreaded performance,
@@ -52,11 +60,12 @@ type memTable struct {
 func NewMemTable(config *litt.Config, name string) litt.ManagedTable {
 
 	table := &memTable{
-		clock:           config.Clock,
-		name:            name,
-		ttl:             config.TTL,
-		data:            make(map[string][]byte),
-		expirationQueue: structures.NewQueue[*expirationRecord](1024),
+		clock:             config.Clock,
+		name:              name,
+		ttl:               config.TTL,
+		data:              make(map[string][]byte),
+		expirationQueue:   structures.NewQueue[*expirationRecord](1024),
+		expirationRecords: make(map[string]*expirationRecord),
 	}
 
 	if config.GCPeriod > 0 {
@@ -82,6 +91,11 @@ func (m *memTable) Size() uint64 {
 	return 0
 }
 
+func (m *memTable) LogicalSize() uint64 {
+	// Like Size(), only data on disk is counted.
+	return 0
+}
+
 func (m *memTable) Name() string {
 	return m.name
 }
@@ -108,6 +122,24 @@ func (m *memTable) Put(key []byte, value []byte) error {
 	}
 	m.data[stringKey] = value
 	m.expirationQueue.Push(expiration)
+	m.expirationRecords[stringKey] = expiration
+
+	return nil
+}
+
+func (m *memTable) Delete(key []byte) error {
+	return m.DeleteBatch([][]byte{key})
+}
+
+func (m *memTable) DeleteBatch(keys [][]byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, key := range keys {
+		stringKey := string(key)
+		delete(m.data, stringKey)
+		delete(m.expirationRecords, stringKey)
+	}
 
 	return nil
 }
@@ -127,6 +159,10 @@ func (m *memTable) Get(key []byte) (value []byte, exists bool, err error) {
 	return value, exists, err
 }
 
+func (m *memTable) UncachedGet(key []byte) (value []byte, exists bool, err error) {
+	return m.Get(key)
+}
+
 func (m *memTable) CacheAwareGet(key []byte, _ bool) (value []byte, exists bool, hot bool, err error) {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -146,6 +182,37 @@ func (m *memTable) Exists(key []byte) (exists bool, err error) {
 	return exists, nil
 }
 
+func (m *memTable) Iterator() (litt.Iterator, error) {
+	return m.RangeIterator(nil, nil)
+}
+
+func (m *memTable) PrefixIterator(prefix []byte) (litt.Iterator, error) {
+	start, end := util.PrefixToRange(prefix)
+	return m.RangeIterator(start, end)
+}
+
+func (m *memTable) RangeIterator(start []byte, end []byte) (litt.Iterator, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	pairs := make([]*types.KVPair, 0)
+	for key, value := range m.data {
+		keyBytes := []byte(key)
+		if util.IsKeyInRange(keyBytes, start, end) {
+			pairs = append(pairs, &types.KVPair{Key: keyBytes, Value: value})
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		return bytes.Compare(pairs[i].Key, pairs[j].Key) < 0
+	})
+
+	return &memTableIterator{
+		pairs: pairs,
+		index: -1,
+	}, nil
+}
+
 func (m *memTable) Flush() error {
 	// This is a no-op for a memory table. Memory tables are ephemeral by nature.
 	return nil
@@ -164,6 +231,7 @@ func (m *memTable) Destroy() error {
 
 	m.data = make(map[string][]byte)
 	m.expirationQueue.Clear()
+	m.expirationRecords = make(map[string]*expirationRecord)
 
 	return nil
 }
@@ -186,6 +254,31 @@ func (m *memTable) SetShardingFactor(shardingFactor uint32) error {
 	return nil
 }
 
+func (m *memTable) SetCompression(compression types.CompressionType) error {
+	if !compression.IsValid() {
+		return fmt.Errorf("unsupported compression type: %s", compression)
+	}
+	// the memory table does not store data on disk, so there is nothing to compress
+	return nil
+}
+
+func (m *memTable) Info() (*litt.TableInfo, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return &litt.TableInfo{
+		Name:       m.name,
+		KeyCount:   uint64(len(m.data)),
+		KeymapType: "none (in-memory table)",
+		TTL:        m.ttl,
+	}, nil
+}
+
+func (m *memTable) EvictOldestSegments(bytes uint64) (uint64, error) {
+	// the memory table does not store data on disk, so there are no segments to evict
+	return 0, nil
+}
+
 func (m *memTable) RunGC() error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -206,8 +299,48 @@ func (m *memTable) RunGC() error {
 			break
 		}
 		m.expirationQueue.Pop()
+		if m.expirationRecords[expiration.key] != expiration {
+			// The key was deleted (and possibly re-written) after this record was created.
+			continue
+		}
 		delete(m.data, expiration.key)
+		delete(m.expirationRecords, expiration.key)
+	}
+
+	return nil
+}
+
+var _ litt.Iterator = &memTableIterator{}
+
+// memTableIterator iterates over a sorted copy of the data in a memTable.
+type memTableIterator struct {
+	// The key-value pairs to iterate over, sorted by key.
+	pairs []*types.KVPair
+	// The index of the current pair.
+	index int
+}
+
+func (i *memTableIterator) Next() bool {
+	if i.index >= len(i.pairs) {
+		return false
 	}
+	i.index++
+	return i.index < len(i.pairs)
+}
+
+func (i *memTableIterator) Key() []byte {
+	return i.pairs[i.index].Key
+}
 
+func (i *memTableIterator) Value() ([]byte, error) {
+	return i.pairs[i.index].Value, nil
+}
+
+func (i *memTableIterator) Error() error {
 	return nil
 }
+
+func (i *memTableIterator) Release() {
+	i.pairs = nil
+	i.index = 0
+}
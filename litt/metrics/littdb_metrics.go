@@ -28,6 +28,9 @@ type LittDBMetrics struct {
 	// The size of individual tables in the database.
 	tableSizeInBytes *prometheus.GaugeVec
 
+	// The size that individual tables would have if values were not compressed.
+	tableLogicalSizeInBytes *prometheus.GaugeVec
+
 	// The number of keys in individual tables in the database.
 	tableKeyCount *prometheus.GaugeVec
 
@@ -74,6 +77,27 @@ type LittDBMetrics struct {
 	// The latency of garbage collection operations.1
 	garbageCollectionLatency *prometheus.SummaryVec
 
+	// The number of corrupt segments detected by the background scrubber since startup.
+	segmentCorruptionCounter *prometheus.CounterVec
+
+	// The number of bytes read from disk by the background scrubber since startup.
+	scrubbedBytesCounter *prometheus.CounterVec
+
+	// The fraction of each table's byte quota that is currently in use. Only reported for tables with a quota.
+	tableQuotaUtilization *prometheus.GaugeVec
+
+	// The fraction of the database-wide byte quota that is currently in use. Only reported if there is a quota.
+	dbQuotaUtilization prometheus.Gauge
+
+	// The number of writes rejected because they would have exceeded a quota.
+	quotaRejectionCounter *prometheus.CounterVec
+
+	// The amount of time writes spent blocked waiting for quota space to become available.
+	quotaBlockLatency *prometheus.SummaryVec
+
+	// The number of bytes evicted ahead of their TTL in order to enforce quotas.
+	quotaEvictedBytesCounter *prometheus.CounterVec
+
 	// Metrics for the write cache.
 	writeCacheMetrics *cache.CacheMetrics
 
@@ -98,6 +122,15 @@ func NewLittDBMetrics(registry *prometheus.Registry, namespace string) *LittDBMe
 		[]string{"table"},
 	)
 
+	tableLogicalSizeInBytes := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "table_logical_size_bytes",
+			Help:      "The size individual tables would have if values were not compressed, in bytes.",
+		},
+		[]string{"table"},
+	)
+
 	tableKeyCount := promauto.With(registry).NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: namespace,
@@ -243,6 +276,69 @@ func NewLittDBMetrics(registry *prometheus.Registry, namespace string) *LittDBMe
 		[]string{"table"},
 	)
 
+	segmentCorruptionCounter := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "segment_corruption_count",
+			Help:      "The number of corrupt segments detected by the background scrubber since startup.",
+		},
+		[]string{"table"},
+	)
+
+	scrubbedBytesCounter := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "scrubbed_bytes",
+			Help:      "The number of bytes read from disk by the background scrubber since startup.",
+		},
+		[]string{"table"},
+	)
+
+	tableQuotaUtilization := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "table_quota_utilization",
+			Help:      "The fraction of each table's byte quota that is currently in use.",
+		},
+		[]string{"table"},
+	)
+
+	dbQuotaUtilization := promauto.With(registry).NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "db_quota_utilization",
+			Help:      "The fraction of the database-wide byte quota that is currently in use.",
+		},
+	)
+
+	quotaRejectionCounter := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "quota_rejected_writes",
+			Help:      "The number of writes rejected because they would have exceeded a quota.",
+		},
+		[]string{"table"},
+	)
+
+	quotaBlockLatency := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  namespace,
+			Name:       "quota_block_latency_ms",
+			Help:       "The amount of time writes spent blocked waiting for quota space to become available.",
+			Objectives: objectives,
+		},
+		[]string{"table"},
+	)
+
+	quotaEvictedBytesCounter := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "quota_evicted_bytes",
+			Help:      "The number of bytes evicted ahead of their TTL in order to enforce quotas.",
+		},
+		[]string{"table"},
+	)
+
 	writeCacheMetrics := cache.NewCacheMetrics(
 		registry,
 		namespace,
@@ -257,6 +353,7 @@ func NewLittDBMetrics(registry *prometheus.Registry, namespace string) *LittDBMe
 
 	return &LittDBMetrics{
 		tableSizeInBytes:         tableSizeInBytes,
+		tableLogicalSizeInBytes:  tableLogicalSizeInBytes,
 		tableKeyCount:            tableKeyCount,
 		bytesReadCounter:         bytesReadCounter,
 		keysReadCounter:          keysReadCounter,
@@ -270,8 +367,15 @@ func NewLittDBMetrics(registry *prometheus.Registry, namespace string) *LittDBMe
 		flushCount:               flushCount,
 		flushLatency:             flushLatency,
 		garbageCollectionLatency: garbageCollectionLatency,
+		segmentCorruptionCounter: segmentCorruptionCounter,
+		scrubbedBytesCounter:     scrubbedBytesCounter,
 		segmentFlushLatency:      segmentFlushLatency,
 		keymapFlushLatency:       keymapFlushLatency,
+		tableQuotaUtilization:    tableQuotaUtilization,
+		dbQuotaUtilization:       dbQuotaUtilization,
+		quotaRejectionCounter:    quotaRejectionCounter,
+		quotaBlockLatency:        quotaBlockLatency,
+		quotaEvictedBytesCounter: quotaEvictedBytesCounter,
 		writeCacheMetrics:        writeCacheMetrics,
 		readCacheMetrics:         readCacheMetrics,
 	}
@@ -290,6 +394,9 @@ func (m *LittDBMetrics) CollectPeriodicMetrics(tables map[string]litt.ManagedTab
 		tableSize := table.Size()
 		m.tableSizeInBytes.WithLabelValues(tableName).Set(float64(tableSize))
 
+		tableLogicalSize := table.LogicalSize()
+		m.tableLogicalSizeInBytes.WithLabelValues(tableName).Set(float64(tableLogicalSize))
+
 		tableKeyCount := table.KeyCount()
 		m.tableKeyCount.WithLabelValues(tableName).Set(float64(tableKeyCount))
 	}
@@ -371,6 +478,69 @@ func (m *LittDBMetrics) ReportGarbageCollectionLatency(tableName string, latency
 	m.garbageCollectionLatency.WithLabelValues(tableName).Observe(common.ToMilliseconds(latency))
 }
 
+// ReportSegmentCorruption reports that a corrupt segment was detected.
+func (m *LittDBMetrics) ReportSegmentCorruption(tableName string) {
+	if m == nil {
+		return
+	}
+
+	m.segmentCorruptionCounter.WithLabelValues(tableName).Inc()
+}
+
+// ReportScrubbedBytes reports the number of bytes read from disk by the background scrubber.
+func (m *LittDBMetrics) ReportScrubbedBytes(tableName string, bytes uint64) {
+	if m == nil {
+		return
+	}
+
+	m.scrubbedBytesCounter.WithLabelValues(tableName).Add(float64(bytes))
+}
+
+// ReportTableQuotaUtilization reports the number of bytes used by a table relative to its quota.
+func (m *LittDBMetrics) ReportTableQuotaUtilization(tableName string, usage uint64, quota uint64) {
+	if m == nil || quota == 0 {
+		return
+	}
+
+	m.tableQuotaUtilization.WithLabelValues(tableName).Set(float64(usage) / float64(quota))
+}
+
+// ReportDBQuotaUtilization reports the number of bytes used by the database relative to the database-wide quota.
+func (m *LittDBMetrics) ReportDBQuotaUtilization(usage uint64, quota uint64) {
+	if m == nil || quota == 0 {
+		return
+	}
+
+	m.dbQuotaUtilization.Set(float64(usage) / float64(quota))
+}
+
+// ReportQuotaRejection reports that a write was rejected because it would have exceeded a quota.
+func (m *LittDBMetrics) ReportQuotaRejection(tableName string) {
+	if m == nil {
+		return
+	}
+
+	m.quotaRejectionCounter.WithLabelValues(tableName).Inc()
+}
+
+// ReportQuotaBlockLatency reports the amount of time a write spent blocked waiting for quota space.
+func (m *LittDBMetrics) ReportQuotaBlockLatency(tableName string, latency time.Duration) {
+	if m == nil {
+		return
+	}
+
+	m.quotaBlockLatency.WithLabelValues(tableName).Observe(common.ToMilliseconds(latency))
+}
+
+// ReportQuotaEviction reports that data was evicted from a table ahead of its TTL in order to enforce a quota.
+func (m *LittDBMetrics) ReportQuotaEviction(tableName string, bytes uint64) {
+	if m == nil {
+		return
+	}
+
+	m.quotaEvictedBytesCounter.WithLabelValues(tableName).Add(float64(bytes))
+}
+
 func (m *LittDBMetrics) GetWriteCacheMetrics() *cache.CacheMetrics {
 	if m == nil {
 		return nil
@@ -0,0 +1,39 @@
+package litt
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+)
+
+// PutIfAbsentWriter writes values to tables, skipping keys that are already present. A table does not permit a key
+// to be written twice, so callers that may race to write the same key (e.g. caches populated on a miss) must check
+// whether the key exists before writing it. PutIfAbsentWriter makes the check and the write atomic with respect to
+// other writes made through the same PutIfAbsentWriter.
+//
+// The zero value is ready to use. This struct is goroutine safe.
+type PutIfAbsentWriter struct {
+	lock sync.Mutex
+}
+
+// PutIfAbsent writes a value to a table unless the key is already present. Returns true if the value was written.
+// The value is copied, so the caller retains ownership of it. Like Put, the write is not guaranteed to be crash
+// durable until the table is flushed.
+func (w *PutIfAbsentWriter) PutIfAbsent(table Table, key []byte, value []byte) (bool, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	exists, err := table.Exists(key)
+	if err != nil {
+		return false, fmt.Errorf("littdb Exists: %w", err)
+	}
+	if exists {
+		return false, nil
+	}
+
+	err = table.Put(key, bytes.Clone(value))
+	if err != nil {
+		return false, fmt.Errorf("littdb Put: %w", err)
+	}
+	return true, nil
+}
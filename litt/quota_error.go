@@ -0,0 +1,32 @@
+package litt
+
+import "fmt"
+
+// QuotaExceededError is returned by write operations when the write would cause a table or the database to exceed
+// its configured byte quota, and the quota policy does not permit the write to proceed (see Config.QuotaPolicy).
+// Use errors.As to detect this error.
+type QuotaExceededError struct {
+	// The name of the table being written to.
+	TableName string
+
+	// If true, the database-wide quota (Config.DBQuota) was exceeded. If false, the quota of the table was exceeded.
+	DBWide bool
+
+	// The quota that would be exceeded, in bytes.
+	Quota uint64
+
+	// The number of bytes in use at the time the write was attempted.
+	Usage uint64
+
+	// The size of the write, in bytes.
+	WriteSize uint64
+}
+
+func (e *QuotaExceededError) Error() string {
+	scope := fmt.Sprintf("table %s", e.TableName)
+	if e.DBWide {
+		scope = "database"
+	}
+	return fmt.Sprintf("write of %d bytes to table %s would exceed the %s quota (usage: %d bytes, quota: %d bytes)",
+		e.WriteSize, e.TableName, scope, e.Usage, e.Quota)
+}
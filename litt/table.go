@@ -11,14 +11,16 @@ import (
 var TableNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 
 // Table is a key-value store with a namespace that does not overlap with other tables.
-// Values may be written to the table, but once written, they may not be changed or deleted (except via TTL).
+// Values may be written to the table, but once written, they may not be changed. Values leave the table when they
+// expire via TTL, or when they are explicitly deleted.
 //
 // All methods in this interface are thread safe.
 type Table interface {
 	// Name returns the name of the table. Table names are unique across the database.
 	Name() string
 
-	// Put stores a value in the database. May not be used to overwrite an existing value.
+	// Put stores a value in the database. May not be used to overwrite an existing value. A key that has been
+	// deleted may be written again.
 	// Note that when this method returns, data written may not be crash durable on disk
 	// (although the write does have atomicity). In order to ensure crash durability, call Flush().
 	//
@@ -67,9 +69,47 @@ type Table interface {
 	// It is not safe to modify the key byte slice after it is passed to this method.
 	Exists(key []byte) (exists bool, err error)
 
+	// Delete removes a key from the database. Deleting a key that does not exist is a no-op. Once this method
+	// returns, the key is no longer visible to Get() or Exists(). Similar to Put(), the deletion is not guaranteed to
+	// be crash durable until Flush() is called. If the process crashes before the deletion is flushed, the key may
+	// reappear when the database is restarted.
+	//
+	// Deletion is implemented by writing a tombstone. Disk space used by the deleted value is not reclaimed
+	// immediately, but when the segment containing the value is garbage collected.
+	//
+	// It is not safe to modify the key byte slice after it is passed to this method.
+	Delete(key []byte) error
+
+	// DeleteBatch removes multiple keys from the database. Similar to Delete, but allows for multiple keys to be
+	// deleted at once. This may improve performance, but it otherwise has identical properties to a sequence of
+	// Delete calls (i.e. this method does not atomically delete the entire batch).
+	//
+	// It is not safe to modify the key byte slices passed to this function after the call.
+	DeleteBatch(keys [][]byte) error
+
+	// Iterator returns an iterator over all key-value pairs in the table, in ascending lexicographic key order.
+	// The caller is responsible for calling Release() on the returned iterator when done with it.
+	Iterator() (Iterator, error)
+
+	// PrefixIterator returns an iterator over all key-value pairs in the table whose keys start with the given
+	// prefix, in ascending lexicographic key order. The caller is responsible for calling Release() on the returned
+	// iterator when done with it.
+	//
+	// It is not safe to modify the prefix byte slice after it is passed to this method.
+	PrefixIterator(prefix []byte) (Iterator, error)
+
+	// RangeIterator returns an iterator over all key-value pairs in the table with keys in the range [start, end),
+	// in ascending lexicographic key order. A nil start means the range is unbounded below, and a nil end means
+	// the range is unbounded above. The caller is responsible for calling Release() on the returned iterator when
+	// done with it.
+	//
+	// It is not safe to modify the start or end byte slices after they are passed to this method.
+	RangeIterator(start []byte, end []byte) (Iterator, error)
+
 	// Flush ensures that all data written to the database is crash durable on disk. When this method returns,
-	// all data written by Put() operations is guaranteed to be crash durable. Put() operations that overlap with calls
-	// to Flush() may not be crash durable after this method returns.
+	// all data written by Put() operations and all deletions made by Delete() operations are guaranteed to be crash
+	// durable. Put() and Delete() operations that overlap with calls to Flush() may not be crash durable after this
+	// method returns.
 	//
 	// Note that data flushed at the same time is not atomic. If the process crashes mid-flush, some data
 	// being flushed may become persistent, while some may not. Each individual key-value pair is atomic
@@ -86,6 +126,11 @@ type Table interface {
 	// measure size.
 	Size() uint64
 
+	// LogicalSize returns the size that the table would have on disk if no values were compressed. Comparing this
+	// value to Size() reveals the space saved by compression. For tables without compression, this is equal to
+	// Size(). Subject to the same caveats as Size().
+	LogicalSize() uint64
+
 	// KeyCount returns the number of keys in the table.
 	KeyCount() uint64
 
@@ -99,6 +144,12 @@ type Table interface {
 	// writes that can be performed.
 	SetShardingFactor(shardingFactor uint32) error
 
+	// SetCompression sets the algorithm used to compress values before they are written to disk. The new compression
+	// type only applies to data written after this method is called, data already on disk remains readable
+	// regardless of the compression type it was written with. For table implementations that do not store data
+	// on disk, this method does nothing.
+	SetCompression(compression types.CompressionType) error
+
 	// SetWriteCacheSize sets the write cache size, in bytes, for the table. For table implementations without a cache,
 	// this method does nothing. The cache is used to store recently written data. When reading from the table,
 	// if the requested data is present in this cache, the cache is used instead of reading from disk. Reading from the
@@ -136,6 +187,20 @@ type ManagedTable interface {
 	// Destroy cleans up resources used by the table. All data on disk is permanently and unrecoverable deleted.
 	Destroy() error
 
+	// UncachedGet is identical to Get, except that it never reads from or populates any cache. Intended for
+	// inspection tools, which should not change the caching behavior seen by the table's regular readers.
+	UncachedGet(key []byte) (value []byte, exists bool, err error)
+
+	// Info returns high level information about the table. This method is read-only and safe to call concurrently
+	// with all other table operations.
+	Info() (*TableInfo, error)
+
+	// EvictOldestSegments deletes the oldest sealed segments of the table, regardless of TTL, until at least the
+	// requested number of bytes have been freed or no sealed segments remain. Returns the number of bytes freed.
+	// This is used to enforce byte quotas, and blocks until the eviction is complete. For table implementations
+	// that do not store data on disk, this method does nothing.
+	EvictOldestSegments(bytes uint64) (uint64, error)
+
 	// RunGC performs a garbage collection run. This method blocks until that run is complete.
 	// This method is intended for use in tests, where it can be useful to force a garbage collection run to occur
 	// at a specific time.
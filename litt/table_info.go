@@ -0,0 +1,45 @@
+package litt
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt/types"
+)
+
+// TableInfo contains high level information about a table in LittDB.
+type TableInfo struct {
+	// The name of the table.
+	Name string
+	// The number of key-value pairs in the table.
+	KeyCount uint64
+	// The size of the table in bytes.
+	Size uint64
+	// The size the table would have if values were not compressed, in bytes.
+	LogicalSize uint64
+	// If true, the table at the specified path is a snapshot of another table.
+	IsSnapshot bool
+	// The time when the oldest segment was sealed.
+	OldestSegmentSealTime time.Time
+	// The time when the newest segment was sealed.
+	NewestSegmentSealTime time.Time
+	// The index of the oldest segment in the table.
+	LowestSegmentIndex uint32
+	// The index of the newest segment in the table.
+	HighestSegmentIndex uint32
+	// The type of the keymap used by the table. If "", then this table doesn't have a keymap (i.e. it will rebuild
+	// a keymap the next time it is loaded).
+	KeymapType string
+	// The table's TTL. A TTL less than or equal to 0 means that data never expires (i.e. garbage collection is
+	// disabled). Only populated for live tables.
+	TTL time.Duration
+	// The sharding factor used for new segments. Only populated for live tables.
+	ShardingFactor uint32
+	// The compression type used for new segments. Only populated for live tables.
+	Compression types.CompressionType
+	// The time when garbage collection last completed, or the zero time if garbage collection has not yet run.
+	// Only populated for live tables.
+	LastGCTime time.Time
+	// The total number of segments deleted by garbage collection since the table was loaded.
+	// Only populated for live tables.
+	GCSegmentsDeleted uint64
+}
@@ -0,0 +1,132 @@
+package test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/admin"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/test"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/stretchr/testify/require"
+)
+
+// getJSON performs a GET request against the admin server and decodes the JSON response.
+func getJSON(t *testing.T, url string, expectedStatus int, response any) {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+
+	require.Equal(t, expectedStatus, resp.StatusCode)
+	if response != nil {
+		err = json.NewDecoder(resp.Body).Decode(response)
+		require.NoError(t, err)
+	}
+}
+
+func TestAdminServer(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	config, err := litt.DefaultConfig(t.TempDir())
+	require.NoError(t, err)
+	config.Logger = test.GetLogger()
+	config.KeymapType = keymap.UnsafeLevelDBKeymapType
+	config.TargetSegmentFileSize = 100
+	config.Fsync = false
+	config.AdminServerEnabled = true
+	config.AdminServerAddress = "127.0.0.1:0"
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	// The admin server binds to an ephemeral port, so ask the server where it is listening.
+	address := littbuilder.AdminServerAddress(db)
+	require.NotEmpty(t, address)
+	baseURL := fmt.Sprintf("http://%s", address)
+
+	tableA, err := db.GetTable("a")
+	require.NoError(t, err)
+	tableB, err := db.GetTable("b")
+	require.NoError(t, err)
+	err = tableB.SetTTL(time.Hour)
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableBytes(32)
+		value := rand.PrintableVariableBytes(1, 64)
+		err = tableA.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+	}
+	err = tableA.Flush()
+	require.NoError(t, err)
+	err = tableB.(litt.ManagedTable).RunGC()
+	require.NoError(t, err)
+
+	listing := &admin.TableListing{}
+	getJSON(t, baseURL+"/tables", http.StatusOK, listing)
+	require.Equal(t, 2, len(listing.Tables))
+	require.Equal(t, "a", listing.Tables[0].Name)
+	require.Equal(t, uint64(100), listing.Tables[0].KeyCount)
+	require.Equal(t, "b", listing.Tables[1].Name)
+	require.Equal(t, uint64(100), listing.KeyCount)
+	require.Equal(t, db.Size(), listing.Size)
+
+	details := &admin.TableDetails{}
+	getJSON(t, baseURL+"/tables/a", http.StatusOK, details)
+	require.Equal(t, "a", details.Name)
+	require.Equal(t, uint64(100), details.KeyCount)
+	require.Equal(t, tableA.Size(), details.Size)
+	require.Less(t, details.LowestSegmentIndex, details.HighestSegmentIndex)
+	require.Equal(t, string(keymap.UnsafeLevelDBKeymapType), details.KeymapType)
+	require.False(t, details.GCEnabled)
+
+	details = &admin.TableDetails{}
+	getJSON(t, baseURL+"/tables/b", http.StatusOK, details)
+	require.True(t, details.GCEnabled)
+	require.Equal(t, time.Hour.String(), details.TTL)
+	require.False(t, details.LastGCTime.IsZero())
+
+	getJSON(t, baseURL+"/tables/does-not-exist", http.StatusNotFound, nil)
+
+	// The admin server must not create tables.
+	listing = &admin.TableListing{}
+	getJSON(t, baseURL+"/tables", http.StatusOK, listing)
+	require.Equal(t, 2, len(listing.Tables))
+
+	for key, expectedValue := range expectedValues {
+		lookup := &admin.KeyLookup{}
+		getJSON(t, baseURL+"/tables/a/keys/"+hex.EncodeToString([]byte(key)), http.StatusOK, lookup)
+		require.True(t, lookup.Exists)
+		require.Equal(t, len(expectedValue), lookup.ValueSize)
+		require.Empty(t, lookup.Value)
+
+		lookup = &admin.KeyLookup{}
+		getJSON(t, baseURL+"/tables/a/keys/"+hex.EncodeToString([]byte(key))+"?value=true",
+			http.StatusOK, lookup)
+		require.Equal(t, hex.EncodeToString(expectedValue), lookup.Value)
+	}
+
+	lookup := &admin.KeyLookup{}
+	getJSON(t, baseURL+"/tables/a/keys/"+hex.EncodeToString(rand.PrintableBytes(32)), http.StatusOK, lookup)
+	require.False(t, lookup.Exists)
+
+	getJSON(t, baseURL+"/tables/a/keys/not-hex", http.StatusBadRequest, nil)
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	// The server should be shut down when the DB is closed.
+	_, err = http.Get(baseURL + "/tables")
+	require.Error(t, err)
+}
@@ -0,0 +1,99 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/stretchr/testify/require"
+)
+
+func batchTest(t *testing.T, builder *dbBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	db, err := builder.builder(t, directory)
+	require.NoError(t, err)
+
+	tableCount := rand.Int32Range(2, 8)
+	tableNames := make([]string, 0, tableCount)
+	for i := int32(0); i < tableCount; i++ {
+		tableNames = append(tableNames, fmt.Sprintf("table-%d-%s", i, rand.PrintableBytes(8)))
+	}
+
+	// first key is table name, second key is the key in the kv-pair
+	expectedValues := make(map[string]map[string][]byte)
+	for _, tableName := range tableNames {
+		expectedValues[tableName] = make(map[string][]byte)
+	}
+
+	iterations := 100
+	for i := 0; i < iterations; i++ {
+		batch := db.NewBatch()
+
+		operationCount := rand.Int32Range(1, 20)
+		for j := int32(0); j < operationCount; j++ {
+			tableName := tableNames[rand.Intn(len(tableNames))]
+
+			if len(expectedValues[tableName]) > 0 && rand.BoolWithProbability(0.2) {
+				for key := range expectedValues[tableName] {
+					batch.Delete(tableName, []byte(key))
+					delete(expectedValues[tableName], key)
+					break
+				}
+			} else {
+				key := rand.PrintableVariableBytes(32, 64)
+				value := rand.PrintableVariableBytes(1, 128)
+				batch.Put(tableName, key, value)
+				expectedValues[tableName][string(key)] = value
+			}
+		}
+		require.Equal(t, int(operationCount), batch.Size())
+
+		err = batch.Commit()
+		require.NoError(t, err)
+
+		// A batch may only be committed once.
+		require.Error(t, batch.Commit())
+
+		// Once in a while, verify that all expected values are present.
+		if rand.BoolWithProbability(0.1) || i == iterations-1 {
+			for tableName, tableValues := range expectedValues {
+				table, err := db.GetTable(tableName)
+				require.NoError(t, err)
+				require.Equal(t, uint64(len(tableValues)), table.KeyCount())
+
+				for expectedKey, expectedValue := range tableValues {
+					value, ok, err := table.Get([]byte(expectedKey))
+					require.NoError(t, err)
+					require.True(t, ok)
+					require.Equal(t, expectedValue, value)
+				}
+			}
+		}
+	}
+
+	// Invalid table names are rejected.
+	batch := db.NewBatch()
+	batch.Put("this table name is invalid!", []byte("key"), []byte("value"))
+	require.Error(t, batch.Commit())
+
+	err = db.Destroy()
+	require.NoError(t, err)
+
+	// ensure that the test directory is empty (i.e. no batch journals were left behind)
+	entries, err := os.ReadDir(directory)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+	for _, builder := range builders {
+		t.Run(builder.name, func(t *testing.T) {
+			batchTest(t, builder)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/stretchr/testify/require"
+)
+
+var compressionTypes = []types.CompressionType{
+	types.NoCompression,
+	types.SnappyCompression,
+	types.ZstdCompression,
+}
+
+// compressibleValue generates a random value that compresses well.
+func compressibleValue(rand *random.TestRandom) []byte {
+	return bytes.Repeat(rand.PrintableBytes(8), int(rand.Int32Range(1, 64)))
+}
+
+func compressionTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(time.Now, tableName, directory)
+	require.NoError(t, err)
+
+	err = table.SetCompression(types.CompressionType(99))
+	require.Error(t, err)
+
+	expectedValues := make(map[string][]byte)
+
+	iterations := 1000
+	for i := 0; i < iterations; i++ {
+		// Once in a while, change the compression type.
+		if rand.BoolWithProbability(0.05) {
+			err = table.SetCompression(compressionTypes[rand.Intn(len(compressionTypes))])
+			require.NoError(t, err)
+		}
+
+		key := rand.PrintableVariableBytes(32, 64)
+		value := compressibleValue(rand)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+
+		// Once in a while, flush the table.
+		if rand.BoolWithProbability(0.1) {
+			err = table.Flush()
+			require.NoError(t, err)
+		}
+
+		// Once in a while, verify the contents of the table.
+		if rand.BoolWithProbability(0.01) || i == iterations-1 /* always check on the last iteration */ {
+			for expectedKey, expectedValue := range expectedValues {
+				value, ok, err := table.Get([]byte(expectedKey))
+				require.NoError(t, err)
+				require.True(t, ok)
+				require.Equal(t, expectedValue, value)
+			}
+		}
+	}
+
+	err = table.Flush()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, table.LogicalSize(), table.Size())
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestCompression(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			compressionTest(t, tb)
+		})
+	}
+}
+
+// Verifies that data written with different compression types remains readable after a restart, and that the
+// compression setting of a table is preserved across restarts.
+func compressionRestartTest(t *testing.T, builder *dbBuilder) {
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	db, err := builder.builder(t, directory)
+	require.NoError(t, err)
+
+	tableName := rand.String(8)
+	table, err := db.GetTable(tableName)
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	writeValues := func(table litt.Table) {
+		for i := 0; i < 100; i++ {
+			key := rand.PrintableVariableBytes(32, 64)
+			value := compressibleValue(rand)
+			err = table.Put(key, value)
+			require.NoError(t, err)
+			expectedValues[string(key)] = value
+		}
+		err = table.Flush()
+		require.NoError(t, err)
+	}
+	verifyValues := func(table litt.Table) {
+		for expectedKey, expectedValue := range expectedValues {
+			value, ok, err := table.Get([]byte(expectedKey))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+
+	// Write data using each compression type.
+	for _, compression := range compressionTypes {
+		err = table.SetCompression(compression)
+		require.NoError(t, err)
+		writeValues(table)
+	}
+	verifyValues(table)
+
+	// The last compression type set was zstd, so the uncompressed size should exceed the on-disk size.
+	require.Greater(t, db.LogicalSize(), db.Size())
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	// Restart the DB. All data should still be readable.
+	db, err = builder.builder(t, directory)
+	require.NoError(t, err)
+	table, err = db.GetTable(tableName)
+	require.NoError(t, err)
+	verifyValues(table)
+
+	// The compression type should be preserved across the restart, so new data should be compressed.
+	sizeBefore := table.Size()
+	logicalSizeBefore := table.LogicalSize()
+	require.Greater(t, logicalSizeBefore, sizeBefore)
+	writeValues(table)
+	require.Greater(t, table.LogicalSize()-logicalSizeBefore, table.Size()-sizeBefore)
+	verifyValues(table)
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
+
+func TestCompressionRestart(t *testing.T) {
+	t.Parallel()
+	for _, builder := range restartableBuilders {
+		t.Run(builder.name, func(t *testing.T) {
+			compressionRestartTest(t, builder)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/stretchr/testify/require"
+)
+
+func deleteTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(time.Now, tableName, directory)
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	deletedValues := make(map[string]struct{})
+
+	iterations := 1000
+	for i := 0; i < iterations; i++ {
+		choice := rand.Float64()
+
+		if choice < 0.5 || len(expectedValues) == 0 {
+			// Write some data.
+			batchSize := rand.Int32Range(1, 10)
+			batch := make([]*types.KVPair, 0, batchSize)
+			for j := int32(0); j < batchSize; j++ {
+				key := rand.PrintableVariableBytes(32, 64)
+				value := rand.PrintableVariableBytes(1, 128)
+				batch = append(batch, &types.KVPair{Key: key, Value: value})
+				expectedValues[string(key)] = value
+			}
+			err = table.PutBatch(batch)
+			require.NoError(t, err)
+		} else if choice < 0.7 {
+			// Delete a single key.
+			for key := range expectedValues {
+				err = table.Delete([]byte(key))
+				require.NoError(t, err)
+				delete(expectedValues, key)
+				deletedValues[key] = struct{}{}
+				break
+			}
+		} else if choice < 0.8 {
+			// Delete a batch of keys. Include a key that was never written and a duplicate key, both of
+			// which should be ignored.
+			batchSize := rand.Intn(10) + 1
+			keys := make([][]byte, 0, batchSize+2)
+			for key := range expectedValues {
+				if len(keys) >= batchSize {
+					break
+				}
+				keys = append(keys, []byte(key))
+			}
+			keys = append(keys, rand.PrintableVariableBytes(32, 64))
+			keys = append(keys, keys[0])
+
+			err = table.DeleteBatch(keys)
+			require.NoError(t, err)
+			for _, key := range keys {
+				if _, ok := expectedValues[string(key)]; ok {
+					delete(expectedValues, string(key))
+					deletedValues[string(key)] = struct{}{}
+				}
+			}
+		} else if choice < 0.9 && len(deletedValues) > 0 {
+			// Write a new value for a key that was previously deleted.
+			for key := range deletedValues {
+				value := rand.PrintableVariableBytes(1, 128)
+				err = table.Put([]byte(key), value)
+				require.NoError(t, err)
+				expectedValues[key] = value
+				delete(deletedValues, key)
+				break
+			}
+		}
+
+		// Once in a while, flush the table.
+		if rand.BoolWithProbability(0.1) {
+			err = table.Flush()
+			require.NoError(t, err)
+		}
+
+		// Once in a while, sleep for a short time to give the garbage collector a chance to run.
+		if rand.BoolWithProbability(0.01) {
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		// Once in a while, verify the contents of the table.
+		if rand.BoolWithProbability(0.01) || i == iterations-1 /* always check on the last iteration */ {
+			for expectedKey, expectedValue := range expectedValues {
+				value, ok, err := table.Get([]byte(expectedKey))
+				require.NoError(t, err)
+				require.True(t, ok)
+				require.Equal(t, expectedValue, value)
+
+				ok, err = table.Exists([]byte(expectedKey))
+				require.NoError(t, err)
+				require.True(t, ok)
+			}
+
+			for deletedKey := range deletedValues {
+				_, ok, err := table.Get([]byte(deletedKey))
+				require.NoError(t, err)
+				require.False(t, ok)
+
+				ok, err = table.Exists([]byte(deletedKey))
+				require.NoError(t, err)
+				require.False(t, ok)
+			}
+
+			require.Equal(t, uint64(len(expectedValues)), table.KeyCount())
+
+			// Deleted keys should not be visible to iterators.
+			iterator, err := table.Iterator()
+			require.NoError(t, err)
+			keys, _ := drainIterator(t, iterator)
+			require.Equal(t, len(expectedValues), len(keys))
+			for _, key := range keys {
+				_, ok := expectedValues[key]
+				require.True(t, ok)
+			}
+		}
+	}
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			deleteTest(t, tb)
+		})
+	}
+}
+
+// Verifies that garbage collecting a segment containing a deleted value does not remove a newer value written
+// for the same key after the deletion.
+func deleteGarbageCollectionTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	startTime := rand.Time()
+	var fakeTime atomic.Pointer[time.Time]
+	fakeTime.Store(&startTime)
+	clock := func() time.Time {
+		return *fakeTime.Load()
+	}
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(clock, tableName, directory)
+	require.NoError(t, err)
+
+	ttl := time.Minute
+	err = table.SetTTL(ttl)
+	require.NoError(t, err)
+
+	// Write some values, then delete them.
+	oldKeys := make([][]byte, 0)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		err = table.Put(key, rand.PrintableVariableBytes(1, 128))
+		require.NoError(t, err)
+		oldKeys = append(oldKeys, key)
+	}
+	err = table.DeleteBatch(oldKeys)
+	require.NoError(t, err)
+	err = table.Flush()
+	require.NoError(t, err)
+
+	// Advance the clock so that the new values are written at a later time than the old values.
+	newTime := startTime.Add(ttl / 2)
+	fakeTime.Store(&newTime)
+
+	// Write new values for half of the deleted keys.
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < len(oldKeys)/2; i++ {
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(oldKeys[i], value)
+		require.NoError(t, err)
+		expectedValues[string(oldKeys[i])] = value
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	// Advance the clock so that the old values (and the tombstones) expire, but the new values do not.
+	newTime = startTime.Add(ttl + ttl/4)
+	fakeTime.Store(&newTime)
+	err = table.RunGC()
+	require.NoError(t, err)
+
+	for i, key := range oldKeys {
+		value, ok, err := table.Get(key)
+		require.NoError(t, err)
+		if i < len(oldKeys)/2 {
+			require.True(t, ok)
+			require.Equal(t, expectedValues[string(key)], value)
+		} else {
+			require.False(t, ok)
+		}
+	}
+	require.Equal(t, uint64(len(expectedValues)), table.KeyCount())
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestDeleteGarbageCollection(t *testing.T) {
+	t.Parallel()
+	for _, tb := range noCacheTableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			deleteGarbageCollectionTest(t, tb)
+		})
+	}
+}
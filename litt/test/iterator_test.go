@@ -0,0 +1,232 @@
+package test
+
+import (
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/stretchr/testify/require"
+)
+
+// drainIterator reads all key-value pairs from an iterator and releases it.
+func drainIterator(t *testing.T, iterator litt.Iterator) (keys []string, values map[string][]byte) {
+	keys = make([]string, 0)
+	values = make(map[string][]byte)
+	for iterator.Next() {
+		key := string(iterator.Key())
+		value, err := iterator.Value()
+		require.NoError(t, err)
+		keys = append(keys, key)
+		values[key] = value
+	}
+	require.NoError(t, iterator.Error())
+	iterator.Release()
+	return keys, values
+}
+
+// expectedKeysInRange returns the sorted subset of keys in the range [start, end).
+func expectedKeysInRange(sortedKeys []string, start []byte, end []byte) []string {
+	result := make([]string, 0)
+	for _, key := range sortedKeys {
+		if util.IsKeyInRange([]byte(key), start, end) {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+func iteratorTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(time.Now, tableName, directory)
+	require.NoError(t, err)
+
+	// Use a small alphabet of prefixes so that prefix scans return non-trivial results.
+	prefixes := []string{"alpha-", "beta-", "gamma-", "delta-"}
+
+	expectedValues := make(map[string][]byte)
+
+	iterations := 500
+	for i := 0; i < iterations; i++ {
+		prefix := prefixes[rand.Intn(len(prefixes))]
+		key := []byte(prefix + string(rand.PrintableVariableBytes(8, 16)))
+		if _, ok := expectedValues[string(key)]; ok {
+			continue
+		}
+		value := rand.PrintableVariableBytes(1, 128)
+
+		err = table.PutBatch([]*types.KVPair{{Key: key, Value: value}})
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+
+		// Flush once in a while so that the iterator has to merge flushed and unflushed data.
+		if rand.BoolWithProbability(0.1) {
+			err = table.Flush()
+			require.NoError(t, err)
+		}
+
+		if rand.BoolWithProbability(0.02) || i == iterations-1 {
+			sortedKeys := make([]string, 0, len(expectedValues))
+			for k := range expectedValues {
+				sortedKeys = append(sortedKeys, k)
+			}
+			sort.Strings(sortedKeys)
+
+			// Full scan.
+			iterator, err := table.Iterator()
+			require.NoError(t, err)
+			keys, values := drainIterator(t, iterator)
+			require.Equal(t, sortedKeys, keys)
+			for k, v := range values {
+				require.Equal(t, expectedValues[k], v)
+			}
+
+			// Prefix scan.
+			scanPrefix := []byte(prefixes[rand.Intn(len(prefixes))])
+			start, end := util.PrefixToRange(scanPrefix)
+			iterator, err = table.PrefixIterator(scanPrefix)
+			require.NoError(t, err)
+			keys, values = drainIterator(t, iterator)
+			require.Equal(t, expectedKeysInRange(sortedKeys, start, end), keys)
+			for k, v := range values {
+				require.Equal(t, expectedValues[k], v)
+			}
+
+			// Range scan.
+			start = []byte(sortedKeys[rand.Intn(len(sortedKeys))])
+			end = []byte(sortedKeys[rand.Intn(len(sortedKeys))])
+			if string(start) > string(end) {
+				start, end = end, start
+			}
+			iterator, err = table.RangeIterator(start, end)
+			require.NoError(t, err)
+			keys, _ = drainIterator(t, iterator)
+			require.Equal(t, expectedKeysInRange(sortedKeys, start, end), keys)
+
+			// Unbounded range scans.
+			iterator, err = table.RangeIterator(start, nil)
+			require.NoError(t, err)
+			keys, _ = drainIterator(t, iterator)
+			require.Equal(t, expectedKeysInRange(sortedKeys, start, nil), keys)
+
+			iterator, err = table.RangeIterator(nil, end)
+			require.NoError(t, err)
+			keys, _ = drainIterator(t, iterator)
+			require.Equal(t, expectedKeysInRange(sortedKeys, nil, end), keys)
+		}
+	}
+
+	// Data written after an iterator is created should not change the set of keys that were present when the
+	// iterator was created, and values for those keys must remain readable.
+	iterator, err := table.Iterator()
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		err = table.Put([]byte("zzz-"+rand.String(8)), rand.PrintableVariableBytes(1, 128))
+		require.NoError(t, err)
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	count := 0
+	for iterator.Next() {
+		expectedValue, ok := expectedValues[string(iterator.Key())]
+		require.True(t, ok)
+		value, err := iterator.Value()
+		require.NoError(t, err)
+		require.Equal(t, expectedValue, value)
+		count++
+	}
+	require.NoError(t, iterator.Error())
+	require.Equal(t, len(expectedValues), count)
+	iterator.Release()
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestIterator(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			iteratorTest(t, tb)
+		})
+	}
+}
+
+// Verifies that an open iterator prevents the garbage collector from deleting the data it references.
+func iteratorGarbageCollectionTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	startTime := rand.Time()
+	var fakeTime atomic.Pointer[time.Time]
+	fakeTime.Store(&startTime)
+	clock := func() time.Time {
+		return *fakeTime.Load()
+	}
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(clock, tableName, directory)
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	iterator, err := table.Iterator()
+	require.NoError(t, err)
+
+	// Expire all data.
+	err = table.SetTTL(time.Second)
+	require.NoError(t, err)
+	newTime := startTime.Add(time.Hour)
+	fakeTime.Store(&newTime)
+	err = table.RunGC()
+	require.NoError(t, err)
+
+	// A fresh iterator should see that data has been garbage collected.
+	freshIterator, err := table.Iterator()
+	require.NoError(t, err)
+	freshKeys, _ := drainIterator(t, freshIterator)
+	require.Less(t, len(freshKeys), len(expectedValues))
+
+	// The original iterator should still be able to read everything.
+	count := 0
+	for iterator.Next() {
+		value, err := iterator.Value()
+		require.NoError(t, err)
+		require.Equal(t, expectedValues[string(iterator.Key())], value)
+		count++
+	}
+	require.NoError(t, iterator.Error())
+	require.Equal(t, len(expectedValues), count)
+	iterator.Release()
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestIteratorGarbageCollection(t *testing.T) {
+	t.Parallel()
+	for _, tb := range noCacheTableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			iteratorGarbageCollectionTest(t, tb)
+		})
+	}
+}
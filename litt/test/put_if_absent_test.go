@@ -0,0 +1,69 @@
+package test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutIfAbsent(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	config, err := litt.DefaultConfig(t.TempDir())
+	require.NoError(t, err)
+	config.Fsync = false
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close()) }()
+	table, err := db.GetTable("test")
+	require.NoError(t, err)
+
+	var writer litt.PutIfAbsentWriter
+
+	// Concurrent writers of the same key don't fail, and exactly one of them writes its value.
+	key := rand.PrintableBytes(32)
+	writerCount := 8
+	values := make([][]byte, writerCount)
+	var writtenCount atomic.Int32
+	var writtenIndex atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < writerCount; i++ {
+		values[i] = rand.PrintableBytes(64)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			written, err := writer.PutIfAbsent(table, key, values[i])
+			require.NoError(t, err)
+			if written {
+				writtenCount.Add(1)
+				writtenIndex.Store(int32(i))
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), writtenCount.Load())
+
+	value, exists, err := table.Get(key)
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, values[writtenIndex.Load()], value)
+
+	// The written value is a copy, so the caller may reuse its buffer.
+	otherKey := rand.PrintableBytes(32)
+	otherValue := rand.PrintableBytes(64)
+	expectedValue := append([]byte(nil), otherValue...)
+	written, err := writer.PutIfAbsent(table, otherKey, otherValue)
+	require.NoError(t, err)
+	require.True(t, written)
+	otherValue[0] ^= 0xff
+	value, exists, err = table.Get(otherKey)
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, expectedValue, value)
+}
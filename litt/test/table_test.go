@@ -552,3 +552,37 @@ func TestInvalidTableName(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, table)
 }
+
+func TestUncachedGet(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	baseTable, err := buildMemTable(time.Now, "test", t.TempDir())
+	require.NoError(t, err)
+
+	writeCache := cache.NewFIFOCache[string, []byte](500, nil, nil)
+	readCache := cache.NewFIFOCache[string, []byte](500, nil, nil)
+	table := tablecache.NewCachedTable(baseTable, writeCache, readCache, nil)
+
+	// Write directly to the base table, so that the value is in neither cache.
+	key := rand.PrintableBytes(32)
+	value := rand.PrintableBytes(32)
+	require.NoError(t, baseTable.Put(key, value))
+
+	readValue, exists, err := table.UncachedGet(key)
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, value, readValue)
+	require.Equal(t, 0, readCache.Size())
+
+	_, exists, err = table.UncachedGet(rand.PrintableBytes(32))
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// A regular read populates the read cache.
+	readValue, exists, err = table.Get(key)
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, value, readValue)
+	require.Equal(t, 1, readCache.Size())
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CompressionType describes the algorithm used to compress values before they are written to disk.
+type CompressionType uint8
+
+const (
+	// NoCompression means that values are written to disk as-is.
+	NoCompression CompressionType = 0
+
+	// SnappyCompression compresses values using snappy. Snappy is very fast, but has a lower compression ratio
+	// than zstd.
+	SnappyCompression CompressionType = 1
+
+	// ZstdCompression compresses values using zstd. Zstd has a better compression ratio than snappy, at the cost
+	// of additional CPU time.
+	ZstdCompression CompressionType = 2
+)
+
+// String returns the name of the compression type.
+func (c CompressionType) String() string {
+	switch c {
+	case NoCompression:
+		return "none"
+	case SnappyCompression:
+		return "snappy"
+	case ZstdCompression:
+		return "zstd"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(c))
+	}
+}
+
+// IsValid returns true if this is a known compression type.
+func (c CompressionType) IsValid() bool {
+	return c <= ZstdCompression
+}
+
+// ParseCompressionType parses a compression type from its name (i.e. "none", "snappy", or "zstd").
+func ParseCompressionType(name string) (CompressionType, error) {
+	switch strings.ToLower(name) {
+	case "", "none":
+		return NoCompression, nil
+	case "snappy":
+		return SnappyCompression, nil
+	case "zstd":
+		return ZstdCompression, nil
+	default:
+		return NoCompression, fmt.Errorf("unknown compression type: %s", name)
+	}
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QuotaPolicy describes what the database does when a write would cause a table or the database as a whole to
+// exceed its byte quota.
+type QuotaPolicy uint8
+
+const (
+	// QuotaPolicyReject causes writes that would exceed a quota to fail with a litt.QuotaExceededError.
+	QuotaPolicyReject QuotaPolicy = 0
+
+	// QuotaPolicyBlock causes writes that would exceed a quota to block until enough space has been freed
+	// (e.g. by TTL based garbage collection or by dropping a table), applying backpressure to the writer.
+	QuotaPolicyBlock QuotaPolicy = 1
+
+	// QuotaPolicyEvict causes the oldest segments to be deleted ahead of their TTL until the write fits within
+	// the quota.
+	QuotaPolicyEvict QuotaPolicy = 2
+)
+
+// String returns the name of the quota policy.
+func (p QuotaPolicy) String() string {
+	switch p {
+	case QuotaPolicyReject:
+		return "reject"
+	case QuotaPolicyBlock:
+		return "block"
+	case QuotaPolicyEvict:
+		return "evict"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(p))
+	}
+}
+
+// IsValid returns true if this is a known quota policy.
+func (p QuotaPolicy) IsValid() bool {
+	return p <= QuotaPolicyEvict
+}
+
+// ParseQuotaPolicy parses a quota policy from its name (i.e. "reject", "block", or "evict").
+func ParseQuotaPolicy(name string) (QuotaPolicy, error) {
+	switch strings.ToLower(name) {
+	case "", "reject":
+		return QuotaPolicyReject, nil
+	case "block":
+		return QuotaPolicyBlock, nil
+	case "evict":
+		return QuotaPolicyEvict, nil
+	default:
+		return QuotaPolicyReject, fmt.Errorf("unknown quota policy: %s", name)
+	}
+}
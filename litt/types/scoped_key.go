@@ -6,6 +6,9 @@ type ScopedKey struct {
 	Key []byte
 	// The location where the value associated with the key is stored.
 	Address Address
-	// The length of the value associated with the key.
+	// The length of the value associated with the key, as stored on disk (i.e. after compression, if any).
 	ValueSize uint32
+	// If true, then this is a tombstone, i.e. a record that the key has been deleted. A tombstone has no value,
+	// and its address refers only to the segment that contains the tombstone (the offset is always zero).
+	Tombstone bool
 }
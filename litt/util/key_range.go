@@ -0,0 +1,40 @@
+package util
+
+import "bytes"
+
+// PrefixToRange converts a key prefix into an equivalent half-open key range [start, end). Every key that starts
+// with the prefix is contained in the range, and every key in the range starts with the prefix. If the prefix is
+// empty, the returned range is unbounded in both directions (i.e. both start and end are nil). If the prefix is
+// made up entirely of 0xff bytes, there is no finite upper bound and the returned end is nil.
+func PrefixToRange(prefix []byte) (start []byte, end []byte) {
+	if len(prefix) == 0 {
+		return nil, nil
+	}
+
+	start = prefix
+
+	// The smallest key greater than all keys with the prefix is obtained by incrementing the last byte that
+	// is not 0xff and truncating everything after it.
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xff {
+			end = make([]byte, i+1)
+			copy(end, prefix)
+			end[i]++
+			return start, end
+		}
+	}
+
+	return start, nil
+}
+
+// IsKeyInRange returns true if the key is in the half-open range [start, end). A nil start means the range is
+// unbounded below, and a nil end means the range is unbounded above.
+func IsKeyInRange(key []byte, start []byte, end []byte) bool {
+	if start != nil && bytes.Compare(key, start) < 0 {
+		return false
+	}
+	if end != nil && bytes.Compare(key, end) >= 0 {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// ChunkRepairServer is an HTTP server that lets operators trigger a chunk repair on a running node, e.g. right after
+// replacing a failed disk, instead of waiting for the next background repair or restarting the node.
+//
+// The following endpoint is supported:
+//
+//   - POST /repair-chunks: finds the missing bundles of blobs that are still live and rebuilds them. The request
+//     returns once the repair is done, and reports the number of bundles repaired. Cancelling the request
+//     interrupts the repair. Returns 409 if a repair is already in progress.
+//
+// The server should only be reachable by the operator, since repairs are expensive.
+type ChunkRepairServer struct {
+	logger logging.Logger
+
+	// Performs the repairs.
+	repairer *ChunkRepairer
+
+	// The listener the server is bound to.
+	listener net.Listener
+
+	// The underlying HTTP server.
+	server *http.Server
+}
+
+// ChunkRepairResult is the JSON response for the repair endpoint.
+type ChunkRepairResult struct {
+	RepairedCount int `json:"repairedCount"`
+}
+
+// NewChunkRepairServer creates a new chunk repair server listening on the given address (e.g. "127.0.0.1:9103") and
+// starts serving requests in the background. The caller is responsible for calling Close() when the server is no
+// longer needed.
+func NewChunkRepairServer(
+	logger logging.Logger,
+	address string,
+	repairer *ChunkRepairer,
+) (*ChunkRepairServer, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+
+	s := &ChunkRepairServer{
+		logger:   logger,
+		repairer: repairer,
+		listener: listener,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /repair-chunks", s.handleRepairChunks)
+
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	logger.Infof("Starting chunk repair server at %s", listener.Addr())
+	go func() {
+		err := s.server.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Errorf("chunk repair server error: %v", err)
+		}
+	}()
+
+	return s, nil
+}
+
+// Address returns the address the server is listening on.
+func (s *ChunkRepairServer) Address() string {
+	return s.listener.Addr().String()
+}
+
+// Close stops the server.
+func (s *ChunkRepairServer) Close() error {
+	err := s.server.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close chunk repair server: %w", err)
+	}
+	return nil
+}
+
+func (s *ChunkRepairServer) handleRepairChunks(w http.ResponseWriter, r *http.Request) {
+	repaired, err := s.repairer.RepairMissingBundles(r.Context())
+	if errors.Is(err, errRepairInProgress) {
+		s.writeError(w, http.StatusConflict, err)
+		return
+	}
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, &ChunkRepairResult{RepairedCount: repaired})
+}
+
+func (s *ChunkRepairServer) writeJSON(w http.ResponseWriter, status int, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		s.logger.Errorf("failed to write chunk repair server response: %v", err)
+	}
+}
+
+func (s *ChunkRepairServer) writeError(w http.ResponseWriter, status int, err error) {
+	s.writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,100 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/test"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/stretchr/testify/require"
+)
+
+// postRepairChunks sends a repair request to the chunk repair server, and decodes the JSON response.
+func postRepairChunks(t *testing.T, baseURL string, expectedStatus int, response any) {
+	resp, err := http.Post(baseURL+"/repair-chunks", "application/json", nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+
+	require.Equal(t, expectedStatus, resp.StatusCode)
+	if response != nil {
+		err = json.NewDecoder(resp.Body).Decode(response)
+		require.NoError(t, err)
+	}
+}
+
+func TestChunkRepairServer(t *testing.T) {
+	rand := random.NewTestRandom()
+	store := newTestValidatorStore(t)
+
+	chainState, err := coremock.MakeChainDataMock(map[core.QuorumID]int{0: 4})
+	require.NoError(t, err)
+	var blobVersionParams atomic.Pointer[corev2.BlobVersionParameterMap]
+	blobVersionParams.Store(corev2.NewBlobVersionParameterMap(
+		map[corev2.BlobVersion]*core.BlobVersionParameters{0: {
+			NumChunks:       8192,
+			CodingRate:      8,
+			MaxNumOperators: 2048,
+		}}))
+
+	// Two blobs whose bundles were lost.
+	records := []*BlobRecord{randomBlobRecord(rand), randomBlobRecord(rand)}
+	require.NoError(t, store.StoreBlobRecords(records))
+
+	data := make([]byte, 16*encoding.BYTES_PER_SYMBOL)
+	validatorClient := &fakeValidatorClient{data: data}
+	repairer := NewChunkRepairer(
+		test.GetLogger(),
+		store,
+		chainState,
+		validatorClient,
+		fakeChunkEncoder{},
+		coremock.MakeOperatorId(1),
+		&blobVersionParams,
+		nil,
+		time.Now)
+
+	server, err := NewChunkRepairServer(test.GetLogger(), "127.0.0.1:0", repairer)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, server.Close()) }()
+	baseURL := fmt.Sprintf("http://%s", server.Address())
+
+	result := &ChunkRepairResult{}
+	postRepairChunks(t, baseURL, http.StatusOK, result)
+	require.Equal(t, 2, result.RepairedCount)
+	for _, record := range records {
+		blobKey, err := record.BlobHeader.BlobKey()
+		require.NoError(t, err)
+		bundleKey, err := BundleKey(blobKey, core.QuorumID(0))
+		require.NoError(t, err)
+		exists, err := store.BundleExists(bundleKey)
+		require.NoError(t, err)
+		require.True(t, exists)
+	}
+
+	// Nothing is left to repair.
+	postRepairChunks(t, baseURL, http.StatusOK, result)
+	require.Equal(t, 0, result.RepairedCount)
+
+	// Requests are rejected while another repair is running.
+	repairer.repairLock.Lock()
+	errorResponse := make(map[string]string)
+	postRepairChunks(t, baseURL, http.StatusConflict, &errorResponse)
+	require.Contains(t, errorResponse["error"], "already in progress")
+	repairer.repairLock.Unlock()
+
+	// Only POST is supported.
+	resp, err := http.Get(baseURL + "/repair-chunks")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
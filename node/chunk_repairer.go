@@ -0,0 +1,443 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/validator"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/v2/kzg/prover"
+	"github.com/Layr-Labs/eigenda/encoding/v2/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/encoding/v2/rs"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// errRepairInProgress is returned by RepairMissingBundles if another repair is already running.
+var errRepairInProgress = errors.New("a chunk repair is already in progress")
+
+// errBlobNotAssigned is returned by RepairBlob if the blob has no chunks assigned to this validator.
+var errBlobNotAssigned = errors.New("blob is not assigned to this validator")
+
+// The delay before a blob that failed to be repaired is retried. The delay doubles with each consecutive failure,
+// up to maxRepairRetryDelay.
+const minRepairRetryDelay = time.Minute
+
+// The maximum delay before a blob that failed to be repaired is retried.
+const maxRepairRetryDelay = time.Hour
+
+// The interval at which repaired bundles that have outlived the records of their blobs are deleted.
+const repairedBundleExpiryInterval = time.Minute
+
+// repairFailure tracks a blob that failed to be repaired, so that it is retried with backoff.
+type repairFailure struct {
+	// The number of consecutive failed repair attempts.
+	attempts int
+	// The blob isn't retried before this time.
+	nextAttempt time.Time
+}
+
+// ChunkEncoder encodes a blob into chunks, including their proofs. Implemented by the v2 KZG prover.
+type ChunkEncoder interface {
+	// GetFrames encodes a blob into frames. The i-th frame returned is the chunk with index indices[i].
+	GetFrames(
+		ctx context.Context,
+		inputFr []fr.Element,
+		params encoding.EncodingParams,
+	) (frames []*encoding.Frame, indices []uint32, err error)
+}
+
+// ChunkRepairer rebuilds the bundles of blobs that are still live, but that are missing from the validator store
+// (e.g. after a disk failure). A missing bundle is rebuilt by reconstructing the blob from chunks held by other
+// validators, re-encoding the blob, and storing the chunks assigned to this validator.
+type ChunkRepairer struct {
+	logger logging.Logger
+
+	// The store containing this validator's chunks, and the records of the blobs they belong to.
+	store ValidatorStore
+
+	// Used to look up the chunk assignments of a blob.
+	chainState core.ChainState
+
+	// Used to reconstruct blobs from the chunks held by other validators.
+	validatorClient validator.ValidatorClient
+
+	// Used to re-encode reconstructed blobs.
+	encoder ChunkEncoder
+
+	// The ID of this validator.
+	operatorID core.OperatorID
+
+	// The blob version parameters, kept up to date by the node.
+	blobVersionParams *atomic.Pointer[corev2.BlobVersionParameterMap]
+
+	// Metrics for the node. No-op if nil.
+	metrics *Metrics
+
+	// Returns the current time.
+	timeSource func() time.Time
+
+	// Held while a repair is running, so that background and on-demand repairs don't rebuild the same bundles.
+	repairLock sync.Mutex
+
+	// Blobs that failed to be repaired, and when they may be retried. Only accessed while holding repairLock.
+	failures map[corev2.BlobKey]*repairFailure
+}
+
+// NewChunkRepairer creates a new ChunkRepairer.
+func NewChunkRepairer(
+	logger logging.Logger,
+	store ValidatorStore,
+	chainState core.ChainState,
+	validatorClient validator.ValidatorClient,
+	encoder ChunkEncoder,
+	operatorID core.OperatorID,
+	blobVersionParams *atomic.Pointer[corev2.BlobVersionParameterMap],
+	metrics *Metrics,
+	timeSource func() time.Time,
+) *ChunkRepairer {
+	return &ChunkRepairer{
+		logger:            logger.With("component", "ChunkRepairer"),
+		store:             store,
+		chainState:        chainState,
+		validatorClient:   validatorClient,
+		encoder:           encoder,
+		operatorID:        operatorID,
+		blobVersionParams: blobVersionParams,
+		metrics:           metrics,
+		timeSource:        timeSource,
+		failures:          make(map[corev2.BlobKey]*repairFailure),
+	}
+}
+
+// buildChunkRepairer creates the ChunkRepairer used by the node to rebuild missing bundles.
+func (n *Node) buildChunkRepairer(reg *prometheus.Registry, verifier *verifier.Verifier) (*ChunkRepairer, error) {
+	rsEncoder, err := rs.NewEncoder(n.Logger, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rs encoder: %w", err)
+	}
+
+	kzgProver, err := prover.NewProver(n.Logger, prover.KzgConfigFromV1Config(&n.Config.EncoderConfig), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prover: %w", err)
+	}
+
+	validatorClient := validator.NewValidatorClient(
+		n.Logger,
+		n.Transactor,
+		n.ChainState,
+		rsEncoder,
+		verifier,
+		validator.DefaultClientConfig(),
+		validator.NewValidatorClientMetrics(reg))
+
+	return NewChunkRepairer(
+		n.Logger,
+		n.ValidatorStore,
+		n.ChainState,
+		validatorClient,
+		kzgProver,
+		n.Config.ID,
+		&n.BlobVersionParams,
+		n.Metrics,
+		time.Now), nil
+}
+
+// startExpiringRepairedBundles deletes repaired bundles that have outlived the records of their blobs every interval
+// in the background, until the context is cancelled. Repaired bundles don't get a full TTL, so this runs even if
+// chunk repairs are disabled, since bundles may have been repaired by an earlier run of the node.
+func startExpiringRepairedBundles(
+	ctx context.Context,
+	logger logging.Logger,
+	store ValidatorStore,
+	interval time.Duration,
+) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				deleted, err := store.DeleteExpiredRepairedBundles()
+				if err != nil {
+					logger.Error("failed to delete expired repaired bundles", "err", err)
+				} else if deleted > 0 {
+					logger.Info("deleted expired repaired bundles", "count", deleted)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Start runs RepairMissingBundles every interval in the background, until the context is cancelled.
+func (r *ChunkRepairer) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				_, err := r.RepairMissingBundles(ctx)
+				if errors.Is(err, errRepairInProgress) {
+					r.logger.Debug("skipping background chunk repair, a repair is already in progress")
+				} else if err != nil {
+					r.logger.Error("failed to repair missing bundles", "err", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// RepairMissingBundles finds the blobs whose bundles are missing from the store, and rebuilds them. A blob that
+// can't be repaired is logged and skipped, so that it doesn't prevent other blobs from being repaired, and isn't
+// retried until its backoff delay has elapsed. The records of blobs that turn out not to be assigned to this
+// validator are deleted. Returns the number of bundles repaired. Only one repair runs at a time,
+// errRepairInProgress is returned if another repair is already running.
+func (r *ChunkRepairer) RepairMissingBundles(ctx context.Context) (int, error) {
+	if !r.repairLock.TryLock() {
+		return 0, errRepairInProgress
+	}
+	defer r.repairLock.Unlock()
+
+	start := r.timeSource()
+
+	// Look for missing bundles before repairing any, so that the iterator isn't held open while blobs are
+	// downloaded (an open iterator prevents expired data from being garbage collected).
+	missing := make([]*BlobRecord, 0)
+	recordCount := 0
+	backedOff := 0
+	missingKeys := make(map[corev2.BlobKey]struct{})
+	err := r.store.ForEachBlobRecord(func(record *BlobRecord) error {
+		recordCount++
+
+		blobKey, err := record.BlobHeader.BlobKey()
+		if err != nil {
+			return fmt.Errorf("failed to get blob key: %w", err)
+		}
+
+		// The current sampling scheme will store the same chunks for all quorums, so we always use quorum 0 as
+		// the quorum key in storage.
+		bundleKey, err := BundleKey(blobKey, core.QuorumID(0))
+		if err != nil {
+			return fmt.Errorf("failed to get bundle key: %w", err)
+		}
+
+		exists, err := r.store.BundleExists(bundleKey)
+		if err != nil {
+			return fmt.Errorf("failed to check bundle for blob %s: %w", blobKey.Hex(), err)
+		}
+		if exists {
+			return nil
+		}
+
+		missingKeys[blobKey] = struct{}{}
+		if failure, ok := r.failures[blobKey]; ok && start.Before(failure.nextAttempt) {
+			backedOff++
+			return nil
+		}
+		missing = append(missing, record)
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to find missing bundles: %w", err)
+	}
+
+	// Forget blobs that have been repaired or whose records have expired.
+	for blobKey := range r.failures {
+		if _, ok := missingKeys[blobKey]; !ok {
+			delete(r.failures, blobKey)
+		}
+	}
+
+	if len(missing) == 0 {
+		r.logger.Debug("no missing bundles to repair", "blobCount", recordCount, "backedOffCount", backedOff)
+		return 0, nil
+	}
+	r.logger.Info("repairing missing bundles",
+		"blobCount", recordCount, "missingCount", len(missing), "backedOffCount", backedOff)
+
+	repaired := 0
+	failed := 0
+	unassigned := make([]corev2.BlobKey, 0)
+	for _, record := range missing {
+		if ctx.Err() != nil {
+			return repaired, fmt.Errorf("repair interrupted: %w", ctx.Err())
+		}
+
+		blobKey, err := record.BlobHeader.BlobKey()
+		if err != nil {
+			return repaired, fmt.Errorf("failed to get blob key: %w", err)
+		}
+
+		err = r.RepairBlob(ctx, record)
+		if errors.Is(err, errBlobRecordExpired) {
+			// The blob expired while others were being repaired, there is nothing left to repair.
+			delete(r.failures, blobKey)
+			continue
+		}
+		if errors.Is(err, errBlobNotAssigned) {
+			unassigned = append(unassigned, blobKey)
+			delete(r.failures, blobKey)
+			continue
+		}
+		if err != nil {
+			r.logger.Warn("failed to repair bundle", "err", err)
+			r.reportBundleRepair("failure")
+			r.recordFailure(blobKey)
+			failed++
+			continue
+		}
+		r.reportBundleRepair("success")
+		delete(r.failures, blobKey)
+		repaired++
+	}
+
+	if len(unassigned) > 0 {
+		// There is nothing to rebuild for these blobs, so their records are dropped rather than checked every run.
+		err = r.store.DeleteBlobRecords(unassigned)
+		if err != nil {
+			r.logger.Warn("failed to delete records of unassigned blobs", "count", len(unassigned), "err", err)
+		}
+	}
+
+	r.logger.Info("finished repairing missing bundles",
+		"repairedCount", repaired,
+		"failedCount", failed,
+		"unassignedCount", len(unassigned),
+		"duration", r.timeSource().Sub(start))
+
+	return repaired, nil
+}
+
+// recordFailure records a failed repair attempt for a blob, and schedules its next attempt.
+func (r *ChunkRepairer) recordFailure(blobKey corev2.BlobKey) {
+	failure, ok := r.failures[blobKey]
+	if !ok {
+		failure = &repairFailure{}
+		r.failures[blobKey] = failure
+	}
+	failure.attempts++
+
+	delay := minRepairRetryDelay
+	for i := 1; i < failure.attempts && delay < maxRepairRetryDelay; i++ {
+		delay *= 2
+	}
+	failure.nextAttempt = r.timeSource().Add(min(delay, maxRepairRetryDelay))
+}
+
+// RepairBlob rebuilds the bundle this validator holds for a blob, and writes it to the store. The repaired bundle
+// expires along with the record of its blob. Returns an error wrapping errBlobNotAssigned if no chunks of the blob
+// are assigned to this validator, or wrapping errBlobRecordExpired if the blob expired before it was repaired.
+func (r *ChunkRepairer) RepairBlob(ctx context.Context, record *BlobRecord) error {
+	header := record.BlobHeader
+
+	blobKey, err := header.BlobKey()
+	if err != nil {
+		return fmt.Errorf("failed to get blob key: %w", err)
+	}
+
+	blobVersionParams := r.blobVersionParams.Load()
+	if blobVersionParams == nil {
+		return fmt.Errorf("blob version params is nil")
+	}
+	blobParams, ok := blobVersionParams.Get(header.BlobVersion)
+	if !ok {
+		return fmt.Errorf("blob version %d not found", header.BlobVersion)
+	}
+
+	operatorState, err := r.chainState.GetOperatorState(ctx, uint(record.ReferenceBlockNumber), header.QuorumNumbers)
+	if err != nil {
+		return fmt.Errorf("failed to get operator state for blob %s: %w", blobKey.Hex(), err)
+	}
+
+	assignments, err := corev2.GetAssignmentsForBlob(operatorState, blobParams, header.QuorumNumbers)
+	if err != nil {
+		return fmt.Errorf("failed to get assignments for blob %s: %w", blobKey.Hex(), err)
+	}
+	assignment, ok := assignments[r.operatorID]
+	if !ok || len(assignment.Indices) == 0 {
+		return fmt.Errorf("%w: blob %s", errBlobNotAssigned, blobKey.Hex())
+	}
+
+	headerWithHashedPayment, err := header.GetBlobHeaderWithHashedPayment()
+	if err != nil {
+		return fmt.Errorf("failed to get blob header with hashed payment: %w", err)
+	}
+
+	data, err := r.validatorClient.GetBlob(ctx, headerWithHashedPayment, record.ReferenceBlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to reconstruct blob %s from other validators: %w", blobKey.Hex(), err)
+	}
+
+	symbols, err := rs.ToFrArray(data)
+	if err != nil {
+		return fmt.Errorf("failed to convert blob %s to field elements: %w", blobKey.Hex(), err)
+	}
+
+	encodingParams, err := corev2.GetEncodingParams(header.BlobCommitments.Length, blobParams)
+	if err != nil {
+		return fmt.Errorf("failed to get encoding params for blob %s: %w", blobKey.Hex(), err)
+	}
+
+	frames, indices, err := r.encoder.GetFrames(ctx, symbols, encodingParams)
+	if err != nil {
+		return fmt.Errorf("failed to encode blob %s: %w", blobKey.Hex(), err)
+	}
+
+	framesByIndex := make(map[uint32]*encoding.Frame, len(frames))
+	for i, index := range indices {
+		framesByIndex[index] = frames[i]
+	}
+
+	// The chunks of a bundle are ordered by their index in the assignment, just like the bundles downloaded
+	// from the relays.
+	bundle := make(core.Bundle, 0, len(assignment.Indices))
+	for _, index := range assignment.Indices {
+		frame, ok := framesByIndex[index]
+		if !ok {
+			return fmt.Errorf("chunk %d of blob %s missing from encoding", index, blobKey.Hex())
+		}
+		bundle = append(bundle, frame)
+	}
+
+	bundleBytes, err := bundle.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize bundle for blob %s: %w", blobKey.Hex(), err)
+	}
+
+	bundleKey, err := BundleKey(blobKey, core.QuorumID(0))
+	if err != nil {
+		return fmt.Errorf("failed to get bundle key: %w", err)
+	}
+
+	err = r.store.StoreRepairedBundle(record, &BundleToStore{
+		BundleKey:   bundleKey,
+		BundleBytes: bundleBytes,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store bundle for blob %s: %w", blobKey.Hex(), err)
+	}
+
+	return nil
+}
+
+func (r *ChunkRepairer) reportBundleRepair(status string) {
+	if r.metrics == nil {
+		return
+	}
+	r.metrics.RepairedBundles.WithLabelValues(status).Inc()
+}
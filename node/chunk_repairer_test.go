@@ -0,0 +1,478 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/test"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/docker/go-units"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeValidatorClient returns the same data for every blob, except for the blobs it is told to fail.
+type fakeValidatorClient struct {
+	data         []byte
+	failingBlobs map[corev2.BlobKey]struct{}
+	calls        atomic.Int32
+}
+
+func (c *fakeValidatorClient) GetBlob(
+	_ context.Context,
+	blobHeader *corev2.BlobHeaderWithHashedPayment,
+	_ uint64,
+) ([]byte, error) {
+	c.calls.Add(1)
+
+	blobKey, err := blobHeader.BlobKey()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := c.failingBlobs[blobKey]; ok {
+		return nil, errors.New("not enough chunks")
+	}
+	return c.data, nil
+}
+
+// fakeChunkEncoder encodes each chunk as a single coefficient equal to the chunk's index, and returns the chunks
+// in reverse order.
+type fakeChunkEncoder struct{}
+
+func (fakeChunkEncoder) GetFrames(
+	_ context.Context,
+	_ []fr.Element,
+	params encoding.EncodingParams,
+) ([]*encoding.Frame, []uint32, error) {
+	frames := make([]*encoding.Frame, params.NumChunks)
+	indices := make([]uint32, params.NumChunks)
+	for i := range frames {
+		index := uint32(params.NumChunks) - 1 - uint32(i)
+		frames[i] = &encoding.Frame{Coeffs: []fr.Element{fr.NewElement(uint64(index))}}
+		indices[i] = index
+	}
+	return frames, indices, nil
+}
+
+func randomBlobRecord(rand *random.TestRandom) *BlobRecord {
+	return &BlobRecord{
+		BlobHeader: &corev2.BlobHeader{
+			BlobVersion: 0,
+			BlobCommitments: encoding.BlobCommitments{
+				Commitment:       &encoding.G1Commitment{},
+				LengthCommitment: &encoding.G2Commitment{},
+				LengthProof:      &encoding.G2Commitment{},
+				Length:           16,
+			},
+			QuorumNumbers: []core.QuorumID{0},
+			PaymentMetadata: core.PaymentMetadata{
+				AccountID:         rand.Address(),
+				Timestamp:         rand.Int64Range(1, 1000),
+				CumulativePayment: big.NewInt(100),
+			},
+		},
+		ReferenceBlockNumber: uint64(rand.Uint32()),
+	}
+}
+
+func newTestValidatorStoreConfig(chunksPath string, blobRecordsPath string) *Config {
+	return &Config{
+		GetChunksHotCacheReadLimitMB:  units.GiB,
+		GetChunksHotBurstLimitMB:      units.GiB,
+		GetChunksColdCacheReadLimitMB: units.GiB,
+		GetChunksColdBurstLimitMB:     units.GiB,
+		LittDBStoragePaths:            []string{chunksPath},
+		BlobRecordsStoragePaths:       []string{blobRecordsPath},
+	}
+}
+
+func newTestValidatorStore(t *testing.T) ValidatorStore {
+	t.Helper()
+
+	config := newTestValidatorStoreConfig(t.TempDir(), t.TempDir())
+	store, err := NewValidatorStore(test.GetLogger(), config, time.Now, 2*time.Hour, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop())
+	})
+
+	return store
+}
+
+func TestBlobRecords(t *testing.T) {
+	rand := random.NewTestRandom()
+	store := newTestValidatorStore(t)
+
+	expectedRecords := make(map[corev2.BlobKey]*BlobRecord)
+	for i := 0; i < 3; i++ {
+		records := make([]*BlobRecord, 0)
+		for j := 0; j < rand.IntRange(1, 5); j++ {
+			record := randomBlobRecord(rand)
+			blobKey, err := record.BlobHeader.BlobKey()
+			require.NoError(t, err)
+			expectedRecords[blobKey] = record
+			records = append(records, record)
+		}
+		require.NoError(t, store.StoreBlobRecords(records))
+	}
+
+	foundRecords := make(map[corev2.BlobKey]*BlobRecord)
+	err := store.ForEachBlobRecord(func(record *BlobRecord) error {
+		blobKey, err := record.BlobHeader.BlobKey()
+		require.NoError(t, err)
+		foundRecords[blobKey] = record
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, len(expectedRecords), len(foundRecords))
+	for blobKey, expectedRecord := range expectedRecords {
+		foundRecord, ok := foundRecords[blobKey]
+		require.True(t, ok)
+		require.Equal(t, expectedRecord.ReferenceBlockNumber, foundRecord.ReferenceBlockNumber)
+	}
+
+	// Iteration stops at the first error.
+	expectedErr := errors.New("stop")
+	calls := 0
+	err = store.ForEachBlobRecord(func(record *BlobRecord) error {
+		calls++
+		return expectedErr
+	})
+	require.ErrorIs(t, err, expectedErr)
+	require.Equal(t, 1, calls)
+}
+
+func TestBlobRecordsSurviveLostChunks(t *testing.T) {
+	rand := random.NewTestRandom()
+	chunksPath := t.TempDir()
+	config := newTestValidatorStoreConfig(chunksPath, t.TempDir())
+
+	store, err := NewValidatorStore(test.GetLogger(), config, time.Now, 2*time.Hour, nil)
+	require.NoError(t, err)
+
+	record := randomBlobRecord(rand)
+	blobKey, err := record.BlobHeader.BlobKey()
+	require.NoError(t, err)
+	bundleKey, err := BundleKey(blobKey, core.QuorumID(0))
+	require.NoError(t, err)
+	_, err = store.StoreBatch([]*BundleToStore{{BundleKey: bundleKey, BundleBytes: rand.Bytes(64)}})
+	require.NoError(t, err)
+	require.NoError(t, store.StoreBlobRecords([]*BlobRecord{record}))
+	require.NoError(t, store.Stop())
+
+	// Wipe the chunks volume.
+	require.NoError(t, os.RemoveAll(chunksPath))
+
+	store, err = NewValidatorStore(test.GetLogger(), config, time.Now, 2*time.Hour, nil)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Stop()) }()
+
+	exists, err := store.BundleExists(bundleKey)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	foundKeys := make([]corev2.BlobKey, 0)
+	err = store.ForEachBlobRecord(func(record *BlobRecord) error {
+		blobKey, err := record.BlobHeader.BlobKey()
+		require.NoError(t, err)
+		foundKeys = append(foundKeys, blobKey)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []corev2.BlobKey{blobKey}, foundKeys)
+}
+
+func TestBlobRecordsStoragePaths(t *testing.T) {
+	rand := random.NewTestRandom()
+	chunksPath := t.TempDir()
+
+	// Blob records can't share a directory with the chunks.
+	for _, blobRecordsPath := range []string{chunksPath, chunksPath + "/", filepath.Join(chunksPath, "records")} {
+		config := newTestValidatorStoreConfig(chunksPath, blobRecordsPath)
+		_, err := NewValidatorStore(test.GetLogger(), config, time.Now, 2*time.Hour, nil)
+		require.ErrorContains(t, err, "overlaps", blobRecordsPath)
+	}
+	config := newTestValidatorStoreConfig(filepath.Join(chunksPath, "chunks"), chunksPath)
+	_, err := NewValidatorStore(test.GetLogger(), config, time.Now, 2*time.Hour, nil)
+	require.ErrorContains(t, err, "overlaps")
+
+	// Without blob records storage paths, blob records aren't stored.
+	config = newTestValidatorStoreConfig(chunksPath, "")
+	config.BlobRecordsStoragePaths = nil
+	store, err := NewValidatorStore(test.GetLogger(), config, time.Now, 2*time.Hour, nil)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Stop()) }()
+	require.NoError(t, store.StoreBlobRecords([]*BlobRecord{randomBlobRecord(rand)}))
+	err = store.ForEachBlobRecord(func(record *BlobRecord) error {
+		return nil
+	})
+	require.Error(t, err)
+}
+
+func TestRepairedBundlesExpireWithBlobRecords(t *testing.T) {
+	rand := random.NewTestRandom()
+	const ttl = 2 * time.Hour
+	var now atomic.Pointer[time.Time]
+	start := time.Unix(0, rand.Int64Range(1, 1<<62))
+	now.Store(&start)
+	timeSource := func() time.Time { return *now.Load() }
+	advance := func(d time.Duration) {
+		next := now.Load().Add(d)
+		now.Store(&next)
+	}
+
+	config := newTestValidatorStoreConfig(t.TempDir(), t.TempDir())
+	store, err := NewValidatorStore(test.GetLogger(), config, timeSource, ttl, nil)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Stop()) }()
+
+	record := randomBlobRecord(rand)
+	require.NoError(t, store.StoreBlobRecords([]*BlobRecord{record}))
+	var storedRecord *BlobRecord
+	err = store.ForEachBlobRecord(func(record *BlobRecord) error {
+		storedRecord = record
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, storedRecord)
+	require.True(t, start.Equal(storedRecord.StoredAt))
+
+	blobKey, err := record.BlobHeader.BlobKey()
+	require.NoError(t, err)
+	bundleKey, err := BundleKey(blobKey, core.QuorumID(0))
+	require.NoError(t, err)
+
+	// The bundle is repaired halfway through the lifetime of its blob.
+	advance(ttl / 2)
+	bundle := &BundleToStore{BundleKey: bundleKey, BundleBytes: rand.Bytes(64)}
+	require.NoError(t, store.StoreRepairedBundle(storedRecord, bundle))
+
+	// The repaired bundle is kept until the record of its blob expires, not for a full TTL after the repair.
+	advance(ttl/2 - time.Second)
+	deleted, err := store.DeleteExpiredRepairedBundles()
+	require.NoError(t, err)
+	require.Equal(t, 0, deleted)
+	exists, err := store.BundleExists(bundleKey)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	advance(time.Second)
+	deleted, err = store.DeleteExpiredRepairedBundles()
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted)
+	exists, err = store.BundleExists(bundleKey)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	deleted, err = store.DeleteExpiredRepairedBundles()
+	require.NoError(t, err)
+	require.Equal(t, 0, deleted)
+
+	// Expired records are skipped, and can't be repaired.
+	err = store.ForEachBlobRecord(func(record *BlobRecord) error {
+		return errors.New("expired record visited")
+	})
+	require.NoError(t, err)
+	err = store.StoreRepairedBundle(storedRecord, bundle)
+	require.ErrorIs(t, err, errBlobRecordExpired)
+}
+
+func TestRepairMissingBundles(t *testing.T) {
+	ctx := t.Context()
+	rand := random.NewTestRandom()
+	store := newTestValidatorStore(t)
+
+	chainState, err := coremock.MakeChainDataMock(map[core.QuorumID]int{0: 4})
+	require.NoError(t, err)
+	operatorID := coremock.MakeOperatorId(1)
+
+	blobParams := &core.BlobVersionParameters{
+		NumChunks:       8192,
+		CodingRate:      8,
+		MaxNumOperators: 2048,
+	}
+	var blobVersionParams atomic.Pointer[corev2.BlobVersionParameterMap]
+	blobVersionParams.Store(corev2.NewBlobVersionParameterMap(
+		map[corev2.BlobVersion]*core.BlobVersionParameters{0: blobParams}))
+
+	// The first blob's bundle is present, the others were lost.
+	records := make([]*BlobRecord, 4)
+	bundleKeys := make([][]byte, len(records))
+	for i := range records {
+		records[i] = randomBlobRecord(rand)
+		blobKey, err := records[i].BlobHeader.BlobKey()
+		require.NoError(t, err)
+		bundleKeys[i], err = BundleKey(blobKey, core.QuorumID(0))
+		require.NoError(t, err)
+	}
+	require.NoError(t, store.StoreBlobRecords(records))
+	presentBundle := rand.Bytes(64)
+	_, err = store.StoreBatch([]*BundleToStore{{BundleKey: bundleKeys[0], BundleBytes: presentBundle}})
+	require.NoError(t, err)
+
+	// The last blob can't be reconstructed.
+	failingKey, err := records[3].BlobHeader.BlobKey()
+	require.NoError(t, err)
+	// Zeroing the most significant byte of each symbol keeps it a valid field element.
+	data := rand.Bytes(16 * encoding.BYTES_PER_SYMBOL)
+	for i := 0; i < len(data); i += encoding.BYTES_PER_SYMBOL {
+		data[i] = 0
+	}
+	validatorClient := &fakeValidatorClient{
+		data:         data,
+		failingBlobs: map[corev2.BlobKey]struct{}{failingKey: {}},
+	}
+
+	now := time.Now()
+	repairer := NewChunkRepairer(
+		test.GetLogger(),
+		store,
+		chainState,
+		validatorClient,
+		fakeChunkEncoder{},
+		operatorID,
+		&blobVersionParams,
+		nil,
+		func() time.Time { return now })
+
+	// Only one repair runs at a time.
+	repairer.repairLock.Lock()
+	_, err = repairer.RepairMissingBundles(ctx)
+	require.ErrorIs(t, err, errRepairInProgress)
+	repairer.repairLock.Unlock()
+	require.Zero(t, validatorClient.calls.Load())
+
+	repaired, err := repairer.RepairMissingBundles(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, repaired)
+	require.Equal(t, int32(3), validatorClient.calls.Load())
+
+	// The present bundle is untouched.
+	bundleBytes, err := store.GetBundleData(bundleKeys[0])
+	require.NoError(t, err)
+	require.Equal(t, presentBundle, bundleBytes)
+
+	// The repaired bundles contain the chunks assigned to this validator, in assignment order.
+	for i := 1; i < 3; i++ {
+		header := records[i].BlobHeader
+		operatorState, err := chainState.GetOperatorState(
+			ctx, uint(records[i].ReferenceBlockNumber), header.QuorumNumbers)
+		require.NoError(t, err)
+		assignment, err := corev2.GetAssignmentForBlob(operatorState, blobParams, header.QuorumNumbers, operatorID)
+		require.NoError(t, err)
+
+		bundleBytes, err := store.GetBundleData(bundleKeys[i])
+		require.NoError(t, err)
+		bundle, err := core.Bundle{}.Deserialize(bundleBytes)
+		require.NoError(t, err)
+
+		require.Equal(t, len(assignment.Indices), len(bundle))
+		for j, index := range assignment.Indices {
+			require.Equal(t, []fr.Element{fr.NewElement(uint64(index))}, []fr.Element(bundle[j].Coeffs))
+		}
+	}
+
+	exists, err := store.BundleExists(bundleKeys[3])
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// The blob that couldn't be repaired isn't retried until its backoff delay has elapsed.
+	repaired, err = repairer.RepairMissingBundles(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, repaired)
+	require.Equal(t, int32(3), validatorClient.calls.Load())
+
+	// Only the blob that couldn't be repaired is retried.
+	now = now.Add(minRepairRetryDelay)
+	repaired, err = repairer.RepairMissingBundles(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, repaired)
+	require.Equal(t, int32(4), validatorClient.calls.Load())
+
+	// The backoff delay doubles with each consecutive failure.
+	now = now.Add(minRepairRetryDelay)
+	_, err = repairer.RepairMissingBundles(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int32(4), validatorClient.calls.Load())
+	now = now.Add(minRepairRetryDelay)
+	_, err = repairer.RepairMissingBundles(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int32(5), validatorClient.calls.Load())
+
+	// Once the blob can be reconstructed, it is repaired and forgotten.
+	validatorClient.failingBlobs = nil
+	now = now.Add(maxRepairRetryDelay)
+	repaired, err = repairer.RepairMissingBundles(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, repaired)
+	require.Empty(t, repairer.failures)
+}
+
+func TestRepairDeletesUnassignedBlobRecords(t *testing.T) {
+	ctx := t.Context()
+	rand := random.NewTestRandom()
+	store := newTestValidatorStore(t)
+
+	// This validator is only part of quorum 0.
+	chainState, err := coremock.MakeChainDataMock(map[core.QuorumID]int{0: 4, 1: 2})
+	require.NoError(t, err)
+	operatorID := coremock.MakeOperatorId(3)
+
+	var blobVersionParams atomic.Pointer[corev2.BlobVersionParameterMap]
+	blobVersionParams.Store(corev2.NewBlobVersionParameterMap(
+		map[corev2.BlobVersion]*core.BlobVersionParameters{0: {
+			NumChunks:       8192,
+			CodingRate:      8,
+			MaxNumOperators: 2048,
+		}}))
+
+	assigned := randomBlobRecord(rand)
+	unassigned := randomBlobRecord(rand)
+	unassigned.BlobHeader.QuorumNumbers = []core.QuorumID{1}
+	require.NoError(t, store.StoreBlobRecords([]*BlobRecord{assigned, unassigned}))
+	assignedKey, err := assigned.BlobHeader.BlobKey()
+	require.NoError(t, err)
+
+	validatorClient := &fakeValidatorClient{data: make([]byte, 16*encoding.BYTES_PER_SYMBOL)}
+	repairer := NewChunkRepairer(
+		test.GetLogger(),
+		store,
+		chainState,
+		validatorClient,
+		fakeChunkEncoder{},
+		operatorID,
+		&blobVersionParams,
+		nil,
+		time.Now)
+
+	err = repairer.RepairBlob(ctx, unassigned)
+	require.ErrorIs(t, err, errBlobNotAssigned)
+
+	repaired, err := repairer.RepairMissingBundles(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, repaired)
+	require.Equal(t, int32(1), validatorClient.calls.Load())
+	require.Empty(t, repairer.failures)
+
+	// The unassigned blob's record is deleted, so it isn't checked again.
+	foundKeys := make([]corev2.BlobKey, 0)
+	err = store.ForEachBlobRecord(func(record *BlobRecord) error {
+		blobKey, err := record.BlobHeader.BlobKey()
+		require.NoError(t, err)
+		foundKeys = append(foundKeys, blobKey)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []corev2.BlobKey{assignedKey}, foundKeys)
+}
@@ -91,6 +91,18 @@ type Config struct {
 	// If true, triggers deletion of v1 data on node startup
 	DeleteV1Data bool
 
+	// If true, the node looks for missing bundles of blobs that are still live once on startup, and rebuilds them
+	// from chunks held by other validators.
+	RepairChunks bool
+
+	// The period at which the node looks for missing bundles and rebuilds them in the background. If zero,
+	// missing bundles are not repaired in the background.
+	ChunkRepairPeriod time.Duration
+
+	// The address of the HTTP server used to trigger chunk repairs on demand (see ChunkRepairServer). If empty, the
+	// server is not started.
+	ChunkRepairServerAddress string
+
 	OnchainStateRefreshInterval time.Duration
 	ChunkDownloadTimeout        time.Duration
 	GRPCMsgSizeLimitV2          int
@@ -135,6 +147,12 @@ type Config struct {
 	// Directories do not need to be on the same filesystem.
 	LittDBStoragePaths []string
 
+	// The list of paths to the littDB storage directories for blob records, which are used to rebuild lost chunks.
+	// These must be separate from LittDBStoragePaths, and should be on a different volume, so that the records
+	// survive the loss of the chunks they describe. If empty, blob records are not stored and chunks can't be
+	// repaired.
+	BlobRecordsStoragePaths []string
+
 	// If true, then LittDB will refuse to start if it can't acquire locks on the database file structure.
 	//
 	// Ideally, this would always be enabled. But PID reuse in common platforms such as Docker/Kubernetes can lead to
@@ -385,6 +403,14 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		return nil, errors.New("on-demand-meter-fuzz-factor must be > 0")
 	}
 
+	chunkRepairEnabled := ctx.GlobalBool(flags.RepairChunksFlag.Name) ||
+		ctx.GlobalDuration(flags.ChunkRepairPeriodFlag.Name) > 0 ||
+		ctx.GlobalString(flags.ChunkRepairServerAddressFlag.Name) != ""
+	if chunkRepairEnabled && len(ctx.GlobalStringSlice(flags.BlobRecordsStoragePathsFlag.Name)) == 0 {
+		return nil, fmt.Errorf("the %s flag is required if chunk repair is enabled",
+			flags.BlobRecordsStoragePathsFlag.Name)
+	}
+
 	return &Config{
 		Hostname:                            ctx.GlobalString(flags.HostnameFlag.Name),
 		V2DispersalPort:                     v2DispersalPort,
@@ -426,6 +452,9 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		BlsSignerConfig:                     blsSignerConfig,
 		EnforceSingleBlobBatches:            ctx.GlobalBool(flags.EnforceSingleBlobBatchesFlag.Name),
 		DeleteV1Data:                        ctx.GlobalBool(flags.DeleteV1DataFlag.Name),
+		RepairChunks:                        ctx.GlobalBool(flags.RepairChunksFlag.Name),
+		ChunkRepairPeriod:                   ctx.GlobalDuration(flags.ChunkRepairPeriodFlag.Name),
+		ChunkRepairServerAddress:            ctx.GlobalString(flags.ChunkRepairServerAddressFlag.Name),
 		OnchainStateRefreshInterval:         ctx.GlobalDuration(flags.OnchainStateRefreshIntervalFlag.Name),
 		ChunkDownloadTimeout:                ctx.GlobalDuration(flags.ChunkDownloadTimeoutFlag.Name),
 		GRPCMsgSizeLimitV2:                  ctx.GlobalInt(flags.GRPCMsgSizeLimitV2Flag.Name),
@@ -445,6 +474,7 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		LittDBReadCacheSizeBytes:        uint64(ctx.GlobalFloat64(flags.LittDBReadCacheSizeGBFlag.Name) * units.GiB),
 		LittDBReadCacheSizeFraction:     ctx.GlobalFloat64(flags.LittDBReadCacheSizeFractionFlag.Name),
 		LittDBStoragePaths:              ctx.GlobalStringSlice(flags.LittDBStoragePathsFlag.Name),
+		BlobRecordsStoragePaths:         ctx.GlobalStringSlice(flags.BlobRecordsStoragePathsFlag.Name),
 		LittRespectLocks:                ctx.GlobalBool(flags.LittRespectLocksFlag.Name),
 		LittMinimumFlushInterval:        ctx.GlobalDuration(flags.LittMinimumFlushIntervalFlag.Name),
 		LittSnapshotDirectory:           ctx.GlobalString(flags.LittSnapshotDirectoryFlag.Name),
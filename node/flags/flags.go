@@ -574,6 +574,35 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DELETE_V1_DATA"),
 	}
+	RepairChunksFlag = cli.BoolFlag{
+		Name: common.PrefixFlag(FlagPrefix, "repair-chunks"),
+		Usage: "When enabled, rebuilds missing bundles of blobs that are still live from chunks held by other " +
+			"validators on node startup",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "REPAIR_CHUNKS"),
+	}
+	ChunkRepairServerAddressFlag = cli.StringFlag{
+		Name: common.PrefixFlag(FlagPrefix, "chunk-repair-server-address"),
+		Usage: "The address (e.g. 127.0.0.1:9103) of an HTTP server that rebuilds missing bundles when it receives " +
+			"POST /repair-chunks. Should only be reachable by the operator. Disabled if empty.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_REPAIR_SERVER_ADDRESS"),
+	}
+	BlobRecordsStoragePathsFlag = cli.StringSliceFlag{
+		Name: common.PrefixFlag(FlagPrefix, "blob-records-storage-paths"),
+		Usage: "Comma separated list of paths to store the records used to rebuild lost chunks. Must be separate " +
+			"from the LittDB storage paths, ideally on a different volume. Required if chunk repair is enabled.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "BLOB_RECORDS_STORAGE_PATHS"),
+	}
+	ChunkRepairPeriodFlag = cli.DurationFlag{
+		Name: common.PrefixFlag(FlagPrefix, "chunk-repair-period"),
+		Usage: "The period at which missing bundles of blobs that are still live are rebuilt from chunks held by " +
+			"other validators. Missing bundles are not repaired in the background if 0.",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_REPAIR_PERIOD"),
+	}
 
 	/////////////////////////////////////////////////////////////////////////////
 	// TEST FLAGS SECTION
@@ -729,6 +758,10 @@ var optionalFlags = []cli.Flag{
 	OverrideV2TtlFlag,
 	EnforceSingleBlobBatchesFlag,
 	DeleteV1DataFlag,
+	RepairChunksFlag,
+	ChunkRepairPeriodFlag,
+	ChunkRepairServerAddressFlag,
+	BlobRecordsStoragePathsFlag,
 }
 
 func init() {
@@ -343,6 +343,9 @@ func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (
 		return nil, api.NewErrorInternal(fmt.Sprintf("failed to sign batch: %v", err))
 	}
 
+	probe.SetStage("store_blob_records")
+	s.storeBlobRecords(batch, blobShards, batchHeaderHash)
+
 	success = true
 
 	return &pb.StoreChunksReply{
@@ -419,6 +422,30 @@ func (s *ServerV2) validateAndStoreChunksLittDB(
 	return nil
 }
 
+// storeBlobRecords stores a record of each blob in the batch that has chunks assigned to this validator, so that the
+// chunks can be rebuilt if they are lost. There is nothing to rebuild for the other blobs in the batch. No chunks are
+// downloaded for unassigned blobs, so their bundles are empty.
+//
+// Blob records are only used to rebuild lost chunks, so failing to store them doesn't fail the request, and they are
+// stored after the batch is signed.
+func (s *ServerV2) storeBlobRecords(batch *corev2.Batch, blobShards []*corev2.BlobShard, batchHeaderHash [32]byte) {
+	blobRecords := make([]*node.BlobRecord, 0, len(blobShards))
+	for _, blobShard := range blobShards {
+		if len(blobShard.Bundle) == 0 {
+			continue
+		}
+		blobRecords = append(blobRecords, &node.BlobRecord{
+			BlobHeader:           blobShard.BlobHeader,
+			ReferenceBlockNumber: batch.BatchHeader.ReferenceBlockNumber,
+		})
+	}
+	err := s.node.ValidatorStore.StoreBlobRecords(blobRecords)
+	if err != nil {
+		s.logger.Warn("failed to store blob records",
+			"batchHeaderHash", hex.EncodeToString(batchHeaderHash[:]), "err", err)
+	}
+}
+
 // validateStoreChunksRequest validates the StoreChunksRequest and returns deserialized batch in the request
 func (s *ServerV2) validateStoreChunksRequest(req *pb.StoreChunksRequest) (*corev2.Batch, error) {
 	// The signature is created by go-ethereum library, which contains 1 additional byte (for
@@ -297,6 +297,18 @@ func TestV2StoreChunksSuccess(t *testing.T) {
 		require.Equal(t, blobKeys[1], requests[0].BlobKey)
 	})
 	c.store.On("StoreBatch", mock.Anything, mock.Anything).Return(nil, nil)
+	c.store.On("StoreBlobRecords", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		// Records are stored for each blob with chunks assigned to this validator.
+		records := args.Get(0).([]*node.BlobRecord)
+		recordKeys := make([]v2.BlobKey, 0, len(records))
+		for _, record := range records {
+			blobKey, err := record.BlobHeader.BlobKey()
+			require.NoError(t, err)
+			require.Equal(t, batch.BatchHeader.ReferenceBlockNumber, record.ReferenceBlockNumber)
+			recordKeys = append(recordKeys, blobKey)
+		}
+		require.ElementsMatch(t, blobKeys, recordKeys)
+	})
 	request := &validator.StoreChunksRequest{
 		DisperserID: 0,
 		Batch:       batchProto,
@@ -305,6 +317,7 @@ func TestV2StoreChunksSuccess(t *testing.T) {
 	reply, err := c.server.StoreChunks(t.Context(), request)
 	require.NoError(t, err)
 	require.NotNil(t, reply.GetSignature())
+	c.store.AssertCalled(t, "StoreBlobRecords", mock.Anything)
 	sigBytes := reply.GetSignature()
 	point, err := new(core.Signature).Deserialize(sigBytes)
 	require.NoError(t, err)
@@ -314,6 +327,77 @@ func TestV2StoreChunksSuccess(t *testing.T) {
 	require.True(t, sig.Verify(c.node.KeyPair.GetPubKeyG2(), bhh))
 }
 
+func TestV2StoreChunksSkipsUnassignedBlobRecords(t *testing.T) {
+	config := makeConfig(t)
+	c := newTestComponents(t, config)
+
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+
+	// This validator is only part of quorum 0, so the third blob (quorums 1 and 2) isn't assigned to it.
+	otherOperatorID := core.OperatorID{1}
+	operatorState := &core.OperatorState{
+		Operators:   make(map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo),
+		Totals:      make(map[core.QuorumID]*core.OperatorInfo),
+		BlockNumber: 100,
+	}
+	for _, quorumID := range []core.QuorumID{0, 1, 2} {
+		operatorID := otherOperatorID
+		if quorumID == 0 {
+			operatorID = opID
+		}
+		operatorState.Operators[quorumID] = map[core.OperatorID]*core.OperatorInfo{
+			operatorID: {Stake: big.NewInt(100), Index: 0},
+		}
+		operatorState.Totals[quorumID] = &core.OperatorInfo{Stake: big.NewInt(100), Index: 1}
+	}
+	c.node.OperatorStateCache.(*operatorstate.MockOperatorStateCache).SetOperatorState(
+		t.Context(), 100, operatorState)
+
+	c.validator.On("ValidateBlobs", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.validator.On("ValidateBatchHeader", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	bundles00Bytes, err := bundles[0][0].Serialize()
+	require.NoError(t, err)
+	bundles10Bytes, err := bundles[1][0].Serialize()
+	require.NoError(t, err)
+	c.relayClient.On(
+		"GetChunksByRange",
+		mock.Anything,
+		v2.RelayKey(0),
+		mock.Anything,
+	).Return([][]byte{bundles00Bytes}, nil).Run(func(args mock.Arguments) {
+		requests := args.Get(2).([]*relay.ChunkRequestByRange)
+		require.Len(t, requests, 1)
+		require.Equal(t, blobKeys[0], requests[0].BlobKey)
+	})
+	c.relayClient.On(
+		"GetChunksByRange",
+		mock.Anything,
+		v2.RelayKey(1),
+		mock.Anything,
+	).Return([][]byte{bundles10Bytes}, nil)
+	c.store.On("StoreBatch", mock.Anything, mock.Anything).Return(nil, nil)
+	c.store.On("StoreBlobRecords", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		records := args.Get(0).([]*node.BlobRecord)
+		recordKeys := make([]v2.BlobKey, 0, len(records))
+		for _, record := range records {
+			blobKey, err := record.BlobHeader.BlobKey()
+			require.NoError(t, err)
+			recordKeys = append(recordKeys, blobKey)
+		}
+		require.ElementsMatch(t, blobKeys[:2], recordKeys)
+	})
+	request := &validator.StoreChunksRequest{
+		DisperserID: 0,
+		Batch:       batchProto,
+	}
+	c.signRequest(t, request)
+	_, err = c.server.StoreChunks(t.Context(), request)
+	require.NoError(t, err)
+	c.store.AssertCalled(t, "StoreBlobRecords", mock.Anything)
+}
+
 func TestV2StoreChunksDownloadFailure(t *testing.T) {
 	config := makeConfig(t)
 	c := newTestComponents(t, config)
@@ -416,6 +500,7 @@ func TestV2StoreChunksLevelDBValidationFailure(t *testing.T) {
 		require.Equal(t, blobKeys[1], requests[0].BlobKey)
 	})
 	c.store.On("StoreBatch", mock.Anything, mock.Anything).Return([]kvstore.Key{mockKey{}}, nil)
+	c.store.On("StoreBlobRecords", mock.Anything).Return(nil)
 	c.store.On("DeleteKeys", mock.Anything, mock.Anything).Return(nil)
 	request := &validator.StoreChunksRequest{
 		DisperserID: 0,
@@ -43,6 +43,8 @@ type Metrics struct {
 	AccuBlobs *prometheus.CounterVec
 	// Total number of changes in the node's socket address.
 	AccuSocketUpdates prometheus.Counter
+	// Accumulated number of missing bundles rebuilt from other validators, by status.
+	RepairedBundles *prometheus.CounterVec
 	// avs node spec eigen_ metrics: https://eigen.nethermind.io/docs/spec/metrics/metrics-prom-spec
 	EigenMetrics eigenmetrics.Metrics
 	// Reachability gauge to monitoring the reachability of the node's retrieval/dispersal sockets
@@ -143,6 +145,15 @@ func NewMetrics(eigenMetrics eigenmetrics.Metrics, reg *prometheus.Registry, log
 				Help:      "the total number of node's socket address updates",
 			},
 		),
+		// The "status" label has values: success, failure.
+		RepairedBundles: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_repaired_bundles_total",
+				Help:      "the total number of missing bundles rebuilt from chunks held by other validators",
+			},
+			[]string{"status"},
+		),
 		ReachabilityGauge: promauto.With(reg).NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: Namespace,
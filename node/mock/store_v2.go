@@ -2,6 +2,7 @@ package mock
 
 import (
 	"github.com/Layr-Labs/eigenda/common/kvstore"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/stretchr/testify/mock"
 )
@@ -38,6 +39,36 @@ func (m *MockStoreV2) GetBundleData(bundleKey []byte) ([]byte, error) {
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (m *MockStoreV2) BundleExists(bundleKey []byte) (bool, error) {
+	args := m.Called(bundleKey)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStoreV2) StoreBlobRecords(records []*node.BlobRecord) error {
+	args := m.Called(records)
+	return args.Error(0)
+}
+
+func (m *MockStoreV2) ForEachBlobRecord(f func(record *node.BlobRecord) error) error {
+	args := m.Called(f)
+	return args.Error(0)
+}
+
+func (m *MockStoreV2) DeleteBlobRecords(blobKeys []corev2.BlobKey) error {
+	args := m.Called(blobKeys)
+	return args.Error(0)
+}
+
+func (m *MockStoreV2) StoreRepairedBundle(record *node.BlobRecord, bundle *node.BundleToStore) error {
+	args := m.Called(record, bundle)
+	return args.Error(0)
+}
+
+func (m *MockStoreV2) DeleteExpiredRepairedBundles() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockStoreV2) Stop() error {
 	return nil
 }
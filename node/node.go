@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -117,6 +118,12 @@ type Node struct {
 
 	// Global on-demand throughput meter (enforced using on-chain PaymentVault params)
 	onDemandMeterer *meterer.OnDemandMeterer
+
+	// Rebuilds missing bundles from chunks held by other validators. Nil unless chunk repair is enabled.
+	chunkRepairer *ChunkRepairer
+
+	// Serves on-demand chunk repair requests. Nil unless a chunk repair server address is configured.
+	chunkRepairServer *ChunkRepairServer
 }
 
 // NewNode creates a new Node with the provided config.
@@ -396,6 +403,19 @@ func NewNode(
 
 	n.BlobVersionParams.Store(blobVersionParams)
 
+	if config.RepairChunks || config.ChunkRepairPeriod > 0 || config.ChunkRepairServerAddress != "" {
+		n.chunkRepairer, err = n.buildChunkRepairer(reg, verifierV2)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create chunk repairer: %w", err)
+		}
+	}
+	if config.ChunkRepairServerAddress != "" {
+		n.chunkRepairServer, err = NewChunkRepairServer(logger, config.ChunkRepairServerAddress, n.chunkRepairer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start chunk repair server: %w", err)
+		}
+	}
+
 	n.startPprof()
 	n.startMetrics()
 	n.startNodeAPI()
@@ -589,6 +609,23 @@ func (n *Node) startV2() {
 		_ = n.RefreshOnchainState()
 	}()
 	go n.checkNodeReachability(v2CheckPath)
+
+	if n.ValidatorStore != nil {
+		startExpiringRepairedBundles(n.CTX, n.Logger, n.ValidatorStore, repairedBundleExpiryInterval)
+	}
+	if n.chunkRepairer != nil {
+		if n.Config.RepairChunks {
+			go func() {
+				_, err := n.chunkRepairer.RepairMissingBundles(n.CTX)
+				if err != nil && !errors.Is(err, errRepairInProgress) {
+					n.Logger.Error("failed to repair missing bundles", "err", err)
+				}
+			}()
+		}
+		if n.Config.ChunkRepairPeriod > 0 {
+			n.chunkRepairer.Start(n.CTX, n.Config.ChunkRepairPeriod)
+		}
+	}
 }
 
 // Start the Node API if enabled.
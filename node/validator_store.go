@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"crypto/rand"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common/structures"
@@ -13,6 +16,7 @@ import (
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/litt"
 	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/docker/go-units"
@@ -23,8 +27,14 @@ import (
 const (
 	// The name of the littDB table containing chunk data.
 	chunksTableName = "chunks"
+	// The name of the littDB table containing a record of each blob whose chunks are stored.
+	blobRecordsTableName = "blob_records"
+	// The name of the littDB table containing the expiry time of each bundle rebuilt by the chunk repairer.
+	repairedBundlesTableName = "repaired_bundles"
 	// The metrics prefix for littDB.
 	littDBMetricsPrefix = "node_littdb"
+	// The metrics prefix for the littDB instance containing blob records.
+	blobRecordsLittDBMetricsPrefix = "node_blob_records_littdb"
 )
 
 // BundleToStore is a struct that holds the bundle key and the bundle bytes.
@@ -35,6 +45,56 @@ type BundleToStore struct {
 	BundleBytes []byte
 }
 
+// errBlobRecordExpired is returned by StoreRepairedBundle if the record of the blob has already expired.
+var errBlobRecordExpired = errors.New("blob record has expired")
+
+// BlobRecord describes a blob whose chunks are stored by this validator. Blob records are kept for as long as the
+// chunks, and contain enough information to rebuild the chunks from other validators if they are lost.
+type BlobRecord struct {
+	// The header of the blob.
+	BlobHeader *corev2.BlobHeader
+	// The reference block number of the batch containing the blob.
+	ReferenceBlockNumber uint64
+	// The time at which the record was stored, set by ValidatorStore.StoreBlobRecords. The record and the chunks it
+	// describes expire one TTL after this time.
+	StoredAt time.Time
+}
+
+// The length of the fixed size prefix of a serialized blob record (reference block number and storage time).
+const blobRecordPrefixLength = 16
+
+// Serialize serializes the blob record.
+func (r *BlobRecord) Serialize() ([]byte, error) {
+	certificateBytes, err := (&corev2.BlobCertificate{BlobHeader: r.BlobHeader}).Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize blob header: %w", err)
+	}
+
+	data := make([]byte, blobRecordPrefixLength+len(certificateBytes))
+	binary.BigEndian.PutUint64(data, r.ReferenceBlockNumber)
+	binary.BigEndian.PutUint64(data[8:], uint64(r.StoredAt.UnixNano()))
+	copy(data[blobRecordPrefixLength:], certificateBytes)
+	return data, nil
+}
+
+// DeserializeBlobRecord deserializes a blob record serialized with BlobRecord.Serialize().
+func DeserializeBlobRecord(data []byte) (*BlobRecord, error) {
+	if len(data) < blobRecordPrefixLength {
+		return nil, fmt.Errorf("blob record must have at least %d bytes, got %d", blobRecordPrefixLength, len(data))
+	}
+
+	certificate, err := corev2.DeserializeBlobCertificate(data[blobRecordPrefixLength:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize blob header: %w", err)
+	}
+
+	return &BlobRecord{
+		BlobHeader:           certificate.BlobHeader,
+		ReferenceBlockNumber: binary.BigEndian.Uint64(data),
+		StoredAt:             time.Unix(0, int64(binary.BigEndian.Uint64(data[8:]))),
+	}, nil
+}
+
 // ValidatorStore encapsulates the database for storing batches of chunk data for the V2 validator node.
 type ValidatorStore interface {
 
@@ -45,6 +105,35 @@ type ValidatorStore interface {
 	// The returned chunks are encoded in bundle format.
 	GetBundleData(bundleKey []byte) ([]byte, error)
 
+	// BundleExists returns true if the store contains a bundle with the given bundle key. Unlike GetBundleData,
+	// this is not subject to read rate limits.
+	BundleExists(bundleKey []byte) (bool, error)
+
+	// StoreBlobRecords stores a record of each blob in a batch, so that its chunks can be rebuilt if they are lost.
+	// Records expire at the same time as the chunks they describe. Records aren't flushed, they become crash durable
+	// along with later writes to the blob records table. This is a no-op if blob records storage paths aren't
+	// configured.
+	StoreBlobRecords(records []*BlobRecord) error
+
+	// ForEachBlobRecord calls the given function for each unexpired blob record in the store. Iteration stops at the
+	// first error returned by the function. Returns an error if blob records storage paths aren't configured.
+	ForEachBlobRecord(f func(record *BlobRecord) error) error
+
+	// StoreRepairedBundle stores a bundle rebuilt for the blob described by the record. Unlike bundles stored by
+	// StoreBatch, a repaired bundle doesn't get a full TTL: it expires along with the record of its blob, once
+	// DeleteExpiredRepairedBundles is called. Returns an error wrapping errBlobRecordExpired if the record has already
+	// expired. Returns an error if blob records storage paths aren't configured.
+	StoreRepairedBundle(record *BlobRecord, bundle *BundleToStore) error
+
+	// DeleteExpiredRepairedBundles deletes the repaired bundles that have outlived the records of their blobs.
+	// Returns the number of bundles deleted. This is a no-op if blob records storage paths aren't configured.
+	DeleteExpiredRepairedBundles() (int, error)
+
+	// DeleteBlobRecords deletes the records of the given blobs, e.g. for blobs that aren't assigned to this validator.
+	// Deleting a record that doesn't exist is a no-op. This is a no-op if blob records storage paths aren't
+	// configured.
+	DeleteBlobRecords(blobKeys []corev2.BlobKey) error
+
 	// Stop stops the store.
 	Stop() error
 }
@@ -59,6 +148,21 @@ type validatorStore struct {
 	// The table where chunks are stored in the littDB database.
 	chunkTable litt.Table
 
+	// The littDB database for storing blob records. Kept separate from the chunk database, so that the records
+	// survive the loss of the chunks they describe. Nil if blob records are not stored.
+	blobRecordsDB litt.DB
+
+	// The table where blob records are stored in the blob records database. Nil if blob records are not stored.
+	blobRecordTable litt.Table
+
+	// The table in the blob records database where the expiry time of each repaired bundle is stored, keyed by
+	// bundle key. Nil if blob records are not stored.
+	repairedBundleTable litt.Table
+
+	// Serializes the existence checks, writes and deletions of blob records and repaired bundle expiry times, so that
+	// concurrent requests for the same blob don't both write its key.
+	blobRecordsLock sync.Mutex
+
 	// The length of time to store data in the database.
 	ttl time.Duration
 
@@ -145,6 +249,16 @@ func NewValidatorStore(
 		return nil, fmt.Errorf("failed to set TTL for chunks table: %w", err)
 	}
 
+	var blobRecordsDB litt.DB
+	var blobRecordTable litt.Table
+	var repairedBundleTable litt.Table
+	if len(config.BlobRecordsStoragePaths) > 0 {
+		blobRecordsDB, blobRecordTable, repairedBundleTable, err = newBlobRecordsDB(logger, config, ttl, registry)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to create blob records store: %w", err), littDB.Close())
+		}
+	}
+
 	salt := [16]byte{}
 	_, err = rand.Read(salt[:])
 	if err != nil {
@@ -163,6 +277,9 @@ func NewValidatorStore(
 		timeSource:           timeSource,
 		littDB:               littDB,
 		chunkTable:           chunkTable,
+		blobRecordsDB:        blobRecordsDB,
+		blobRecordTable:      blobRecordTable,
+		repairedBundleTable:  repairedBundleTable,
 		ttl:                  ttl,
 		duplicateRequestLock: structures.NewIndexLock(1024),
 		duplicateRequestSalt: salt,
@@ -173,6 +290,82 @@ func NewValidatorStore(
 	return store, nil
 }
 
+// newBlobRecordsDB creates the littDB database where blob records are stored, and returns it along with its blob
+// records table and its repaired bundles table.
+func newBlobRecordsDB(
+	logger logging.Logger,
+	config *Config,
+	ttl time.Duration,
+	registry *prometheus.Registry,
+) (litt.DB, litt.Table, litt.Table, error) {
+
+	// Blob records are useless if they are lost along with the chunks, so they must not share a directory.
+	for _, recordsPath := range config.BlobRecordsStoragePaths {
+		for _, chunksPath := range config.LittDBStoragePaths {
+			if pathsOverlap(recordsPath, chunksPath) {
+				return nil, nil, nil, fmt.Errorf(
+					"blob records storage path %s overlaps with littDB storage path %s", recordsPath, chunksPath)
+			}
+		}
+	}
+
+	logger.Info("Using blob records storage paths", "paths", strings.Join(config.BlobRecordsStoragePaths, ","))
+
+	littConfig, err := litt.DefaultConfig(config.BlobRecordsStoragePaths...)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create new litt config: %w", err)
+	}
+	littConfig.ShardingFactor = uint32(len(config.BlobRecordsStoragePaths))
+	littConfig.MetricsEnabled = true
+	littConfig.MetricsRegistry = registry
+	littConfig.MetricsNamespace = blobRecordsLittDBMetricsPrefix
+	littConfig.Logger = logger
+	littConfig.DoubleWriteProtection = config.LittDBDoubleWriteProtection
+	littConfig.PurgeLocks = !config.LittRespectLocks
+
+	blobRecordsDB, err := littbuilder.NewDB(littConfig)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create new litt store: %w", err)
+	}
+
+	blobRecordTable, err := blobRecordsDB.GetTable(blobRecordsTableName)
+	if err != nil {
+		return nil, nil, nil, errors.Join(
+			fmt.Errorf("failed to get blob records table: %w", err), blobRecordsDB.Close())
+	}
+
+	err = blobRecordTable.SetTTL(ttl)
+	if err != nil {
+		return nil, nil, nil, errors.Join(
+			fmt.Errorf("failed to set TTL for blob records table: %w", err), blobRecordsDB.Close())
+	}
+
+	// A repaired bundle expires at most one TTL after it is repaired, so its expiry time never outlives this TTL.
+	repairedBundleTable, err := blobRecordsDB.GetTable(repairedBundlesTableName)
+	if err != nil {
+		return nil, nil, nil, errors.Join(
+			fmt.Errorf("failed to get repaired bundles table: %w", err), blobRecordsDB.Close())
+	}
+
+	err = repairedBundleTable.SetTTL(ttl)
+	if err != nil {
+		return nil, nil, nil, errors.Join(
+			fmt.Errorf("failed to set TTL for repaired bundles table: %w", err), blobRecordsDB.Close())
+	}
+
+	return blobRecordsDB, blobRecordTable, repairedBundleTable, nil
+}
+
+// pathsOverlap returns true if the two paths are the same, or if one of them contains the other.
+func pathsOverlap(a string, b string) bool {
+	isWithin := func(path string, dir string) bool {
+		rel, err := filepath.Rel(dir, path)
+		return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+	}
+	a, b = filepath.Clean(a), filepath.Clean(b)
+	return isWithin(a, b) || isWithin(b, a)
+}
+
 func (s *validatorStore) StoreBatch(batchData []*BundleToStore) (uint64, error) {
 	if len(batchData) == 0 {
 		return 0, fmt.Errorf("no batch data")
@@ -285,6 +478,240 @@ func (s *validatorStore) getChunksLittDB(bundleKey []byte) (data []byte, exists
 	return bundle, true, nil
 }
 
+func (s *validatorStore) BundleExists(bundleKey []byte) (bool, error) {
+	exists, err := s.chunkTable.Exists(bundleKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to check existence: %w", err)
+	}
+	return exists, nil
+}
+
+func (s *validatorStore) StoreBlobRecords(records []*BlobRecord) error {
+	if s.blobRecordTable == nil || len(records) == 0 {
+		return nil
+	}
+
+	storedAt := s.timeSource()
+	keys := make([][]byte, 0, len(records))
+	values := make([][]byte, 0, len(records))
+	for _, record := range records {
+		blobKey, err := record.BlobHeader.BlobKey()
+		if err != nil {
+			return fmt.Errorf("failed to get blob key: %w", err)
+		}
+
+		stamped := *record
+		stamped.StoredAt = storedAt
+		recordBytes, err := stamped.Serialize()
+		if err != nil {
+			return fmt.Errorf("failed to serialize blob record: %w", err)
+		}
+
+		keys = append(keys, blobKey[:])
+		values = append(values, recordBytes)
+	}
+
+	s.blobRecordsLock.Lock()
+	defer s.blobRecordsLock.Unlock()
+
+	batch := make([]*types.KVPair, 0, len(records))
+	written := make(map[string]struct{}, len(records))
+	for i, key := range keys {
+		if _, ok := written[string(key)]; ok {
+			continue
+		}
+		exists, err := s.blobRecordTable.Exists(key)
+		if err != nil {
+			return fmt.Errorf("failed to check existence: %w", err)
+		}
+		if exists {
+			// Data is already present, no need to write it again.
+			continue
+		}
+		written[string(key)] = struct{}{}
+		batch = append(batch, &types.KVPair{Key: key, Value: values[i]})
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+
+	err := s.blobRecordTable.PutBatch(batch)
+	if err != nil {
+		return fmt.Errorf("failed to put blob records: %w", err)
+	}
+
+	return nil
+}
+
+func (s *validatorStore) ForEachBlobRecord(f func(record *BlobRecord) error) error {
+	if s.blobRecordTable == nil {
+		return errors.New("blob records are not stored, blob records storage paths must be configured")
+	}
+
+	now := s.timeSource()
+	iterator, err := s.blobRecordTable.Iterator()
+	if err != nil {
+		return fmt.Errorf("failed to create blob records iterator: %w", err)
+	}
+	defer iterator.Release()
+
+	for iterator.Next() {
+		recordBytes, err := iterator.Value()
+		if err != nil {
+			return fmt.Errorf("failed to read blob record: %w", err)
+		}
+
+		record, err := DeserializeBlobRecord(recordBytes)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize blob record: %w", err)
+		}
+		if s.blobRecordExpired(record, now) {
+			// Expired records linger until they are garbage collected.
+			continue
+		}
+
+		err = f(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := iterator.Error(); err != nil {
+		return fmt.Errorf("failed to iterate over blob records: %w", err)
+	}
+
+	return nil
+}
+
+func (s *validatorStore) DeleteBlobRecords(blobKeys []corev2.BlobKey) error {
+	if s.blobRecordTable == nil || len(blobKeys) == 0 {
+		return nil
+	}
+
+	keys := make([][]byte, 0, len(blobKeys))
+	for _, blobKey := range blobKeys {
+		keys = append(keys, blobKey[:])
+	}
+
+	s.blobRecordsLock.Lock()
+	defer s.blobRecordsLock.Unlock()
+
+	err := s.blobRecordTable.DeleteBatch(keys)
+	if err != nil {
+		return fmt.Errorf("failed to delete blob records: %w", err)
+	}
+
+	err = s.blobRecordTable.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to flush blob records table: %w", err)
+	}
+
+	return nil
+}
+
+// blobRecordExpired returns true if the record, and the chunks it describes, have expired at the given time.
+func (s *validatorStore) blobRecordExpired(record *BlobRecord, now time.Time) bool {
+	return !record.StoredAt.Add(s.ttl).After(now)
+}
+
+func (s *validatorStore) StoreRepairedBundle(record *BlobRecord, bundle *BundleToStore) error {
+	if s.repairedBundleTable == nil {
+		return errors.New("repaired bundles are not stored, blob records storage paths must be configured")
+	}
+
+	now := s.timeSource()
+	if s.blobRecordExpired(record, now) {
+		return fmt.Errorf("%w: stored at %v", errBlobRecordExpired, record.StoredAt)
+	}
+	expiry := record.StoredAt.Add(s.ttl)
+
+	// The expiry time is made durable before the bundle is written, so that a repaired bundle is never left
+	// without one.
+	s.blobRecordsLock.Lock()
+	exists, err := s.repairedBundleTable.Exists(bundle.BundleKey)
+	if err == nil && !exists {
+		expiryBytes := make([]byte, 8)
+		binary.BigEndian.PutUint64(expiryBytes, uint64(expiry.UnixNano()))
+		err = s.repairedBundleTable.Put(bundle.BundleKey, expiryBytes)
+		if err == nil {
+			err = s.repairedBundleTable.Flush()
+		}
+	}
+	s.blobRecordsLock.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to store expiry of repaired bundle: %w", err)
+	}
+
+	_, err = s.StoreBatch([]*BundleToStore{bundle})
+	if err != nil {
+		return fmt.Errorf("failed to store repaired bundle: %w", err)
+	}
+
+	return nil
+}
+
+func (s *validatorStore) DeleteExpiredRepairedBundles() (int, error) {
+	if s.repairedBundleTable == nil {
+		return 0, nil
+	}
+
+	now := s.timeSource()
+
+	// Expired bundles are collected before deleting any, so that the iterator isn't held open while deleting.
+	expired := make([][]byte, 0)
+	iterator, err := s.repairedBundleTable.Iterator()
+	if err != nil {
+		return 0, fmt.Errorf("failed to create repaired bundles iterator: %w", err)
+	}
+	for iterator.Next() {
+		expiryBytes, err := iterator.Value()
+		if err != nil {
+			iterator.Release()
+			return 0, fmt.Errorf("failed to read repaired bundle expiry: %w", err)
+		}
+		if len(expiryBytes) != 8 {
+			iterator.Release()
+			return 0, fmt.Errorf("repaired bundle expiry must be 8 bytes, got %d", len(expiryBytes))
+		}
+		if time.Unix(0, int64(binary.BigEndian.Uint64(expiryBytes))).After(now) {
+			continue
+		}
+		expired = append(expired, bytes.Clone(iterator.Key()))
+	}
+	err = iterator.Error()
+	iterator.Release()
+	if err != nil {
+		return 0, fmt.Errorf("failed to iterate over repaired bundles: %w", err)
+	}
+
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	// Bundles are deleted before their expiry times, so that an interrupted deletion is retried.
+	err = s.chunkTable.DeleteBatch(expired)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired repaired bundles: %w", err)
+	}
+	err = s.chunkTable.Flush()
+	if err != nil {
+		return 0, fmt.Errorf("failed to flush chunk table: %w", err)
+	}
+
+	s.blobRecordsLock.Lock()
+	defer s.blobRecordsLock.Unlock()
+	err = s.repairedBundleTable.DeleteBatch(expired)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expiry of repaired bundles: %w", err)
+	}
+	err = s.repairedBundleTable.Flush()
+	if err != nil {
+		return 0, fmt.Errorf("failed to flush repaired bundles table: %w", err)
+	}
+
+	return len(expired), nil
+}
+
 func BundleKey(blobKey corev2.BlobKey, quorumID core.QuorumID) ([]byte, error) {
 	buf := bytes.NewBuffer(blobKey[:])
 	err := binary.Write(buf, binary.LittleEndian, quorumID)
@@ -302,5 +729,12 @@ func (s *validatorStore) Stop() error {
 		}
 	}
 
+	if s.blobRecordsDB != nil {
+		err := s.blobRecordsDB.Close()
+		if err != nil {
+			return fmt.Errorf("failed to close blob records littDB: %v", err)
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,257 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	cachecommon "github.com/Layr-Labs/eigenda/common/cache"
+	"github.com/Layr-Labs/eigenda/core"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/relay/metrics"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"golang.org/x/sync/errgroup"
+)
+
+// cachePrewarmStatuses are the statuses of the blobs that the cachePrewarmer watches for. A blob's certificate and
+// chunks are written by the time it is encoded, and validators request its chunks while the disperser is gathering
+// signatures. Blobs are watched in both statuses so that blobs which leave the Encoded status before they are
+// seen are still pre-warmed before validators ask for them.
+var cachePrewarmStatuses = []dispv2.BlobStatus{dispv2.Encoded, dispv2.GatheringSignatures}
+
+// cachePrewarmer watches the metadata store for newly certified blobs assigned to this relay, and loads their
+// metadata and chunks before any validator asks for them. Metadata is loaded into the metadata provider's cache.
+// Chunks are loaded into a separate cache with its own memory budget, which is consulted before downloading
+// chunk ranges from the chunk store.
+//
+// A nil cachePrewarmer is valid, and never holds any chunks.
+type cachePrewarmer struct {
+	ctx    context.Context
+	logger logging.Logger
+
+	// metadataStore is polled for newly certified blobs.
+	metadataStore blobstore.MetadataStore
+
+	// metadataProvider is used to load the metadata of newly certified blobs, and to filter out blobs
+	// that are not assigned to this relay.
+	metadataProvider *metadataProvider
+
+	// chunkProvider is used to download all chunks of newly certified blobs.
+	chunkProvider *chunkProvider
+
+	// frames contains the chunks of pre-warmed blobs. The total size of the chunks is bounded by the
+	// memory budget of the prewarmer, and the oldest blobs are evicted first.
+	frames cachecommon.Cache[v2.BlobKey, *core.ChunksData]
+
+	// cursors holds the position in the status index of each of the watched statuses.
+	// Only accessed by the goroutine running the prewarmer.
+	cursors map[dispv2.BlobStatus]*blobstore.StatusIndexCursor
+
+	// pollInterval is the time to wait before polling again when no new blobs are found.
+	pollInterval time.Duration
+
+	// batchSize is the maximum number of blobs to fetch from the metadata store per status in each poll.
+	batchSize int32
+
+	// maxConcurrency is the maximum number of blobs loaded in parallel.
+	maxConcurrency int
+
+	// metrics reports the effectiveness of the prewarmer.
+	metrics *metrics.CachePrewarmMetrics
+}
+
+// newCachePrewarmer creates a new cachePrewarmer. Returns nil if the memory budget is 0, i.e. if pre-warming
+// is disabled.
+func newCachePrewarmer(
+	ctx context.Context,
+	logger logging.Logger,
+	metadataStore blobstore.MetadataStore,
+	metadataProvider *metadataProvider,
+	chunkProvider *chunkProvider,
+	budgetBytes uint64,
+	pollInterval time.Duration,
+	batchSize int,
+	maxConcurrency int,
+	metrics *metrics.CachePrewarmMetrics) (*cachePrewarmer, error) {
+
+	if budgetBytes == 0 {
+		return nil, nil
+	}
+	if metadataStore == nil {
+		return nil, fmt.Errorf("metadataStore is required to pre-warm caches")
+	}
+	if pollInterval <= 0 {
+		return nil, fmt.Errorf("pollInterval must be positive, got %v", pollInterval)
+	}
+	if batchSize <= 0 || batchSize > 1<<31-1 {
+		return nil, fmt.Errorf("batchSize must be positive and fit in an int32, got %d", batchSize)
+	}
+	if maxConcurrency <= 0 {
+		return nil, fmt.Errorf("maxConcurrency must be positive, got %d", maxConcurrency)
+	}
+
+	frames := cachecommon.NewFIFOCache[v2.BlobKey, *core.ChunksData](
+		budgetBytes,
+		func(_ v2.BlobKey, frames *core.ChunksData) uint64 {
+			return frames.Size()
+		},
+		nil)
+
+	return &cachePrewarmer{
+		ctx:              ctx,
+		logger:           logger.With("component", "CachePrewarmer"),
+		metadataStore:    metadataStore,
+		metadataProvider: metadataProvider,
+		chunkProvider:    chunkProvider,
+		frames:           cachecommon.NewThreadSafeCache(frames),
+		cursors:          make(map[dispv2.BlobStatus]*blobstore.StatusIndexCursor),
+		pollInterval:     pollInterval,
+		batchSize:        int32(batchSize),
+		maxConcurrency:   maxConcurrency,
+		metrics:          metrics,
+	}, nil
+}
+
+// run polls the metadata store for newly certified blobs until the context is cancelled.
+func (p *cachePrewarmer) run() {
+	if p == nil {
+		return
+	}
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		default:
+			foundData := false
+			for _, status := range cachePrewarmStatuses {
+				found, err := p.prewarmBlobs(status)
+				if err != nil {
+					p.logger.Errorf("Error pre-warming %s blobs: %v", status.String(), err)
+				}
+				foundData = foundData || found
+			}
+
+			if !foundData {
+				// No data found, back off for a bit
+				select {
+				case <-time.After(p.pollInterval):
+				case <-p.ctx.Done():
+				}
+			}
+		}
+	}
+}
+
+// prewarmBlobs fetches a batch of blobs with the given status from the metadata store, and loads those that
+// are assigned to this relay. Returns true if at least one blob was fetched, false otherwise.
+func (p *cachePrewarmer) prewarmBlobs(status dispv2.BlobStatus) (bool, error) {
+	blobMetadatas, cursor, err := p.metadataStore.GetBlobMetadataByStatusPaginated(
+		p.ctx,
+		status,
+		p.cursors[status],
+		p.batchSize)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch blobs from metadata store: %w", err)
+	}
+	if cursor != nil {
+		p.cursors[status] = cursor
+	}
+
+	runner := errgroup.Group{}
+	runner.SetLimit(p.maxConcurrency)
+
+	for _, blobMetadata := range blobMetadatas {
+		if blobMetadata == nil || blobMetadata.BlobHeader == nil {
+			continue
+		}
+
+		blobKey, err := blobMetadata.BlobHeader.BlobKey()
+		if err != nil {
+			p.logger.Errorf("Failed to compute blob key, skipping: %v", err)
+			continue
+		}
+
+		if _, ok := p.frames.Get(blobKey); ok {
+			// Already pre-warmed while the blob had an earlier status.
+			continue
+		}
+
+		runner.Go(func() error {
+			p.prewarmBlob(blobKey)
+			return nil
+		})
+	}
+
+	_ = runner.Wait()
+	p.metrics.ReportSize(p.frames.Weight())
+
+	return len(blobMetadatas) > 0, nil
+}
+
+// prewarmBlob loads the metadata and chunks of a single blob.
+func (p *cachePrewarmer) prewarmBlob(blobKey v2.BlobKey) {
+	mMap, err := p.metadataProvider.GetMetadataForBlobs(p.ctx, []v2.BlobKey{blobKey})
+	if err != nil {
+		// Most likely the blob is assigned to a different relay.
+		p.logger.Debugf("Not pre-warming blob %s: %v", blobKey.Hex(), err)
+		p.metrics.ReportBlob("not_assigned")
+		return
+	}
+
+	frames, err := p.chunkProvider.fetchFrames(blobKeyWithMetadata{blobKey: blobKey, metadata: *mMap[blobKey]})
+	if err != nil {
+		p.logger.Warnf("Failed to pre-warm chunks for blob %s: %v", blobKey.Hex(), err)
+		p.metrics.ReportBlob("failed")
+		return
+	}
+
+	p.frames.Put(blobKey, frames)
+	p.metrics.ReportBlob("loaded")
+}
+
+// getPrewarmedChunkData fills in chunkDataObjects with the chunks of each range request that can be served from
+// pre-warmed blobs. Returns a request for the chunks that could not be served, and the index within the original
+// request of each of its chunk requests.
+func (p *cachePrewarmer) getPrewarmedChunkData(
+	request *pb.GetChunksRequest,
+	chunkDataObjects []*core.ChunksData,
+) (*pb.GetChunksRequest, []int) {
+
+	missIndices := make([]int, 0, len(request.GetChunkRequests()))
+	if p == nil {
+		for i := range request.GetChunkRequests() {
+			missIndices = append(missIndices, i)
+		}
+		return request, missIndices
+	}
+
+	misses := make([]*pb.ChunkRequest, 0, len(request.GetChunkRequests()))
+	for i, chunkRequest := range request.GetChunkRequests() {
+		byRange := chunkRequest.GetByRange()
+		blobKey := v2.BlobKey(byRange.GetBlobKey())
+
+		frames, ok := p.frames.Get(blobKey)
+		if ok {
+			// Selecting a subset copies the list of chunks, so the pre-warmed data is never modified.
+			chunkData, err := selectFrameSubsetByRange(
+				[]*pb.ChunkRequestByRange{byRange},
+				frameMap{blobKey: frames})
+			if err == nil {
+				chunkDataObjects[i] = chunkData
+				p.metrics.ReportLookup(true)
+				continue
+			}
+			// Let the chunk store decide how to handle a range that doesn't fit the blob.
+		}
+
+		p.metrics.ReportLookup(false)
+		misses = append(misses, chunkRequest)
+		missIndices = append(missIndices, i)
+	}
+
+	return &pb.GetChunksRequest{ChunkRequests: misses}, missIndices
+}
@@ -0,0 +1,279 @@
+package relay
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	"github.com/Layr-Labs/eigenda/core"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/relay/metrics"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func randomChunksData(rand *random.TestRandom, chunkCount int) *core.ChunksData {
+	chunks := make([][]byte, chunkCount)
+	for i := range chunks {
+		chunks[i] = rand.Bytes(64)
+	}
+	return &core.ChunksData{
+		Chunks:   chunks,
+		Format:   core.GnarkChunkEncodingFormat,
+		ChunkLen: 1,
+	}
+}
+
+func newTestCachePrewarmer(t *testing.T, budgetBytes uint64) *cachePrewarmer {
+	t.Helper()
+
+	prewarmer, err := newCachePrewarmer(
+		t.Context(),
+		logger,
+		&blobstore.BlobMetadataStore{}, // not used in this test
+		nil,
+		nil,
+		budgetBytes,
+		time.Second,
+		1,
+		1,
+		metrics.NewCachePrewarmMetrics(prometheus.NewRegistry()))
+	require.NoError(t, err)
+	require.NotNil(t, prewarmer)
+
+	return prewarmer
+}
+
+func TestGetPrewarmedChunkData(t *testing.T) {
+	rand := random.NewTestRandom()
+
+	prewarmer := newTestCachePrewarmer(t, 1024*1024)
+
+	prewarmedKey := v2.BlobKey(rand.Bytes(32))
+	prewarmedFrames := randomChunksData(rand, 8)
+	prewarmer.frames.Put(prewarmedKey, prewarmedFrames)
+	otherKey := v2.BlobKey(rand.Bytes(32))
+
+	byRange := func(key v2.BlobKey, start uint32, end uint32) *pb.ChunkRequest {
+		return &pb.ChunkRequest{
+			Request: &pb.ChunkRequest_ByRange{
+				ByRange: &pb.ChunkRequestByRange{
+					BlobKey:    key[:],
+					StartIndex: start,
+					EndIndex:   end,
+				},
+			},
+		}
+	}
+
+	request := &pb.GetChunksRequest{
+		ChunkRequests: []*pb.ChunkRequest{
+			byRange(prewarmedKey, 0, 2),
+			byRange(otherKey, 0, 1),
+			byRange(prewarmedKey, 3, 5),
+			// doesn't fit the blob, so it is left to the chunk store
+			byRange(prewarmedKey, 4, 100),
+		},
+	}
+
+	chunkDataObjects := make([]*core.ChunksData, len(request.GetChunkRequests()))
+	missRequest, missIndices := prewarmer.getPrewarmedChunkData(request, chunkDataObjects)
+
+	require.Equal(t, []int{1, 3}, missIndices)
+	require.Equal(t,
+		[]*pb.ChunkRequest{request.GetChunkRequests()[1], request.GetChunkRequests()[3]},
+		missRequest.GetChunkRequests())
+
+	require.Equal(t, prewarmedFrames.Chunks[0:2], chunkDataObjects[0].Chunks)
+	require.Equal(t, prewarmedFrames.Chunks[3:5], chunkDataObjects[2].Chunks)
+	require.Nil(t, chunkDataObjects[1])
+	require.Nil(t, chunkDataObjects[3])
+
+	// Combining bundles must not modify the pre-warmed data.
+	chunkDataObjects[0].Chunks = append(chunkDataObjects[0].Chunks, chunkDataObjects[2].Chunks...)
+	cachedFrames, ok := prewarmer.frames.Get(prewarmedKey)
+	require.True(t, ok)
+	require.Equal(t, prewarmedFrames.Chunks[2], cachedFrames.Chunks[2])
+
+	// A nil prewarmer serves nothing.
+	var disabled *cachePrewarmer
+	chunkDataObjects = make([]*core.ChunksData, len(request.GetChunkRequests()))
+	missRequest, missIndices = disabled.getPrewarmedChunkData(request, chunkDataObjects)
+	require.Equal(t, request, missRequest)
+	require.Equal(t, []int{0, 1, 2, 3}, missIndices)
+}
+
+func TestCachePrewarmerBudget(t *testing.T) {
+	rand := random.NewTestRandom()
+
+	frameCount := 16
+	blobSize := randomChunksData(rand, frameCount).Size()
+	blobCount := 4
+	prewarmer := newTestCachePrewarmer(t, blobSize*uint64(blobCount))
+
+	keys := make([]v2.BlobKey, 0)
+	for i := 0; i < blobCount*2; i++ {
+		key := v2.BlobKey(rand.Bytes(32))
+		keys = append(keys, key)
+		prewarmer.frames.Put(key, randomChunksData(rand, frameCount))
+		require.LessOrEqual(t, prewarmer.frames.Weight(), blobSize*uint64(blobCount))
+	}
+
+	// The oldest blobs are evicted once the budget is exceeded.
+	for i, key := range keys {
+		_, ok := prewarmer.frames.Get(key)
+		require.Equal(t, i >= blobCount, ok)
+	}
+
+	// Pre-warming is disabled without a budget.
+	prewarmer, err := newCachePrewarmer(
+		t.Context(), logger, nil, nil, nil, 0, time.Second, 1, 1, nil)
+	require.NoError(t, err)
+	require.Nil(t, prewarmer)
+}
+
+func TestCachePrewarming(t *testing.T) {
+	ctx := t.Context()
+	rand := random.NewTestRandom()
+	setup(t)
+	defer teardown(t)
+
+	// These are used to write data to S3/dynamoDB
+	metadataStore := buildMetadataStore(t)
+	chunkReader, chunkWriter := buildChunkStore(t, logger)
+
+	config := defaultConfig()
+	config.RelayKeys = []v2.RelayKey{1}
+	config.AuthenticationDisabled = true
+	config.CachePrewarmBytes = 64 * 1024 * 1024
+	config.CachePrewarmPollInterval = 10 * time.Millisecond
+	config.CachePrewarmBatchSize = 2
+	config.CachePrewarmMaxConcurrency = 4
+
+	addr := fmt.Sprintf("0.0.0.0:%d", config.GRPCPort)
+	listener, err := net.Listen("tcp", addr)
+	require.NoError(t, err)
+
+	chainReader := newMockChainReader(t)
+	server, err := NewServer(
+		ctx,
+		prometheus.NewRegistry(),
+		logger,
+		config,
+		metadataStore,
+		nil, /* not used in this test */
+		chunkReader,
+		chainReader,
+		nil, /* not used in this test */
+		listener)
+	require.NoError(t, err)
+
+	go func() {
+		_ = server.Start(ctx)
+	}()
+
+	defer func() {
+		err = server.Stop()
+		require.NoError(t, err)
+	}()
+
+	expectedData := make(map[v2.BlobKey][]*encoding.Frame)
+	assignedKeys := make([]v2.BlobKey, 0)
+	unassignedKeys := make([]v2.BlobKey, 0)
+
+	blobCount := 5
+	for i := 0; i < blobCount; i++ {
+		header, _, chunks := randomBlobChunks(t)
+
+		blobKey, err := header.BlobKey()
+		require.NoError(t, err)
+		expectedData[blobKey] = chunks
+
+		relayKey := v2.RelayKey(1)
+		if i%2 == 1 {
+			relayKey = 2
+			unassignedKeys = append(unassignedKeys, blobKey)
+		} else {
+			assignedKeys = append(assignedKeys, blobKey)
+		}
+
+		coeffs, chunkProofs := disassembleFrames(t, chunks)
+		err = chunkWriter.PutFrameProofs(ctx, blobKey, chunkProofs)
+		require.NoError(t, err)
+		fragmentInfo, err := chunkWriter.PutFrameCoefficients(ctx, blobKey, coeffs)
+		require.NoError(t, err)
+
+		err = metadataStore.PutBlobCertificate(
+			ctx,
+			&v2.BlobCertificate{
+				BlobHeader: header,
+				RelayKeys:  []v2.RelayKey{relayKey},
+			},
+			&encoding.FragmentInfo{
+				SymbolsPerFrame: fragmentInfo.SymbolsPerFrame,
+			})
+		require.NoError(t, err)
+
+		now := uint64(time.Now().UnixNano())
+		err = metadataStore.PutBlobMetadata(ctx, &dispv2.BlobMetadata{
+			BlobHeader:  header,
+			BlobStatus:  dispv2.Encoded,
+			Expiry:      uint64(time.Now().Add(time.Hour).Unix()),
+			RequestedAt: now,
+			UpdatedAt:   now,
+		})
+		require.NoError(t, err)
+	}
+
+	// Only blobs assigned to this relay are pre-warmed.
+	require.Eventually(t, func() bool {
+		for _, key := range assignedKeys {
+			if _, ok := server.cachePrewarmer.frames.Get(key); !ok {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+	for _, key := range unassignedKeys {
+		_, ok := server.cachePrewarmer.frames.Get(key)
+		require.False(t, ok)
+	}
+
+	// Pre-warmed chunks are served as if they were downloaded from the chunk store.
+	operatorKeys := make(map[uint32]*core.KeyPair)
+	operatorKeys[0], err = rand.BLS()
+	require.NoError(t, err)
+
+	for _, key := range assignedKeys {
+		chunkCount := uint32(len(expectedData[key]))
+		start := rand.Uint32n(chunkCount)
+		end := start + 1 + rand.Uint32n(chunkCount-start)
+
+		response, err := getChunks(t, rand, operatorKeys, &pb.GetChunksRequest{
+			ChunkRequests: []*pb.ChunkRequest{
+				{
+					Request: &pb.ChunkRequest_ByRange{
+						ByRange: &pb.ChunkRequestByRange{
+							BlobKey:    key[:],
+							StartIndex: start,
+							EndIndex:   end,
+						},
+					},
+				},
+			},
+			Timestamp: uint32(time.Now().Unix()),
+		})
+		require.NoError(t, err)
+		require.Len(t, response.GetData(), 1)
+
+		bundle, err := core.Bundle{}.Deserialize(response.GetData()[0])
+		require.NoError(t, err)
+		require.Equal(t, expectedData[key][start:end], []*encoding.Frame(bundle))
+	}
+}
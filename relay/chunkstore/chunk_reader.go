@@ -117,14 +117,11 @@ func (r *chunkReader) GetBinaryChunkProofsRange(
 	endChunkIndex uint32,
 ) ([][]byte, bool, error) {
 
-	if firstChunkIndex >= endChunkIndex {
-		return nil, false, fmt.Errorf("invalid startIndex (%d) or endIndex (%d)", firstChunkIndex, endChunkIndex)
+	firstByteIndex, endByteIndex, err := proofsByteRange(firstChunkIndex, endChunkIndex)
+	if err != nil {
+		return nil, false, err
 	}
 
-	firstByteIndex := firstChunkIndex * encoding.SerializedProofLength
-	count := endChunkIndex - firstChunkIndex
-	size := count * encoding.SerializedProofLength
-
 	s3Key := s3.ScopedProofKey(blobKey)
 
 	data, found, err := r.client.DownloadPartialObject(
@@ -132,7 +129,7 @@ func (r *chunkReader) GetBinaryChunkProofsRange(
 		r.bucket,
 		s3Key,
 		int64(firstByteIndex),
-		int64(firstByteIndex+size))
+		int64(endByteIndex))
 	if err != nil {
 		return nil, false, fmt.Errorf("failed to download proofs from S3 for blob %s: %w", blobKey.Hex(), err)
 	}
@@ -157,18 +154,11 @@ func (r *chunkReader) GetBinaryChunkCoefficientRange(
 	symbolsPerFrame uint32,
 ) ([][]byte, bool, error) {
 
-	if startIndex >= endIndex {
-		return nil, false, fmt.Errorf("invalid startIndex (%d) or endIndex (%d)", startIndex, endIndex)
-	}
-
-	if symbolsPerFrame == 0 {
-		return nil, false, fmt.Errorf("symbolsPerFrame must be greater than 0")
+	firstByteIndex, endByteIndex, err := coefficientsByteRange(startIndex, endIndex, symbolsPerFrame)
+	if err != nil {
+		return nil, false, err
 	}
 
-	bytesPerFrame := encoding.BYTES_PER_SYMBOL * symbolsPerFrame
-	firstByteIndex := 4 + startIndex*bytesPerFrame
-	size := (endIndex - startIndex) * bytesPerFrame
-
 	s3Key := s3.ScopedChunkKey(blobKey)
 
 	data, found, err := r.client.DownloadPartialObject(
@@ -176,7 +166,7 @@ func (r *chunkReader) GetBinaryChunkCoefficientRange(
 		r.bucket,
 		s3Key,
 		int64(firstByteIndex),
-		int64(firstByteIndex+size))
+		int64(endByteIndex))
 	if err != nil {
 		return nil, false, fmt.Errorf("failed to download coefficients from S3 for blob %s: %w", blobKey.Hex(), err)
 	}
@@ -195,3 +185,33 @@ func (r *chunkReader) GetBinaryChunkCoefficientRange(
 
 	return frames, true, nil
 }
+
+// proofsByteRange returns the byte range [first, end) that holds the proofs with indices [startIndex, endIndex)
+// within a serialized proofs object.
+func proofsByteRange(startIndex uint32, endIndex uint32) (uint32, uint32, error) {
+	if startIndex >= endIndex {
+		return 0, 0, fmt.Errorf("invalid startIndex (%d) or endIndex (%d)", startIndex, endIndex)
+	}
+
+	firstByteIndex := startIndex * encoding.SerializedProofLength
+	size := (endIndex - startIndex) * encoding.SerializedProofLength
+	return firstByteIndex, firstByteIndex + size, nil
+}
+
+// coefficientsByteRange returns the byte range [first, end) that holds the frames with indices [startIndex, endIndex)
+// within a serialized coefficients object.
+func coefficientsByteRange(startIndex uint32, endIndex uint32, symbolsPerFrame uint32) (uint32, uint32, error) {
+	if startIndex >= endIndex {
+		return 0, 0, fmt.Errorf("invalid startIndex (%d) or endIndex (%d)", startIndex, endIndex)
+	}
+
+	if symbolsPerFrame == 0 {
+		return 0, 0, fmt.Errorf("symbolsPerFrame must be greater than 0")
+	}
+
+	// The object starts with a 4 byte header holding the number of symbols per frame.
+	bytesPerFrame := encoding.BYTES_PER_SYMBOL * symbolsPerFrame
+	firstByteIndex := 4 + startIndex*bytesPerFrame
+	size := (endIndex - startIndex) * bytesPerFrame
+	return firstByteIndex, firstByteIndex + size, nil
+}
@@ -1,6 +1,31 @@
 package chunkstore
 
+import "time"
+
 type Config struct {
 	BucketName string
 	Backend    string
 }
+
+// LocalTierConfig configures the optional on-disk tier that a relay keeps in front of the object store.
+type LocalTierConfig struct {
+	// Directories where the tier stores data. Data can be spread across several drives by providing several paths.
+	// The tier is disabled if no paths are provided.
+	Paths []string
+
+	// Maximum number of bytes the tier may occupy on disk. When this is exceeded, the oldest objects are evicted
+	// ahead of their TTL. Zero means no limit.
+	MaxSizeBytes uint64
+
+	// Time-to-live of objects in the tier. Zero means objects never expire.
+	TTL time.Duration
+
+	// Maximum time permitted for downloading an object from the object store into the tier. A download is shared
+	// by all readers of the object, so it isn't bound to any single reader's context. Zero means no timeout.
+	DownloadTimeout time.Duration
+}
+
+// Enabled returns whether the local tier is configured.
+func (c *LocalTierConfig) Enabled() bool {
+	return len(c.Paths) > 0
+}
@@ -0,0 +1,329 @@
+package chunkstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/s3"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/v2/rs"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/relay/metrics"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"golang.org/x/sync/singleflight"
+)
+
+// Names of the LittDB tables of the local tier, which are also used as metric labels.
+const (
+	proofsDataType       = "proofs"
+	coefficientsDataType = "coefficients"
+)
+
+var _ ChunkReader = (*LocalTierChunkReader)(nil)
+
+// LocalTierChunkReader is a ChunkReader that keeps a copy of the objects it reads from the object store on local
+// disk, in a LittDB instance. The first read of a blob's proofs or coefficients downloads the whole object, so that
+// all subsequent reads for the blob, whatever their range, are served locally. Since the tier is on disk, it is
+// still warm after a restart.
+//
+// Objects are immutable once written by the encoder, so the local copies never need to be invalidated.
+//
+// This struct is goroutine safe.
+type LocalTierChunkReader struct {
+	logger  logging.Logger
+	client  s3.S3Client
+	bucket  string
+	metrics *metrics.ChunkLocalTierMetrics
+
+	// The maximum time permitted for a download from the object store. Zero means no timeout.
+	downloadTimeout time.Duration
+
+	db                litt.DB
+	proofsTable       litt.Table
+	coefficientsTable litt.Table
+
+	// Deduplicates concurrent downloads of the same object, which are common when many validators ask for the
+	// chunks of a freshly dispersed blob at once.
+	downloads singleflight.Group
+	// Writes objects that may be downloaded by several readers, since a key can't be written twice.
+	writer litt.PutIfAbsentWriter
+}
+
+// NewLocalTierChunkReader creates a new LocalTierChunkReader, opening (or creating) the LittDB instance at the
+// configured paths. Close must be called once the reader is no longer needed.
+func NewLocalTierChunkReader(
+	logger logging.Logger,
+	s3Client s3.S3Client,
+	bucketName string,
+	config LocalTierConfig,
+	metrics *metrics.ChunkLocalTierMetrics,
+) (*LocalTierChunkReader, error) {
+
+	if !config.Enabled() {
+		return nil, fmt.Errorf("no paths configured for the local chunk tier")
+	}
+
+	littConfig, err := litt.DefaultConfig(config.Paths...)
+	if err != nil {
+		return nil, fmt.Errorf("littdb config: %w", err)
+	}
+	littConfig.Logger = logger
+	// The tier is a cache of the object store, so objects lost in a crash are simply downloaded again.
+	littConfig.Fsync = false
+	if config.MaxSizeBytes > 0 {
+		littConfig.DBQuota = config.MaxSizeBytes
+		littConfig.QuotaPolicy = types.QuotaPolicyEvict
+	}
+
+	db, err := littbuilder.NewDB(littConfig)
+	if err != nil {
+		return nil, fmt.Errorf("open littdb at %v: %w", config.Paths, err)
+	}
+
+	reader := &LocalTierChunkReader{
+		logger:          logger,
+		client:          s3Client,
+		bucket:          bucketName,
+		metrics:         metrics,
+		downloadTimeout: config.DownloadTimeout,
+		db:              db,
+	}
+
+	reader.proofsTable, err = getTableWithTTL(db, proofsDataType, config)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	reader.coefficientsTable, err = getTableWithTTL(db, coefficientsDataType, config)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return reader, nil
+}
+
+// getTableWithTTL gets a LittDB table, and applies the configured TTL to it. The TTL is persisted with the table, so
+// this updates it in case the configured value changed since the last run.
+func getTableWithTTL(db litt.DB, name string, config LocalTierConfig) (litt.Table, error) {
+	table, err := db.GetTable(name)
+	if err != nil {
+		return nil, fmt.Errorf("get littdb table %s: %w", name, err)
+	}
+	err = table.SetTTL(config.TTL)
+	if err != nil {
+		return nil, fmt.Errorf("set TTL of littdb table %s: %w", name, err)
+	}
+	return table, nil
+}
+
+// Close releases the LittDB instance. The reader must not be used after it is closed.
+func (r *LocalTierChunkReader) Close() error {
+	err := r.db.Close()
+	if err != nil {
+		return fmt.Errorf("close littdb: %w", err)
+	}
+	return nil
+}
+
+func (r *LocalTierChunkReader) GetBinaryChunkProofs(ctx context.Context, blobKey corev2.BlobKey) ([][]byte, error) {
+	data, found, err := r.getObject(ctx, r.proofsTable, proofsDataType, s3.ScopedProofKey(blobKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proofs for blob %s: %w", blobKey.Hex(), err)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("proofs not found for blob %s", blobKey.Hex())
+	}
+
+	proofs, err := encoding.SplitSerializedFrameProofs(bytes.Clone(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to split proofs for blob %s: %w", blobKey.Hex(), err)
+	}
+
+	return proofs, nil
+}
+
+func (r *LocalTierChunkReader) GetBinaryChunkCoefficients(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+) (uint32, [][]byte, error) {
+
+	data, found, err := r.getObject(ctx, r.coefficientsTable, coefficientsDataType, s3.ScopedChunkKey(blobKey))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get coefficients for blob %s: %w", blobKey.Hex(), err)
+	}
+
+	if !found {
+		return 0, nil, fmt.Errorf("coefficients not found for blob %s", blobKey.Hex())
+	}
+
+	elementCount, frames, err := rs.SplitSerializedFrameCoeffs(bytes.Clone(data))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to split coefficient frames for blob %s: %w", blobKey.Hex(), err)
+	}
+
+	return elementCount, frames, nil
+}
+
+func (r *LocalTierChunkReader) GetBinaryChunkProofsRange(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	startIndex uint32,
+	endIndex uint32,
+) ([][]byte, bool, error) {
+
+	firstByteIndex, endByteIndex, err := proofsByteRange(startIndex, endIndex)
+	if err != nil {
+		return nil, false, err
+	}
+
+	data, found, err := r.getObjectRange(
+		ctx, r.proofsTable, proofsDataType, s3.ScopedProofKey(blobKey), firstByteIndex, endByteIndex)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get proofs for blob %s: %w", blobKey.Hex(), err)
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	proofs, err := encoding.SplitSerializedFrameProofs(data)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to split proofs for blob %s: %w", blobKey.Hex(), err)
+	}
+
+	return proofs, true, nil
+}
+
+func (r *LocalTierChunkReader) GetBinaryChunkCoefficientRange(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	startIndex uint32,
+	endIndex uint32,
+	symbolsPerFrame uint32,
+) ([][]byte, bool, error) {
+
+	firstByteIndex, endByteIndex, err := coefficientsByteRange(startIndex, endIndex, symbolsPerFrame)
+	if err != nil {
+		return nil, false, err
+	}
+
+	data, found, err := r.getObjectRange(
+		ctx, r.coefficientsTable, coefficientsDataType, s3.ScopedChunkKey(blobKey), firstByteIndex, endByteIndex)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get coefficients for blob %s: %w", blobKey.Hex(), err)
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	frames, err := rs.SplitSerializedFrameCoeffsWithElementCount(data, symbolsPerFrame)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"failed to split coefficient frames for blob %s, symbols per frame %d: %w",
+			blobKey.Hex(), symbolsPerFrame, err)
+	}
+
+	return frames, true, nil
+}
+
+// getObjectRange returns a copy of the bytes [firstByteIndex, endByteIndex) of an object. Like partial downloads from
+// the object store, the range must be within the bounds of the object.
+func (r *LocalTierChunkReader) getObjectRange(
+	ctx context.Context,
+	table litt.Table,
+	dataType string,
+	key string,
+	firstByteIndex uint32,
+	endByteIndex uint32,
+) ([]byte, bool, error) {
+
+	data, found, err := r.getObject(ctx, table, dataType, key)
+	if err != nil || !found {
+		return nil, found, err
+	}
+
+	if uint64(endByteIndex) > uint64(len(data)) {
+		return nil, false, fmt.Errorf(
+			"range [%d, %d) is out of bounds of object %s with length %d",
+			firstByteIndex, endByteIndex, key, len(data))
+	}
+
+	// LittDB does not permit values it returns to be mutated.
+	return bytes.Clone(data[firstByteIndex:endByteIndex]), true, nil
+}
+
+// getObject returns an object from the local tier, downloading it from the object store on a miss. The returned
+// bytes must not be mutated.
+func (r *LocalTierChunkReader) getObject(
+	ctx context.Context,
+	table litt.Table,
+	dataType string,
+	key string,
+) ([]byte, bool, error) {
+
+	data, exists, err := table.Get([]byte(key))
+	if err != nil {
+		return nil, false, fmt.Errorf("littdb Get: %w", err)
+	}
+	if exists {
+		r.metrics.ReportHit(dataType)
+		return data, true, nil
+	}
+	r.metrics.ReportMiss(dataType)
+
+	// The download is shared by every concurrent reader of the object, so it runs with its own timeout instead of
+	// the context of whichever reader started it. Each reader only waits for it as long as its own context allows.
+	downloadResult := r.downloads.DoChan(dataType+"/"+key, func() (interface{}, error) {
+		downloadCtx := context.WithoutCancel(ctx)
+		if r.downloadTimeout > 0 {
+			var cancel context.CancelFunc
+			downloadCtx, cancel = context.WithTimeout(downloadCtx, r.downloadTimeout)
+			defer cancel()
+		}
+
+		data, found, err := r.client.DownloadObject(downloadCtx, r.bucket, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download %s from S3: %w", dataType, err)
+		}
+		if !found {
+			return nil, nil
+		}
+
+		// Failing to populate the local tier doesn't prevent the object from being served.
+		err = r.put(table, key, data)
+		if err != nil {
+			r.metrics.ReportWriteFailure(dataType)
+			r.logger.Warn("failed to write object to the local chunk tier", "key", key, "error", err)
+		}
+		r.metrics.ReportSize(r.db.Size())
+
+		return data, nil
+	})
+
+	var result singleflight.Result
+	select {
+	case result = <-downloadResult:
+	case <-ctx.Done():
+		return nil, false, fmt.Errorf("waiting for download of object %s: %w", key, ctx.Err())
+	}
+	if result.Err != nil {
+		return nil, false, fmt.Errorf("fetch object %s: %w", key, result.Err)
+	}
+
+	data, _ = result.Val.([]byte)
+	return data, data != nil, nil
+}
+
+// put writes an object to the local tier. Writing an object that is already present is a no-op.
+func (r *LocalTierChunkReader) put(table litt.Table, key string, data []byte) error {
+	_, err := r.writer.PutIfAbsent(table, []byte(key), data)
+	return err
+}
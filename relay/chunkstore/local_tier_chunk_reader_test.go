@@ -0,0 +1,226 @@
+package chunkstore
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	s3common "github.com/Layr-Labs/eigenda/common/s3"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/v2/rs"
+	"github.com/Layr-Labs/eigenda/relay/metrics"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildLocalTierChunkReader(t *testing.T, client s3common.S3Client, paths ...string) *LocalTierChunkReader {
+	t.Helper()
+	return buildLocalTierChunkReaderWithConfig(t, client, LocalTierConfig{Paths: paths})
+}
+
+func buildLocalTierChunkReaderWithConfig(
+	t *testing.T,
+	client s3common.S3Client,
+	config LocalTierConfig,
+) *LocalTierChunkReader {
+	t.Helper()
+
+	reader, err := NewLocalTierChunkReader(
+		logger,
+		client,
+		bucket,
+		config,
+		metrics.NewChunkLocalTierMetrics(prometheus.NewRegistry()))
+	require.NoError(t, err)
+	return reader
+}
+
+// blockingS3Client is an S3 client whose downloads block until released, or until their context is done.
+type blockingS3Client struct {
+	*s3common.MockS3Client
+	started  chan struct{}
+	released chan struct{}
+}
+
+func (c *blockingS3Client) DownloadObject(ctx context.Context, bucket string, key string) ([]byte, bool, error) {
+	c.started <- struct{}{}
+	select {
+	case <-c.released:
+		return c.MockS3Client.DownloadObject(ctx, bucket, key)
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	}
+}
+
+func TestLocalTierProofs(t *testing.T) {
+	random.InitializeRandom()
+	ctx := t.Context()
+	client := s3common.NewMockS3Client()
+	writer := NewChunkWriter(client, bucket)
+	reader := buildLocalTierChunkReader(t, client, t.TempDir())
+	defer func() { require.NoError(t, reader.Close()) }()
+
+	key := corev2.BlobKey(random.RandomBytes(32))
+	proofs := getProofs(t, rand.Intn(100)+100)
+	err := writer.PutFrameProofs(ctx, key, proofs)
+	require.NoError(t, err)
+
+	// the first read is a miss, which downloads the whole object
+	startIndex := uint32(rand.Intn(50))
+	endIndex := startIndex + 1 + uint32(rand.Intn(50))
+	binaryProofs, found, err := reader.GetBinaryChunkProofsRange(ctx, key, startIndex, endIndex)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, proofs[startIndex:endIndex], encoding.DeserializeSplitFrameProofs(binaryProofs))
+	require.Equal(t, 1, client.Called["DownloadObject"])
+
+	// subsequent reads, of any range, are served from disk
+	binaryProofs, found, err = reader.GetBinaryChunkProofsRange(ctx, key, 0, uint32(len(proofs)))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, proofs, encoding.DeserializeSplitFrameProofs(binaryProofs))
+
+	binaryProofs, err = reader.GetBinaryChunkProofs(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, proofs, encoding.DeserializeSplitFrameProofs(binaryProofs))
+	require.Equal(t, 1, client.Called["DownloadObject"])
+	require.Equal(t, 0, client.Called["DownloadPartialObject"])
+
+	// out of bounds ranges are rejected, like partial downloads from the object store
+	_, _, err = reader.GetBinaryChunkProofsRange(ctx, key, 0, uint32(len(proofs))+1)
+	require.Error(t, err)
+
+	// missing objects are reported as not found
+	_, found, err = reader.GetBinaryChunkProofsRange(ctx, corev2.BlobKey(random.RandomBytes(32)), 0, 1)
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestLocalTierCoefficients(t *testing.T) {
+	random.InitializeRandom()
+	ctx := t.Context()
+	client := s3common.NewMockS3Client()
+	writer := NewChunkWriter(client, bucket)
+	reader := buildLocalTierChunkReader(t, client, t.TempDir())
+	defer func() { require.NoError(t, reader.Close()) }()
+
+	chunkSize := uint64(rand.Intn(1024) + 100)
+	params := encoding.ParamsFromSysPar(3, 1, chunkSize)
+	encoder, err := rs.NewEncoder(logger, encoding.DefaultConfig())
+	require.NoError(t, err)
+
+	key := corev2.BlobKey(random.RandomBytes(32))
+	coefficients := generateRandomFrameCoeffs(t, encoder, int(chunkSize), params)
+	_, err = writer.PutFrameCoefficients(ctx, key, coefficients)
+	require.NoError(t, err)
+
+	symbolsPerFrame := uint32(len(coefficients[0]))
+	for startIndex := 0; startIndex < len(coefficients); startIndex++ {
+		binaryFrames, found, err := reader.GetBinaryChunkCoefficientRange(
+			ctx, key, uint32(startIndex), uint32(len(coefficients)), symbolsPerFrame)
+		require.NoError(t, err)
+		require.True(t, found)
+		frames := rs.DeserializeSplitFrameCoeffs(symbolsPerFrame, binaryFrames)
+		require.Equal(t, coefficients[startIndex:], frames)
+	}
+
+	elementCount, binaryFrames, err := reader.GetBinaryChunkCoefficients(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, symbolsPerFrame, elementCount)
+	require.Equal(t, coefficients, rs.DeserializeSplitFrameCoeffs(elementCount, binaryFrames))
+
+	require.Equal(t, 1, client.Called["DownloadObject"])
+}
+
+// TestLocalTierSurvivesRestart checks that objects written to the local tier are still served after it is reopened.
+func TestLocalTierSurvivesRestart(t *testing.T) {
+	random.InitializeRandom()
+	ctx := t.Context()
+	client := s3common.NewMockS3Client()
+	writer := NewChunkWriter(client, bucket)
+	directory := t.TempDir()
+
+	key := corev2.BlobKey(random.RandomBytes(32))
+	proofs := getProofs(t, 10)
+	err := writer.PutFrameProofs(ctx, key, proofs)
+	require.NoError(t, err)
+
+	reader := buildLocalTierChunkReader(t, client, directory)
+	_, err = reader.GetBinaryChunkProofs(ctx, key)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+
+	reader = buildLocalTierChunkReader(t, client, directory)
+	defer func() { require.NoError(t, reader.Close()) }()
+	binaryProofs, found, err := reader.GetBinaryChunkProofsRange(ctx, key, 2, 5)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, proofs[2:5], encoding.DeserializeSplitFrameProofs(binaryProofs))
+	require.Equal(t, 1, client.Called["DownloadObject"])
+}
+
+func TestLocalTierSharedDownloadOutlivesCaller(t *testing.T) {
+	random.InitializeRandom()
+	client := &blockingS3Client{
+		MockS3Client: s3common.NewMockS3Client(),
+		started:      make(chan struct{}, 1),
+		released:     make(chan struct{}),
+	}
+	writer := NewChunkWriter(client.MockS3Client, bucket)
+	reader := buildLocalTierChunkReader(t, client, t.TempDir())
+	defer func() { require.NoError(t, reader.Close()) }()
+
+	key := corev2.BlobKey(random.RandomBytes(32))
+	proofs := getProofs(t, 10)
+	require.NoError(t, writer.PutFrameProofs(t.Context(), key, proofs))
+
+	// The first caller starts the download, then gives up on it.
+	firstCtx, cancelFirst := context.WithCancel(t.Context())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := reader.GetBinaryChunkProofs(firstCtx, key)
+		firstErr <- err
+	}()
+	<-client.started
+
+	// The second caller joins the same download.
+	secondResult := make(chan error, 1)
+	go func() {
+		binaryProofs, err := reader.GetBinaryChunkProofs(t.Context(), key)
+		if err == nil && !assert.Equal(t, proofs, encoding.DeserializeSplitFrameProofs(binaryProofs)) {
+			err = errors.New("unexpected proofs")
+		}
+		secondResult <- err
+	}()
+
+	// Cancelling the first caller returns immediately, without cancelling the shared download.
+	cancelFirst()
+	require.ErrorIs(t, <-firstErr, context.Canceled)
+
+	close(client.released)
+	require.NoError(t, <-secondResult)
+	require.Equal(t, 1, client.Called["DownloadObject"])
+}
+
+func TestLocalTierDownloadTimeout(t *testing.T) {
+	random.InitializeRandom()
+	client := &blockingS3Client{
+		MockS3Client: s3common.NewMockS3Client(),
+		started:      make(chan struct{}, 1),
+		released:     make(chan struct{}),
+	}
+	reader := buildLocalTierChunkReaderWithConfig(t, client, LocalTierConfig{
+		Paths:           []string{t.TempDir()},
+		DownloadTimeout: 10 * time.Millisecond,
+	})
+	defer func() { require.NoError(t, reader.Close()) }()
+
+	// Downloads that never complete are bounded by the download timeout, even if the caller's context isn't.
+	_, err := reader.GetBinaryChunkProofs(t.Context(), corev2.BlobKey(random.RandomBytes(32)))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
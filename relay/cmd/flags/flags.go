@@ -115,6 +115,65 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHUNK_MAX_CONCURRENCY"),
 		Value:    32,
 	}
+	ChunkLocalTierPathsFlag = cli.StringSliceFlag{
+		Name: common.PrefixFlag(FlagPrefix, "chunk-local-tier-paths"),
+		Usage: "Directories where proofs and coefficients downloaded from the object store are kept on local disk. " +
+			"The local tier is disabled if empty.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHUNK_LOCAL_TIER_PATHS"),
+	}
+	ChunkLocalTierMaxBytesFlag = cli.Uint64Flag{
+		Name: common.PrefixFlag(FlagPrefix, "chunk-local-tier-max-bytes"),
+		Usage: "Maximum size of the local chunk tier on disk, in bytes. The oldest objects are evicted once " +
+			"exceeded. 0 means no limit.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHUNK_LOCAL_TIER_MAX_BYTES"),
+		Value:    0,
+	}
+	ChunkLocalTierTTLFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-local-tier-ttl"),
+		Usage:    "Time-to-live of objects in the local chunk tier. 0 means objects never expire.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHUNK_LOCAL_TIER_TTL"),
+		Value:    0,
+	}
+	ChunkLocalTierDownloadTimeoutFlag = cli.DurationFlag{
+		Name: common.PrefixFlag(FlagPrefix, "chunk-local-tier-download-timeout"),
+		Usage: "Timeout for downloading an object from the object store into the local chunk tier. Downloads are " +
+			"shared by concurrent readers of the object. 0 means no timeout.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHUNK_LOCAL_TIER_DOWNLOAD_TIMEOUT"),
+		Value:    20 * time.Second,
+	}
+	CachePrewarmBytesFlag = cli.Uint64Flag{
+		Name: common.PrefixFlag(FlagPrefix, "cache-prewarm-bytes"),
+		Usage: "Memory budget, in bytes, for the chunks of newly certified blobs that are loaded before they are " +
+			"requested. Caches are not pre-warmed if 0.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CACHE_PREWARM_BYTES"),
+		Value:    0,
+	}
+	CachePrewarmPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "cache-prewarm-poll-interval"),
+		Usage:    "Time to wait before polling for newly certified blobs again when none are found",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CACHE_PREWARM_POLL_INTERVAL"),
+		Value:    time.Second,
+	}
+	CachePrewarmBatchSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "cache-prewarm-batch-size"),
+		Usage:    "Max number of newly certified blobs fetched from the metadata store in each poll",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CACHE_PREWARM_BATCH_SIZE"),
+		Value:    64,
+	}
+	CachePrewarmMaxConcurrencyFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "cache-prewarm-max-concurrency"),
+		Usage:    "Max number of blobs pre-warmed in parallel",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CACHE_PREWARM_MAX_CONCURRENCY"),
+		Value:    8,
+	}
 	MaxKeysPerGetChunksRequestFlag = cli.IntFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "max-keys-per-get-chunks-request"),
 		Usage:    "Max number of keys to fetch in a single GetChunks request",
@@ -122,6 +181,13 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_KEYS_PER_GET_CHUNKS_REQUEST"),
 		Value:    1024,
 	}
+	StreamChunksMaxBundlesInFlightFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "stream-chunks-max-bundles-in-flight"),
+		Usage:    "Max number of bundles a StreamChunks request downloads ahead of the bundle being sent",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STREAM_CHUNKS_MAX_BUNDLES_IN_FLIGHT"),
+		Value:    4,
+	}
 	MaxGetBlobOpsPerSecondFlag = cli.Float64Flag{
 		Name:     common.PrefixFlag(FlagPrefix, "max-get-blob-ops-per-second"),
 		Usage:    "Max number of GetBlob operations per second",
@@ -227,6 +293,60 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_CONCURRENT_GET_CHUNK_OPS_CLIENT"),
 		Value:    1,
 	}
+	SharedClientLimitsBackendFlag = cli.StringFlag{
+		Name: common.PrefixFlag(FlagPrefix, "shared-client-limits-backend"),
+		Usage: "Where per-client GetChunk rate limits are kept. Empty means each relay replica keeps its own, " +
+			"\"redis\" shares them across all replicas using the same Redis server.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SHARED_CLIENT_LIMITS_BACKEND"),
+		Value:    "",
+	}
+	SharedClientLimitsRedisAddressFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "shared-client-limits-redis-address"),
+		Usage:    "Address (host:port) of the Redis server holding shared per-client rate limits",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SHARED_CLIENT_LIMITS_REDIS_ADDRESS"),
+	}
+	SharedClientLimitsRedisUsernameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "shared-client-limits-redis-username"),
+		Usage:    "Username used to authenticate to the Redis server holding shared per-client rate limits",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SHARED_CLIENT_LIMITS_REDIS_USERNAME"),
+	}
+	SharedClientLimitsRedisPasswordFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "shared-client-limits-redis-password"),
+		Usage:    "Password used to authenticate to the Redis server holding shared per-client rate limits",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SHARED_CLIENT_LIMITS_REDIS_PASSWORD"),
+	}
+	SharedClientLimitsRedisDBFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "shared-client-limits-redis-db"),
+		Usage:    "Index of the Redis database holding shared per-client rate limits",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SHARED_CLIENT_LIMITS_REDIS_DB"),
+		Value:    0,
+	}
+	SharedClientLimitsRedisEnableTLSFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "shared-client-limits-redis-enable-tls"),
+		Usage:    "Enable TLS on the connection to the Redis server holding shared per-client rate limits",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SHARED_CLIENT_LIMITS_REDIS_ENABLE_TLS"),
+	}
+	SharedClientLimitsKeyPrefixFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "shared-client-limits-key-prefix"),
+		Usage:    "Prefix of the keys of shared per-client rate limits. Must be the same for all replicas of a relay.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SHARED_CLIENT_LIMITS_KEY_PREFIX"),
+		Value:    "eigenda-relay-limiter",
+	}
+	SharedClientLimitsTimeoutFlag = cli.DurationFlag{
+		Name: common.PrefixFlag(FlagPrefix, "shared-client-limits-timeout"),
+		Usage: "Max time spent waiting for shared per-client rate limits, " +
+			"after which the relay falls back to its own per-client limits",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SHARED_CLIENT_LIMITS_TIMEOUT"),
+		Value:    50 * time.Millisecond,
+	}
 	OperatorStateRetrieverAddrFlag = cli.StringFlag{
 		Name: common.PrefixFlag(FlagPrefix, "bls-operator-state-retriever-addr"),
 		Usage: "[Deprecated: use EigenDADirectory instead] Address of the OperatorStateRetriever contract. " +
@@ -399,7 +519,16 @@ var optionalFlags = []cli.Flag{
 	BlobMaxConcurrencyFlag,
 	ChunkCacheBytesFlag,
 	ChunkMaxConcurrencyFlag,
+	ChunkLocalTierPathsFlag,
+	ChunkLocalTierMaxBytesFlag,
+	ChunkLocalTierTTLFlag,
+	ChunkLocalTierDownloadTimeoutFlag,
 	MaxKeysPerGetChunksRequestFlag,
+	StreamChunksMaxBundlesInFlightFlag,
+	CachePrewarmBytesFlag,
+	CachePrewarmPollIntervalFlag,
+	CachePrewarmBatchSizeFlag,
+	CachePrewarmMaxConcurrencyFlag,
 	MaxGetBlobOpsPerSecondFlag,
 	GetBlobOpsBurstinessFlag,
 	MaxGetBlobBytesPerSecondFlag,
@@ -415,6 +544,14 @@ var optionalFlags = []cli.Flag{
 	MaxGetChunkBytesPerSecondClientFlag,
 	GetChunkBytesBurstinessClientFlag,
 	MaxConcurrentGetChunkOpsClientFlag,
+	SharedClientLimitsBackendFlag,
+	SharedClientLimitsRedisAddressFlag,
+	SharedClientLimitsRedisUsernameFlag,
+	SharedClientLimitsRedisPasswordFlag,
+	SharedClientLimitsRedisDBFlag,
+	SharedClientLimitsRedisEnableTLSFlag,
+	SharedClientLimitsKeyPrefixFlag,
+	SharedClientLimitsTimeoutFlag,
 	AuthenticationKeyCacheSizeFlag,
 	AuthenticationTimeoutFlag,
 	AuthenticationDisabledFlag,
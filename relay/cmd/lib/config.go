@@ -9,6 +9,7 @@ import (
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	core "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/relay"
+	"github.com/Layr-Labs/eigenda/relay/chunkstore"
 	"github.com/Layr-Labs/eigenda/relay/cmd/flags"
 	"github.com/Layr-Labs/eigenda/relay/limiter"
 	"github.com/urfave/cli"
@@ -34,6 +35,9 @@ type Config struct {
 	OCICompartmentID string
 	OCINamespace     string
 
+	// ChunkLocalTier configures the optional on-disk tier in front of the object store for chunk reads.
+	ChunkLocalTier chunkstore.LocalTierConfig
+
 	// MetadataTableName is the name of the DynamoDB table that stores metadata. Default is "metadata".
 	MetadataTableName string
 
@@ -68,33 +72,52 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		OCICompartmentID:     ctx.String(flags.OCICompartmentIDFlag.Name),
 		OCINamespace:         ctx.String(flags.OCINamespaceFlag.Name),
 		MetadataTableName:    ctx.String(flags.MetadataTableNameFlag.Name),
+		ChunkLocalTier: chunkstore.LocalTierConfig{
+			Paths:           ctx.StringSlice(flags.ChunkLocalTierPathsFlag.Name),
+			MaxSizeBytes:    ctx.Uint64(flags.ChunkLocalTierMaxBytesFlag.Name),
+			TTL:             ctx.Duration(flags.ChunkLocalTierTTLFlag.Name),
+			DownloadTimeout: ctx.Duration(flags.ChunkLocalTierDownloadTimeoutFlag.Name),
+		},
 		RelayConfig: relay.Config{
-			RelayKeys:                  make([]core.RelayKey, len(relayKeys)),
-			GRPCPort:                   ctx.Int(flags.GRPCPortFlag.Name),
-			MaxGRPCMessageSize:         ctx.Int(flags.MaxGRPCMessageSizeFlag.Name),
-			MetadataCacheSize:          ctx.Int(flags.MetadataCacheSizeFlag.Name),
-			MetadataMaxConcurrency:     ctx.Int(flags.MetadataMaxConcurrencyFlag.Name),
-			BlobCacheBytes:             ctx.Uint64(flags.BlobCacheBytes.Name),
-			BlobMaxConcurrency:         ctx.Int(flags.BlobMaxConcurrencyFlag.Name),
-			ChunkCacheBytes:            ctx.Uint64(flags.ChunkCacheBytesFlag.Name),
-			ChunkMaxConcurrency:        ctx.Int(flags.ChunkMaxConcurrencyFlag.Name),
-			MaxKeysPerGetChunksRequest: ctx.Int(flags.MaxKeysPerGetChunksRequestFlag.Name),
+			RelayKeys:                      make([]core.RelayKey, len(relayKeys)),
+			GRPCPort:                       ctx.Int(flags.GRPCPortFlag.Name),
+			MaxGRPCMessageSize:             ctx.Int(flags.MaxGRPCMessageSizeFlag.Name),
+			MetadataCacheSize:              ctx.Int(flags.MetadataCacheSizeFlag.Name),
+			MetadataMaxConcurrency:         ctx.Int(flags.MetadataMaxConcurrencyFlag.Name),
+			BlobCacheBytes:                 ctx.Uint64(flags.BlobCacheBytes.Name),
+			BlobMaxConcurrency:             ctx.Int(flags.BlobMaxConcurrencyFlag.Name),
+			ChunkCacheBytes:                ctx.Uint64(flags.ChunkCacheBytesFlag.Name),
+			ChunkMaxConcurrency:            ctx.Int(flags.ChunkMaxConcurrencyFlag.Name),
+			MaxKeysPerGetChunksRequest:     ctx.Int(flags.MaxKeysPerGetChunksRequestFlag.Name),
+			StreamChunksMaxBundlesInFlight: ctx.Int(flags.StreamChunksMaxBundlesInFlightFlag.Name),
+			CachePrewarmBytes:              ctx.Uint64(flags.CachePrewarmBytesFlag.Name),
+			CachePrewarmPollInterval:       ctx.Duration(flags.CachePrewarmPollIntervalFlag.Name),
+			CachePrewarmBatchSize:          ctx.Int(flags.CachePrewarmBatchSizeFlag.Name),
+			CachePrewarmMaxConcurrency:     ctx.Int(flags.CachePrewarmMaxConcurrencyFlag.Name),
 			RateLimits: limiter.Config{
-				MaxGetBlobOpsPerSecond:          ctx.Float64(flags.MaxGetBlobOpsPerSecondFlag.Name),
-				GetBlobOpsBurstiness:            ctx.Int(flags.GetBlobOpsBurstinessFlag.Name),
-				MaxGetBlobBytesPerSecond:        ctx.Float64(flags.MaxGetBlobBytesPerSecondFlag.Name),
-				GetBlobBytesBurstiness:          ctx.Int(flags.GetBlobBytesBurstinessFlag.Name),
-				MaxConcurrentGetBlobOps:         ctx.Int(flags.MaxConcurrentGetBlobOpsFlag.Name),
-				MaxGetChunkOpsPerSecond:         ctx.Float64(flags.MaxGetChunkOpsPerSecondFlag.Name),
-				GetChunkOpsBurstiness:           ctx.Int(flags.GetChunkOpsBurstinessFlag.Name),
-				MaxGetChunkBytesPerSecond:       ctx.Float64(flags.MaxGetChunkBytesPerSecondFlag.Name),
-				GetChunkBytesBurstiness:         ctx.Int(flags.GetChunkBytesBurstinessFlag.Name),
-				MaxConcurrentGetChunkOps:        ctx.Int(flags.MaxConcurrentGetChunkOpsFlag.Name),
-				MaxGetChunkOpsPerSecondClient:   ctx.Float64(flags.MaxGetChunkOpsPerSecondClientFlag.Name),
-				GetChunkOpsBurstinessClient:     ctx.Int(flags.GetChunkOpsBurstinessClientFlag.Name),
-				MaxGetChunkBytesPerSecondClient: ctx.Float64(flags.MaxGetChunkBytesPerSecondClientFlag.Name),
-				GetChunkBytesBurstinessClient:   ctx.Int(flags.GetChunkBytesBurstinessClientFlag.Name),
-				MaxConcurrentGetChunkOpsClient:  ctx.Int(flags.MaxConcurrentGetChunkOpsClientFlag.Name),
+				MaxGetBlobOpsPerSecond:           ctx.Float64(flags.MaxGetBlobOpsPerSecondFlag.Name),
+				GetBlobOpsBurstiness:             ctx.Int(flags.GetBlobOpsBurstinessFlag.Name),
+				MaxGetBlobBytesPerSecond:         ctx.Float64(flags.MaxGetBlobBytesPerSecondFlag.Name),
+				GetBlobBytesBurstiness:           ctx.Int(flags.GetBlobBytesBurstinessFlag.Name),
+				MaxConcurrentGetBlobOps:          ctx.Int(flags.MaxConcurrentGetBlobOpsFlag.Name),
+				MaxGetChunkOpsPerSecond:          ctx.Float64(flags.MaxGetChunkOpsPerSecondFlag.Name),
+				GetChunkOpsBurstiness:            ctx.Int(flags.GetChunkOpsBurstinessFlag.Name),
+				MaxGetChunkBytesPerSecond:        ctx.Float64(flags.MaxGetChunkBytesPerSecondFlag.Name),
+				GetChunkBytesBurstiness:          ctx.Int(flags.GetChunkBytesBurstinessFlag.Name),
+				MaxConcurrentGetChunkOps:         ctx.Int(flags.MaxConcurrentGetChunkOpsFlag.Name),
+				MaxGetChunkOpsPerSecondClient:    ctx.Float64(flags.MaxGetChunkOpsPerSecondClientFlag.Name),
+				GetChunkOpsBurstinessClient:      ctx.Int(flags.GetChunkOpsBurstinessClientFlag.Name),
+				MaxGetChunkBytesPerSecondClient:  ctx.Float64(flags.MaxGetChunkBytesPerSecondClientFlag.Name),
+				GetChunkBytesBurstinessClient:    ctx.Int(flags.GetChunkBytesBurstinessClientFlag.Name),
+				MaxConcurrentGetChunkOpsClient:   ctx.Int(flags.MaxConcurrentGetChunkOpsClientFlag.Name),
+				SharedClientLimitsBackend:        ctx.String(flags.SharedClientLimitsBackendFlag.Name),
+				SharedClientLimitsRedisAddress:   ctx.String(flags.SharedClientLimitsRedisAddressFlag.Name),
+				SharedClientLimitsRedisUsername:  ctx.String(flags.SharedClientLimitsRedisUsernameFlag.Name),
+				SharedClientLimitsRedisPassword:  ctx.String(flags.SharedClientLimitsRedisPasswordFlag.Name),
+				SharedClientLimitsRedisDB:        ctx.Int(flags.SharedClientLimitsRedisDBFlag.Name),
+				SharedClientLimitsRedisEnableTLS: ctx.Bool(flags.SharedClientLimitsRedisEnableTLSFlag.Name),
+				SharedClientLimitsKeyPrefix:      ctx.String(flags.SharedClientLimitsKeyPrefixFlag.Name),
+				SharedClientLimitsTimeout:        ctx.Duration(flags.SharedClientLimitsTimeoutFlag.Name),
 			},
 			AuthenticationKeyCacheSize:   ctx.Int(flags.AuthenticationKeyCacheSizeFlag.Name),
 			AuthenticationDisabled:       ctx.Bool(flags.AuthenticationDisabledFlag.Name),
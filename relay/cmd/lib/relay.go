@@ -17,6 +17,7 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/relay"
 	"github.com/Layr-Labs/eigenda/relay/chunkstore"
+	"github.com/Layr-Labs/eigenda/relay/metrics"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/urfave/cli"
@@ -78,6 +79,23 @@ func RunRelay(cliCtx *cli.Context) error {
 	// Create blob store and chunk reader
 	blobStore := blobstore.NewBlobStore(config.BucketName, objectStorageClient, logger)
 	chunkReader := chunkstore.NewChunkReader(objectStorageClient, config.BucketName)
+	if config.ChunkLocalTier.Enabled() {
+		localTierChunkReader, err := chunkstore.NewLocalTierChunkReader(
+			logger,
+			objectStorageClient,
+			config.BucketName,
+			config.ChunkLocalTier,
+			metrics.NewChunkLocalTierMetrics(metricsRegistry))
+		if err != nil {
+			return fmt.Errorf("failed to create local tier chunk reader: %w", err)
+		}
+		defer func() {
+			if err := localTierChunkReader.Close(); err != nil {
+				logger.Warn("Error closing local tier chunk reader", "error", err)
+			}
+		}()
+		chunkReader = localTierChunkReader
+	}
 
 	// Create eth writer
 	tx, err := eth.NewWriter(logger, ethClient, config.OperatorStateRetrieverAddr, config.EigenDAServiceManagerAddr)
@@ -40,9 +40,27 @@ type Config struct {
 	// impact concurrency utilized by the s3 client to upload/download fragmented files.
 	ChunkMaxConcurrency int
 
+	// CachePrewarmBytes is the memory budget, in bytes, for the chunks of newly certified blobs that the relay
+	// loads before they are requested. If zero, caches are not pre-warmed.
+	CachePrewarmBytes uint64
+
+	// CachePrewarmPollInterval is how long to wait before polling the metadata store again when no newly
+	// certified blobs are found.
+	CachePrewarmPollInterval time.Duration
+
+	// CachePrewarmBatchSize is the maximum number of blobs fetched from the metadata store in each poll.
+	CachePrewarmBatchSize int
+
+	// CachePrewarmMaxConcurrency is the maximum number of blobs pre-warmed in parallel.
+	CachePrewarmMaxConcurrency int
+
 	// MaxKeysPerGetChunksRequest is the maximum number of keys that can be requested in a single GetChunks request.
 	MaxKeysPerGetChunksRequest int
 
+	// StreamChunksMaxBundlesInFlight is the maximum number of bundles a StreamChunks call downloads ahead of the
+	// bundle it is currently sending. This bounds the memory used by each call. Default is 4.
+	StreamChunksMaxBundlesInFlight int
+
 	// RateLimits contains configuration for rate limiting.
 	RateLimits limiter.Config
 
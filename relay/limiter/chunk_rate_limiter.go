@@ -1,6 +1,7 @@
 package limiter
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -38,6 +39,19 @@ type ChunkRateLimiter struct {
 	// perClientOperationsInFlight is the number of GetChunk operations currently in flight for each client.
 	perClientOperationsInFlight map[string]int
 
+	// shared per-client limiters
+
+	// clientBuckets holds the per-client rate and bandwidth buckets shared with the other replicas of the relay.
+	// If nil, the per-client limiters above are used. If the shared buckets are unavailable, the replica falls back
+	// to the per-client limiters above.
+	clientBuckets ClientBucketStore
+
+	// clientBucketKeyPrefix is prepended to the keys of the shared buckets.
+	clientBucketKeyPrefix string
+
+	// clientBucketTimeout is the maximum time spent waiting for the shared buckets.
+	clientBucketTimeout time.Duration
+
 	// Encapsulates relay metrics.
 	relayMetrics *metrics.RelayMetrics
 
@@ -45,9 +59,11 @@ type ChunkRateLimiter struct {
 	lock sync.Mutex
 }
 
-// NewChunkRateLimiter creates a new ChunkRateLimiter.
+// NewChunkRateLimiter creates a new ChunkRateLimiter. If clientBuckets is not nil, per-client rate and bandwidth
+// limits are enforced with the buckets it holds, which may be shared with other relay replicas.
 func NewChunkRateLimiter(
 	config *Config,
+	clientBuckets ClientBucketStore,
 	relayMetrics *metrics.RelayMetrics) *ChunkRateLimiter {
 
 	globalOpLimiter := rate.NewLimiter(rate.Limit(
@@ -58,6 +74,15 @@ func NewChunkRateLimiter(
 		config.MaxGetChunkBytesPerSecond),
 		config.GetChunkBytesBurstiness)
 
+	clientBucketKeyPrefix := config.SharedClientLimitsKeyPrefix
+	if clientBucketKeyPrefix == "" {
+		clientBucketKeyPrefix = "eigenda-relay-limiter"
+	}
+	clientBucketTimeout := config.SharedClientLimitsTimeout
+	if clientBucketTimeout == 0 {
+		clientBucketTimeout = 50 * time.Millisecond
+	}
+
 	return &ChunkRateLimiter{
 		config:                      config,
 		globalOpLimiter:             globalOpLimiter,
@@ -65,6 +90,9 @@ func NewChunkRateLimiter(
 		perClientOpLimiter:          make(map[string]*rate.Limiter),
 		perClientBandwidthLimiter:   make(map[string]*rate.Limiter),
 		perClientOperationsInFlight: make(map[string]int),
+		clientBuckets:               clientBuckets,
+		clientBucketKeyPrefix:       clientBucketKeyPrefix,
+		clientBucketTimeout:         clientBucketTimeout,
 		relayMetrics:                relayMetrics,
 	}
 }
@@ -80,6 +108,38 @@ func (l *ChunkRateLimiter) BeginGetChunkOperation(
 		return nil
 	}
 
+	err := l.reserveGetChunkOperation(now, requesterID)
+	if err != nil {
+		return err
+	}
+	if l.clientBuckets == nil {
+		return nil
+	}
+
+	// The shared buckets are consulted without holding the lock, so that a slow backend doesn't serialize
+	// all operations. The reservation is released if the client is over its budget.
+	allowed := l.takeClientTokens(now, requesterID, "ops", 1,
+		l.config.MaxGetChunkOpsPerSecondClient, l.config.GetChunkOpsBurstinessClient, l.perClientOpLimiter)
+	if !allowed {
+		l.lock.Lock()
+		l.globalOperationsInFlight--
+		l.perClientOperationsInFlight[requesterID]--
+		l.globalOpLimiter.AllowN(now, -1)
+		l.lock.Unlock()
+
+		if l.relayMetrics != nil {
+			l.relayMetrics.ReportChunkRateLimited("client rate")
+		}
+		return fmt.Errorf("client rate limit %0.1fhz exceeded for GetChunks, try again later",
+			l.config.MaxGetChunkOpsPerSecondClient)
+	}
+
+	return nil
+}
+
+// reserveGetChunkOperation checks all limits of a GetChunk operation, except for the per-client rate limit if the
+// per-client buckets are shared, and counts the operation as in flight.
+func (l *ChunkRateLimiter) reserveGetChunkOperation(now time.Time, requesterID string) error {
 	l.lock.Lock()
 	defer l.lock.Unlock()
 
@@ -119,7 +179,7 @@ func (l *ChunkRateLimiter) BeginGetChunkOperation(
 		return fmt.Errorf("client concurrent request limit %d exceeded for GetChunks",
 			l.config.MaxConcurrentGetChunkOpsClient)
 	}
-	if l.perClientOpLimiter[requesterID].TokensAt(now) < 1 {
+	if l.clientBuckets == nil && l.perClientOpLimiter[requesterID].TokensAt(now) < 1 {
 		if l.relayMetrics != nil {
 			l.relayMetrics.ReportChunkRateLimited("client rate")
 		}
@@ -130,7 +190,9 @@ func (l *ChunkRateLimiter) BeginGetChunkOperation(
 	l.globalOperationsInFlight++
 	l.perClientOperationsInFlight[requesterID]++
 	l.globalOpLimiter.AllowN(now, 1)
-	l.perClientOpLimiter[requesterID].AllowN(now, 1)
+	if l.clientBuckets == nil {
+		l.perClientOpLimiter[requesterID].AllowN(now, 1)
+	}
 
 	return nil
 }
@@ -155,7 +217,7 @@ func (l *ChunkRateLimiter) RequestGetChunkBandwidth(now time.Time, requesterID s
 		return nil
 	}
 
-	// no lock needed here, as the bandwidth limiters themselves are thread-safe
+	// the lock is only needed to look up per-client limiters, as the bandwidth limiters themselves are thread-safe
 
 	allowed := l.globalBandwidthLimiter.AllowN(now, int(bytes))
 	if !allowed {
@@ -171,11 +233,14 @@ func (l *ChunkRateLimiter) RequestGetChunkBandwidth(now time.Time, requesterID s
 			rateLimit, burstiness)
 	}
 
-	limiter, ok := l.perClientBandwidthLimiter[requesterID]
+	l.lock.Lock()
+	_, ok := l.perClientBandwidthLimiter[requesterID]
+	l.lock.Unlock()
 	if !ok {
 		return fmt.Errorf("internal error, unable to find bandwidth limiter for client ID %s", requesterID)
 	}
-	allowed = limiter.AllowN(now, int(bytes))
+	allowed = l.takeClientTokens(now, requesterID, "bytes", bytes,
+		l.config.MaxGetChunkBytesPerSecondClient, l.config.GetChunkBytesBurstinessClient, l.perClientBandwidthLimiter)
 	if !allowed {
 		l.globalBandwidthLimiter.AllowN(now, -int(bytes))
 		if l.relayMetrics != nil {
@@ -192,3 +257,34 @@ func (l *ChunkRateLimiter) RequestGetChunkBandwidth(now time.Time, requesterID s
 
 	return nil
 }
+
+// takeClientTokens takes tokens from one of the buckets of a client. The shared bucket is used if there is one, and
+// the replica's own limiter, from localLimiters, otherwise or if the shared bucket is unavailable.
+func (l *ChunkRateLimiter) takeClientTokens(
+	now time.Time,
+	requesterID string,
+	bucketType string,
+	tokens uint32,
+	ratePerSecond float64,
+	burst int,
+	localLimiters map[string]*rate.Limiter,
+) bool {
+	if l.clientBuckets != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), l.clientBucketTimeout)
+		defer cancel()
+
+		key := fmt.Sprintf("%s:%s:%s", l.clientBucketKeyPrefix, bucketType, requesterID)
+		allowed, err := l.clientBuckets.TakeTokens(ctx, key, float64(tokens), ratePerSecond, burst, now)
+		if err == nil {
+			return allowed
+		}
+		if l.relayMetrics != nil {
+			l.relayMetrics.ReportSharedClientLimitsFailure()
+		}
+	}
+
+	l.lock.Lock()
+	limiter := localLimiters[requesterID]
+	l.lock.Unlock()
+	return limiter.AllowN(now, int(tokens))
+}
@@ -23,7 +23,7 @@ func TestConcurrentGetChunksOperations(t *testing.T) {
 
 	userID := random.RandomString(64)
 
-	limiter := NewChunkRateLimiter(config, nil)
+	limiter := NewChunkRateLimiter(config, nil, nil)
 
 	// time starts at current time, but advances manually afterward
 	now := time.Now()
@@ -57,7 +57,7 @@ func TestGetChunksRateLimit(t *testing.T) {
 
 	userID := random.RandomString(64)
 
-	limiter := NewChunkRateLimiter(config, nil)
+	limiter := NewChunkRateLimiter(config, nil, nil)
 
 	// time starts at current time, but advances manually afterward
 	now := time.Now()
@@ -121,7 +121,7 @@ func TestGetChunksBandwidthLimit(t *testing.T) {
 
 	userID := random.RandomString(64)
 
-	limiter := NewChunkRateLimiter(config, nil)
+	limiter := NewChunkRateLimiter(config, nil, nil)
 
 	// time starts at current time, but advances manually afterward
 	now := time.Now()
@@ -171,7 +171,7 @@ func TestPerClientConcurrencyLimit(t *testing.T) {
 	userID1 := random.RandomString(64)
 	userID2 := random.RandomString(64)
 
-	limiter := NewChunkRateLimiter(config, nil)
+	limiter := NewChunkRateLimiter(config, nil, nil)
 
 	// time starts at current time, but advances manually afterward
 	now := time.Now()
@@ -219,7 +219,7 @@ func TestOpLimitPerClient(t *testing.T) {
 	userID1 := random.RandomString(64)
 	userID2 := random.RandomString(64)
 
-	limiter := NewChunkRateLimiter(config, nil)
+	limiter := NewChunkRateLimiter(config, nil, nil)
 
 	// time starts at current time, but advances manually afterward
 	now := time.Now()
@@ -277,7 +277,7 @@ func TestBandwidthLimitPerClient(t *testing.T) {
 	userID1 := random.RandomString(64)
 	userID2 := random.RandomString(64)
 
-	limiter := NewChunkRateLimiter(config, nil)
+	limiter := NewChunkRateLimiter(config, nil, nil)
 
 	// time starts at current time, but advances manually afterward
 	now := time.Now()
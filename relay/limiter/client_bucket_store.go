@@ -0,0 +1,118 @@
+package limiter
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ClientBucketStore holds per-client token buckets. Implementations backed by a shared service (e.g. Redis) allow
+// the replicas of a relay to enforce a single budget per client, rather than one budget per replica.
+type ClientBucketStore interface {
+
+	// TakeTokens refills the bucket with the given key at ratePerSecond, up to burst tokens, and then takes the
+	// requested number of tokens if the bucket holds enough of them. Returns whether the tokens were taken.
+	// Buckets that don't exist yet start full.
+	//
+	// This is equivalent to rate.Limiter.AllowN, except that the rate and burst are provided with each call. now is
+	// the caller's clock, which stores shared by several replicas may ignore in favor of a clock of their own.
+	TakeTokens(
+		ctx context.Context,
+		key string,
+		tokens float64,
+		ratePerSecond float64,
+		burst int,
+		now time.Time,
+	) (bool, error)
+
+	// Close releases the resources held by the store.
+	Close() error
+}
+
+// NewClientBucketStore creates the ClientBucketStore selected by config.SharedClientLimitsBackend. Returns nil if
+// the per-client buckets are not shared, in which case each replica keeps its own buckets. The shared backend
+// doesn't need to be reachable when the store is created.
+func NewClientBucketStore(config *Config) (ClientBucketStore, error) {
+	switch config.SharedClientLimitsBackend {
+	case LocalClientLimitsBackend:
+		return nil, nil
+	case RedisClientLimitsBackend:
+		return NewRedisClientBucketStore(config), nil
+	default:
+		return nil, fmt.Errorf("unknown shared client limits backend %q", config.SharedClientLimitsBackend)
+	}
+}
+
+var _ ClientBucketStore = (*LocalClientBucketStore)(nil)
+
+// LocalClientBucketStore is an in-process ClientBucketStore. It stands in for a shared backend in tests: several
+// ChunkRateLimiters using the same LocalClientBucketStore behave like relay replicas sharing a backend.
+type LocalClientBucketStore struct {
+	buckets map[string]*tokenBucket
+	// if set, TakeTokens returns this error, which simulates an unavailable backend
+	err  error
+	lock sync.Mutex
+}
+
+// The state of a token bucket, as of the last time tokens were taken from it.
+type tokenBucket struct {
+	tokens     float64
+	lastUpdate time.Time
+}
+
+// NewLocalClientBucketStore creates a new LocalClientBucketStore.
+func NewLocalClientBucketStore() *LocalClientBucketStore {
+	return &LocalClientBucketStore{
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// SetError makes all subsequent calls to TakeTokens fail with err, until it is called again with nil.
+func (s *LocalClientBucketStore) SetError(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.err = err
+}
+
+func (s *LocalClientBucketStore) TakeTokens(
+	_ context.Context,
+	key string,
+	tokens float64,
+	ratePerSecond float64,
+	burst int,
+	now time.Time,
+) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.err != nil {
+		return false, s.err
+	}
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{
+			tokens:     float64(burst),
+			lastUpdate: now,
+		}
+		s.buckets[key] = bucket
+	}
+
+	if now.After(bucket.lastUpdate) {
+		refill := now.Sub(bucket.lastUpdate).Seconds() * ratePerSecond
+		bucket.tokens = math.Min(float64(burst), bucket.tokens+refill)
+		bucket.lastUpdate = now
+	}
+
+	if bucket.tokens < tokens {
+		return false, nil
+	}
+	bucket.tokens -= tokens
+	return true, nil
+}
+
+func (s *LocalClientBucketStore) Close() error {
+	return nil
+}
@@ -0,0 +1,238 @@
+package limiter
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/rand"
+)
+
+// TestSharedOpLimitPerClient checks that relay replicas sharing a ClientBucketStore enforce a single per-client
+// rate limit, rather than one limit per replica.
+func TestSharedOpLimitPerClient(t *testing.T) {
+	random.InitializeRandom()
+
+	config := defaultConfig()
+	config.MaxGetChunkOpsPerSecondClient = float64(2 + rand.Intn(10))
+	config.GetChunkOpsBurstinessClient = int(config.MaxGetChunkOpsPerSecondClient) + rand.Intn(10)
+	config.GetChunkOpsBurstiness = math.MaxInt32
+
+	store := NewLocalClientBucketStore()
+	limiter1 := NewChunkRateLimiter(config, store, nil)
+	limiter2 := NewChunkRateLimiter(config, store, nil)
+
+	userID := random.RandomString(64)
+
+	// time starts at current time, but advances manually afterward
+	now := time.Now()
+
+	// The burst is shared by both replicas.
+	for i := 0; i < config.GetChunkOpsBurstinessClient; i++ {
+		limiter := limiter1
+		if i%2 == 0 {
+			limiter = limiter2
+		}
+		err := limiter.BeginGetChunkOperation(now, userID)
+		require.NoError(t, err)
+		limiter.FinishGetChunkOperation(userID)
+	}
+
+	err := limiter1.BeginGetChunkOperation(now, userID)
+	require.Error(t, err)
+	err = limiter2.BeginGetChunkOperation(now, userID)
+	require.Error(t, err)
+
+	// Rejected operations are not left in flight.
+	require.Equal(t, 0, limiter1.globalOperationsInFlight)
+	require.Equal(t, 0, limiter1.perClientOperationsInFlight[userID])
+
+	// Advancing time by a second refills the shared bucket.
+	now = now.Add(time.Second)
+	for i := 0; i < int(config.MaxGetChunkOpsPerSecondClient); i++ {
+		err = limiter2.BeginGetChunkOperation(now, userID)
+		require.NoError(t, err)
+		limiter2.FinishGetChunkOperation(userID)
+	}
+	err = limiter1.BeginGetChunkOperation(now, userID)
+	require.Error(t, err)
+}
+
+// TestSharedBandwidthLimitPerClient checks that relay replicas sharing a ClientBucketStore enforce a single
+// per-client bandwidth limit.
+func TestSharedBandwidthLimitPerClient(t *testing.T) {
+	random.InitializeRandom()
+
+	config := defaultConfig()
+	config.MaxGetChunkBytesPerSecondClient = float64(1024 + rand.Intn(1024*1024))
+	config.GetChunkBytesBurstinessClient = int(config.MaxGetChunkBytesPerSecondClient) + rand.Intn(1024*1024)
+	config.GetChunkBytesBurstiness = math.MaxInt32
+	config.GetChunkOpsBurstiness = math.MaxInt32
+	config.GetChunkOpsBurstinessClient = math.MaxInt32
+
+	store := NewLocalClientBucketStore()
+	limiter1 := NewChunkRateLimiter(config, store, nil)
+	limiter2 := NewChunkRateLimiter(config, store, nil)
+
+	userID := random.RandomString(64)
+	now := time.Now()
+
+	// "register" the user ID with both replicas
+	for _, limiter := range []*ChunkRateLimiter{limiter1, limiter2} {
+		err := limiter.BeginGetChunkOperation(now, userID)
+		require.NoError(t, err)
+		limiter.FinishGetChunkOperation(userID)
+	}
+
+	half := uint32(config.GetChunkBytesBurstinessClient / 2)
+	err := limiter1.RequestGetChunkBandwidth(now, userID, half)
+	require.NoError(t, err)
+	err = limiter2.RequestGetChunkBandwidth(now, userID, uint32(config.GetChunkBytesBurstinessClient)-half)
+	require.NoError(t, err)
+
+	err = limiter1.RequestGetChunkBandwidth(now, userID, 1)
+	require.Error(t, err)
+	err = limiter2.RequestGetChunkBandwidth(now, userID, 1)
+	require.Error(t, err)
+}
+
+// TestSharedClientLimitsFallback checks that a replica falls back to its own per-client limits while the shared
+// backend is unavailable.
+func TestSharedClientLimitsFallback(t *testing.T) {
+	random.InitializeRandom()
+
+	config := defaultConfig()
+	config.MaxGetChunkOpsPerSecondClient = float64(2 + rand.Intn(10))
+	config.GetChunkOpsBurstinessClient = int(config.MaxGetChunkOpsPerSecondClient) + rand.Intn(10)
+	config.GetChunkOpsBurstiness = math.MaxInt32
+
+	store := NewLocalClientBucketStore()
+	store.SetError(errors.New("backend unavailable"))
+	limiter1 := NewChunkRateLimiter(config, store, nil)
+	limiter2 := NewChunkRateLimiter(config, store, nil)
+
+	userID := random.RandomString(64)
+	now := time.Now()
+
+	// Operations are still permitted, and each replica enforces the per-client limit on its own.
+	for _, limiter := range []*ChunkRateLimiter{limiter1, limiter2} {
+		for i := 0; i < config.GetChunkOpsBurstinessClient; i++ {
+			err := limiter.BeginGetChunkOperation(now, userID)
+			require.NoError(t, err)
+			limiter.FinishGetChunkOperation(userID)
+		}
+		err := limiter.BeginGetChunkOperation(now, userID)
+		require.Error(t, err)
+	}
+
+	// Once the backend is back, the shared limit is used again.
+	store.SetError(nil)
+	for i := 0; i < config.GetChunkOpsBurstinessClient; i++ {
+		err := limiter1.BeginGetChunkOperation(now, userID)
+		require.NoError(t, err)
+		limiter1.FinishGetChunkOperation(userID)
+	}
+	err := limiter2.BeginGetChunkOperation(now, userID)
+	require.Error(t, err)
+}
+
+func TestRedisClientBucketStore(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	store := NewRedisClientBucketStore(&Config{SharedClientLimitsRedisAddress: server.Addr()})
+	defer func() { require.NoError(t, store.Close()) }()
+
+	ratePerSecond := 10.0
+	burst := 20
+	// Buckets are refilled according to the Redis server's clock, so the time passed in by the caller is ignored.
+	now := time.Now()
+	server.SetTime(now)
+	var callerNow time.Time
+
+	// Buckets start full.
+	for i := 0; i < burst/5; i++ {
+		taken, err := store.TakeTokens(t.Context(), "key", 5, ratePerSecond, burst, callerNow)
+		require.NoError(t, err)
+		require.True(t, taken)
+	}
+	taken, err := store.TakeTokens(t.Context(), "key", 1, ratePerSecond, burst, callerNow)
+	require.NoError(t, err)
+	require.False(t, taken)
+
+	// Other keys have their own buckets.
+	taken, err = store.TakeTokens(t.Context(), "other-key", float64(burst), ratePerSecond, burst, callerNow)
+	require.NoError(t, err)
+	require.True(t, taken)
+
+	// Buckets refill at the configured rate, up to the burst.
+	now = now.Add(500 * time.Millisecond)
+	server.SetTime(now)
+	taken, err = store.TakeTokens(t.Context(), "key", 6, ratePerSecond, burst, callerNow)
+	require.NoError(t, err)
+	require.False(t, taken)
+	taken, err = store.TakeTokens(t.Context(), "key", 5, ratePerSecond, burst, callerNow)
+	require.NoError(t, err)
+	require.True(t, taken)
+
+	now = now.Add(time.Hour)
+	server.SetTime(now)
+	taken, err = store.TakeTokens(t.Context(), "key", float64(burst+1), ratePerSecond, burst, callerNow)
+	require.NoError(t, err)
+	require.False(t, taken)
+	taken, err = store.TakeTokens(t.Context(), "key", float64(burst), ratePerSecond, burst, callerNow)
+	require.NoError(t, err)
+	require.True(t, taken)
+
+	// Errors are reported once the server is gone.
+	server.Close()
+	_, err = store.TakeTokens(t.Context(), "key", 1, ratePerSecond, burst, callerNow)
+	require.Error(t, err)
+}
+
+func TestRedisClientBucketStoreAuthenticatedDB(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.RequireUserAuth("relay", "secret")
+
+	config := &Config{
+		SharedClientLimitsRedisAddress:  server.Addr(),
+		SharedClientLimitsRedisUsername: "relay",
+		SharedClientLimitsRedisPassword: "wrong",
+	}
+	store := NewRedisClientBucketStore(config)
+	defer func() { require.NoError(t, store.Close()) }()
+	_, err := store.TakeTokens(t.Context(), "key", 1, 10, 20, time.Now())
+	require.Error(t, err)
+
+	config.SharedClientLimitsRedisPassword = "secret"
+	config.SharedClientLimitsRedisDB = 3
+	authenticatedStore := NewRedisClientBucketStore(config)
+	defer func() { require.NoError(t, authenticatedStore.Close()) }()
+	taken, err := authenticatedStore.TakeTokens(t.Context(), "key", 1, 10, 20, time.Now())
+	require.NoError(t, err)
+	require.True(t, taken)
+
+	// The bucket is kept in the configured database.
+	require.True(t, server.DB(3).Exists("key"))
+	require.False(t, server.DB(0).Exists("key"))
+}
+
+func TestRedisClientBucketStoreCreatedWhileUnreachable(t *testing.T) {
+	server := miniredis.RunT(t)
+	address := server.Addr()
+	server.Close()
+
+	// The store can be created while Redis is down, but can't take tokens until it is back.
+	store := NewRedisClientBucketStore(&Config{SharedClientLimitsRedisAddress: address})
+	defer func() { require.NoError(t, store.Close()) }()
+	_, err := store.TakeTokens(t.Context(), "key", 1, 10, 20, time.Now())
+	require.Error(t, err)
+
+	require.NoError(t, server.Restart())
+	taken, err := store.TakeTokens(t.Context(), "key", 1, 10, 20, time.Now())
+	require.NoError(t, err)
+	require.True(t, taken)
+}
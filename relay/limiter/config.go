@@ -1,5 +1,15 @@
 package limiter
 
+import "time"
+
+// Backends that the per-client GetChunk buckets can be kept in.
+const (
+	// Each relay replica keeps its own per-client buckets.
+	LocalClientLimitsBackend = ""
+	// Per-client buckets are kept in Redis, and shared by all relay replicas using the same Redis server.
+	RedisClientLimitsBackend = "redis"
+)
+
 // Config is the configuration for the relay rate limiting.
 type Config struct {
 
@@ -62,4 +72,31 @@ type Config struct {
 	// MaxConcurrentGetChunkOpsClient is the maximum number of concurrent GetChunk operations that are permitted.
 	// Default is 1.
 	MaxConcurrentGetChunkOpsClient int
+
+	// Shared client rate limiting
+
+	// SharedClientLimitsBackend selects where the per-client GetChunk rate and bandwidth buckets are kept. With
+	// LocalClientLimitsBackend (the default), each relay replica keeps its own buckets, so a client talking to N
+	// replicas behind a load balancer gets N times its budget. With RedisClientLimitsBackend, the buckets are shared
+	// by all replicas. Per-client concurrency limits are always enforced by each replica on its own.
+	SharedClientLimitsBackend string
+	// SharedClientLimitsRedisAddress is the address, in host:port form, of the Redis server used by the
+	// RedisClientLimitsBackend.
+	SharedClientLimitsRedisAddress string
+	// SharedClientLimitsRedisUsername is the username used to authenticate to the Redis server, for servers with
+	// ACLs. Empty means the default user.
+	SharedClientLimitsRedisUsername string
+	// SharedClientLimitsRedisPassword is the password used to authenticate to the Redis server. Empty means no
+	// authentication.
+	SharedClientLimitsRedisPassword string
+	// SharedClientLimitsRedisDB is the index of the Redis database holding the shared buckets. Default is 0.
+	SharedClientLimitsRedisDB int
+	// SharedClientLimitsRedisEnableTLS enables TLS on the connection to the Redis server.
+	SharedClientLimitsRedisEnableTLS bool
+	// SharedClientLimitsKeyPrefix is prepended to the keys of the shared buckets. Replicas of the same relay must
+	// use the same prefix. Default is "eigenda-relay-limiter".
+	SharedClientLimitsKeyPrefix string
+	// SharedClientLimitsTimeout is the maximum time spent waiting for the shared backend. If the backend doesn't
+	// answer in time, or returns an error, the replica falls back to its own per-client buckets. Default is 50ms.
+	SharedClientLimitsTimeout time.Duration
 }
@@ -0,0 +1,126 @@
+package limiter
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// takeTokensScript atomically refills a token bucket and takes tokens from it. Buckets are hashes holding the number
+// of tokens and the time (in microseconds) of the last update. Buckets expire once they would have refilled
+// completely, since a missing bucket is equivalent to a full one.
+//
+// The current time is read from the Redis server rather than passed in by the relay, so that replicas with skewed
+// clocks refill shared buckets consistently. Reading the time in a script requires effects replication, which is the
+// default since Redis 5.
+//
+// KEYS[1]: the bucket key
+// ARGV: tokens to take, rate per second, burst, expiry (milliseconds)
+var takeTokensScript = goredis.NewScript(`
+local tokens = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local burst = tonumber(ARGV[3])
+local expiry = tonumber(ARGV[4])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'time')
+local available = tonumber(state[1])
+local last = tonumber(state[2])
+if available == nil or last == nil then
+	available = burst
+	last = now
+end
+
+if now > last then
+	available = math.min(burst, available + (now - last) / 1000000 * rate)
+	last = now
+end
+
+local taken = 0
+if available >= tokens then
+	available = available - tokens
+	taken = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(available), 'time', tostring(last))
+redis.call('PEXPIRE', KEYS[1], expiry)
+return taken
+`)
+
+var _ ClientBucketStore = (*RedisClientBucketStore)(nil)
+
+// RedisClientBucketStore is a ClientBucketStore that keeps buckets in Redis, so that they are shared by all relay
+// replicas using the same Redis server.
+type RedisClientBucketStore struct {
+	client *goredis.Client
+}
+
+// NewRedisClientBucketStore creates a new RedisClientBucketStore connected to the server configured by the
+// config's SharedClientLimitsRedis* fields. The server is not contacted until tokens are taken, so the store can be
+// created while Redis is unavailable. TakeTokens returns an error until Redis is reachable, which makes callers fall
+// back to their own buckets.
+func NewRedisClientBucketStore(config *Config) *RedisClientBucketStore {
+	options := &goredis.Options{
+		Addr:     config.SharedClientLimitsRedisAddress,
+		Username: config.SharedClientLimitsRedisUsername,
+		Password: config.SharedClientLimitsRedisPassword,
+		DB:       config.SharedClientLimitsRedisDB,
+	}
+	if config.SharedClientLimitsRedisEnableTLS {
+		options.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	return &RedisClientBucketStore{
+		client: goredis.NewClient(options),
+	}
+}
+
+// TakeTokens implements ClientBucketStore. The now argument is ignored in favor of the Redis server's clock, which
+// is the one clock shared by all replicas.
+func (s *RedisClientBucketStore) TakeTokens(
+	ctx context.Context,
+	key string,
+	tokens float64,
+	ratePerSecond float64,
+	burst int,
+	_ time.Time,
+) (bool, error) {
+	// Buckets are kept at least a second, so that buckets with a high rate aren't constantly recreated.
+	expiry := time.Second
+	if ratePerSecond > 0 {
+		expiry += time.Duration(math.Min(float64(burst)/ratePerSecond*float64(time.Second), float64(24*time.Hour)))
+	} else {
+		expiry = 24 * time.Hour
+	}
+
+	taken, err := takeTokensScript.Run(
+		ctx,
+		s.client,
+		[]string{key},
+		strconv.FormatFloat(tokens, 'f', -1, 64),
+		strconv.FormatFloat(ratePerSecond, 'f', -1, 64),
+		burst,
+		expiry.Milliseconds(),
+	).Int()
+	if err != nil {
+		return false, fmt.Errorf("run take tokens script for key %s: %w", key, err)
+	}
+
+	return taken == 1, nil
+}
+
+// Close closes the connection to Redis.
+func (s *RedisClientBucketStore) Close() error {
+	err := s.client.Close()
+	if err != nil {
+		return fmt.Errorf("close redis client: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// CachePrewarmMetrics provides metrics for the pre-warming of relay caches with newly certified blobs.
+type CachePrewarmMetrics struct {
+	blobs     *prometheus.CounterVec
+	lookups   *prometheus.CounterVec
+	sizeBytes *prometheus.GaugeVec
+}
+
+// NewCachePrewarmMetrics creates a new CachePrewarmMetrics.
+func NewCachePrewarmMetrics(registry *prometheus.Registry) *CachePrewarmMetrics {
+	blobs := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_prewarm_blob_count",
+			Help:      "Number of newly certified blobs considered for pre-warming, by result",
+		},
+		[]string{"result"},
+	)
+
+	lookups := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_prewarm_lookup_count",
+			Help: "Number of GetChunks chunk requests that were (hit) or were not (miss) served from " +
+				"pre-warmed chunk data",
+		},
+		[]string{"result"},
+	)
+
+	sizeBytes := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "cache_prewarm_size_bytes",
+			Help:      "Size of the pre-warmed chunk data held in memory, in bytes",
+		},
+		[]string{},
+	)
+
+	return &CachePrewarmMetrics{
+		blobs:     blobs,
+		lookups:   lookups,
+		sizeBytes: sizeBytes,
+	}
+}
+
+// ReportBlob reports the result of pre-warming a blob. result is one of "loaded", "not_assigned" or "failed".
+func (m *CachePrewarmMetrics) ReportBlob(result string) {
+	m.blobs.WithLabelValues(result).Inc()
+}
+
+// ReportLookup reports whether a chunk request was served from pre-warmed chunk data. The prefetch hit rate is the
+// ratio of hits to all lookups.
+func (m *CachePrewarmMetrics) ReportLookup(hit bool) {
+	if hit {
+		m.lookups.WithLabelValues("hit").Inc()
+	} else {
+		m.lookups.WithLabelValues("miss").Inc()
+	}
+}
+
+// ReportSize reports the size of the pre-warmed chunk data held in memory.
+func (m *CachePrewarmMetrics) ReportSize(sizeBytes uint64) {
+	m.sizeBytes.WithLabelValues().Set(float64(sizeBytes))
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ChunkLocalTierMetrics provides metrics for the on-disk chunk tier that sits in front of the object store.
+type ChunkLocalTierMetrics struct {
+	hits       *prometheus.CounterVec
+	misses     *prometheus.CounterVec
+	writeFails *prometheus.CounterVec
+	sizeBytes  *prometheus.GaugeVec
+}
+
+// NewChunkLocalTierMetrics creates a new ChunkLocalTierMetrics.
+func NewChunkLocalTierMetrics(registry *prometheus.Registry) *ChunkLocalTierMetrics {
+	hits := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "chunk_local_tier_hit_count",
+			Help:      "Number of chunk store reads served from the local disk tier",
+		},
+		[]string{"data_type"},
+	)
+
+	misses := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "chunk_local_tier_miss_count",
+			Help:      "Number of chunk store reads that had to be fetched from the object store",
+		},
+		[]string{"data_type"},
+	)
+
+	writeFails := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "chunk_local_tier_write_failure_count",
+			Help:      "Number of objects that could not be written to the local disk tier",
+		},
+		[]string{"data_type"},
+	)
+
+	sizeBytes := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "chunk_local_tier_size_bytes",
+			Help:      "Size of the local disk tier on disk, in bytes",
+		},
+		[]string{},
+	)
+
+	return &ChunkLocalTierMetrics{
+		hits:       hits,
+		misses:     misses,
+		writeFails: writeFails,
+		sizeBytes:  sizeBytes,
+	}
+}
+
+// ReportHit reports a read served from the local tier. dataType is either "proofs" or "coefficients".
+func (m *ChunkLocalTierMetrics) ReportHit(dataType string) {
+	m.hits.WithLabelValues(dataType).Inc()
+}
+
+// ReportMiss reports a read that had to be fetched from the object store. dataType is either "proofs" or
+// "coefficients".
+func (m *ChunkLocalTierMetrics) ReportMiss(dataType string) {
+	m.misses.WithLabelValues(dataType).Inc()
+}
+
+// ReportWriteFailure reports an object that was fetched from the object store, but could not be written to the
+// local tier.
+func (m *ChunkLocalTierMetrics) ReportWriteFailure(dataType string) {
+	m.writeFails.WithLabelValues(dataType).Inc()
+}
+
+// ReportSize reports the on-disk size of the local tier.
+func (m *ChunkLocalTierMetrics) ReportSize(sizeBytes uint64) {
+	m.sizeBytes.WithLabelValues().Set(float64(sizeBytes))
+}
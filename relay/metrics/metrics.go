@@ -28,6 +28,7 @@ type RelayMetrics struct {
 	MetadataCacheMetrics *cache.CacheAccessorMetrics
 	ChunkCacheMetrics    *cache.CacheAccessorMetrics
 	BlobCacheMetrics     *cache.CacheAccessorMetrics
+	CachePrewarmMetrics  *CachePrewarmMetrics
 
 	// GetChunks metrics
 	getChunksLatency               *prometheus.SummaryVec
@@ -36,6 +37,7 @@ type RelayMetrics struct {
 	getChunksDataLatency           *prometheus.SummaryVec
 	getChunksAuthFailures          *prometheus.CounterVec
 	getChunksRateLimited           *prometheus.CounterVec
+	sharedClientLimitsFailures     *prometheus.CounterVec
 	getChunksKeyCount              *prometheus.GaugeVec
 	getChunksBandwidth             *prometheus.CounterVec
 	getChunksRequestedBandwidth    *prometheus.CounterVec
@@ -78,6 +80,7 @@ func NewRelayMetrics(registry *prometheus.Registry, logger logging.Logger, port
 	metadataCacheMetrics := cache.NewCacheAccessorMetrics(registry, "metadata")
 	chunkCacheMetrics := cache.NewCacheAccessorMetrics(registry, "chunk")
 	blobCacheMetrics := cache.NewCacheAccessorMetrics(registry, "blob")
+	cachePrewarmMetrics := NewCachePrewarmMetrics(registry)
 
 	objectives := map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001}
 
@@ -139,6 +142,15 @@ func NewRelayMetrics(registry *prometheus.Registry, logger logging.Logger, port
 		[]string{"reason"},
 	)
 
+	sharedClientLimitsFailures := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "shared_client_limits_failure_count",
+			Help:      "Number of per-client rate limit checks that fell back to local limits, shared limits unavailable",
+		},
+		[]string{},
+	)
+
 	getChunksKeyCount := promauto.With(registry).NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: namespace,
@@ -230,12 +242,14 @@ func NewRelayMetrics(registry *prometheus.Registry, logger logging.Logger, port
 		MetadataCacheMetrics:           metadataCacheMetrics,
 		ChunkCacheMetrics:              chunkCacheMetrics,
 		BlobCacheMetrics:               blobCacheMetrics,
+		CachePrewarmMetrics:            cachePrewarmMetrics,
 		getChunksLatency:               getChunksLatency,
 		getChunksAuthenticationLatency: getChunksAuthenticationLatency,
 		getChunksMetadataLatency:       getChunksMetadataLatency,
 		getChunksDataLatency:           getChunksDataLatency,
 		getChunksAuthFailures:          getChunksAuthFailures,
 		getChunksRateLimited:           getChunksRateLimited,
+		sharedClientLimitsFailures:     sharedClientLimitsFailures,
 		getChunksKeyCount:              getChunksKeyCount,
 		getChunksBandwidth:             getChunksBandwidth,
 		getChunksRequestedBandwidth:    getChunksRequestedBandwidth,
@@ -292,6 +306,12 @@ func (m *RelayMetrics) ReportChunkRateLimited(reason string) {
 	m.getChunksRateLimited.WithLabelValues(reason).Inc()
 }
 
+// ReportSharedClientLimitsFailure reports a per-client limit check that fell back to the relay's local limits
+// because the shared limits were unavailable.
+func (m *RelayMetrics) ReportSharedClientLimitsFailure() {
+	m.sharedClientLimitsFailures.WithLabelValues().Inc()
+}
+
 func (m *RelayMetrics) ReportChunkKeyCount(count int) {
 	m.getChunksKeyCount.WithLabelValues().Set(float64(count))
 }
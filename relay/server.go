@@ -53,6 +53,10 @@ type Server struct {
 	// legacyChunkProvider encapsulates logic for fetching chunks using the old-style get by index pattern.
 	legacyChunkProvider *chunkProvider
 
+	// cachePrewarmer loads the chunks of newly certified blobs before they are requested, or is nil if
+	// pre-warming is disabled.
+	cachePrewarmer *cachePrewarmer
+
 	// Provides direct access to the chunk reader client.
 	chunkReader chunkstore.ChunkReader
 
@@ -62,6 +66,10 @@ type Server struct {
 	// chunkRateLimiter enforces rate limits on GetChunk operations.
 	chunkRateLimiter *limiter.ChunkRateLimiter
 
+	// clientBuckets holds the per-client rate limits shared with other relay replicas, or is nil if per-client
+	// rate limits are not shared.
+	clientBuckets limiter.ClientBucketStore
+
 	// listener is the network listener for the gRPC server.
 	listener net.Listener
 
@@ -148,6 +156,21 @@ func NewServer(
 		return nil, fmt.Errorf("error creating chunk provider: %w", err)
 	}
 
+	prewarmer, err := newCachePrewarmer(
+		ctx,
+		logger,
+		metadataStore,
+		mp,
+		cp,
+		config.CachePrewarmBytes,
+		config.CachePrewarmPollInterval,
+		config.CachePrewarmBatchSize,
+		config.CachePrewarmMaxConcurrency,
+		relayMetrics.CachePrewarmMetrics)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cache prewarmer: %w", err)
+	}
+
 	var authenticator auth.RequestAuthenticator
 	if !config.AuthenticationDisabled {
 		authenticator, err = auth.NewRequestAuthenticator(ctx, ics, config.AuthenticationKeyCacheSize)
@@ -164,15 +187,22 @@ func NewServer(
 		return nil, fmt.Errorf("failed to create replay guardian: %w", err)
 	}
 
+	clientBuckets, err := limiter.NewClientBucketStore(&config.RateLimits)
+	if err != nil {
+		return nil, fmt.Errorf("error creating shared client limits: %w", err)
+	}
+
 	server := &Server{
 		config:              config,
 		logger:              logger.With("component", "RelayServer"),
 		metadataProvider:    mp,
 		blobProvider:        bp,
 		legacyChunkProvider: cp,
+		cachePrewarmer:      prewarmer,
 		chunkReader:         chunkReader,
 		blobRateLimiter:     limiter.NewBlobRateLimiter(&config.RateLimits, relayMetrics),
-		chunkRateLimiter:    limiter.NewChunkRateLimiter(&config.RateLimits, relayMetrics),
+		chunkRateLimiter:    limiter.NewChunkRateLimiter(&config.RateLimits, clientBuckets, relayMetrics),
+		clientBuckets:       clientBuckets,
 		authenticator:       authenticator,
 		replayGuardian:      replayGuardian,
 		metrics:             relayMetrics,
@@ -298,17 +328,69 @@ func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*
 		ctx, cancel = context.WithTimeout(ctx, s.config.Timeouts.GetChunksTimeout)
 		defer cancel()
 	}
-	err := s.validateGetChunksRequest(request)
+
+	mMap, finishedFetchingMetadata, err := s.beginGetChunksOperation(ctx, request, start)
 	if err != nil {
 		return nil, err
 	}
+	defer s.chunkRateLimiter.FinishGetChunkOperation(string(request.GetOperatorId()))
+
+	var bytesToSend [][]byte
+
+	if requiresLegacyChunkProvider(request) {
+		frames, err := s.legacyChunkProvider.GetFrames(ctx, mMap)
+		if err != nil {
+			// nolint:wrapcheck
+			return nil, api.NewErrorInternal(fmt.Sprintf("error fetching frames: %v", err))
+		}
+
+		bytesToSend, err = gatherChunkDataToSendLegacy(frames, request)
+		if err != nil {
+			// nolint:wrapcheck
+			return nil, api.NewErrorInternal(fmt.Sprintf("error gathering chunk data: %v", err))
+		}
+	} else {
+		var found bool
+		bytesToSend, found, err = s.gatherChunkDataToSend(ctx, mMap, request)
+		if err != nil {
+			// nolint:wrapcheck
+			return nil, api.NewErrorInternal(fmt.Sprintf("error gathering chunk data: %v", err))
+		}
+		if !found {
+			// nolint:wrapcheck
+			return nil, api.NewErrorNotFound("requested chunks not found")
+		}
+	}
+
+	s.metrics.ReportChunkDataLatency(time.Since(finishedFetchingMetadata))
+	s.metrics.ReportChunkLatency(time.Since(start))
+
+	return &pb.GetChunksReply{
+		Data: bytesToSend,
+	}, nil
+}
+
+// beginGetChunksOperation performs the checks shared by GetChunks and StreamChunks: it validates and authenticates
+// the request, fetches the metadata of the requested blobs, and accounts for the operation and its bandwidth with the
+// rate limiter. If it doesn't return an error, the caller must call chunkRateLimiter.FinishGetChunkOperation once the
+// operation completes. Returns the metadata of the requested blobs, and the time at which it was fetched.
+func (s *Server) beginGetChunksOperation(
+	ctx context.Context,
+	request *pb.GetChunksRequest,
+	start time.Time,
+) (map[v2.BlobKey]*blobMetadata, time.Time, error) {
+
+	err := s.validateGetChunksRequest(request)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
 
 	s.metrics.ReportChunkKeyCount(len(request.GetChunkRequests()))
 
 	if s.authenticator != nil {
 		client, ok := peer.FromContext(ctx)
 		if !ok {
-			return nil, api.NewErrorInvalidArg("could not get peer information")
+			return nil, time.Time{}, api.NewErrorInvalidArg("could not get peer information")
 		}
 		clientAddress := client.Addr.String()
 
@@ -316,14 +398,14 @@ func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*
 		if err != nil {
 			s.metrics.ReportChunkAuthFailure()
 			s.logger.Debug("rejected GetChunks request", "client", clientAddress)
-			return nil, api.NewErrorInvalidArg(fmt.Sprintf("auth failed: %v", err))
+			return nil, time.Time{}, api.NewErrorInvalidArg(fmt.Sprintf("auth failed: %v", err))
 		}
 
 		timestamp := time.Unix(int64(request.GetTimestamp()), 0)
 		err = s.replayGuardian.VerifyRequest(hash, timestamp)
 		if err != nil {
 			s.metrics.ReportChunkAuthFailure()
-			return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to verify request: %v", err))
+			return nil, time.Time{}, api.NewErrorInvalidArg(fmt.Sprintf("failed to verify request: %v", err))
 		}
 
 		s.logger.Debug("received authenticated GetChunks request", "client", clientAddress)
@@ -337,25 +419,30 @@ func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*
 	clientID := string(request.GetOperatorId())
 	err = s.chunkRateLimiter.BeginGetChunkOperation(time.Now(), clientID)
 	if err != nil {
-		return nil, api.NewErrorResourceExhausted(fmt.Sprintf("rate limit exceeded: %v", err))
+		return nil, time.Time{}, api.NewErrorResourceExhausted(fmt.Sprintf("rate limit exceeded: %v", err))
 	}
-	defer s.chunkRateLimiter.FinishGetChunkOperation(clientID)
+	success := false
+	defer func() {
+		if !success {
+			s.chunkRateLimiter.FinishGetChunkOperation(clientID)
+		}
+	}()
 
 	// keys might contain duplicate keys
 	keys, err := getKeysFromChunkRequest(request)
 	if err != nil {
-		return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid request: %v", err))
+		return nil, time.Time{}, api.NewErrorInvalidArg(fmt.Sprintf("invalid request: %v", err))
 	}
 
 	mMap, err := s.metadataProvider.GetMetadataForBlobs(ctx, keys)
 	if err != nil {
 		if strings.Contains(err.Error(), blobstore.ErrMetadataNotFound.Error()) {
 			// nolint:wrapcheck
-			return nil, api.NewErrorNotFound(
+			return nil, time.Time{}, api.NewErrorNotFound(
 				fmt.Sprintf("blob not found, check if blob exists and is assigned to this relay:: %v", keys))
 		}
 		// nolint:wrapcheck
-		return nil, api.NewErrorInternal(fmt.Sprintf("error fetching metadata for blob: %v", err))
+		return nil, time.Time{}, api.NewErrorInternal(fmt.Sprintf("error fetching metadata for blob: %v", err))
 	}
 
 	finishedFetchingMetadata := time.Now()
@@ -363,61 +450,31 @@ func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*
 
 	requiredBandwidth, err := computeChunkRequestRequiredBandwidth(request, mMap)
 	if err != nil {
-		return nil, api.NewErrorInternal(fmt.Sprintf("error computing required bandwidth: %v", err))
+		return nil, time.Time{}, api.NewErrorInternal(fmt.Sprintf("error computing required bandwidth: %v", err))
 	}
 	s.metrics.ReportGetChunksRequestedBandwidthUsage(requiredBandwidth)
 	err = s.chunkRateLimiter.RequestGetChunkBandwidth(time.Now(), clientID, requiredBandwidth)
 	if err != nil {
 		if strings.Contains(err.Error(), "internal error") {
-			return nil, api.NewErrorInternal(err.Error())
+			return nil, time.Time{}, api.NewErrorInternal(err.Error())
 		}
-		return nil, buildInsufficientGetChunksBandwidthError(request, requiredBandwidth, err)
+		return nil, time.Time{}, buildInsufficientGetChunksBandwidthError(request, requiredBandwidth, err)
 	}
 	s.metrics.ReportGetChunksBandwidthUsage(requiredBandwidth)
 
-	// Determine whether to use legacy chunk provider or new chunk provider. We have to use the legacy chunk
-	// provider if there are any requests that use the "by index" query pattern.
-	useLegacyChunkProvider := false
+	success = true
+	return mMap, finishedFetchingMetadata, nil
+}
+
+// requiresLegacyChunkProvider determines whether to use legacy chunk provider or new chunk provider. We have to use
+// the legacy chunk provider if there are any requests that use the "by index" query pattern.
+func requiresLegacyChunkProvider(request *pb.GetChunksRequest) bool {
 	for _, chunkRequest := range request.GetChunkRequests() {
 		if chunkRequest.GetByIndex() != nil {
-			useLegacyChunkProvider = true
-			break
-		}
-	}
-
-	var bytesToSend [][]byte
-
-	if useLegacyChunkProvider {
-		frames, err := s.legacyChunkProvider.GetFrames(ctx, mMap)
-		if err != nil {
-			// nolint:wrapcheck
-			return nil, api.NewErrorInternal(fmt.Sprintf("error fetching frames: %v", err))
-		}
-
-		bytesToSend, err = gatherChunkDataToSendLegacy(frames, request)
-		if err != nil {
-			// nolint:wrapcheck
-			return nil, api.NewErrorInternal(fmt.Sprintf("error gathering chunk data: %v", err))
-		}
-	} else {
-		var found bool
-		bytesToSend, found, err = s.gatherChunkDataToSend(ctx, mMap, request)
-		if err != nil {
-			// nolint:wrapcheck
-			return nil, api.NewErrorInternal(fmt.Sprintf("error gathering chunk data: %v", err))
-		}
-		if !found {
-			// nolint:wrapcheck
-			return nil, api.NewErrorNotFound("requested chunks not found")
+			return true
 		}
 	}
-
-	s.metrics.ReportChunkDataLatency(time.Since(finishedFetchingMetadata))
-	s.metrics.ReportChunkLatency(time.Since(start))
-
-	return &pb.GetChunksReply{
-		Data: bytesToSend,
-	}, nil
+	return false
 }
 
 // getKeysFromChunkRequest gathers a slice of blob keys from a GetChunks request.
@@ -458,21 +515,31 @@ func (s *Server) gatherChunkDataToSend(
 	request *pb.GetChunksRequest,
 ) ([][]byte, bool, error) {
 
-	coefficients, proofs, found, err := s.downloadDataFromRelays(ctx, metadataMap, request)
-	if err != nil {
-		return nil, false, fmt.Errorf("error downloading chunk data from relays: %w", err)
-	}
-	if !found {
-		return nil, false, nil
-	}
+	chunkDataObjects := make([]*core.ChunksData, len(request.GetChunkRequests()))
 
-	chunkDataObjects, err := combineProofsAndCoefficients(
-		proofs,
-		coefficients,
-		request,
-		metadataMap)
-	if err != nil {
-		return nil, false, fmt.Errorf("error building chunk data: %w", err)
+	// Only download the chunks that haven't been pre-warmed.
+	missRequest, missIndices := s.cachePrewarmer.getPrewarmedChunkData(request, chunkDataObjects)
+	if len(missIndices) > 0 {
+		coefficients, proofs, found, err := s.downloadDataFromRelays(ctx, metadataMap, missRequest)
+		if err != nil {
+			return nil, false, fmt.Errorf("error downloading chunk data from relays: %w", err)
+		}
+		if !found {
+			return nil, false, nil
+		}
+
+		downloadedChunkData, err := combineProofsAndCoefficients(
+			proofs,
+			coefficients,
+			missRequest,
+			metadataMap)
+		if err != nil {
+			return nil, false, fmt.Errorf("error building chunk data: %w", err)
+		}
+
+		for i, requestIndex := range missIndices {
+			chunkDataObjects[requestIndex] = downloadedChunkData[i]
+		}
 	}
 
 	bytesToSend, err := buildBinaryChunkData(chunkDataObjects, request)
@@ -872,6 +939,10 @@ func (s *Server) Start(ctx context.Context) error {
 		}()
 	}
 
+	if s.cachePrewarmer != nil {
+		go s.cachePrewarmer.run()
+	}
+
 	// Serve grpc requests
 	s.logger.Info("GRPC Listening", "address", s.listener.Addr().String())
 	if err := s.grpcServer.Serve(s.listener); err != nil {
@@ -907,6 +978,13 @@ func (s *Server) Stop() error {
 		s.grpcServer.GracefulStop()
 	}
 
+	if s.clientBuckets != nil {
+		err := s.clientBuckets.Close()
+		if err != nil {
+			return fmt.Errorf("error closing shared client limits: %w", err)
+		}
+	}
+
 	if s.config.EnableMetrics {
 		err := s.metrics.Stop()
 		if err != nil {
@@ -26,19 +26,20 @@ import (
 
 func defaultConfig() *Config {
 	return &Config{
-		GRPCPort:                     50051,
-		MaxGRPCMessageSize:           units.MB,
-		MetadataCacheSize:            1024 * 1024,
-		MetadataMaxConcurrency:       32,
-		BlobCacheBytes:               1024 * 1024,
-		BlobMaxConcurrency:           32,
-		ChunkCacheBytes:              1024 * 1024,
-		ChunkMaxConcurrency:          32,
-		MaxKeysPerGetChunksRequest:   1024,
-		AuthenticationKeyCacheSize:   1024,
-		AuthenticationDisabled:       false,
-		GetChunksRequestMaxPastAge:   5 * time.Minute,
-		GetChunksRequestMaxFutureAge: 5 * time.Minute,
+		GRPCPort:                       50051,
+		MaxGRPCMessageSize:             units.MB,
+		MetadataCacheSize:              1024 * 1024,
+		MetadataMaxConcurrency:         32,
+		BlobCacheBytes:                 1024 * 1024,
+		BlobMaxConcurrency:             32,
+		ChunkCacheBytes:                1024 * 1024,
+		ChunkMaxConcurrency:            32,
+		MaxKeysPerGetChunksRequest:     1024,
+		StreamChunksMaxBundlesInFlight: 4,
+		AuthenticationKeyCacheSize:     1024,
+		AuthenticationDisabled:         false,
+		GetChunksRequestMaxPastAge:     5 * time.Minute,
+		GetChunksRequestMaxFutureAge:   5 * time.Minute,
 		RateLimits: limiter.Config{
 			MaxGetBlobOpsPerSecond:          1024,
 			GetBlobOpsBurstiness:            1024,
@@ -646,3 +647,50 @@ func TestBatchedReadWriteChunks(t *testing.T) {
 		}
 	}
 }
+
+func TestServerStartsWithSharedClientLimitsUnavailable(t *testing.T) {
+	ctx := t.Context()
+	rand := random.NewTestRandom()
+
+	// Nothing listens on the address of the shared backend.
+	redisListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	redisAddress := redisListener.Addr().String()
+	require.NoError(t, redisListener.Close())
+
+	config := defaultConfig()
+	config.AuthenticationDisabled = true
+	config.RateLimits.SharedClientLimitsBackend = limiter.RedisClientLimitsBackend
+	config.RateLimits.SharedClientLimitsRedisAddress = redisAddress
+	config.RateLimits.SharedClientLimitsTimeout = 50 * time.Millisecond
+	config.RateLimits.MaxGetChunkOpsPerSecondClient = 1
+	config.RateLimits.GetChunkOpsBurstinessClient = 2
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server, err := NewServer(
+		ctx,
+		prometheus.NewRegistry(),
+		test.GetLogger(),
+		config,
+		nil, /* not used in this test */
+		nil, /* not used in this test */
+		nil, /* not used in this test */
+		newMockChainReader(t),
+		&coremock.MockIndexedChainState{},
+		listener)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, server.Stop())
+	}()
+
+	// The relay's own per-client limits are enforced until the shared backend is reachable.
+	requesterID := rand.String(32)
+	now := time.Now()
+	for i := 0; i < config.RateLimits.GetChunkOpsBurstinessClient; i++ {
+		require.NoError(t, server.chunkRateLimiter.BeginGetChunkOperation(now, requesterID))
+		server.chunkRateLimiter.FinishGetChunkOperation(requesterID)
+	}
+	require.Error(t, server.chunkRateLimiter.BeginGetChunkOperation(now, requesterID))
+}
@@ -0,0 +1,166 @@
+package relay
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+)
+
+// StreamChunks retrieves chunks from blobs stored by the relay, and streams them back one bundle at a time.
+//
+// Authentication, rate limiting and bandwidth accounting are identical to GetChunks, and are performed for the
+// request as a whole before anything is sent. The bundles are the same ones GetChunks would return, in the same
+// order, but each is sent as soon as it is downloaded, so that the relay never has to hold the entire reply in memory.
+func (s *Server) StreamChunks(request *pb.GetChunksRequest, stream pb.Relay_StreamChunksServer) error {
+	start := time.Now()
+
+	ctx := stream.Context()
+	if s.config.Timeouts.GetChunksTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.config.Timeouts.GetChunksTimeout)
+		defer cancel()
+	}
+
+	mMap, finishedFetchingMetadata, err := s.beginGetChunksOperation(ctx, request, start)
+	if err != nil {
+		return err
+	}
+	defer s.chunkRateLimiter.FinishGetChunkOperation(string(request.GetOperatorId()))
+
+	if requiresLegacyChunkProvider(request) {
+		// The legacy chunk provider fetches whole blobs, so there is nothing to gain by downloading bundles
+		// one at a time.
+		frames, err := s.legacyChunkProvider.GetFrames(ctx, mMap)
+		if err != nil {
+			// nolint:wrapcheck
+			return api.NewErrorInternal(fmt.Sprintf("error fetching frames: %v", err))
+		}
+
+		bytesToSend, err := gatherChunkDataToSendLegacy(frames, request)
+		if err != nil {
+			// nolint:wrapcheck
+			return api.NewErrorInternal(fmt.Sprintf("error gathering chunk data: %v", err))
+		}
+
+		for _, bundle := range bytesToSend {
+			err = stream.Send(&pb.GetChunksReply{Data: [][]byte{bundle}})
+			if err != nil {
+				return fmt.Errorf("error sending bundle: %w", err)
+			}
+		}
+	} else {
+		err = s.streamChunkData(ctx, mMap, request, stream)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.metrics.ReportChunkDataLatency(time.Since(finishedFetchingMetadata))
+	s.metrics.ReportChunkLatency(time.Since(start))
+
+	return nil
+}
+
+// Used to pass a downloaded bundle from a goroutine up to streamChunkData.
+type bundleResult struct {
+	bundle []byte
+	found  bool
+	err    error
+}
+
+// streamChunkData downloads the bundles of a GetChunks request, and sends each of them as soon as it and all
+// bundles before it have been sent. Up to config.StreamChunksMaxBundlesInFlight bundles are downloaded ahead
+// of the bundle being sent.
+func (s *Server) streamChunkData(
+	ctx context.Context,
+	metadataMap map[v2.BlobKey]*blobMetadata,
+	request *pb.GetChunksRequest,
+	stream pb.Relay_StreamChunksServer,
+) error {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	maxBundlesInFlight := s.config.StreamChunksMaxBundlesInFlight
+	if maxBundlesInFlight < 1 {
+		maxBundlesInFlight = 1
+	}
+
+	// The results of the bundles being downloaded, in the order in which they must be sent. The capacity of this
+	// channel is what limits the number of bundles downloaded ahead of the stream.
+	pending := make(chan chan bundleResult, maxBundlesInFlight)
+
+	go func() {
+		defer close(pending)
+		for _, bundleRequest := range splitChunkRequestIntoBundles(request) {
+			result := make(chan bundleResult, 1)
+			select {
+			case pending <- result:
+			case <-ctx.Done():
+				return
+			}
+
+			go func() {
+				bytesToSend, found, err := s.gatherChunkDataToSend(ctx, metadataMap, bundleRequest)
+				if err != nil || !found {
+					result <- bundleResult{found: found, err: err}
+					return
+				}
+				result <- bundleResult{bundle: bytesToSend[0], found: true}
+			}()
+		}
+	}()
+
+	for result := range pending {
+		bundle := <-result
+		if bundle.err != nil {
+			// nolint:wrapcheck
+			return api.NewErrorInternal(fmt.Sprintf("error gathering chunk data: %v", bundle.err))
+		}
+		if !bundle.found {
+			// nolint:wrapcheck
+			return api.NewErrorNotFound("requested chunks not found")
+		}
+
+		err := stream.Send(&pb.GetChunksReply{Data: [][]byte{bundle.bundle}})
+		if err != nil {
+			return fmt.Errorf("error sending bundle: %w", err)
+		}
+	}
+
+	// If the request timed out while the last bundles were being enqueued, some bundles were never downloaded.
+	if ctx.Err() != nil {
+		// nolint:wrapcheck
+		return api.NewErrorInternal(fmt.Sprintf("error gathering chunk data: %v", ctx.Err()))
+	}
+
+	return nil
+}
+
+// splitChunkRequestIntoBundles splits a GetChunks request made of range requests into one request per bundle of the
+// reply. Consecutive range requests for the same blob are combined into a single bundle, as in buildBinaryChunkData.
+func splitChunkRequestIntoBundles(request *pb.GetChunksRequest) []*pb.GetChunksRequest {
+	chunkRequests := request.GetChunkRequests()
+	bundleRequests := make([]*pb.GetChunksRequest, 0, len(chunkRequests))
+
+	for start := 0; start < len(chunkRequests); {
+		targetKey := chunkRequests[start].GetByRange().GetBlobKey()
+
+		end := start + 1
+		for end < len(chunkRequests) && bytes.Equal(targetKey, chunkRequests[end].GetByRange().GetBlobKey()) {
+			end++
+		}
+
+		bundleRequests = append(bundleRequests, &pb.GetChunksRequest{
+			ChunkRequests: chunkRequests[start:end],
+		})
+		start = end
+	}
+
+	return bundleRequests
+}
@@ -0,0 +1,260 @@
+package relay
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	"github.com/Layr-Labs/eigenda/common/replay"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/relay/auth"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func streamChunks(
+	t *testing.T,
+	random *random.TestRandom,
+	operatorKeys map[uint32]*core.KeyPair,
+	request *pb.GetChunksRequest) ([][]byte, error) {
+	t.Helper()
+	ctx := t.Context()
+
+	// Choose a random operator to send this request as. Operator IDs are expected to be sequential starting at 0.
+	operatorID := random.Uint32() % uint32(len(operatorKeys))
+	operatorIDBytes := make([]byte, 32)
+	binary.BigEndian.PutUint32(operatorIDBytes[24:], operatorID)
+	request.OperatorId = operatorIDBytes
+	signature, err := auth.SignGetChunksRequest(operatorKeys[operatorID], request)
+	require.NoError(t, err)
+	request.OperatorSignature = signature
+
+	var opts []grpc.DialOption
+	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+	conn, err := grpc.NewClient("0.0.0.0:50051", opts...)
+	require.NoError(t, err)
+	defer func() {
+		err = conn.Close()
+		require.NoError(t, err)
+	}()
+
+	client := pb.NewRelayClient(conn)
+	stream, err := client.StreamChunks(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	bundles := make([][]byte, 0)
+	for {
+		reply, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return bundles, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		require.Len(t, reply.GetData(), 1)
+		bundles = append(bundles, reply.GetData()[0])
+	}
+}
+
+func TestSplitChunkRequestIntoBundles(t *testing.T) {
+	rand := random.NewTestRandom()
+
+	byRange := func(key []byte, start uint32, end uint32) *pb.ChunkRequest {
+		return &pb.ChunkRequest{
+			Request: &pb.ChunkRequest_ByRange{
+				ByRange: &pb.ChunkRequestByRange{
+					BlobKey:    key,
+					StartIndex: start,
+					EndIndex:   end,
+				},
+			},
+		}
+	}
+
+	keyA := rand.Bytes(32)
+	keyB := rand.Bytes(32)
+
+	request := &pb.GetChunksRequest{
+		ChunkRequests: []*pb.ChunkRequest{
+			byRange(keyA, 0, 2),
+			byRange(keyA, 4, 6),
+			byRange(keyB, 0, 1),
+			byRange(keyA, 8, 9),
+		},
+	}
+
+	bundleRequests := splitChunkRequestIntoBundles(request)
+	require.Len(t, bundleRequests, 3)
+	require.Equal(t, request.GetChunkRequests()[0:2], bundleRequests[0].GetChunkRequests())
+	require.Equal(t, request.GetChunkRequests()[2:3], bundleRequests[1].GetChunkRequests())
+	require.Equal(t, request.GetChunkRequests()[3:4], bundleRequests[2].GetChunkRequests())
+}
+
+func TestStreamChunks(t *testing.T) {
+	ctx := t.Context()
+	rand := random.NewTestRandom()
+	setup(t)
+	defer teardown(t)
+
+	// These are used to write data to S3/dynamoDB
+	metadataStore := buildMetadataStore(t)
+	chunkReader, chunkWriter := buildChunkStore(t, logger)
+
+	operatorCount := rand.Intn(3) + 1
+	operatorKeys := make(map[uint32]*core.KeyPair)
+	operatorInfo := make(map[core.OperatorID]*core.IndexedOperatorInfo)
+	for i := 0; i < operatorCount; i++ {
+		keypair, err := rand.BLS()
+		require.NoError(t, err)
+		operatorKeys[uint32(i)] = keypair
+
+		var operatorID core.OperatorID
+		binary.BigEndian.PutUint32(operatorID[24:], uint32(i))
+		operatorInfo[operatorID] = &core.IndexedOperatorInfo{
+			PubkeyG1: keypair.GetPubKeyG1(),
+			PubkeyG2: keypair.GetPubKeyG2(),
+		}
+	}
+
+	ics := &coremock.MockIndexedChainState{}
+	blockNumber := uint(rand.Uint32())
+	ics.Mock.On("GetCurrentBlockNumber").Return(blockNumber, nil)
+	ics.Mock.On("GetIndexedOperators", blockNumber).Return(operatorInfo, nil)
+
+	// This is the server used to read it back
+	config := defaultConfig()
+	// Fewer bundles in flight than bundles per request, so that downloads wait for the stream.
+	config.StreamChunksMaxBundlesInFlight = 2
+
+	addr := fmt.Sprintf("0.0.0.0:%d", config.GRPCPort)
+	listener, err := net.Listen("tcp", addr)
+	require.NoError(t, err)
+
+	chainReader := newMockChainReader(t)
+	server, err := NewServer(
+		ctx,
+		prometheus.NewRegistry(),
+		logger,
+		config,
+		metadataStore,
+		nil, /* not used in this test */
+		chunkReader,
+		chainReader,
+		ics,
+		listener)
+	require.NoError(t, err)
+	server.replayGuardian = replay.NewNoOpReplayGuardian() // disable replay protection
+
+	go func() {
+		_ = server.Start(ctx)
+	}()
+
+	defer func() {
+		err = server.Stop()
+		require.NoError(t, err)
+	}()
+
+	expectedData := make(map[v2.BlobKey][]*encoding.Frame)
+	keys := make([]v2.BlobKey, 0)
+
+	blobCount := 5
+	for i := 0; i < blobCount; i++ {
+		header, _, chunks := randomBlobChunks(t)
+
+		blobKey, err := header.BlobKey()
+		require.NoError(t, err)
+		expectedData[blobKey] = chunks
+		keys = append(keys, blobKey)
+
+		coeffs, chunkProofs := disassembleFrames(t, chunks)
+		err = chunkWriter.PutFrameProofs(ctx, blobKey, chunkProofs)
+		require.NoError(t, err)
+		fragmentInfo, err := chunkWriter.PutFrameCoefficients(ctx, blobKey, coeffs)
+		require.NoError(t, err)
+
+		err = metadataStore.PutBlobCertificate(
+			ctx,
+			&v2.BlobCertificate{
+				BlobHeader: header,
+			},
+			&encoding.FragmentInfo{
+				SymbolsPerFrame: fragmentInfo.SymbolsPerFrame,
+			})
+		require.NoError(t, err)
+	}
+
+	// Request each blob in two consecutive ranges, which the relay combines into a single bundle.
+	requestedChunks := make([]*pb.ChunkRequest, 0)
+	for _, key := range keys {
+		boundKey := key
+		chunkCount := uint32(len(expectedData[key]))
+		split := 1 + uint32(rand.Intn(int(chunkCount)-1))
+		for _, indices := range [][2]uint32{{0, split}, {split, chunkCount}} {
+			requestedChunks = append(requestedChunks, &pb.ChunkRequest{
+				Request: &pb.ChunkRequest_ByRange{
+					ByRange: &pb.ChunkRequestByRange{
+						BlobKey:    boundKey[:],
+						StartIndex: indices[0],
+						EndIndex:   indices[1],
+					},
+				},
+			})
+		}
+	}
+
+	bundles, err := streamChunks(t, rand, operatorKeys, &pb.GetChunksRequest{
+		ChunkRequests: requestedChunks,
+		Timestamp:     uint32(time.Now().Unix()),
+	})
+	require.NoError(t, err)
+	require.Len(t, bundles, len(keys))
+
+	for keyIndex, key := range keys {
+		bundle, err := core.Bundle{}.Deserialize(bundles[keyIndex])
+		require.NoError(t, err)
+		require.Equal(t, len(expectedData[key]), len(bundle))
+		for frameIndex, frame := range bundle {
+			require.Equal(t, expectedData[key][frameIndex], frame)
+		}
+	}
+
+	// The stream returns the same bundles as GetChunks.
+	response, err := getChunks(t, rand, operatorKeys, &pb.GetChunksRequest{
+		ChunkRequests: requestedChunks,
+		Timestamp:     uint32(time.Now().Unix()),
+	})
+	require.NoError(t, err)
+	require.Equal(t, response.GetData(), bundles)
+
+	// Requests for missing blobs are rejected before anything is streamed.
+	missingKey := rand.Bytes(32)
+	_, err = streamChunks(t, rand, operatorKeys, &pb.GetChunksRequest{
+		ChunkRequests: []*pb.ChunkRequest{
+			{
+				Request: &pb.ChunkRequest_ByRange{
+					ByRange: &pb.ChunkRequestByRange{
+						BlobKey:    missingKey,
+						StartIndex: 0,
+						EndIndex:   1,
+					},
+				},
+			},
+		},
+		Timestamp: uint32(time.Now().Unix()),
+	})
+	require.Error(t, err)
+}
@@ -12,16 +12,17 @@ import (
 // The grpcPort is set to 0 by default to let the OS assign a port (can be overridden).
 func NewTestConfig(relayIndex int) *Config {
 	return &Config{
-		RelayKeys:                  []v2.RelayKey{v2.RelayKey(relayIndex)},
-		GRPCPort:                   0, // OS assigns port
-		MaxGRPCMessageSize:         1024 * 1024 * 300,
-		MetadataCacheSize:          1024 * 1024,
-		MetadataMaxConcurrency:     32,
-		BlobCacheBytes:             32 * 1024 * 1024,
-		BlobMaxConcurrency:         32,
-		ChunkCacheBytes:            32 * 1024 * 1024,
-		ChunkMaxConcurrency:        32,
-		MaxKeysPerGetChunksRequest: 1024,
+		RelayKeys:                      []v2.RelayKey{v2.RelayKey(relayIndex)},
+		GRPCPort:                       0, // OS assigns port
+		MaxGRPCMessageSize:             1024 * 1024 * 300,
+		MetadataCacheSize:              1024 * 1024,
+		MetadataMaxConcurrency:         32,
+		BlobCacheBytes:                 32 * 1024 * 1024,
+		BlobMaxConcurrency:             32,
+		ChunkCacheBytes:                32 * 1024 * 1024,
+		ChunkMaxConcurrency:            32,
+		MaxKeysPerGetChunksRequest:     1024,
+		StreamChunksMaxBundlesInFlight: 4,
 		RateLimits: limiter.Config{
 			MaxGetBlobOpsPerSecond:          1024,
 			GetBlobOpsBurstiness:            1024,
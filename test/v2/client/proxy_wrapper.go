@@ -65,7 +65,7 @@ func NewProxyWrapper(
 		return nil, fmt.Errorf("build store manager: %w", err)
 	}
 
-	proxyServer := rest.NewServer(proxyConfig.RestSvrCfg, certMgr, keccakMgr, logger, proxyMetrics)
+	proxyServer := rest.NewServer(proxyConfig.RestSvrCfg, certMgr, keccakMgr, nil, logger, proxyMetrics)
 
 	router := mux.NewRouter()
 	proxyServer.RegisterRoutes(router)